@@ -16,12 +16,19 @@ import (
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
 	graphsync "github.com/woodleighschool/go-entrasync"
 
+	appaccess "github.com/woodleighschool/grinch/internal/app/access"
 	appentrasync "github.com/woodleighschool/grinch/internal/app/entrasync"
 	appevents "github.com/woodleighschool/grinch/internal/app/events"
 	appgroups "github.com/woodleighschool/grinch/internal/app/groups"
+	applockdown "github.com/woodleighschool/grinch/internal/app/lockdown"
 	appmemberships "github.com/woodleighschool/grinch/internal/app/memberships"
+	appobservedrules "github.com/woodleighschool/grinch/internal/app/observedrules"
+	apprulechanges "github.com/woodleighschool/grinch/internal/app/rulechanges"
 	apprules "github.com/woodleighschool/grinch/internal/app/rules"
 	appsanta "github.com/woodleighschool/grinch/internal/app/santa"
+	appserviceaccounts "github.com/woodleighschool/grinch/internal/app/serviceaccounts"
+	appunblockrequests "github.com/woodleighschool/grinch/internal/app/unblockrequests"
+	appusers "github.com/woodleighschool/grinch/internal/app/users"
 	"github.com/woodleighschool/grinch/internal/config"
 	"github.com/woodleighschool/grinch/internal/platform/logging"
 	"github.com/woodleighschool/grinch/internal/store/postgres"
@@ -32,11 +39,13 @@ import (
 )
 
 const (
-	frontendDistDir   = "/frontend"
-	idleTimeout       = 2 * time.Minute
-	readHeaderTimeout = 5 * time.Second
-	retentionInterval = 1 * time.Hour
-	shutdownTimeout   = 10 * time.Second
+	dynamicGroupInterval    = 15 * time.Minute
+	frontendDistDir         = "/frontend"
+	idleTimeout             = 2 * time.Minute
+	membershipSweepInterval = 1 * time.Minute
+	readHeaderTimeout       = 5 * time.Second
+	retentionInterval       = 1 * time.Hour
+	shutdownTimeout         = 10 * time.Second
 )
 
 func main() {
@@ -85,14 +94,22 @@ func buildServer(
 	cfg config.Config,
 	store *postgres.Store,
 ) (*http.Server, error) {
-	groupService := appgroups.New(store)
-	ruleService := apprules.New(store)
-	membershipService := appmemberships.New(store)
+	accessService := appaccess.New(store)
+	groupService := appgroups.New(logger, store)
+	ruleService := apprules.New(logger, store)
+	ruleChangeService := apprulechanges.New(store, ruleService, cfg.Rules.RequireApproval)
+	membershipService := appmemberships.New(logger, store)
+	userService := appusers.New(logger, store, cfg.Users.Matching())
+	serviceAccountService := appserviceaccounts.New(store)
+	unblockRequestService := appunblockrequests.New(store, ruleService, ruleChangeService)
+	observedRuleService := appobservedrules.New(store, ruleService, ruleChangeService)
+	lockdownService := applockdown.New(store)
 	syncService := appsanta.New(
 		logger,
 		store,
 		cfg.Events.DecisionAllowlist,
 		ruleService,
+		cfg.Users.Matching(),
 	)
 
 	eventService := appevents.New(logger, store, cfg.Events.RetentionDays)
@@ -104,6 +121,7 @@ func buildServer(
 		EntraClientSecret:  cfg.Auth.EntraClientSecret,
 		JWTSecret:          cfg.Auth.JWTSecret,
 		LocalAdminPassword: cfg.Auth.LocalAdminPass,
+		Roles:              accessService,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("configure auth: %w", err)
@@ -116,12 +134,23 @@ func buildServer(
 
 	apiHandler := apihttp.New(
 		store,
+		accessService,
 		groupService,
 		ruleService,
+		ruleChangeService,
 		membershipService,
+		serviceAccountService,
+		unblockRequestService,
+		observedRuleService,
+		lockdownService,
+		syncService,
+		userService,
 	)
 
 	go eventService.RunRetention(ctx, retentionInterval)
+	go groupService.RunDynamicMemberships(ctx, dynamicGroupInterval)
+	go membershipService.RunSweeper(ctx, membershipSweepInterval)
+	go userService.RefreshPrimaryUsers(ctx)
 
 	return &http.Server{
 		Addr: cfg.HTTP.Addr(),
@@ -131,8 +160,8 @@ func buildServer(
 			syncHandler.RegisterRoutes,
 			authService.RegisterRoutes,
 			func(router chi.Router) {
-				router.Use(authhttp.APIMiddleware(authService.SessionAuthMiddleware()))
-				apiHandler.RegisterRoutes(router)
+				router.Use(authhttp.APIMiddleware(authService.SessionAuthMiddleware(), serviceAccountService))
+				apiHandler.RegisterRoutes(router, authhttp.OperationMiddleware(apihttp.OperationID))
 			},
 			frontendDistDir,
 		),
@@ -169,12 +198,23 @@ func startEntraSync(
 		return fmt.Errorf("entra graph client: %w", err)
 	}
 
-	graphClient := graphsync.NewClient(graph, graphsync.WithTransitiveMemberships())
+	graphClient := appentrasync.NewClient(
+		graph,
+		graphsync.WithTransitiveMemberships(),
+		graphsync.WithUserFields(
+			graphsync.FieldCompanyName,
+			graphsync.FieldDepartment,
+			graphsync.FieldEmployeeID,
+			graphsync.FieldMailNickname,
+			graphsync.FieldOfficeLocation,
+		),
+	)
 
 	service := appentrasync.New(
 		logger,
 		graphClient,
 		store,
+		appusers.New(logger, store, cfg.Users.Matching()),
 		cfg.Entra.Interval,
 	)
 
@@ -0,0 +1,95 @@
+package access
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type RoleMappingWriteInput struct {
+	GroupID uuid.UUID
+	Role    domain.Role
+}
+
+type Store interface {
+	ListRoleMappings(context.Context, domain.ListOptions) ([]domain.RoleMapping, int32, error)
+	GetRoleMapping(context.Context, uuid.UUID) (domain.RoleMapping, error)
+	CreateRoleMapping(context.Context, uuid.UUID, domain.Role) (domain.RoleMapping, error)
+	UpdateRoleMapping(context.Context, uuid.UUID, domain.Role) (domain.RoleMapping, error)
+	DeleteRoleMapping(context.Context, uuid.UUID) error
+	ListRolesForUserUPN(context.Context, string) ([]domain.Role, error)
+}
+
+type Service struct {
+	store Store
+}
+
+func New(store Store) *Service {
+	return &Service{store: store}
+}
+
+func (s *Service) ListRoleMappings(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.RoleMapping, int32, error) {
+	return s.store.ListRoleMappings(ctx, opts)
+}
+
+func (s *Service) GetRoleMapping(ctx context.Context, id uuid.UUID) (domain.RoleMapping, error) {
+	return s.store.GetRoleMapping(ctx, id)
+}
+
+func (s *Service) CreateRoleMapping(ctx context.Context, input RoleMappingWriteInput) (domain.RoleMapping, error) {
+	if err := validateRoleMappingInput(input, true); err != nil {
+		return domain.RoleMapping{}, err
+	}
+
+	return s.store.CreateRoleMapping(ctx, input.GroupID, input.Role)
+}
+
+func (s *Service) UpdateRoleMapping(
+	ctx context.Context,
+	id uuid.UUID,
+	input RoleMappingWriteInput,
+) (domain.RoleMapping, error) {
+	if err := validateRoleMappingInput(input, false); err != nil {
+		return domain.RoleMapping{}, err
+	}
+
+	return s.store.UpdateRoleMapping(ctx, id, input.Role)
+}
+
+func (s *Service) DeleteRoleMapping(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeleteRoleMapping(ctx, id)
+}
+
+// ResolveRoles returns the roles granted to the user with the given UPN.
+// Users outside every mapped group resolve to no roles.
+func (s *Service) ResolveRoles(ctx context.Context, upn string) ([]domain.Role, error) {
+	if upn == "" {
+		return nil, nil
+	}
+
+	return s.store.ListRolesForUserUPN(ctx, upn)
+}
+
+func validateRoleMappingInput(input RoleMappingWriteInput, requireGroup bool) *domain.ValidationError {
+	err := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Role mapping is invalid.",
+	}
+
+	if requireGroup && input.GroupID == uuid.Nil {
+		err.Add("group_id", "is required", "required")
+	}
+	if _, parseErr := domain.ParseRole(string(input.Role)); parseErr != nil {
+		err.Add("role", "must be viewer, helpdesk, rule_editor, or admin", "invalid")
+	}
+
+	if !err.HasFieldErrors() {
+		return nil
+	}
+	return err
+}
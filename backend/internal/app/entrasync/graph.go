@@ -0,0 +1,121 @@
+package entrasync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
+	msgraphusers "github.com/microsoftgraph/msgraph-sdk-go/users"
+	graphsync "github.com/woodleighschool/go-entrasync"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const maxGraphPageSize = int32(999)
+
+// Client fetches Entra snapshots through go-entrasync. Its user select has no jobTitle or
+// on-premises extension attributes, so those are fetched from Graph directly.
+type Client struct {
+	*graphsync.Client
+
+	graph *msgraphsdk.GraphServiceClient
+}
+
+func NewClient(graph *msgraphsdk.GraphServiceClient, opts ...graphsync.Option) *Client {
+	return &Client{Client: graphsync.NewClient(graph, opts...), graph: graph}
+}
+
+// FetchUserAttributes returns the job title and on-premises extension attributes of the enabled
+// member users go-entrasync syncs, keyed by user ID. Empty attributes are omitted.
+func (c *Client) FetchUserAttributes(ctx context.Context) (map[uuid.UUID]map[string]string, error) {
+	builder := c.graph.Users()
+	adapter := c.graph.GetAdapter()
+
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("ConsistencyLevel", "eventual")
+	top := maxGraphPageSize
+	filter := "accountEnabled eq true and userType eq 'Member'"
+	count := true
+
+	attributes := make(map[uuid.UUID]map[string]string)
+	for {
+		resp, err := builder.Get(ctx, &msgraphusers.UsersRequestBuilderGetRequestConfiguration{
+			Headers: headers,
+			QueryParameters: &msgraphusers.UsersRequestBuilderGetQueryParameters{
+				Top:    &top,
+				Select: []string{"id", "jobTitle", "onPremisesExtensionAttributes"},
+				Filter: &filter,
+				Count:  &count,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list user attributes: %w", err)
+		}
+
+		for _, user := range resp.GetValue() {
+			id, userAttributes, ok := graphUserAttributes(user)
+			if ok && len(userAttributes) > 0 {
+				attributes[id] = userAttributes
+			}
+		}
+
+		next := resp.GetOdataNextLink()
+		if next == nil || strings.TrimSpace(*next) == "" {
+			break
+		}
+		builder = msgraphusers.NewUsersRequestBuilder(*next, adapter)
+	}
+
+	return attributes, nil
+}
+
+func graphUserAttributes(user msgraphmodels.Userable) (uuid.UUID, map[string]string, bool) {
+	if user == nil || user.GetId() == nil {
+		return uuid.Nil, nil, false
+	}
+
+	id, err := uuid.Parse(*user.GetId())
+	if err != nil {
+		return uuid.Nil, nil, false
+	}
+
+	attributes := map[string]string{}
+	add := func(name string, value *string) {
+		if value == nil {
+			return
+		}
+		if trimmed := strings.TrimSpace(*value); trimmed != "" {
+			attributes[name] = trimmed
+		}
+	}
+
+	add(domain.UserAttributeJobTitle, user.GetJobTitle())
+
+	if extensions := user.GetOnPremisesExtensionAttributes(); extensions != nil {
+		for n, value := range []*string{
+			extensions.GetExtensionAttribute1(),
+			extensions.GetExtensionAttribute2(),
+			extensions.GetExtensionAttribute3(),
+			extensions.GetExtensionAttribute4(),
+			extensions.GetExtensionAttribute5(),
+			extensions.GetExtensionAttribute6(),
+			extensions.GetExtensionAttribute7(),
+			extensions.GetExtensionAttribute8(),
+			extensions.GetExtensionAttribute9(),
+			extensions.GetExtensionAttribute10(),
+			extensions.GetExtensionAttribute11(),
+			extensions.GetExtensionAttribute12(),
+			extensions.GetExtensionAttribute13(),
+			extensions.GetExtensionAttribute14(),
+			extensions.GetExtensionAttribute15(),
+		} {
+			add(domain.UserExtensionAttribute(n+1), value)
+		}
+	}
+
+	return id, attributes, true
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	graphsync "github.com/woodleighschool/go-entrasync"
 
 	"github.com/woodleighschool/grinch/internal/domain"
@@ -13,26 +14,45 @@ import (
 
 type GraphClient interface {
 	Snapshot(context.Context) (*graphsync.Snapshot, error)
+	FetchUserAttributes(context.Context) (map[uuid.UUID]map[string]string, error)
 }
 
 type DataStore interface {
-	ReconcileSnapshot(context.Context, *graphsync.Snapshot) (domain.EntraSyncResult, error)
+	ReconcileSnapshot(
+		context.Context,
+		*graphsync.Snapshot,
+		map[uuid.UUID]map[string]string,
+	) (domain.EntraSyncResult, error)
+	SyncDynamicUserMemberships(context.Context) ([]uuid.UUID, error)
 	UpdateAllMachineDesiredTargets(context.Context) error
 }
 
+// PrimaryUserResolver re-matches machines' reported primary users after users or aliases change.
+type PrimaryUserResolver interface {
+	ResolvePrimaryUsers(context.Context) ([]uuid.UUID, error)
+}
+
 type Service struct {
-	logger   *slog.Logger
-	client   GraphClient
-	store    DataStore
-	interval time.Duration
+	logger       *slog.Logger
+	client       GraphClient
+	store        DataStore
+	primaryUsers PrimaryUserResolver
+	interval     time.Duration
 }
 
-func New(logger *slog.Logger, client GraphClient, store DataStore, interval time.Duration) *Service {
+func New(
+	logger *slog.Logger,
+	client GraphClient,
+	store DataStore,
+	primaryUsers PrimaryUserResolver,
+	interval time.Duration,
+) *Service {
 	return &Service{
-		logger:   logger,
-		client:   client,
-		store:    store,
-		interval: interval,
+		logger:       logger,
+		client:       client,
+		store:        store,
+		primaryUsers: primaryUsers,
+		interval:     interval,
 	}
 }
 
@@ -59,11 +79,28 @@ func (s *Service) SyncOnce(ctx context.Context) (domain.EntraSyncResult, error)
 		return domain.EntraSyncResult{}, fmt.Errorf("fetch snapshot: %w", err)
 	}
 
-	result, err := s.store.ReconcileSnapshot(ctx, snapshot)
+	userAttributes, err := s.client.FetchUserAttributes(ctx)
+	if err != nil {
+		return domain.EntraSyncResult{}, fmt.Errorf("fetch user attributes: %w", err)
+	}
+
+	result, err := s.store.ReconcileSnapshot(ctx, snapshot, userAttributes)
 	if err != nil {
 		return domain.EntraSyncResult{}, fmt.Errorf("reconcile snapshot: %w", err)
 	}
 
+	// UPNs and aliases may have changed, so primary users are re-matched before the desired rules
+	// of every machine are recomputed below.
+	if _, err = s.primaryUsers.ResolvePrimaryUsers(ctx); err != nil {
+		return domain.EntraSyncResult{}, fmt.Errorf("resolve primary users: %w", err)
+	}
+
+	// Directory attributes may have changed, so dynamic user groups are re-evaluated before the
+	// desired rules of every machine are recomputed below.
+	if _, err = s.store.SyncDynamicUserMemberships(ctx); err != nil {
+		return domain.EntraSyncResult{}, fmt.Errorf("sync dynamic user memberships: %w", err)
+	}
+
 	if err = s.store.UpdateAllMachineDesiredTargets(ctx); err != nil {
 		return domain.EntraSyncResult{}, fmt.Errorf("sync machine desired rule targets: %w", err)
 	}
@@ -0,0 +1,124 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type LocalGroupMappingWriteInput struct {
+	LocalGroup string
+	GroupID    uuid.UUID
+}
+
+func (s *Service) ListLocalGroupMappings(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.LocalGroupMapping, int32, error) {
+	return s.store.ListLocalGroupMappings(ctx, opts)
+}
+
+func (s *Service) GetLocalGroupMapping(ctx context.Context, id uuid.UUID) (domain.LocalGroupMapping, error) {
+	return s.store.GetLocalGroupMapping(ctx, id)
+}
+
+// CreateLocalGroupMapping maps a macOS local group onto a local Grinch group and syncs the
+// memberships of every machine already reporting it.
+func (s *Service) CreateLocalGroupMapping(
+	ctx context.Context,
+	input LocalGroupMappingWriteInput,
+) (domain.LocalGroupMapping, error) {
+	input.LocalGroup = strings.TrimSpace(input.LocalGroup)
+	if err := s.validateLocalGroupMappingInput(ctx, input); err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	mapping, err := s.store.CreateLocalGroupMapping(ctx, input.LocalGroup, input.GroupID)
+	if err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	if err = s.syncLocalGroupMemberships(ctx); err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	return mapping, nil
+}
+
+func (s *Service) UpdateLocalGroupMapping(
+	ctx context.Context,
+	id uuid.UUID,
+	input LocalGroupMappingWriteInput,
+) (domain.LocalGroupMapping, error) {
+	input.LocalGroup = strings.TrimSpace(input.LocalGroup)
+	if err := s.validateLocalGroupMappingInput(ctx, input); err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	mapping, err := s.store.UpdateLocalGroupMapping(ctx, id, input.LocalGroup, input.GroupID)
+	if err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	if err = s.syncLocalGroupMemberships(ctx); err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	return mapping, nil
+}
+
+func (s *Service) DeleteLocalGroupMapping(ctx context.Context, id uuid.UUID) error {
+	if err := s.store.DeleteLocalGroupMapping(ctx, id); err != nil {
+		return err
+	}
+
+	return s.syncLocalGroupMemberships(ctx)
+}
+
+func (s *Service) syncLocalGroupMemberships(ctx context.Context) error {
+	changed, err := s.store.SyncComputedMachineMemberships(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("sync local group memberships: %w", err)
+	}
+
+	for _, machineID := range changed {
+		if err = s.store.UpdateMachineDesiredTargets(ctx, machineID); err != nil {
+			return fmt.Errorf("sync machine desired rule targets: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validateLocalGroupMappingInput(ctx context.Context, input LocalGroupMappingWriteInput) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Local group mapping is invalid.",
+	}
+
+	if input.LocalGroup == "" {
+		validationErr.Add("local_group", "must not be empty", "required")
+	}
+	if input.GroupID == uuid.Nil {
+		validationErr.Add("group_id", "is required", "required")
+	}
+
+	if validationErr.HasFieldErrors() {
+		return validationErr
+	}
+
+	group, err := s.store.GetGroup(ctx, input.GroupID)
+	if err != nil {
+		return err
+	}
+	if group.Source == domain.PrincipalSourceEntra {
+		return domain.ErrGroupReadOnly
+	}
+
+	return nil
+}
@@ -2,6 +2,11 @@ package groups
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -9,24 +14,53 @@ import (
 )
 
 type WriteInput struct {
-	Name        string
-	Description string
+	Name            string
+	Description     string
+	MachineCriteria *domain.MachineGroupCriteria
+	UserCriteria    *domain.UserGroupCriteria
 }
 
 type Store interface {
 	ListGroups(context.Context, domain.ListOptions) ([]domain.Group, int32, error)
 	GetGroup(context.Context, uuid.UUID) (domain.Group, error)
-	CreateLocalGroup(context.Context, string, string) (domain.Group, error)
-	UpdateGroup(context.Context, uuid.UUID, string, string) (domain.Group, error)
+	CreateLocalGroup(
+		context.Context,
+		string,
+		string,
+		*domain.MachineGroupCriteria,
+		*domain.UserGroupCriteria,
+	) (domain.Group, error)
+	UpdateGroup(
+		context.Context,
+		uuid.UUID,
+		string,
+		string,
+		*domain.MachineGroupCriteria,
+		*domain.UserGroupCriteria,
+	) (domain.Group, error)
 	DeleteGroup(context.Context, uuid.UUID) error
+	GroupGrantsRole(context.Context, uuid.UUID) (bool, error)
+	GetMachine(context.Context, uuid.UUID) (domain.Machine, error)
+	SetMachineTags(context.Context, uuid.UUID, []string) error
+	SyncMachineComputedMemberships(context.Context, uuid.UUID, time.Time) (bool, error)
+	SyncComputedMachineMemberships(context.Context, time.Time) ([]uuid.UUID, error)
+	SyncDynamicUserMemberships(context.Context) ([]uuid.UUID, error)
+	UpdateMachineDesiredTargets(context.Context, uuid.UUID) error
+	UpdateMachineDesiredTargetsByPrimaryUserID(context.Context, uuid.UUID) error
+	ListLocalGroupMappings(context.Context, domain.ListOptions) ([]domain.LocalGroupMapping, int32, error)
+	GetLocalGroupMapping(context.Context, uuid.UUID) (domain.LocalGroupMapping, error)
+	CreateLocalGroupMapping(context.Context, string, uuid.UUID) (domain.LocalGroupMapping, error)
+	UpdateLocalGroupMapping(context.Context, uuid.UUID, string, uuid.UUID) (domain.LocalGroupMapping, error)
+	DeleteLocalGroupMapping(context.Context, uuid.UUID) error
 }
 
 type Service struct {
-	store Store
+	logger *slog.Logger
+	store  Store
 }
 
-func New(store Store) *Service {
-	return &Service{store: store}
+func New(logger *slog.Logger, store Store) *Service {
+	return &Service{logger: logger, store: store}
 }
 
 func (s *Service) ListGroups(ctx context.Context, opts domain.ListOptions) ([]domain.Group, int32, error) {
@@ -42,21 +76,201 @@ func (s *Service) CreateGroup(ctx context.Context, input WriteInput) (domain.Gro
 		return domain.Group{}, err
 	}
 
-	return s.store.CreateLocalGroup(ctx, input.Name, input.Description)
+	group, err := s.store.CreateLocalGroup(
+		ctx,
+		input.Name,
+		input.Description,
+		input.MachineCriteria,
+		input.UserCriteria,
+	)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	if input.MachineCriteria == nil && input.UserCriteria == nil {
+		return group, nil
+	}
+
+	if _, err = s.SyncDynamicMemberships(ctx); err != nil {
+		return domain.Group{}, err
+	}
+
+	return s.store.GetGroup(ctx, group.ID)
 }
 
-func (s *Service) UpdateGroup(ctx context.Context, id uuid.UUID, input WriteInput) (domain.Group, error) {
+// UpdateGroup saves a group's details and criteria. Only admins may change the user criteria of a
+// group that grants a role, since those decide who holds it.
+func (s *Service) UpdateGroup(
+	ctx context.Context,
+	actor domain.Actor,
+	id uuid.UUID,
+	input WriteInput,
+) (domain.Group, error) {
 	if err := validateInput(input); err != nil {
 		return domain.Group{}, err
 	}
 
-	return s.store.UpdateGroup(ctx, id, input.Name, input.Description)
+	previous, err := s.store.GetGroup(ctx, id)
+	if err != nil {
+		return domain.Group{}, err
+	}
+	if userCriteriaChanged(previous.UserCriteria, input.UserCriteria) {
+		if err = s.requireRoleGroupAdmin(ctx, actor, id); err != nil {
+			return domain.Group{}, err
+		}
+	}
+
+	group, err := s.store.UpdateGroup(
+		ctx,
+		id,
+		input.Name,
+		input.Description,
+		input.MachineCriteria,
+		input.UserCriteria,
+	)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	if previous.MachineCriteria == nil && input.MachineCriteria == nil &&
+		previous.UserCriteria == nil && input.UserCriteria == nil {
+		return group, nil
+	}
+
+	if _, err = s.SyncDynamicMemberships(ctx); err != nil {
+		return domain.Group{}, err
+	}
+
+	return s.store.GetGroup(ctx, id)
 }
 
-func (s *Service) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+// DeleteGroup deletes a group. Only admins may delete a group that grants a role, which would also
+// drop its role mapping.
+func (s *Service) DeleteGroup(ctx context.Context, actor domain.Actor, id uuid.UUID) error {
+	if err := s.requireRoleGroupAdmin(ctx, actor, id); err != nil {
+		return err
+	}
+
 	return s.store.DeleteGroup(ctx, id)
 }
 
+// requireRoleGroupAdmin refuses non-admins changes that decide who belongs to a group granting a
+// role, directly or through a group it is nested in.
+func (s *Service) requireRoleGroupAdmin(ctx context.Context, actor domain.Actor, groupID uuid.UUID) error {
+	if actor.Admin {
+		return nil
+	}
+
+	grantsRole, err := s.store.GroupGrantsRole(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if grantsRole {
+		return domain.ErrRoleGroupAdminOnly
+	}
+
+	return nil
+}
+
+func userCriteriaChanged(previous, next *domain.UserGroupCriteria) bool {
+	if previous == nil || next == nil {
+		return previous != next
+	}
+
+	return !slices.EqualFunc(previous.Conditions, next.Conditions, func(a, b domain.UserAttributeCondition) bool {
+		return a.Attribute == b.Attribute && a.Operator == b.Operator && slices.Equal(a.Values, b.Values)
+	})
+}
+
+// SetMachineTags replaces a machine's tags and re-evaluates its dynamic group memberships.
+func (s *Service) SetMachineTags(ctx context.Context, machineID uuid.UUID, tags []string) (domain.Machine, error) {
+	if err := s.store.SetMachineTags(ctx, machineID, normalizeTags(tags)); err != nil {
+		return domain.Machine{}, err
+	}
+
+	changed, err := s.store.SyncMachineComputedMemberships(ctx, machineID, time.Now().UTC())
+	if err != nil {
+		return domain.Machine{}, fmt.Errorf("sync dynamic memberships: %w", err)
+	}
+	if changed {
+		if err = s.store.UpdateMachineDesiredTargets(ctx, machineID); err != nil {
+			return domain.Machine{}, fmt.Errorf("sync machine desired rule targets: %w", err)
+		}
+	}
+
+	return s.store.GetMachine(ctx, machineID)
+}
+
+// SyncDynamicMemberships re-evaluates every dynamic group and recomputes the desired rules of
+// machines whose memberships, or whose primary user's memberships, changed. It returns how many
+// machines and users changed.
+func (s *Service) SyncDynamicMemberships(ctx context.Context) (int, error) {
+	changedMachines, err := s.store.SyncComputedMachineMemberships(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("sync dynamic machine memberships: %w", err)
+	}
+
+	for _, machineID := range changedMachines {
+		if err = s.store.UpdateMachineDesiredTargets(ctx, machineID); err != nil {
+			return 0, fmt.Errorf("sync machine desired rule targets: %w", err)
+		}
+	}
+
+	changedUsers, err := s.store.SyncDynamicUserMemberships(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("sync dynamic user memberships: %w", err)
+	}
+
+	for _, userID := range changedUsers {
+		if err = s.store.UpdateMachineDesiredTargetsByPrimaryUserID(ctx, userID); err != nil {
+			return 0, fmt.Errorf("sync machine desired rule targets: %w", err)
+		}
+	}
+
+	return len(changedMachines) + len(changedUsers), nil
+}
+
+// RunDynamicMemberships re-evaluates dynamic groups on an interval so time-based predicates such
+// as last-seen age stay current between preflights.
+func (s *Service) RunDynamicMemberships(ctx context.Context, interval time.Duration) {
+	s.syncDynamicMembershipsAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.InfoContext(ctx, "dynamic group worker stopped")
+			return
+		case <-ticker.C:
+			s.syncDynamicMembershipsAndLog(ctx)
+		}
+	}
+}
+
+func (s *Service) syncDynamicMembershipsAndLog(ctx context.Context) {
+	start := time.Now()
+
+	changed, err := s.SyncDynamicMemberships(ctx)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"dynamic group sync failed",
+			"error", err,
+			"duration", time.Since(start),
+		)
+		return
+	}
+
+	s.logger.InfoContext(
+		ctx,
+		"dynamic group sync complete",
+		"changed_members", changed,
+		"duration", time.Since(start),
+	)
+}
+
 func validateInput(input WriteInput) *domain.ValidationError {
 	err := &domain.ValidationError{
 		Code:   "validation_error",
@@ -67,8 +281,123 @@ func validateInput(input WriteInput) *domain.ValidationError {
 		err.Add("name", "must not be empty", "required")
 	}
 
+	if input.MachineCriteria != nil {
+		validateMachineCriteria(err, *input.MachineCriteria)
+	}
+
+	if input.UserCriteria != nil {
+		validateUserCriteria(err, *input.UserCriteria)
+	}
+
 	if !err.HasFieldErrors() {
 		return nil
 	}
 	return err
 }
+
+func validateMachineCriteria(err *domain.ValidationError, criteria domain.MachineGroupCriteria) {
+	versions := []struct {
+		field string
+		value string
+	}{
+		{"machine_criteria.os_version_min", criteria.OSVersionMin},
+		{"machine_criteria.os_version_max", criteria.OSVersionMax},
+		{"machine_criteria.santa_version_min", criteria.SantaVersionMin},
+		{"machine_criteria.santa_version_max", criteria.SantaVersionMax},
+	}
+	for _, version := range versions {
+		if version.value != "" && !domain.ValidVersion(version.value) {
+			err.Add(version.field, "must be a dotted numeric version", "invalid")
+		}
+	}
+
+	if criteria.OSVersionMin != "" && criteria.OSVersionMax != "" &&
+		domain.CompareVersions(criteria.OSVersionMin, criteria.OSVersionMax) > 0 {
+		err.Add("machine_criteria.os_version_max", "must not be lower than os_version_min", "invalid")
+	}
+	if criteria.SantaVersionMin != "" && criteria.SantaVersionMax != "" &&
+		domain.CompareVersions(criteria.SantaVersionMin, criteria.SantaVersionMax) > 0 {
+		err.Add("machine_criteria.santa_version_max", "must not be lower than santa_version_min", "invalid")
+	}
+
+	patterns := []struct {
+		field  string
+		values []string
+	}{
+		{"machine_criteria.model_identifiers", criteria.ModelIdentifiers},
+		{"machine_criteria.hostname_patterns", criteria.HostnamePatterns},
+		{"machine_criteria.serial_number_patterns", criteria.SerialNumberPatterns},
+	}
+	for _, pattern := range patterns {
+		if slices.ContainsFunc(pattern.values, func(value string) bool {
+			return strings.TrimSpace(value) == "" || !domain.ValidPattern(value)
+		}) {
+			err.Add(pattern.field, "must contain valid glob patterns", "invalid")
+		}
+	}
+
+	for _, mode := range criteria.ClientModes {
+		if _, parseErr := domain.ParseMachineClientMode(string(mode)); parseErr != nil {
+			err.Add("machine_criteria.client_modes", "must contain valid client modes", "invalid")
+			break
+		}
+	}
+
+	if criteria.LastSeenWithinDays < 0 {
+		err.Add("machine_criteria.last_seen_within_days", "must not be negative", "invalid")
+	}
+
+	if slices.ContainsFunc(criteria.Tags, func(tag string) bool { return strings.TrimSpace(tag) == "" }) {
+		err.Add("machine_criteria.tags", "must not contain empty tags", "invalid")
+	}
+}
+
+func validateUserCriteria(err *domain.ValidationError, criteria domain.UserGroupCriteria) {
+	if len(criteria.Conditions) == 0 {
+		err.Add("user_criteria.conditions", "must not be empty", "required")
+		return
+	}
+
+	for i, condition := range criteria.Conditions {
+		field := fmt.Sprintf("user_criteria.conditions[%d]", i)
+
+		if !slices.Contains(domain.UserAttributes(), condition.Attribute) {
+			err.Add(
+				field+".attribute",
+				"must be one of "+strings.Join(domain.UserAttributes(), ", "),
+				"invalid",
+			)
+		}
+
+		operator, parseErr := domain.ParseUserCriteriaOperator(string(condition.Operator))
+		if parseErr != nil {
+			err.Add(field+".operator", "must be equals, not_equals or matches", "invalid")
+		}
+
+		if len(condition.Values) == 0 {
+			err.Add(field+".values", "must not be empty", "required")
+			continue
+		}
+
+		if operator == domain.UserCriteriaOperatorMatches && slices.ContainsFunc(
+			condition.Values,
+			func(value string) bool { return strings.TrimSpace(value) == "" || !domain.ValidPattern(value) },
+		) {
+			err.Add(field+".values", "must contain valid glob patterns", "invalid")
+		}
+	}
+}
+
+// normalizeTags trims, lowercases, and de-duplicates tags.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
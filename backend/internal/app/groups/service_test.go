@@ -0,0 +1,202 @@
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/app/groups"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	group      domain.Group
+	grantsRole bool
+
+	updateCalls int
+	deleteCalls int
+}
+
+func (s *testStore) ListGroups(context.Context, domain.ListOptions) ([]domain.Group, int32, error) {
+	return nil, 0, errors.New("unexpected ListGroups call")
+}
+
+func (s *testStore) GetGroup(context.Context, uuid.UUID) (domain.Group, error) {
+	return s.group, nil
+}
+
+func (s *testStore) CreateLocalGroup(
+	context.Context,
+	string,
+	string,
+	*domain.MachineGroupCriteria,
+	*domain.UserGroupCriteria,
+) (domain.Group, error) {
+	return domain.Group{}, errors.New("unexpected CreateLocalGroup call")
+}
+
+func (s *testStore) UpdateGroup(
+	_ context.Context,
+	_ uuid.UUID,
+	name string,
+	description string,
+	machineCriteria *domain.MachineGroupCriteria,
+	userCriteria *domain.UserGroupCriteria,
+) (domain.Group, error) {
+	s.updateCalls++
+	s.group.Name = name
+	s.group.Description = description
+	s.group.MachineCriteria = machineCriteria
+	s.group.UserCriteria = userCriteria
+	return s.group, nil
+}
+
+func (s *testStore) DeleteGroup(context.Context, uuid.UUID) error {
+	s.deleteCalls++
+	return nil
+}
+
+func (s *testStore) GroupGrantsRole(context.Context, uuid.UUID) (bool, error) {
+	return s.grantsRole, nil
+}
+
+func (s *testStore) GetMachine(context.Context, uuid.UUID) (domain.Machine, error) {
+	return domain.Machine{}, errors.New("unexpected GetMachine call")
+}
+
+func (s *testStore) SetMachineTags(context.Context, uuid.UUID, []string) error {
+	return errors.New("unexpected SetMachineTags call")
+}
+
+func (s *testStore) SyncMachineComputedMemberships(context.Context, uuid.UUID, time.Time) (bool, error) {
+	return false, errors.New("unexpected SyncMachineComputedMemberships call")
+}
+
+func (s *testStore) SyncComputedMachineMemberships(context.Context, time.Time) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (s *testStore) SyncDynamicUserMemberships(context.Context) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (s *testStore) UpdateMachineDesiredTargets(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *testStore) UpdateMachineDesiredTargetsByPrimaryUserID(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *testStore) ListLocalGroupMappings(
+	context.Context,
+	domain.ListOptions,
+) ([]domain.LocalGroupMapping, int32, error) {
+	return nil, 0, errors.New("unexpected ListLocalGroupMappings call")
+}
+
+func (s *testStore) GetLocalGroupMapping(context.Context, uuid.UUID) (domain.LocalGroupMapping, error) {
+	return domain.LocalGroupMapping{}, errors.New("unexpected GetLocalGroupMapping call")
+}
+
+func (s *testStore) CreateLocalGroupMapping(context.Context, string, uuid.UUID) (domain.LocalGroupMapping, error) {
+	return domain.LocalGroupMapping{}, errors.New("unexpected CreateLocalGroupMapping call")
+}
+
+func (s *testStore) UpdateLocalGroupMapping(
+	context.Context,
+	uuid.UUID,
+	string,
+	uuid.UUID,
+) (domain.LocalGroupMapping, error) {
+	return domain.LocalGroupMapping{}, errors.New("unexpected UpdateLocalGroupMapping call")
+}
+
+func (s *testStore) DeleteLocalGroupMapping(context.Context, uuid.UUID) error {
+	return errors.New("unexpected DeleteLocalGroupMapping call")
+}
+
+func newTestService(store *testStore) *groups.Service {
+	return groups.New(slog.New(slog.DiscardHandler), store)
+}
+
+func TestUpdateGroup_LimitsRoleGroupUserCriteriaToAdmins(t *testing.T) {
+	students := &domain.UserGroupCriteria{Conditions: []domain.UserAttributeCondition{{
+		Attribute: domain.UserAttributeDepartment,
+		Operator:  domain.UserCriteriaOperatorEquals,
+		Values:    []string{"Students"},
+	}}}
+	staff := &domain.UserGroupCriteria{Conditions: []domain.UserAttributeCondition{{
+		Attribute: domain.UserAttributeDepartment,
+		Operator:  domain.UserCriteriaOperatorEquals,
+		Values:    []string{"Staff"},
+	}}}
+
+	tests := []struct {
+		name     string
+		actor    domain.Actor
+		previous *domain.UserGroupCriteria
+		next     *domain.UserGroupCriteria
+		wantErr  error
+	}{
+		{name: "helpdesk adds criteria", next: staff, wantErr: domain.ErrRoleGroupAdminOnly},
+		{name: "helpdesk changes criteria", previous: students, next: staff, wantErr: domain.ErrRoleGroupAdminOnly},
+		{name: "helpdesk removes criteria", previous: students, wantErr: domain.ErrRoleGroupAdminOnly},
+		{name: "helpdesk renames keeping criteria", previous: students, next: students},
+		{name: "admin changes criteria", actor: domain.Actor{Admin: true}, previous: students, next: staff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{
+				group:      domain.Group{Source: domain.PrincipalSourceLocal, UserCriteria: tt.previous},
+				grantsRole: true,
+			}
+
+			_, err := newTestService(store).UpdateGroup(
+				context.Background(),
+				tt.actor,
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				groups.WriteInput{Name: "Staff", UserCriteria: tt.next},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateGroup() error = %v, want %v", err, tt.wantErr)
+			}
+
+			wantCalls := 1
+			if tt.wantErr != nil {
+				wantCalls = 0
+			}
+			if store.updateCalls != wantCalls {
+				t.Fatalf("updateCalls = %d, want %d", store.updateCalls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestDeleteGroup_LimitsRoleGroupsToAdmins(t *testing.T) {
+	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	store := &testStore{grantsRole: true}
+	service := newTestService(store)
+
+	if err := service.DeleteGroup(context.Background(), domain.Actor{}, groupID); !errors.Is(
+		err,
+		domain.ErrRoleGroupAdminOnly,
+	) {
+		t.Fatalf("DeleteGroup() error = %v, want %v", err, domain.ErrRoleGroupAdminOnly)
+	}
+	if store.deleteCalls != 0 {
+		t.Fatalf("deleteCalls = %d, want 0", store.deleteCalls)
+	}
+
+	if err := service.DeleteGroup(context.Background(), domain.Actor{Admin: true}, groupID); err != nil {
+		t.Fatalf("DeleteGroup() as admin error = %v", err)
+	}
+	if store.deleteCalls != 1 {
+		t.Fatalf("deleteCalls = %d, want 1", store.deleteCalls)
+	}
+}
@@ -0,0 +1,354 @@
+// Package lockdown reports what switching machines from monitor to lockdown mode would block.
+package lockdown
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const (
+	defaultLookbackDays = 30
+	maxLookbackDays     = 365
+)
+
+type Store interface {
+	GetGroup(context.Context, uuid.UUID) (domain.Group, error)
+	ListMachineIDsByEffectiveGroupID(context.Context, uuid.UUID) ([]uuid.UUID, error)
+	ListResolvedRulesForMachines(context.Context, []uuid.UUID) (map[uuid.UUID][]domain.MachineResolvedRule, error)
+	ListUnknownExecutions(context.Context, []uuid.UUID, time.Time) ([]domain.UnknownExecution, error)
+	ListRules(context.Context, domain.RuleListOptions) ([]domain.RuleSummary, int32, error)
+}
+
+type Service struct {
+	store Store
+	now   func() time.Time
+}
+
+func New(store Store) *Service {
+	return &Service{store: store, now: time.Now}
+}
+
+// ReadinessInput selects the machines to analyze: the members of a group, explicit machines, or
+// both. LookbackDays defaults to 30.
+type ReadinessInput struct {
+	GroupID      *uuid.UUID
+	MachineIDs   []uuid.UUID
+	LookbackDays int32
+}
+
+// Readiness replays the allow_unknown executions the selected machines reported in the lookback
+// window against the rules that currently resolve for each machine. Executions with no matching
+// rule would hit lockdown's default deny; executions matched by a blocklist rule would be blocked
+// by that rule. Allowlist and CEL rules count as covering the execution.
+func (s *Service) Readiness(
+	ctx context.Context,
+	input ReadinessInput,
+) (domain.LockdownReadinessReport, error) {
+	if err := validateReadinessInput(input); err != nil {
+		return domain.LockdownReadinessReport{}, err
+	}
+
+	machineIDs, err := s.machineIDs(ctx, input)
+	if err != nil {
+		return domain.LockdownReadinessReport{}, err
+	}
+
+	lookbackDays := input.LookbackDays
+	if lookbackDays == 0 {
+		lookbackDays = defaultLookbackDays
+	}
+	since := s.now().UTC().AddDate(0, 0, -int(lookbackDays))
+
+	executions, err := s.store.ListUnknownExecutions(ctx, machineIDs, since)
+	if err != nil {
+		return domain.LockdownReadinessReport{}, err
+	}
+
+	resolved, err := s.store.ListResolvedRulesForMachines(ctx, machineIDs)
+	if err != nil {
+		return domain.LockdownReadinessReport{}, err
+	}
+
+	indexes := make(map[uuid.UUID]domain.MachineRuleIndex, len(machineIDs))
+	for _, machineID := range machineIDs {
+		indexes[machineID] = domain.NewMachineRuleIndex(resolved[machineID])
+	}
+
+	existingRules, _, err := s.store.ListRules(ctx, domain.RuleListOptions{})
+	if err != nil {
+		return domain.LockdownReadinessReport{}, err
+	}
+
+	report := buildReport(executions, indexes, existingRules)
+	report.Since = since
+	report.MachineCount = int32(len(machineIDs)) //nolint:gosec // machine counts stay far below MaxInt32
+
+	return report, nil
+}
+
+func (s *Service) machineIDs(ctx context.Context, input ReadinessInput) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]struct{}, len(input.MachineIDs))
+	machineIDs := make([]uuid.UUID, 0, len(input.MachineIDs))
+	add := func(ids []uuid.UUID) {
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			machineIDs = append(machineIDs, id)
+		}
+	}
+
+	if input.GroupID != nil {
+		if _, err := s.store.GetGroup(ctx, *input.GroupID); err != nil {
+			return nil, err
+		}
+
+		groupMachineIDs, err := s.store.ListMachineIDsByEffectiveGroupID(ctx, *input.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		add(groupMachineIDs)
+	}
+	add(input.MachineIDs)
+
+	return machineIDs, nil
+}
+
+func validateReadinessInput(input ReadinessInput) error {
+	err := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Lockdown readiness request is invalid.",
+	}
+
+	if input.GroupID == nil && len(input.MachineIDs) == 0 {
+		err.Add("group_id", "group_id or machine_ids is required", "required")
+	}
+	if input.LookbackDays < 0 || input.LookbackDays > maxLookbackDays {
+		err.Add("lookback_days", "must be between 1 and 365", "invalid")
+	}
+
+	if !err.HasFieldErrors() {
+		return nil
+	}
+
+	return err
+}
+
+type executableImpact struct {
+	impact   domain.LockdownExecutableImpact
+	identity domain.ExecutableIdentity
+	machines map[uuid.UUID]struct{}
+	users    map[string]struct{}
+}
+
+type userImpact struct {
+	impact      domain.LockdownUserImpact
+	executables map[uuid.UUID]struct{}
+	machines    map[uuid.UUID]struct{}
+}
+
+type machineImpact struct {
+	impact      domain.LockdownMachineImpact
+	executables map[uuid.UUID]struct{}
+	users       map[string]struct{}
+}
+
+func buildReport(
+	executions []domain.UnknownExecution,
+	indexes map[uuid.UUID]domain.MachineRuleIndex,
+	existingRules []domain.RuleSummary,
+) domain.LockdownReadinessReport {
+	executables := make(map[uuid.UUID]*executableImpact)
+	users := make(map[string]*userImpact)
+	machines := make(map[uuid.UUID]*machineImpact)
+	var blockedExecutions int32
+
+	for _, execution := range executions {
+		rule, matched := indexes[execution.MachineID].Match(execution.Identity)
+		if matched && (rule.Policy == domain.RulePolicyAllowlist || rule.Policy == domain.RulePolicyCEL) {
+			continue
+		}
+
+		blockedExecutions += execution.ExecutionCount
+
+		executable, ok := executables[execution.ExecutableID]
+		if !ok {
+			executable = &executableImpact{
+				impact: domain.LockdownExecutableImpact{
+					ExecutableID: execution.ExecutableID,
+					FileName:     execution.FileName,
+					FileSHA256:   execution.Identity.FileSHA256,
+					SigningID:    execution.Identity.SigningID,
+					TeamID:       execution.Identity.TeamID,
+				},
+				identity: execution.Identity,
+				machines: make(map[uuid.UUID]struct{}),
+				users:    make(map[string]struct{}),
+			}
+			executables[execution.ExecutableID] = executable
+		}
+		executable.impact.ExecutionCount += execution.ExecutionCount
+		executable.machines[execution.MachineID] = struct{}{}
+		if execution.LastExecutedAt.After(executable.impact.LastExecutedAt) {
+			executable.impact.LastExecutedAt = execution.LastExecutedAt
+		}
+		if matched && executable.impact.RuleID == nil {
+			ruleID := rule.RuleID
+			executable.impact.RuleID = &ruleID
+		}
+
+		machine, ok := machines[execution.MachineID]
+		if !ok {
+			machine = &machineImpact{
+				impact: domain.LockdownMachineImpact{
+					MachineID: execution.MachineID,
+					Hostname:  execution.Hostname,
+				},
+				executables: make(map[uuid.UUID]struct{}),
+				users:       make(map[string]struct{}),
+			}
+			machines[execution.MachineID] = machine
+		}
+		machine.impact.ExecutionCount += execution.ExecutionCount
+		machine.executables[execution.ExecutableID] = struct{}{}
+
+		if execution.ExecutingUser == "" {
+			continue
+		}
+
+		executable.users[execution.ExecutingUser] = struct{}{}
+		machine.users[execution.ExecutingUser] = struct{}{}
+
+		user, ok := users[execution.ExecutingUser]
+		if !ok {
+			user = &userImpact{
+				impact:      domain.LockdownUserImpact{ExecutingUser: execution.ExecutingUser},
+				executables: make(map[uuid.UUID]struct{}),
+				machines:    make(map[uuid.UUID]struct{}),
+			}
+			users[execution.ExecutingUser] = user
+		}
+		user.impact.ExecutionCount += execution.ExecutionCount
+		user.executables[execution.ExecutableID] = struct{}{}
+		user.machines[execution.MachineID] = struct{}{}
+	}
+
+	ruleIDs := make(map[string]uuid.UUID, len(existingRules))
+	for _, rule := range existingRules {
+		ruleIDs[domain.MachineRuleTargetKey(domain.MachineRuleTarget{
+			RuleType:   rule.RuleType,
+			Identifier: rule.Identifier,
+		})] = rule.ID
+	}
+
+	report := domain.LockdownReadinessReport{
+		BlockedExecutionCount: blockedExecutions,
+		Executables:           make([]domain.LockdownExecutableImpact, 0, len(executables)),
+		Users:                 make([]domain.LockdownUserImpact, 0, len(users)),
+		Machines:              make([]domain.LockdownMachineImpact, 0, len(machines)),
+	}
+
+	for _, executable := range executables {
+		executable.impact.MachineCount = countOf(executable.machines)
+		executable.impact.UserCount = countOf(executable.users)
+		if executable.impact.RuleID == nil {
+			executable.impact.SuggestedRules = suggestRules(executable.identity, ruleIDs)
+		} else {
+			executable.impact.SuggestedRules = []domain.LockdownRuleSuggestion{}
+		}
+		report.Executables = append(report.Executables, executable.impact)
+	}
+	for _, user := range users {
+		user.impact.ExecutableCount = countOf(user.executables)
+		user.impact.MachineCount = countOf(user.machines)
+		report.Users = append(report.Users, user.impact)
+	}
+	for _, machine := range machines {
+		machine.impact.ExecutableCount = countOf(machine.executables)
+		machine.impact.UserCount = countOf(machine.users)
+		report.Machines = append(report.Machines, machine.impact)
+	}
+
+	sortReport(&report)
+
+	return report
+}
+
+// suggestRules lists the rules that would allow an executable, narrowest first. CDHash rules are
+// left out because they break on every rebuild.
+func suggestRules(
+	identity domain.ExecutableIdentity,
+	ruleIDs map[string]uuid.UUID,
+) []domain.LockdownRuleSuggestion {
+	ruleTypes := []domain.RuleType{
+		domain.RuleTypeBinary,
+		domain.RuleTypeSigningID,
+		domain.RuleTypeCertificate,
+		domain.RuleTypeTeamID,
+	}
+
+	suggestions := make([]domain.LockdownRuleSuggestion, 0, len(ruleTypes))
+	for _, ruleType := range ruleTypes {
+		identifier := identity.Identifier(ruleType)
+		if identifier == "" {
+			continue
+		}
+
+		suggestion := domain.LockdownRuleSuggestion{RuleType: ruleType, Identifier: identifier}
+		if ruleID, ok := ruleIDs[domain.MachineRuleTargetKey(domain.MachineRuleTarget{
+			RuleType:   ruleType,
+			Identifier: identifier,
+		})]; ok {
+			suggestion.RuleID = &ruleID
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
+
+func sortReport(report *domain.LockdownReadinessReport) {
+	sort.Slice(report.Executables, func(i, j int) bool {
+		a, b := report.Executables[i], report.Executables[j]
+		if a.MachineCount != b.MachineCount {
+			return a.MachineCount > b.MachineCount
+		}
+		if a.UserCount != b.UserCount {
+			return a.UserCount > b.UserCount
+		}
+		if a.ExecutionCount != b.ExecutionCount {
+			return a.ExecutionCount > b.ExecutionCount
+		}
+		return a.FileName < b.FileName
+	})
+	sort.Slice(report.Users, func(i, j int) bool {
+		a, b := report.Users[i], report.Users[j]
+		if a.ExecutableCount != b.ExecutableCount {
+			return a.ExecutableCount > b.ExecutableCount
+		}
+		if a.ExecutionCount != b.ExecutionCount {
+			return a.ExecutionCount > b.ExecutionCount
+		}
+		return a.ExecutingUser < b.ExecutingUser
+	})
+	sort.Slice(report.Machines, func(i, j int) bool {
+		a, b := report.Machines[i], report.Machines[j]
+		if a.ExecutableCount != b.ExecutableCount {
+			return a.ExecutableCount > b.ExecutableCount
+		}
+		if a.ExecutionCount != b.ExecutionCount {
+			return a.ExecutionCount > b.ExecutionCount
+		}
+		return a.Hostname < b.Hostname
+	})
+}
+
+func countOf[K comparable](set map[K]struct{}) int32 {
+	return int32(len(set)) //nolint:gosec // report sets are bounded by the fleet and its executables
+}
@@ -0,0 +1,226 @@
+package lockdown_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/app/lockdown"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	groupMachines []uuid.UUID
+	resolved      map[uuid.UUID][]domain.MachineResolvedRule
+	resolveCalls  int
+	executions    []domain.UnknownExecution
+	rules         []domain.RuleSummary
+}
+
+func (s *testStore) GetGroup(_ context.Context, id uuid.UUID) (domain.Group, error) {
+	return domain.Group{ID: id}, nil
+}
+
+func (s *testStore) ListMachineIDsByEffectiveGroupID(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return s.groupMachines, nil
+}
+
+func (s *testStore) ListResolvedRulesForMachines(
+	_ context.Context,
+	machineIDs []uuid.UUID,
+) (map[uuid.UUID][]domain.MachineResolvedRule, error) {
+	s.resolveCalls++
+
+	resolved := make(map[uuid.UUID][]domain.MachineResolvedRule, len(machineIDs))
+	for _, id := range machineIDs {
+		if rules, ok := s.resolved[id]; ok {
+			resolved[id] = rules
+		}
+	}
+	return resolved, nil
+}
+
+func (s *testStore) ListUnknownExecutions(
+	_ context.Context,
+	machineIDs []uuid.UUID,
+	_ time.Time,
+) ([]domain.UnknownExecution, error) {
+	selected := make(map[uuid.UUID]bool, len(machineIDs))
+	for _, id := range machineIDs {
+		selected[id] = true
+	}
+
+	executions := make([]domain.UnknownExecution, 0, len(s.executions))
+	for _, execution := range s.executions {
+		if selected[execution.MachineID] {
+			executions = append(executions, execution)
+		}
+	}
+	return executions, nil
+}
+
+func (s *testStore) ListRules(context.Context, domain.RuleListOptions) ([]domain.RuleSummary, int32, error) {
+	return s.rules, 0, nil
+}
+
+func resolvedRule(ruleType domain.RuleType, identifier string, policy domain.RulePolicy) domain.MachineResolvedRule {
+	return domain.MachineResolvedRule{
+		MachineRuleTarget: domain.MachineRuleTarget{RuleType: ruleType, Identifier: identifier, Policy: policy},
+		RuleID:            uuid.New(),
+	}
+}
+
+func TestReadinessReportsExecutionsWithoutAllowingRule(t *testing.T) {
+	laptop, desktop := uuid.New(), uuid.New()
+	browser, updater, tool := uuid.New(), uuid.New(), uuid.New()
+	existingSigningRule := uuid.New()
+
+	store := &testStore{
+		groupMachines: []uuid.UUID{laptop, desktop},
+		resolved: map[uuid.UUID][]domain.MachineResolvedRule{
+			laptop: {resolvedRule(domain.RuleTypeTeamID, "EQHXZ8M8AV", domain.RulePolicyAllowlist)},
+		},
+		executions: []domain.UnknownExecution{
+			{
+				MachineID:    laptop,
+				ExecutableID: browser,
+				FileName:     "Browser",
+				Identity: domain.ExecutableIdentity{
+					FileSHA256: "browser-sha",
+					SigningID:  "EQHXZ8M8AV:com.example.browser",
+					TeamID:     "EQHXZ8M8AV",
+				},
+				ExecutingUser:  "alice",
+				ExecutionCount: 4,
+			},
+			{
+				MachineID:    desktop,
+				ExecutableID: browser,
+				FileName:     "Browser",
+				Identity: domain.ExecutableIdentity{
+					FileSHA256: "browser-sha",
+					SigningID:  "EQHXZ8M8AV:com.example.browser",
+					TeamID:     "EQHXZ8M8AV",
+				},
+				ExecutingUser:  "bob",
+				ExecutionCount: 2,
+			},
+			{
+				MachineID:      laptop,
+				ExecutableID:   updater,
+				FileName:       "Updater",
+				Identity:       domain.ExecutableIdentity{FileSHA256: "updater-sha"},
+				ExecutingUser:  "alice",
+				ExecutionCount: 1,
+			},
+			{
+				MachineID:      desktop,
+				ExecutableID:   tool,
+				FileName:       "Tool",
+				Identity:       domain.ExecutableIdentity{FileSHA256: "tool-sha"},
+				ExecutingUser:  "bob",
+				ExecutionCount: 7,
+			},
+		},
+		rules: []domain.RuleSummary{
+			{ID: existingSigningRule, RuleType: domain.RuleTypeSigningID, Identifier: "EQHXZ8M8AV:com.example.browser"},
+		},
+	}
+
+	groupID := uuid.New()
+	report, err := lockdown.New(store).Readiness(context.Background(), lockdown.ReadinessInput{GroupID: &groupID})
+	if err != nil {
+		t.Fatalf("Readiness() error = %v", err)
+	}
+
+	if report.MachineCount != 2 {
+		t.Fatalf("MachineCount = %d, want 2", report.MachineCount)
+	}
+	if store.resolveCalls != 1 {
+		t.Fatalf("resolveCalls = %d, want one batch for every machine", store.resolveCalls)
+	}
+	if report.BlockedExecutionCount != 10 {
+		t.Fatalf("BlockedExecutionCount = %d, want 10", report.BlockedExecutionCount)
+	}
+	if len(report.Executables) != 3 {
+		t.Fatalf("len(Executables) = %d, want 3", len(report.Executables))
+	}
+
+	// The browser is allowed by Team ID on the laptop, so only the desktop run counts.
+	first := report.Executables[0]
+	if first.ExecutableID != tool || first.ExecutionCount != 7 {
+		t.Fatalf("Executables[0] = %s with %d executions, want tool with 7", first.FileName, first.ExecutionCount)
+	}
+	browserImpact := report.Executables[1]
+	if browserImpact.ExecutableID != browser || browserImpact.MachineCount != 1 || browserImpact.ExecutionCount != 2 {
+		t.Fatalf("Executables[1] = %+v, want browser blocked on one machine twice", browserImpact)
+	}
+
+	var suggestedSigningRule *uuid.UUID
+	for _, suggestion := range browserImpact.SuggestedRules {
+		if suggestion.RuleType == domain.RuleTypeSigningID {
+			suggestedSigningRule = suggestion.RuleID
+		}
+	}
+	if suggestedSigningRule == nil || *suggestedSigningRule != existingSigningRule {
+		t.Fatalf("signing ID suggestion rule = %v, want existing rule %s", suggestedSigningRule, existingSigningRule)
+	}
+
+	if len(report.Users) != 2 || report.Users[0].ExecutingUser != "bob" || report.Users[0].ExecutableCount != 2 {
+		t.Fatalf("Users = %+v, want bob first with two executables", report.Users)
+	}
+	if len(report.Machines) != 2 || report.Machines[0].MachineID != desktop {
+		t.Fatalf("Machines = %+v, want desktop first", report.Machines)
+	}
+}
+
+func TestReadinessTreatsBlocklistMatchAsBlockedWithoutSuggestions(t *testing.T) {
+	machineID, executableID := uuid.New(), uuid.New()
+	blocklist := resolvedRule(domain.RuleTypeBinary, "game-sha", domain.RulePolicyBlocklist)
+
+	store := &testStore{
+		resolved: map[uuid.UUID][]domain.MachineResolvedRule{
+			machineID: {
+				blocklist,
+				resolvedRule(domain.RuleTypeTeamID, "GAMESTUDIO", domain.RulePolicyAllowlist),
+			},
+		},
+		executions: []domain.UnknownExecution{{
+			MachineID:      machineID,
+			ExecutableID:   executableID,
+			FileName:       "Game",
+			Identity:       domain.ExecutableIdentity{FileSHA256: "game-sha", TeamID: "GAMESTUDIO"},
+			ExecutionCount: 1,
+		}},
+	}
+
+	report, err := lockdown.New(store).Readiness(context.Background(), lockdown.ReadinessInput{
+		MachineIDs: []uuid.UUID{machineID},
+	})
+	if err != nil {
+		t.Fatalf("Readiness() error = %v", err)
+	}
+
+	if len(report.Executables) != 1 {
+		t.Fatalf("len(Executables) = %d, want 1", len(report.Executables))
+	}
+	impact := report.Executables[0]
+	if impact.RuleID == nil || *impact.RuleID != blocklist.RuleID {
+		t.Fatalf("RuleID = %v, want blocklist rule %s", impact.RuleID, blocklist.RuleID)
+	}
+	if len(impact.SuggestedRules) != 0 {
+		t.Fatalf("SuggestedRules = %+v, want none", impact.SuggestedRules)
+	}
+}
+
+func TestReadinessRequiresMachines(t *testing.T) {
+	_, err := lockdown.New(&testStore{}).Readiness(context.Background(), lockdown.ReadinessInput{})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Readiness() error = %v, want validation error", err)
+	}
+}
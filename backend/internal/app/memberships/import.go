@@ -0,0 +1,162 @@
+package memberships
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+// membershipImportKeyPrecedence is the order key columns are tried in when a row fills several.
+var membershipImportKeyPrecedence = []domain.MembershipImportKey{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+	domain.MembershipImportKeyMachineID,
+	domain.MembershipImportKeySerialNumber,
+	domain.MembershipImportKeyHostname,
+	domain.MembershipImportKeyUPN,
+}
+
+type ImportInput struct {
+	GroupID        uuid.UUID
+	Mode           domain.MembershipImportMode
+	CSV            string
+	AllowUnmatched bool
+}
+
+// ImportMemberships applies a CSV of machines and users to a group's explicit memberships. The
+// CSV needs a header naming at least one of machine_id, serial_number, hostname or upn; other
+// columns are ignored. Add creates memberships, remove deletes them, and replace also deletes
+// every explicit user and machine membership the CSV does not list. Rows that do not resolve to
+// exactly one member are reported and skipped. A replace that matches nobody is rejected, as is one
+// with unmatched rows unless AllowUnmatched confirms it. Desired targets are recomputed once,
+// afterwards. Only admins may import into a group that grants a role.
+func (s *Service) ImportMemberships(
+	ctx context.Context,
+	actor domain.Actor,
+	input ImportInput,
+) (domain.MembershipImportResult, error) {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Membership import is invalid.",
+	}
+
+	switch input.Mode {
+	case domain.MembershipImportModeAdd, domain.MembershipImportModeRemove, domain.MembershipImportModeReplace:
+	default:
+		validationErr.Add("mode", "must be add, remove or replace", "invalid")
+	}
+
+	rows := parseMembershipImportCSV(input.CSV, validationErr)
+
+	if validationErr.HasFieldErrors() {
+		return domain.MembershipImportResult{}, validationErr
+	}
+
+	group, err := s.store.GetGroup(ctx, input.GroupID)
+	if err != nil {
+		return domain.MembershipImportResult{}, err
+	}
+	if group.Source == domain.PrincipalSourceEntra {
+		return domain.MembershipImportResult{}, domain.ErrGroupReadOnly
+	}
+	if err = requireRoleGroupAdmin(ctx, s.store, actor, input.GroupID); err != nil {
+		return domain.MembershipImportResult{}, err
+	}
+
+	result, changedMachineIDs, err := s.store.ImportMemberships(
+		ctx,
+		input.GroupID,
+		input.Mode,
+		input.AllowUnmatched,
+		rows,
+	)
+	if err != nil {
+		return domain.MembershipImportResult{}, err
+	}
+
+	if err = s.store.UpdateMachineDesiredTargetsByMachineIDs(ctx, changedMachineIDs); err != nil {
+		return domain.MembershipImportResult{}, err
+	}
+
+	return result, nil
+}
+
+// parseMembershipImportCSV reads import rows, numbered by CSV line, adding any problem with the
+// CSV itself to validationErr. Rows without a value in any key column get an empty Key.
+func parseMembershipImportCSV(
+	data string,
+	validationErr *domain.ValidationError,
+) []domain.MembershipImportRow {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		validationErr.Add("csv", "must include a header row", "required")
+		return nil
+	}
+	if err != nil {
+		validationErr.Add("csv", err.Error(), "invalid")
+		return nil
+	}
+
+	columns := map[domain.MembershipImportKey]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if key, parseErr := domain.ParseMembershipImportKey(name); parseErr == nil {
+			if _, ok := columns[key]; !ok {
+				columns[key] = i
+			}
+		}
+	}
+	if len(columns) == 0 {
+		validationErr.Add("csv", "header must include a machine_id, serial_number, hostname or upn column", "invalid")
+		return nil
+	}
+
+	var rows []domain.MembershipImportRow
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			validationErr.Add("csv", readErr.Error(), "invalid")
+			return nil
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, membershipImportRow(line, record, columns))
+	}
+
+	if len(rows) == 0 {
+		validationErr.Add("csv", "must include at least one row", "required")
+		return nil
+	}
+
+	return rows
+}
+
+func membershipImportRow(
+	line int,
+	record []string,
+	columns map[domain.MembershipImportKey]int,
+) domain.MembershipImportRow {
+	for _, key := range membershipImportKeyPrecedence {
+		column, ok := columns[key]
+		if !ok || column >= len(record) {
+			continue
+		}
+
+		if value := strings.TrimSpace(record[column]); value != "" {
+			return domain.MembershipImportRow{Row: line, Key: key, Value: value}
+		}
+	}
+
+	return domain.MembershipImportRow{Row: line, Value: strings.Join(record, ",")}
+}
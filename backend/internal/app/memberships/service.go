@@ -3,6 +3,8 @@ package memberships
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,6 +15,8 @@ type CreateInput struct {
 	GroupID    uuid.UUID
 	MemberKind domain.MemberKind
 	MemberID   uuid.UUID
+	StartsAt   *time.Time
+	ExpiresAt  *time.Time
 }
 
 type Store interface {
@@ -24,19 +28,35 @@ type Store interface {
 		domain.MemberKind,
 		uuid.UUID,
 		domain.MembershipOrigin,
+		*time.Time,
+		*time.Time,
 	) (domain.Membership, error)
 	DeleteMembership(context.Context, uuid.UUID, domain.MemberKind) error
+	SweepMemberships(context.Context, time.Time) ([]domain.MembershipMember, error)
+	ImportMemberships(
+		context.Context,
+		uuid.UUID,
+		domain.MembershipImportMode,
+		bool,
+		[]domain.MembershipImportRow,
+	) (domain.MembershipImportResult, []uuid.UUID, error)
+	PreviewMembershipChange(context.Context, domain.MembershipChange) (domain.PolicyImpactPreview, error)
 	GetGroup(context.Context, uuid.UUID) (domain.Group, error)
+	GroupGrantsRole(context.Context, uuid.UUID) (bool, error)
 	UpdateMachineDesiredTargets(context.Context, uuid.UUID) error
 	UpdateMachineDesiredTargetsByPrimaryUserID(context.Context, uuid.UUID) error
+	UpdateMachineDesiredTargetsByGroupID(context.Context, uuid.UUID) error
+	UpdateMachineDesiredTargetsByMachineIDs(context.Context, []uuid.UUID) error
 }
 
 type Service struct {
-	store Store
+	logger *slog.Logger
+	store  Store
+	now    func() time.Time
 }
 
-func New(store Store) *Service {
-	return &Service{store: store}
+func New(logger *slog.Logger, store Store) *Service {
+	return &Service{logger: logger, store: store, now: time.Now}
 }
 
 func (s *Service) ListMemberships(
@@ -46,11 +66,41 @@ func (s *Service) ListMemberships(
 	return s.store.ListMemberships(ctx, opts)
 }
 
+// ListExpiringMemberships lists memberships that expire within the given window, soonest first.
+func (s *Service) ListExpiringMemberships(
+	ctx context.Context,
+	within time.Duration,
+	opts domain.ListOptions,
+) ([]domain.Membership, int32, error) {
+	if within <= 0 {
+		validationErr := &domain.ValidationError{
+			Code:   "validation_error",
+			Detail: "Expiring membership query is invalid.",
+		}
+		validationErr.Add("within_days", "must be positive", "invalid")
+		return nil, 0, validationErr
+	}
+
+	if opts.Sort == "" {
+		opts.Sort = "expires_at"
+	}
+
+	expiresBefore := s.now().UTC().Add(within)
+	return s.store.ListMemberships(ctx, domain.MembershipListOptions{
+		ListOptions:   opts,
+		ExpiresBefore: &expiresBefore,
+	})
+}
+
 func (s *Service) GetMembership(ctx context.Context, id uuid.UUID) (domain.Membership, error) {
 	return s.store.GetMembership(ctx, id)
 }
 
-func (s *Service) CreateMembership(ctx context.Context, input CreateInput) (domain.Membership, error) {
+func (s *Service) CreateMembership(
+	ctx context.Context,
+	actor domain.Actor,
+	input CreateInput,
+) (domain.Membership, error) {
 	group, err := s.store.GetGroup(ctx, input.GroupID)
 	if err != nil {
 		return domain.Membership{}, err
@@ -58,6 +108,9 @@ func (s *Service) CreateMembership(ctx context.Context, input CreateInput) (doma
 	if group.Source == domain.PrincipalSourceEntra {
 		return domain.Membership{}, domain.ErrGroupReadOnly
 	}
+	if err = requireRoleGroupAdmin(ctx, s.store, actor, input.GroupID); err != nil {
+		return domain.Membership{}, err
+	}
 
 	validationErr := &domain.ValidationError{
 		Code:   "validation_error",
@@ -65,9 +118,15 @@ func (s *Service) CreateMembership(ctx context.Context, input CreateInput) (doma
 	}
 
 	switch input.MemberKind {
-	case domain.MemberKindUser, domain.MemberKindMachine:
+	case domain.MemberKindUser, domain.MemberKindMachine, domain.MemberKindGroup:
 	default:
-		validationErr.Add("member_kind", "must be user or machine", "invalid")
+		validationErr.Add("member_kind", "must be user, machine or group", "invalid")
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(s.now()) {
+		validationErr.Add("expires_at", "must be in the future", "invalid")
+	}
+	if input.StartsAt != nil && input.ExpiresAt != nil && !input.ExpiresAt.After(*input.StartsAt) {
+		validationErr.Add("expires_at", "must be after starts_at", "invalid")
 	}
 
 	if validationErr.HasFieldErrors() {
@@ -80,11 +139,17 @@ func (s *Service) CreateMembership(ctx context.Context, input CreateInput) (doma
 		input.MemberKind,
 		input.MemberID,
 		domain.MembershipOriginExplicit,
+		input.StartsAt,
+		input.ExpiresAt,
 	)
 	if err != nil {
 		return domain.Membership{}, err
 	}
 
+	if membership.Pending {
+		return membership, nil
+	}
+
 	if err = syncMembershipMachineRuleTargets(ctx, s.store, input.MemberKind, input.MemberID); err != nil {
 		return domain.Membership{}, err
 	}
@@ -92,7 +157,7 @@ func (s *Service) CreateMembership(ctx context.Context, input CreateInput) (doma
 	return membership, nil
 }
 
-func (s *Service) DeleteMembership(ctx context.Context, id uuid.UUID) error {
+func (s *Service) DeleteMembership(ctx context.Context, actor domain.Actor, id uuid.UUID) error {
 	membership, err := s.store.GetMembership(ctx, id)
 	if err != nil {
 		return err
@@ -101,14 +166,137 @@ func (s *Service) DeleteMembership(ctx context.Context, id uuid.UUID) error {
 	if membership.Group.Source == domain.PrincipalSourceEntra {
 		return domain.ErrGroupReadOnly
 	}
+	if err = requireRoleGroupAdmin(ctx, s.store, actor, membership.Group.ID); err != nil {
+		return err
+	}
+	if membership.Origin == domain.MembershipOriginDynamic ||
+		(membership.Origin == domain.MembershipOriginSynced && membership.Member.Kind == domain.MemberKindMachine) {
+		return domain.ErrMembershipManaged
+	}
 
 	if err = s.store.DeleteMembership(ctx, id, membership.Member.Kind); err != nil {
 		return err
 	}
+	if membership.Pending {
+		return nil
+	}
 
 	return syncMembershipMachineRuleTargets(ctx, s.store, membership.Member.Kind, membership.Member.ID)
 }
 
+// SweepMemberships activates memberships whose start has passed, deletes expired ones, and
+// recomputes desired rule targets for the machines they affect. It returns the number of members
+// whose memberships changed.
+func (s *Service) SweepMemberships(ctx context.Context) (int, error) {
+	members, err := s.store.SweepMemberships(ctx, s.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("sweep memberships: %w", err)
+	}
+
+	for _, member := range members {
+		if err = syncMembershipMachineRuleTargets(ctx, s.store, member.Kind, member.ID); err != nil {
+			return 0, fmt.Errorf("sync machine desired rule targets: %w", err)
+		}
+	}
+
+	return len(members), nil
+}
+
+// RunSweeper sweeps time-bound memberships on an interval until ctx is cancelled.
+func (s *Service) RunSweeper(ctx context.Context, interval time.Duration) {
+	s.sweepMembershipsAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.InfoContext(ctx, "membership sweeper stopped")
+			return
+		case <-ticker.C:
+			s.sweepMembershipsAndLog(ctx)
+		}
+	}
+}
+
+func (s *Service) sweepMembershipsAndLog(ctx context.Context) {
+	start := time.Now()
+
+	changed, err := s.SweepMemberships(ctx)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"membership sweep failed",
+			"error", err,
+			"duration", time.Since(start),
+		)
+		return
+	}
+
+	s.logger.InfoContext(
+		ctx,
+		"membership sweep complete",
+		"changed_members", changed,
+		"duration", time.Since(start),
+	)
+}
+
+// PreviewMembershipChange reports how adding or removing a group member would change the rules
+// resolved for the affected machines, without applying the change.
+func (s *Service) PreviewMembershipChange(
+	ctx context.Context,
+	change domain.MembershipChange,
+) (domain.PolicyImpactPreview, error) {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Group membership change is invalid.",
+	}
+
+	switch change.Action {
+	case domain.MembershipChangeActionAdd, domain.MembershipChangeActionRemove:
+	default:
+		validationErr.Add("action", "must be add or remove", "invalid")
+	}
+	switch change.MemberKind {
+	case domain.MemberKindUser, domain.MemberKindMachine, domain.MemberKindGroup:
+	default:
+		validationErr.Add("member_kind", "must be user, machine or group", "invalid")
+	}
+
+	if validationErr.HasFieldErrors() {
+		return domain.PolicyImpactPreview{}, validationErr
+	}
+
+	group, err := s.store.GetGroup(ctx, change.GroupID)
+	if err != nil {
+		return domain.PolicyImpactPreview{}, err
+	}
+	if group.Source == domain.PrincipalSourceEntra {
+		return domain.PolicyImpactPreview{}, domain.ErrGroupReadOnly
+	}
+
+	return s.store.PreviewMembershipChange(ctx, change)
+}
+
+// requireRoleGroupAdmin refuses non-admins any change to the members of a group that grants a
+// role, directly or through a group it is nested in, because membership is how roles are held.
+func requireRoleGroupAdmin(ctx context.Context, store Store, actor domain.Actor, groupID uuid.UUID) error {
+	if actor.Admin {
+		return nil
+	}
+
+	grantsRole, err := store.GroupGrantsRole(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if grantsRole {
+		return domain.ErrRoleGroupAdminOnly
+	}
+
+	return nil
+}
+
 func syncMembershipMachineRuleTargets(
 	ctx context.Context,
 	store Store,
@@ -120,6 +308,8 @@ func syncMembershipMachineRuleTargets(
 		return store.UpdateMachineDesiredTargets(ctx, memberID)
 	case domain.MemberKindUser:
 		return store.UpdateMachineDesiredTargetsByPrimaryUserID(ctx, memberID)
+	case domain.MemberKindGroup:
+		return store.UpdateMachineDesiredTargetsByGroupID(ctx, memberID)
 	default:
 		return fmt.Errorf("unsupported member kind %q", memberKind)
 	}
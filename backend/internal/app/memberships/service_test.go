@@ -3,7 +3,9 @@ package memberships_test
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,15 +16,26 @@ import (
 type testStore struct {
 	group               domain.Group
 	getGroupErr         error
+	grantsRole          bool
 	getMembershipResult domain.Membership
 	getMembershipErr    error
 
 	createdMembership domain.Membership
+	createErr         error
 	createCalls       int
 	deleteCalls       int
+	previewCalls      int
+
+	sweptMembers []domain.MembershipMember
+
+	importedRows          []domain.MembershipImportRow
+	importAllowUnmatched  bool
+	importChangedMachines []uuid.UUID
+	recomputedMachineIDs  [][]uuid.UUID
 
 	syncedMachineIDs []uuid.UUID
 	syncedUserIDs    []uuid.UUID
+	syncedGroupIDs   []uuid.UUID
 }
 
 func (s *testStore) ListMemberships(context.Context, domain.MembershipListOptions) ([]domain.Membership, int32, error) {
@@ -43,8 +56,14 @@ func (s *testStore) CreateMembership(
 	domain.MemberKind,
 	uuid.UUID,
 	domain.MembershipOrigin,
+	*time.Time,
+	*time.Time,
 ) (domain.Membership, error) {
 	s.createCalls++
+	if s.createErr != nil {
+		return domain.Membership{}, s.createErr
+	}
+
 	return s.createdMembership, nil
 }
 
@@ -53,6 +72,30 @@ func (s *testStore) DeleteMembership(context.Context, uuid.UUID, domain.MemberKi
 	return nil
 }
 
+func (s *testStore) SweepMemberships(context.Context, time.Time) ([]domain.MembershipMember, error) {
+	return s.sweptMembers, nil
+}
+
+func (s *testStore) ImportMemberships(
+	_ context.Context,
+	_ uuid.UUID,
+	mode domain.MembershipImportMode,
+	allowUnmatched bool,
+	rows []domain.MembershipImportRow,
+) (domain.MembershipImportResult, []uuid.UUID, error) {
+	s.importedRows = rows
+	s.importAllowUnmatched = allowUnmatched
+	return domain.MembershipImportResult{Mode: mode}, s.importChangedMachines, nil
+}
+
+func (s *testStore) PreviewMembershipChange(
+	context.Context,
+	domain.MembershipChange,
+) (domain.PolicyImpactPreview, error) {
+	s.previewCalls++
+	return domain.PolicyImpactPreview{}, nil
+}
+
 func (s *testStore) GetGroup(context.Context, uuid.UUID) (domain.Group, error) {
 	if s.getGroupErr != nil {
 		return domain.Group{}, s.getGroupErr
@@ -61,6 +104,10 @@ func (s *testStore) GetGroup(context.Context, uuid.UUID) (domain.Group, error) {
 	return s.group, nil
 }
 
+func (s *testStore) GroupGrantsRole(context.Context, uuid.UUID) (bool, error) {
+	return s.grantsRole, nil
+}
+
 func (s *testStore) UpdateMachineDesiredTargets(_ context.Context, machineID uuid.UUID) error {
 	s.syncedMachineIDs = append(s.syncedMachineIDs, machineID)
 	return nil
@@ -71,8 +118,18 @@ func (s *testStore) UpdateMachineDesiredTargetsByPrimaryUserID(_ context.Context
 	return nil
 }
 
+func (s *testStore) UpdateMachineDesiredTargetsByGroupID(_ context.Context, groupID uuid.UUID) error {
+	s.syncedGroupIDs = append(s.syncedGroupIDs, groupID)
+	return nil
+}
+
+func (s *testStore) UpdateMachineDesiredTargetsByMachineIDs(_ context.Context, machineIDs []uuid.UUID) error {
+	s.recomputedMachineIDs = append(s.recomputedMachineIDs, machineIDs)
+	return nil
+}
+
 func newTestService(store *testStore) *memberships.Service {
-	return memberships.New(store)
+	return memberships.New(slog.New(slog.DiscardHandler), store)
 }
 
 func TestCreateMembership_CreatesMembershipAndSyncsPrimaryUserTargets(t *testing.T) {
@@ -90,7 +147,7 @@ func TestCreateMembership_CreatesMembershipAndSyncsPrimaryUserTargets(t *testing
 	}
 
 	service := newTestService(store)
-	membership, err := service.CreateMembership(context.Background(), memberships.CreateInput{
+	membership, err := service.CreateMembership(context.Background(), domain.Actor{}, memberships.CreateInput{
 		GroupID:    groupID,
 		MemberKind: domain.MemberKindUser,
 		MemberID:   userID,
@@ -110,6 +167,122 @@ func TestCreateMembership_CreatesMembershipAndSyncsPrimaryUserTargets(t *testing
 	}
 }
 
+func TestCreateMembership_NestsGroupAndSyncsItsMachines(t *testing.T) {
+	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000013")
+	memberGroupID := uuid.MustParse("00000000-0000-0000-0000-000000000014")
+
+	store := &testStore{
+		group: domain.Group{
+			ID:     groupID,
+			Source: domain.PrincipalSourceLocal,
+		},
+	}
+
+	service := newTestService(store)
+	if _, err := service.CreateMembership(context.Background(), domain.Actor{}, memberships.CreateInput{
+		GroupID:    groupID,
+		MemberKind: domain.MemberKindGroup,
+		MemberID:   memberGroupID,
+	}); err != nil {
+		t.Fatalf("CreateMembership() error = %v", err)
+	}
+
+	if len(store.syncedGroupIDs) != 1 || store.syncedGroupIDs[0] != memberGroupID {
+		t.Fatalf("syncedGroupIDs = %v, want [%v]", store.syncedGroupIDs, memberGroupID)
+	}
+}
+
+func TestCreateMembership_ReturnsCycleWithoutSyncing(t *testing.T) {
+	store := &testStore{
+		group:     domain.Group{Source: domain.PrincipalSourceLocal},
+		createErr: domain.ErrMembershipCycle,
+	}
+
+	service := newTestService(store)
+	_, err := service.CreateMembership(context.Background(), domain.Actor{}, memberships.CreateInput{
+		GroupID:    uuid.MustParse("00000000-0000-0000-0000-000000000015"),
+		MemberKind: domain.MemberKindGroup,
+		MemberID:   uuid.MustParse("00000000-0000-0000-0000-000000000016"),
+	})
+	if !errors.Is(err, domain.ErrMembershipCycle) {
+		t.Fatalf("CreateMembership() error = %v, want %v", err, domain.ErrMembershipCycle)
+	}
+	if len(store.syncedGroupIDs) != 0 {
+		t.Fatalf("syncedGroupIDs = %v, want none", store.syncedGroupIDs)
+	}
+}
+
+func TestCreateMembership_PendingMembershipSkipsSync(t *testing.T) {
+	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	machineID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	startsAt := time.Now().Add(24 * time.Hour)
+
+	store := &testStore{
+		group: domain.Group{
+			ID:     groupID,
+			Source: domain.PrincipalSourceLocal,
+		},
+		createdMembership: domain.Membership{
+			ID:       uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			StartsAt: &startsAt,
+			Pending:  true,
+		},
+	}
+
+	service := newTestService(store)
+	if _, err := service.CreateMembership(context.Background(), domain.Actor{}, memberships.CreateInput{
+		GroupID:    groupID,
+		MemberKind: domain.MemberKindMachine,
+		MemberID:   machineID,
+		StartsAt:   &startsAt,
+	}); err != nil {
+		t.Fatalf("CreateMembership() error = %v", err)
+	}
+
+	if len(store.syncedMachineIDs) != 0 {
+		t.Fatalf("syncedMachineIDs = %v, want none", store.syncedMachineIDs)
+	}
+}
+
+func TestCreateMembership_RejectsInvalidWindow(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	startsAt := time.Now().Add(48 * time.Hour)
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		input memberships.CreateInput
+	}{
+		{
+			name:  "already expired",
+			input: memberships.CreateInput{MemberKind: domain.MemberKindUser, ExpiresAt: &past},
+		},
+		{
+			name: "expires before start",
+			input: memberships.CreateInput{
+				MemberKind: domain.MemberKindUser,
+				StartsAt:   &startsAt,
+				ExpiresAt:  &expiresAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{group: domain.Group{Source: domain.PrincipalSourceLocal}}
+
+			_, err := newTestService(store).CreateMembership(context.Background(), domain.Actor{}, tt.input)
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("CreateMembership() error = %v, want validation error", err)
+			}
+			if store.createCalls != 0 {
+				t.Fatalf("createCalls = %d, want 0", store.createCalls)
+			}
+		})
+	}
+}
+
 func TestCreateMembership_RejectsReadOnlyGroup(t *testing.T) {
 	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000004")
 
@@ -121,7 +294,7 @@ func TestCreateMembership_RejectsReadOnlyGroup(t *testing.T) {
 	}
 
 	service := newTestService(store)
-	_, err := service.CreateMembership(context.Background(), memberships.CreateInput{
+	_, err := service.CreateMembership(context.Background(), domain.Actor{}, memberships.CreateInput{
 		GroupID:    groupID,
 		MemberKind: domain.MemberKindMachine,
 		MemberID:   uuid.MustParse("00000000-0000-0000-0000-000000000005"),
@@ -131,6 +304,79 @@ func TestCreateMembership_RejectsReadOnlyGroup(t *testing.T) {
 	}
 }
 
+func TestCreateMembership_LimitsRoleGroupsToAdmins(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      domain.Actor
+		memberKind domain.MemberKind
+		wantErr    error
+	}{
+		{name: "helpdesk adds a user", memberKind: domain.MemberKindUser, wantErr: domain.ErrRoleGroupAdminOnly},
+		{name: "helpdesk nests a group", memberKind: domain.MemberKindGroup, wantErr: domain.ErrRoleGroupAdminOnly},
+		{name: "admin adds a user", actor: domain.Actor{Admin: true}, memberKind: domain.MemberKindUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{
+				group:      domain.Group{Source: domain.PrincipalSourceLocal},
+				grantsRole: true,
+			}
+
+			_, err := newTestService(store).CreateMembership(context.Background(), tt.actor, memberships.CreateInput{
+				GroupID:    uuid.MustParse("00000000-0000-0000-0000-000000000020"),
+				MemberKind: tt.memberKind,
+				MemberID:   uuid.MustParse("00000000-0000-0000-0000-000000000021"),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateMembership() error = %v, want %v", err, tt.wantErr)
+			}
+
+			wantCalls := 1
+			if tt.wantErr != nil {
+				wantCalls = 0
+			}
+			if store.createCalls != wantCalls {
+				t.Fatalf("createCalls = %d, want %d", store.createCalls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestDeleteMembership_LimitsRoleGroupsToAdmins(t *testing.T) {
+	store := &testStore{
+		grantsRole: true,
+		getMembershipResult: domain.Membership{
+			ID: uuid.MustParse("00000000-0000-0000-0000-000000000022"),
+			Group: domain.MembershipGroup{
+				ID:     uuid.MustParse("00000000-0000-0000-0000-000000000023"),
+				Source: domain.PrincipalSourceLocal,
+			},
+			Member: domain.MembershipMember{
+				Kind: domain.MemberKindUser,
+				ID:   uuid.MustParse("00000000-0000-0000-0000-000000000024"),
+			},
+		},
+	}
+
+	service := newTestService(store)
+	err := service.DeleteMembership(context.Background(), domain.Actor{}, store.getMembershipResult.ID)
+	if !errors.Is(err, domain.ErrRoleGroupAdminOnly) {
+		t.Fatalf("DeleteMembership() error = %v, want %v", err, domain.ErrRoleGroupAdminOnly)
+	}
+	if store.deleteCalls != 0 {
+		t.Fatalf("deleteCalls = %d, want 0", store.deleteCalls)
+	}
+
+	admin := domain.Actor{Admin: true}
+	if err = service.DeleteMembership(context.Background(), admin, store.getMembershipResult.ID); err != nil {
+		t.Fatalf("DeleteMembership() as admin error = %v", err)
+	}
+	if store.deleteCalls != 1 {
+		t.Fatalf("deleteCalls = %d, want 1", store.deleteCalls)
+	}
+}
+
 func TestDeleteMembership_DeletesMembershipAndSyncsMachineTargets(t *testing.T) {
 	membershipID := uuid.MustParse("00000000-0000-0000-0000-000000000006")
 	machineID := uuid.MustParse("00000000-0000-0000-0000-000000000007")
@@ -150,7 +396,7 @@ func TestDeleteMembership_DeletesMembershipAndSyncsMachineTargets(t *testing.T)
 	}
 
 	service := newTestService(store)
-	if err := service.DeleteMembership(context.Background(), membershipID); err != nil {
+	if err := service.DeleteMembership(context.Background(), domain.Actor{}, membershipID); err != nil {
 		t.Fatalf("DeleteMembership() error = %v", err)
 	}
 
@@ -161,3 +407,217 @@ func TestDeleteMembership_DeletesMembershipAndSyncsMachineTargets(t *testing.T)
 		t.Fatalf("syncedMachineIDs = %v, want [%v]", store.syncedMachineIDs, machineID)
 	}
 }
+
+func TestDeleteMembership_RejectsDynamicMembership(t *testing.T) {
+	store := &testStore{
+		getMembershipResult: domain.Membership{
+			ID: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			Group: domain.MembershipGroup{
+				ID:     uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+				Source: domain.PrincipalSourceLocal,
+			},
+			Member: domain.MembershipMember{
+				Kind: domain.MemberKindMachine,
+				ID:   uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			},
+			Origin: domain.MembershipOriginDynamic,
+		},
+	}
+
+	service := newTestService(store)
+	err := service.DeleteMembership(context.Background(), domain.Actor{}, store.getMembershipResult.ID)
+	if !errors.Is(err, domain.ErrMembershipManaged) {
+		t.Fatalf("DeleteMembership() error = %v, want ErrMembershipManaged", err)
+	}
+	if store.deleteCalls != 0 {
+		t.Fatalf("deleteCalls = %d, want 0", store.deleteCalls)
+	}
+}
+
+func TestDeleteMembership_RejectsSyncedMachineMembership(t *testing.T) {
+	store := &testStore{
+		getMembershipResult: domain.Membership{
+			ID: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			Group: domain.MembershipGroup{
+				ID:     uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+				Source: domain.PrincipalSourceLocal,
+			},
+			Member: domain.MembershipMember{
+				Kind: domain.MemberKindMachine,
+				ID:   uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			},
+			Origin: domain.MembershipOriginSynced,
+		},
+	}
+
+	service := newTestService(store)
+	err := service.DeleteMembership(context.Background(), domain.Actor{}, store.getMembershipResult.ID)
+	if !errors.Is(err, domain.ErrMembershipManaged) {
+		t.Fatalf("DeleteMembership() error = %v, want ErrMembershipManaged", err)
+	}
+	if store.deleteCalls != 0 {
+		t.Fatalf("deleteCalls = %d, want 0", store.deleteCalls)
+	}
+}
+
+func TestSweepMemberships_SyncsChangedMembers(t *testing.T) {
+	machineID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	store := &testStore{
+		sweptMembers: []domain.MembershipMember{
+			{Kind: domain.MemberKindMachine, ID: machineID},
+			{Kind: domain.MemberKindUser, ID: userID},
+			{Kind: domain.MemberKindGroup, ID: groupID},
+		},
+	}
+
+	changed, err := newTestService(store).SweepMemberships(context.Background())
+	if err != nil {
+		t.Fatalf("SweepMemberships() error = %v", err)
+	}
+	if changed != 3 {
+		t.Fatalf("changed = %d, want 3", changed)
+	}
+	if len(store.syncedMachineIDs) != 1 || store.syncedMachineIDs[0] != machineID {
+		t.Fatalf("syncedMachineIDs = %v, want [%v]", store.syncedMachineIDs, machineID)
+	}
+	if len(store.syncedUserIDs) != 1 || store.syncedUserIDs[0] != userID {
+		t.Fatalf("syncedUserIDs = %v, want [%v]", store.syncedUserIDs, userID)
+	}
+	if len(store.syncedGroupIDs) != 1 || store.syncedGroupIDs[0] != groupID {
+		t.Fatalf("syncedGroupIDs = %v, want [%v]", store.syncedGroupIDs, groupID)
+	}
+}
+
+func TestImportMemberships_ParsesRowsAndRecomputesOnce(t *testing.T) {
+	changed := []uuid.UUID{
+		uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+	}
+	store := &testStore{
+		group:                 domain.Group{Source: domain.PrincipalSourceLocal},
+		importChangedMachines: changed,
+	}
+
+	csv := "Hostname,notes,serial_number,upn\n" +
+		"lab-01,first,C02ABC,\n" +
+		",,,student@example.org\n" +
+		",no key,,\n"
+
+	service := newTestService(store)
+	result, err := service.ImportMemberships(context.Background(), domain.Actor{}, memberships.ImportInput{
+		Mode:           domain.MembershipImportModeReplace,
+		CSV:            csv,
+		AllowUnmatched: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportMemberships() error = %v", err)
+	}
+	if result.Mode != domain.MembershipImportModeReplace {
+		t.Fatalf("result.Mode = %q, want replace", result.Mode)
+	}
+	if !store.importAllowUnmatched {
+		t.Fatal("importAllowUnmatched = false, want the confirmation passed to the store")
+	}
+
+	want := []domain.MembershipImportRow{
+		{Row: 2, Key: domain.MembershipImportKeySerialNumber, Value: "C02ABC"},
+		{Row: 3, Key: domain.MembershipImportKeyUPN, Value: "student@example.org"},
+		{Row: 4, Value: ",no key,,"},
+	}
+	if len(store.importedRows) != len(want) {
+		t.Fatalf("importedRows = %+v, want %+v", store.importedRows, want)
+	}
+	for i := range want {
+		if store.importedRows[i] != want[i] {
+			t.Fatalf("importedRows[%d] = %+v, want %+v", i, store.importedRows[i], want[i])
+		}
+	}
+
+	if len(store.recomputedMachineIDs) != 1 || len(store.recomputedMachineIDs[0]) != len(changed) {
+		t.Fatalf("recomputedMachineIDs = %v, want one recompute of %v", store.recomputedMachineIDs, changed)
+	}
+}
+
+func TestImportMemberships_LimitsRoleGroupsToAdmins(t *testing.T) {
+	store := &testStore{
+		group:      domain.Group{Source: domain.PrincipalSourceLocal},
+		grantsRole: true,
+	}
+
+	service := newTestService(store)
+	_, err := service.ImportMemberships(context.Background(), domain.Actor{}, memberships.ImportInput{
+		GroupID: uuid.MustParse("00000000-0000-0000-0000-000000000025"),
+		Mode:    domain.MembershipImportModeAdd,
+		CSV:     "upn\nstudent@example.com\n",
+	})
+	if !errors.Is(err, domain.ErrRoleGroupAdminOnly) {
+		t.Fatalf("ImportMemberships() error = %v, want %v", err, domain.ErrRoleGroupAdminOnly)
+	}
+	if store.importedRows != nil {
+		t.Fatalf("importedRows = %v, want no import", store.importedRows)
+	}
+}
+
+func TestImportMemberships_RejectsCSVWithoutKeyColumn(t *testing.T) {
+	store := &testStore{group: domain.Group{Source: domain.PrincipalSourceLocal}}
+
+	_, err := newTestService(store).ImportMemberships(context.Background(), domain.Actor{}, memberships.ImportInput{
+		Mode: domain.MembershipImportModeAdd,
+		CSV:  "name,notes\nlab-01,first\n",
+	})
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ImportMemberships() error = %v, want validation error", err)
+	}
+	if store.importedRows != nil {
+		t.Fatalf("importedRows = %+v, want no import", store.importedRows)
+	}
+}
+
+func TestPreviewMembershipChange_RejectsReadOnlyGroupWithoutPreviewing(t *testing.T) {
+	groupID := uuid.MustParse("00000000-0000-0000-0000-000000000009")
+
+	store := &testStore{
+		group: domain.Group{
+			ID:     groupID,
+			Source: domain.PrincipalSourceEntra,
+		},
+	}
+
+	service := newTestService(store)
+	_, err := service.PreviewMembershipChange(context.Background(), domain.MembershipChange{
+		Action:     domain.MembershipChangeActionRemove,
+		GroupID:    groupID,
+		MemberKind: domain.MemberKindUser,
+		MemberID:   uuid.MustParse("00000000-0000-0000-0000-00000000000a"),
+	})
+	if !errors.Is(err, domain.ErrGroupReadOnly) {
+		t.Fatalf("PreviewMembershipChange() error = %v, want %v", err, domain.ErrGroupReadOnly)
+	}
+	if store.previewCalls != 0 {
+		t.Fatalf("previewCalls = %d, want 0", store.previewCalls)
+	}
+}
+
+func TestPreviewMembershipChange_RejectsUnknownAction(t *testing.T) {
+	store := &testStore{group: domain.Group{Source: domain.PrincipalSourceLocal}}
+
+	service := newTestService(store)
+	_, err := service.PreviewMembershipChange(context.Background(), domain.MembershipChange{
+		Action:     "replace",
+		GroupID:    uuid.MustParse("00000000-0000-0000-0000-00000000000b"),
+		MemberKind: domain.MemberKindMachine,
+		MemberID:   uuid.MustParse("00000000-0000-0000-0000-00000000000c"),
+	})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("PreviewMembershipChange() error = %v, want validation error", err)
+	}
+	if store.previewCalls != 0 {
+		t.Fatalf("previewCalls = %d, want 0", store.previewCalls)
+	}
+}
@@ -0,0 +1,272 @@
+package observedrules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type Store interface {
+	GetExecutable(context.Context, uuid.UUID) (domain.Executable, error)
+	GetExecutionEvent(context.Context, uuid.UUID) (domain.ExecutionEvent, error)
+	GetRuleByIdentifier(context.Context, domain.RuleType, string) (domain.Rule, error)
+}
+
+// Rules writes the derived rule. It is satisfied by rules.Service.
+type Rules interface {
+	CreateRule(context.Context, domain.RuleWriteInput) (domain.Rule, error)
+	UpdateRule(context.Context, uuid.UUID, domain.RuleWriteInput) (domain.Rule, error)
+}
+
+// RuleChanges routes the derived rule through review when rule changes require approval. It is
+// satisfied by rulechanges.Service.
+type RuleChanges interface {
+	RequiresApproval() bool
+	ProposeCreate(context.Context, domain.Actor, domain.RuleWriteInput) (domain.RuleChangeProposal, error)
+	ProposeUpdate(context.Context, domain.Actor, uuid.UUID, domain.RuleWriteInput) (domain.RuleChangeProposal, error)
+}
+
+type Service struct {
+	store       Store
+	rules       Rules
+	ruleChanges RuleChanges
+}
+
+func New(store Store, rules Rules, ruleChanges RuleChanges) *Service {
+	return &Service{store: store, rules: rules, ruleChanges: ruleChanges}
+}
+
+// observation holds the identifying fields shared by executables and execution events.
+type observation struct {
+	FileName     string
+	FileSHA256   string
+	SigningID    string
+	TeamID       string
+	CDHash       string
+	SigningChain []domain.SigningChainEntry
+}
+
+// CreateRule derives the identifier for the requested rule type from an executable or execution
+// event. An existing rule for that identifier gains the requested targets ahead of its own;
+// otherwise a new rule is created. Rules matching the same executable by another identifier are
+// reported as warnings.
+func (s *Service) CreateRule(
+	ctx context.Context,
+	actor domain.Actor,
+	input domain.ObservedRuleInput,
+) (domain.ObservedRuleResult, error) {
+	if err := validateSource(input); err != nil {
+		return domain.ObservedRuleResult{}, err
+	}
+
+	observed, err := s.observation(ctx, input)
+	if err != nil {
+		return domain.ObservedRuleResult{}, err
+	}
+
+	identifier := observed.identity().Identifier(input.RuleType)
+	if identifier == "" {
+		validationErr := &domain.ValidationError{
+			Code:   "validation_error",
+			Detail: "Rule cannot be derived from this executable.",
+		}
+		validationErr.Add("rule_type", fmt.Sprintf("executable has no %s identifier", input.RuleType), "invalid")
+		return domain.ObservedRuleResult{}, validationErr
+	}
+
+	result := domain.ObservedRuleResult{
+		RuleType:   input.RuleType,
+		Identifier: identifier,
+		Warnings:   []string{},
+	}
+
+	existing, err := s.store.GetRuleByIdentifier(ctx, input.RuleType, identifier)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.ObservedRuleResult{}, err
+	}
+	found := err == nil
+
+	var write domain.RuleWriteInput
+	if found {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"Rule %q already covers this identifier; the requested targets were added to it.", existing.Name,
+		))
+		write = domain.RuleSnapshotFromRule(existing).WriteInput()
+		write.Targets = domain.MergeRuleTargets(write.Targets, input.Targets)
+	} else {
+		result.Created = true
+		write = newRule(observed, input, identifier)
+	}
+
+	overlaps, err := s.overlappingRules(ctx, observed, input.RuleType)
+	if err != nil {
+		return domain.ObservedRuleResult{}, err
+	}
+	result.Warnings = append(result.Warnings, overlaps...)
+
+	if s.ruleChanges.RequiresApproval() {
+		var proposal domain.RuleChangeProposal
+		if found {
+			proposal, err = s.ruleChanges.ProposeUpdate(ctx, actor, existing.ID, write)
+		} else {
+			proposal, err = s.ruleChanges.ProposeCreate(ctx, actor, write)
+		}
+		if err != nil {
+			return domain.ObservedRuleResult{}, err
+		}
+		result.Proposal = &proposal
+		return result, nil
+	}
+
+	var rule domain.Rule
+	if found {
+		rule, err = s.rules.UpdateRule(ctx, existing.ID, write)
+	} else {
+		rule, err = s.rules.CreateRule(ctx, write)
+	}
+	if err != nil {
+		return domain.ObservedRuleResult{}, err
+	}
+	result.Rule = &rule
+
+	return result, nil
+}
+
+func (s *Service) observation(ctx context.Context, input domain.ObservedRuleInput) (observation, error) {
+	if input.ExecutableID != nil {
+		executable, err := s.store.GetExecutable(ctx, *input.ExecutableID)
+		if err != nil {
+			return observation{}, err
+		}
+		return observation{
+			FileName:     executable.FileName,
+			FileSHA256:   executable.FileSHA256,
+			SigningID:    executable.SigningID,
+			TeamID:       executable.TeamID,
+			CDHash:       executable.CDHash,
+			SigningChain: executable.SigningChain,
+		}, nil
+	}
+
+	event, err := s.store.GetExecutionEvent(ctx, *input.ExecutionEventID)
+	if err != nil {
+		return observation{}, err
+	}
+	return observation{
+		FileName:     event.FileName,
+		FileSHA256:   event.FileSHA256,
+		SigningID:    event.SigningID,
+		TeamID:       event.TeamID,
+		CDHash:       event.CDHash,
+		SigningChain: event.SigningChain,
+	}, nil
+}
+
+// overlappingRules describes rules that already match the executable by another rule type.
+func (s *Service) overlappingRules(
+	ctx context.Context,
+	observed observation,
+	requested domain.RuleType,
+) ([]string, error) {
+	warnings := make([]string, 0)
+	identity := observed.identity()
+
+	for _, ruleType := range domain.RuleTypesByPrecedence() {
+		identifier := identity.Identifier(ruleType)
+		if ruleType == requested || identifier == "" {
+			continue
+		}
+
+		rule, err := s.store.GetRuleByIdentifier(ctx, ruleType, identifier)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		warnings = append(warnings, fmt.Sprintf("Rule %q also matches this executable by %s.", rule.Name, ruleType))
+	}
+
+	return warnings, nil
+}
+
+// identity returns the identifiers Santa matches rules against. Certificate rules use the leaf
+// certificate, which Santa reports first in the signing chain.
+func (observed observation) identity() domain.ExecutableIdentity {
+	identity := domain.ExecutableIdentity{
+		FileSHA256: observed.FileSHA256,
+		CDHash:     observed.CDHash,
+		SigningID:  observed.SigningID,
+		TeamID:     observed.TeamID,
+	}
+	if len(observed.SigningChain) > 0 {
+		identity.CertificateSHA256 = observed.SigningChain[0].SHA256
+	}
+
+	return identity
+}
+
+func newRule(observed observation, input domain.ObservedRuleInput, identifier string) domain.RuleWriteInput {
+	name := input.Name
+	if name == "" {
+		name = defaultRuleName(observed, input.RuleType, identifier)
+	}
+
+	return domain.RuleWriteInput{
+		Name:          name,
+		Description:   input.Description,
+		RuleType:      input.RuleType,
+		Identifier:    identifier,
+		CustomMessage: input.CustomMessage,
+		CustomURL:     input.CustomURL,
+		Enabled:       true,
+		Targets:       input.Targets,
+	}
+}
+
+func defaultRuleName(observed observation, ruleType domain.RuleType, identifier string) string {
+	var name string
+	switch ruleType {
+	case domain.RuleTypeBinary, domain.RuleTypeCDHash:
+		name = observed.FileName
+	case domain.RuleTypeCertificate:
+		name = observed.SigningChain[0].CommonName
+	case domain.RuleTypeTeamID:
+		if len(observed.SigningChain) > 0 {
+			name = observed.SigningChain[0].Organization
+		}
+	case domain.RuleTypeSigningID:
+		name = observed.SigningID
+	}
+
+	if name == "" {
+		return identifier
+	}
+	return name
+}
+
+func validateSource(input domain.ObservedRuleInput) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Rule source is invalid.",
+	}
+
+	if (input.ExecutableID == nil) == (input.ExecutionEventID == nil) {
+		validationErr.Add("executable_id", "exactly one of executable_id or execution_event_id is required", "invalid")
+	}
+	if _, err := domain.ParseRuleType(string(input.RuleType)); err != nil {
+		validationErr.Add("rule_type", "must be a supported rule type", "invalid")
+	}
+
+	if !validationErr.HasFieldErrors() {
+		return nil
+	}
+
+	return validationErr
+}
@@ -0,0 +1,178 @@
+package observedrules_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/app/observedrules"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	executable domain.Executable
+	rules      map[domain.RuleType]domain.Rule
+}
+
+func (s *testStore) GetExecutable(context.Context, uuid.UUID) (domain.Executable, error) {
+	return s.executable, nil
+}
+
+func (s *testStore) GetExecutionEvent(context.Context, uuid.UUID) (domain.ExecutionEvent, error) {
+	return domain.ExecutionEvent{}, errors.New("unexpected GetExecutionEvent call")
+}
+
+func (s *testStore) GetRuleByIdentifier(
+	_ context.Context,
+	ruleType domain.RuleType,
+	identifier string,
+) (domain.Rule, error) {
+	rule, ok := s.rules[ruleType]
+	if !ok || rule.Identifier != identifier {
+		return domain.Rule{}, pgx.ErrNoRows
+	}
+	return rule, nil
+}
+
+type testRules struct {
+	created []domain.RuleWriteInput
+	updated []domain.RuleWriteInput
+}
+
+func (r *testRules) CreateRule(_ context.Context, input domain.RuleWriteInput) (domain.Rule, error) {
+	r.created = append(r.created, input)
+	return domain.Rule{ID: uuid.New(), Name: input.Name, RuleType: input.RuleType, Identifier: input.Identifier}, nil
+}
+
+func (r *testRules) UpdateRule(_ context.Context, id uuid.UUID, input domain.RuleWriteInput) (domain.Rule, error) {
+	r.updated = append(r.updated, input)
+	return domain.Rule{ID: id, Name: input.Name, RuleType: input.RuleType, Identifier: input.Identifier}, nil
+}
+
+type testRuleChanges struct{}
+
+func (testRuleChanges) RequiresApproval() bool {
+	return false
+}
+
+func (testRuleChanges) ProposeCreate(
+	context.Context,
+	domain.Actor,
+	domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	return domain.RuleChangeProposal{}, errors.New("unexpected ProposeCreate call")
+}
+
+func (testRuleChanges) ProposeUpdate(
+	context.Context,
+	domain.Actor,
+	uuid.UUID,
+	domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	return domain.RuleChangeProposal{}, errors.New("unexpected ProposeUpdate call")
+}
+
+func signedExecutable() domain.Executable {
+	return domain.Executable{
+		ID:         uuid.New(),
+		FileName:   "Chrome",
+		FileSHA256: "binary-sha",
+		TeamID:     "EQHXZ8M8AV",
+		SigningChain: []domain.SigningChainEntry{
+			{CommonName: "Developer ID Application: Google LLC", Organization: "Google LLC", SHA256: "leaf-sha"},
+			{CommonName: "Developer ID Certification Authority", SHA256: "intermediate-sha"},
+		},
+	}
+}
+
+func TestCreateRule_CertificateUsesLeafCertificate(t *testing.T) {
+	executable := signedExecutable()
+	rules := &testRules{}
+	service := observedrules.New(&testStore{executable: executable}, rules, testRuleChanges{})
+
+	result, err := service.CreateRule(context.Background(), domain.Actor{}, domain.ObservedRuleInput{
+		ExecutableID: &executable.ID,
+		RuleType:     domain.RuleTypeCertificate,
+	})
+	if err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+
+	if !result.Created || result.Identifier != "leaf-sha" {
+		t.Fatalf("result = created %t identifier %q, want created leaf-sha", result.Created, result.Identifier)
+	}
+	if len(rules.created) != 1 || rules.created[0].Name != "Developer ID Application: Google LLC" {
+		t.Fatalf("created = %+v, want one rule named after the leaf certificate", rules.created)
+	}
+}
+
+func TestCreateRule_ExtendsExistingRuleAndWarns(t *testing.T) {
+	executable := signedExecutable()
+	groupID := uuid.New()
+	store := &testStore{
+		executable: executable,
+		rules: map[domain.RuleType]domain.Rule{
+			domain.RuleTypeBinary: {
+				ID:         uuid.New(),
+				Name:       "Chrome binary",
+				RuleType:   domain.RuleTypeBinary,
+				Identifier: "binary-sha",
+				Targets: domain.RuleTargets{
+					Include: []domain.IncludeRuleTarget{
+						{SubjectKind: domain.RuleTargetSubjectKindAllDevices, Policy: domain.RulePolicyBlocklist},
+					},
+				},
+			},
+			domain.RuleTypeTeamID: {
+				ID:         uuid.New(),
+				Name:       "Google",
+				RuleType:   domain.RuleTypeTeamID,
+				Identifier: "EQHXZ8M8AV",
+			},
+		},
+	}
+	rules := &testRules{}
+	service := observedrules.New(store, rules, testRuleChanges{})
+
+	result, err := service.CreateRule(context.Background(), domain.Actor{}, domain.ObservedRuleInput{
+		ExecutableID: &executable.ID,
+		RuleType:     domain.RuleTypeBinary,
+		Targets: domain.RuleTargetsWriteInput{
+			Include: []domain.IncludeRuleTargetWriteInput{
+				{SubjectKind: domain.RuleTargetSubjectKindGroup, SubjectID: &groupID, Policy: domain.RulePolicyAllowlist},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+
+	if result.Created || len(rules.updated) != 1 {
+		t.Fatalf("created = %t, updates = %d, want extension of the existing rule", result.Created, len(rules.updated))
+	}
+	include := rules.updated[0].Targets.Include
+	if len(include) != 2 || include[0].SubjectID == nil || *include[0].SubjectID != groupID {
+		t.Fatalf("include = %+v, want requested group first", include)
+	}
+	if len(result.Warnings) != 2 {
+		t.Fatalf("warnings = %q, want existing rule and team ID overlap", result.Warnings)
+	}
+}
+
+func TestCreateRule_RejectsMissingIdentifier(t *testing.T) {
+	executable := signedExecutable()
+	service := observedrules.New(&testStore{executable: executable}, &testRules{}, testRuleChanges{})
+
+	_, err := service.CreateRule(context.Background(), domain.Actor{}, domain.ObservedRuleInput{
+		ExecutableID: &executable.ID,
+		RuleType:     domain.RuleTypeSigningID,
+	})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("CreateRule() error = %v, want validation error", err)
+	}
+}
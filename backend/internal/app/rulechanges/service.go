@@ -0,0 +1,235 @@
+package rulechanges
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type ReviewInput struct {
+	Comment string
+}
+
+type Store interface {
+	ListRuleChangeProposals(
+		context.Context,
+		domain.RuleChangeProposalListOptions,
+	) ([]domain.RuleChangeProposal, int32, error)
+	GetRuleChangeProposal(context.Context, uuid.UUID) (domain.RuleChangeProposal, error)
+	CreateRuleChangeProposal(context.Context, domain.RuleChangeProposal) (domain.RuleChangeProposal, error)
+	ReviewRuleChangeProposal(
+		context.Context,
+		uuid.UUID,
+		domain.RuleChangeStatus,
+		domain.Actor,
+		string,
+	) (bool, error)
+}
+
+// Rules reads, validates and applies rules. It is satisfied by rules.Service, so proposals are
+// validated and applied the same way as direct writes.
+type Rules interface {
+	GetRule(context.Context, uuid.UUID) (domain.Rule, error)
+	ValidateRule(domain.RuleWriteInput) error
+	ApplyProposal(context.Context, domain.RuleChangeProposal, domain.Actor, string) ([]string, error)
+}
+
+type Service struct {
+	store           Store
+	rules           Rules
+	requireApproval bool
+}
+
+func New(store Store, rules Rules, requireApproval bool) *Service {
+	return &Service{store: store, rules: rules, requireApproval: requireApproval}
+}
+
+// RequiresApproval reports whether rule writes must go through a reviewed proposal.
+func (s *Service) RequiresApproval() bool {
+	return s.requireApproval
+}
+
+func (s *Service) ListProposals(
+	ctx context.Context,
+	opts domain.RuleChangeProposalListOptions,
+) ([]domain.RuleChangeProposal, int32, error) {
+	return s.store.ListRuleChangeProposals(ctx, opts)
+}
+
+func (s *Service) GetProposal(ctx context.Context, id uuid.UUID) (domain.RuleChangeProposal, error) {
+	return s.store.GetRuleChangeProposal(ctx, id)
+}
+
+func (s *Service) ProposeCreate(
+	ctx context.Context,
+	actor domain.Actor,
+	input domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	if err := s.rules.ValidateRule(input); err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	proposed := domain.RuleSnapshotFromInput(input)
+
+	return s.store.CreateRuleChangeProposal(ctx, domain.RuleChangeProposal{
+		Action:         domain.RuleChangeActionCreate,
+		RuleName:       input.Name,
+		Proposed:       &proposed,
+		ProposedBy:     actor.ID,
+		ProposedByName: actor.Name,
+	})
+}
+
+func (s *Service) ProposeUpdate(
+	ctx context.Context,
+	actor domain.Actor,
+	ruleID uuid.UUID,
+	input domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	if err := s.rules.ValidateRule(input); err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	rule, err := s.rules.GetRule(ctx, ruleID)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	current := domain.RuleSnapshotFromRule(rule)
+	proposed := domain.RuleSnapshotFromInput(input)
+
+	return s.store.CreateRuleChangeProposal(ctx, domain.RuleChangeProposal{
+		Action:         domain.RuleChangeActionUpdate,
+		RuleID:         &rule.ID,
+		RuleName:       rule.Name,
+		BaseUpdatedAt:  &rule.UpdatedAt,
+		Current:        &current,
+		Proposed:       &proposed,
+		ProposedBy:     actor.ID,
+		ProposedByName: actor.Name,
+	})
+}
+
+func (s *Service) ProposeDelete(
+	ctx context.Context,
+	actor domain.Actor,
+	ruleID uuid.UUID,
+) (domain.RuleChangeProposal, error) {
+	rule, err := s.rules.GetRule(ctx, ruleID)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	current := domain.RuleSnapshotFromRule(rule)
+
+	return s.store.CreateRuleChangeProposal(ctx, domain.RuleChangeProposal{
+		Action:         domain.RuleChangeActionDelete,
+		RuleID:         &rule.ID,
+		RuleName:       rule.Name,
+		BaseUpdatedAt:  &rule.UpdatedAt,
+		Current:        &current,
+		ProposedBy:     actor.ID,
+		ProposedByName: actor.Name,
+	})
+}
+
+// Approve marks a pending proposal approved and applies its rule change through the rules
+// service, in one transaction. The proposer cannot approve their own change, and updates or
+// deletes are refused when the rule changed after proposal. The returned proposal carries the
+// saved rule's warnings.
+func (s *Service) Approve(
+	ctx context.Context,
+	reviewer domain.Actor,
+	id uuid.UUID,
+	input ReviewInput,
+) (domain.RuleChangeProposal, error) {
+	proposal, err := s.reviewable(ctx, reviewer, id)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	if err = s.checkBase(ctx, proposal); err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	warnings, err := s.rules.ApplyProposal(ctx, proposal, reviewer, input.Comment)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	approved, err := s.store.GetRuleChangeProposal(ctx, id)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+	approved.Warnings = warnings
+
+	return approved, nil
+}
+
+func (s *Service) Reject(
+	ctx context.Context,
+	reviewer domain.Actor,
+	id uuid.UUID,
+	input ReviewInput,
+) (domain.RuleChangeProposal, error) {
+	if _, err := s.reviewable(ctx, reviewer, id); err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	claimed, err := s.store.ReviewRuleChangeProposal(ctx, id, domain.RuleChangeStatusRejected, reviewer, input.Comment)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+	if !claimed {
+		return domain.RuleChangeProposal{}, domain.ErrRuleChangeConflict
+	}
+
+	return s.store.GetRuleChangeProposal(ctx, id)
+}
+
+func (s *Service) reviewable(
+	ctx context.Context,
+	reviewer domain.Actor,
+	id uuid.UUID,
+) (domain.RuleChangeProposal, error) {
+	proposal, err := s.store.GetRuleChangeProposal(ctx, id)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	if proposal.Status != domain.RuleChangeStatusPending {
+		return domain.RuleChangeProposal{}, domain.ErrRuleChangeConflict
+	}
+	if reviewer.ID == "" || reviewer.ID == proposal.ProposedBy {
+		return domain.RuleChangeProposal{}, domain.ErrRuleChangeSelfReview
+	}
+
+	return proposal, nil
+}
+
+// checkBase rejects approval when the target rule was edited or removed after the proposal.
+func (s *Service) checkBase(ctx context.Context, proposal domain.RuleChangeProposal) error {
+	if proposal.Action == domain.RuleChangeActionCreate {
+		return nil
+	}
+	if proposal.RuleID == nil || proposal.BaseUpdatedAt == nil {
+		return domain.ErrRuleChangeConflict
+	}
+
+	rule, err := s.rules.GetRule(ctx, *proposal.RuleID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrRuleChangeConflict
+	}
+	if err != nil {
+		return err
+	}
+	if !rule.UpdatedAt.Equal(*proposal.BaseUpdatedAt) {
+		return domain.ErrRuleChangeConflict
+	}
+
+	return nil
+}
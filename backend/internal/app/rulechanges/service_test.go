@@ -0,0 +1,195 @@
+package rulechanges_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/app/rulechanges"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	proposal domain.RuleChangeProposal
+
+	reviewCalls int
+}
+
+func (s *testStore) ListRuleChangeProposals(
+	context.Context,
+	domain.RuleChangeProposalListOptions,
+) ([]domain.RuleChangeProposal, int32, error) {
+	return nil, 0, errors.New("unexpected ListRuleChangeProposals call")
+}
+
+func (s *testStore) GetRuleChangeProposal(context.Context, uuid.UUID) (domain.RuleChangeProposal, error) {
+	return s.proposal, nil
+}
+
+func (s *testStore) CreateRuleChangeProposal(
+	_ context.Context,
+	proposal domain.RuleChangeProposal,
+) (domain.RuleChangeProposal, error) {
+	s.proposal = proposal
+	return proposal, nil
+}
+
+func (s *testStore) ReviewRuleChangeProposal(
+	_ context.Context,
+	_ uuid.UUID,
+	status domain.RuleChangeStatus,
+	reviewer domain.Actor,
+	_ string,
+) (bool, error) {
+	s.reviewCalls++
+	s.proposal.Status = status
+	s.proposal.ReviewedBy = reviewer.ID
+	return true, nil
+}
+
+type testRules struct {
+	store *testStore
+	rule  domain.Rule
+
+	applyErr error
+	applied  []domain.RuleChangeProposal
+	warnings []string
+}
+
+func (r *testRules) GetRule(context.Context, uuid.UUID) (domain.Rule, error) {
+	return r.rule, nil
+}
+
+func (r *testRules) ValidateRule(domain.RuleWriteInput) error {
+	return nil
+}
+
+func (r *testRules) ApplyProposal(
+	_ context.Context,
+	proposal domain.RuleChangeProposal,
+	reviewer domain.Actor,
+	_ string,
+) ([]string, error) {
+	if r.applyErr != nil {
+		return nil, r.applyErr
+	}
+	if r.store.proposal.Status != domain.RuleChangeStatusPending {
+		return nil, domain.ErrRuleChangeConflict
+	}
+
+	r.applied = append(r.applied, proposal)
+	r.store.proposal.Status = domain.RuleChangeStatusApproved
+	r.store.proposal.ReviewedBy = reviewer.ID
+	return r.warnings, nil
+}
+
+func newUpdateFixture(t *testing.T) (*testStore, *testRules, *rulechanges.Service) {
+	t.Helper()
+
+	store := &testStore{}
+	rules := &testRules{
+		store: store,
+		rule: domain.Rule{
+			ID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Name:       "Block installer",
+			RuleType:   domain.RuleTypeBinary,
+			Identifier: "abc",
+			UpdatedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+	service := rulechanges.New(store, rules, true)
+
+	_, err := service.ProposeUpdate(
+		context.Background(),
+		domain.Actor{ID: "entra_alice", Name: "Alice"},
+		rules.rule.ID,
+		domain.RuleWriteInput{Name: "Block installer", RuleType: domain.RuleTypeBinary, Identifier: "def"},
+	)
+	if err != nil {
+		t.Fatalf("ProposeUpdate() error = %v", err)
+	}
+	store.proposal.Status = domain.RuleChangeStatusPending
+
+	return store, rules, service
+}
+
+func TestApprove_RejectsProposerReview(t *testing.T) {
+	store, rules, service := newUpdateFixture(t)
+
+	_, err := service.Approve(
+		context.Background(),
+		domain.Actor{ID: "entra_alice", Name: "Alice"},
+		store.proposal.ID,
+		rulechanges.ReviewInput{},
+	)
+	if !errors.Is(err, domain.ErrRuleChangeSelfReview) {
+		t.Fatalf("Approve() error = %v, want %v", err, domain.ErrRuleChangeSelfReview)
+	}
+	if store.reviewCalls != 0 || len(rules.applied) != 0 {
+		t.Fatalf("reviewCalls = %d, applied = %d, want 0 and 0", store.reviewCalls, len(rules.applied))
+	}
+}
+
+func TestApprove_AppliesUpdateThroughRules(t *testing.T) {
+	store, rules, service := newUpdateFixture(t)
+	rules.warnings = []string{"Conflicts with Allow installer."}
+
+	proposal, err := service.Approve(
+		context.Background(),
+		domain.Actor{ID: "entra_bob", Name: "Bob"},
+		store.proposal.ID,
+		rulechanges.ReviewInput{Comment: "ok"},
+	)
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	if proposal.Status != domain.RuleChangeStatusApproved {
+		t.Fatalf("Status = %q, want %q", proposal.Status, domain.RuleChangeStatusApproved)
+	}
+	if len(rules.applied) != 1 || rules.applied[0].Proposed.Identifier != "def" {
+		t.Fatalf("applied = %+v, want one update with identifier def", rules.applied)
+	}
+	if len(proposal.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want the applied rule's warnings", proposal.Warnings)
+	}
+}
+
+func TestApprove_RejectsStaleUpdate(t *testing.T) {
+	store, rules, service := newUpdateFixture(t)
+	rules.rule.UpdatedAt = rules.rule.UpdatedAt.Add(time.Minute)
+
+	_, err := service.Approve(
+		context.Background(),
+		domain.Actor{ID: "entra_bob", Name: "Bob"},
+		store.proposal.ID,
+		rulechanges.ReviewInput{},
+	)
+	if !errors.Is(err, domain.ErrRuleChangeConflict) {
+		t.Fatalf("Approve() error = %v, want %v", err, domain.ErrRuleChangeConflict)
+	}
+	if len(rules.applied) != 0 {
+		t.Fatalf("applied = %d, want 0", len(rules.applied))
+	}
+}
+
+func TestApprove_LeavesProposalPendingWhenApplyFails(t *testing.T) {
+	store, rules, service := newUpdateFixture(t)
+	rules.applyErr = errors.New("unique violation")
+
+	_, err := service.Approve(
+		context.Background(),
+		domain.Actor{ID: "entra_bob", Name: "Bob"},
+		store.proposal.ID,
+		rulechanges.ReviewInput{},
+	)
+	if !errors.Is(err, rules.applyErr) {
+		t.Fatalf("Approve() error = %v, want %v", err, rules.applyErr)
+	}
+	if store.proposal.Status != domain.RuleChangeStatusPending {
+		t.Fatalf("Status = %q, want pending", store.proposal.Status)
+	}
+}
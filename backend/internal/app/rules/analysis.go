@@ -0,0 +1,344 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+// AnalyzeRules finds targeting problems across enabled rules: rules for the same executables that
+// resolve to allow and block on the same machines, include targets that never win because a
+// higher-priority target already matches every machine they match, and excludes that remove a
+// rule from every machine its includes match. A non-nil ruleID keeps only findings involving it,
+// and only that rule and the rules overlapping it are analyzed, against the machines their
+// targets name.
+func (s *Service) AnalyzeRules(ctx context.Context, ruleID *uuid.UUID) ([]domain.RuleFinding, error) {
+	overlaps, err := s.store.ListRuleOverlaps(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var involved []uuid.UUID
+	if ruleID != nil {
+		involved = involvedRuleIDs(*ruleID, overlaps)
+	}
+
+	rules, _, err := s.store.ListRules(ctx, domain.RuleListOptions{ListOptions: domain.ListOptions{IDs: involved}})
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.store.ListRuleTargetsByRuleID(ctx, involved)
+	if err != nil {
+		return nil, err
+	}
+
+	var scope *domain.MachineTargetingScope
+	if ruleID != nil {
+		scope = targetingScope(targets)
+	}
+
+	targeting, err := s.store.GetMachineTargeting(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := analyzeRules(rules, targets, targeting, overlaps)
+	if ruleID == nil {
+		return findings, nil
+	}
+
+	selected := make([]domain.RuleFinding, 0, len(findings))
+	for _, finding := range findings {
+		if finding.RuleID == *ruleID || (finding.OtherRuleID != nil && *finding.OtherRuleID == *ruleID) {
+			selected = append(selected, finding)
+		}
+	}
+
+	return selected, nil
+}
+
+// ruleWarnings analyzes a saved rule. The write has already committed, so an analysis failure is
+// logged and the rule is returned without warnings instead of failing the request.
+func (s *Service) ruleWarnings(ctx context.Context, ruleID uuid.UUID) []string {
+	findings, err := s.AnalyzeRules(ctx, &ruleID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "rule analysis failed", "rule_id", ruleID, "error", err)
+		return []string{}
+	}
+
+	warnings := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		warnings = append(warnings, finding.Message)
+	}
+
+	return warnings
+}
+
+// involvedRuleIDs lists the rule and the rules that overlap it.
+func involvedRuleIDs(ruleID uuid.UUID, overlaps []domain.RuleOverlap) []uuid.UUID {
+	seen := map[uuid.UUID]struct{}{ruleID: {}}
+	ids := []uuid.UUID{ruleID}
+	for _, overlap := range overlaps {
+		for _, id := range []uuid.UUID{overlap.RuleID, overlap.OtherRuleID} {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
+
+// targetingScope collects the subjects the targets name, so only their machines are loaded.
+func targetingScope(targetsByRule map[uuid.UUID]domain.RuleTargets) *domain.MachineTargetingScope {
+	scope := &domain.MachineTargetingScope{
+		MachineIDs: []uuid.UUID{},
+		UserIDs:    []uuid.UUID{},
+		GroupIDs:   []uuid.UUID{},
+	}
+
+	add := func(kind domain.RuleTargetSubjectKind, subjectID *uuid.UUID) {
+		switch kind {
+		case domain.RuleTargetSubjectKindAllDevices:
+			scope.AllDevices = true
+		case domain.RuleTargetSubjectKindAllUsers:
+			scope.AllUsers = true
+		case domain.RuleTargetSubjectKindMachine:
+			if subjectID != nil {
+				scope.MachineIDs = append(scope.MachineIDs, *subjectID)
+			}
+		case domain.RuleTargetSubjectKindUser:
+			if subjectID != nil {
+				scope.UserIDs = append(scope.UserIDs, *subjectID)
+			}
+		case domain.RuleTargetSubjectKindGroup:
+			if subjectID != nil {
+				scope.GroupIDs = append(scope.GroupIDs, *subjectID)
+			}
+		}
+	}
+
+	for _, targets := range targetsByRule {
+		for _, include := range targets.Include {
+			add(include.SubjectKind, include.SubjectID)
+		}
+		for _, exclude := range targets.Exclude {
+			add(exclude.SubjectKind, &exclude.SubjectID)
+		}
+	}
+
+	return scope
+}
+
+type machineSet map[uuid.UUID]struct{}
+
+func newMachineSet(ids []uuid.UUID) machineSet {
+	set := make(machineSet, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func analyzeRules(
+	rules []domain.RuleSummary,
+	targetsByRule map[uuid.UUID]domain.RuleTargets,
+	targeting domain.MachineTargeting,
+	overlaps []domain.RuleOverlap,
+) []domain.RuleFinding {
+	allMachines := newMachineSet(targeting.MachineIDs)
+	primaryUserMachines := newMachineSet(targeting.PrimaryUserMachineIDs)
+	groupMachines := make(map[uuid.UUID]machineSet, len(targeting.GroupMachineIDs))
+	for groupID, machineIDs := range targeting.GroupMachineIDs {
+		groupMachines[groupID] = newMachineSet(machineIDs)
+	}
+	userMachines := make(map[uuid.UUID]machineSet, len(targeting.UserMachineIDs))
+	for userID, machineIDs := range targeting.UserMachineIDs {
+		userMachines[userID] = newMachineSet(machineIDs)
+	}
+
+	matches := func(kind domain.RuleTargetSubjectKind, subjectID *uuid.UUID) machineSet {
+		switch kind {
+		case domain.RuleTargetSubjectKindAllDevices:
+			return allMachines
+		case domain.RuleTargetSubjectKindAllUsers:
+			return primaryUserMachines
+		case domain.RuleTargetSubjectKindGroup:
+			if subjectID != nil {
+				return groupMachines[*subjectID]
+			}
+		case domain.RuleTargetSubjectKindMachine:
+			if subjectID != nil {
+				if _, ok := allMachines[*subjectID]; ok {
+					return machineSet{*subjectID: {}}
+				}
+			}
+		case domain.RuleTargetSubjectKindUser:
+			if subjectID != nil {
+				return userMachines[*subjectID]
+			}
+		}
+		return nil
+	}
+
+	findings := make([]domain.RuleFinding, 0)
+	resolved := make(map[uuid.UUID]map[uuid.UUID]domain.RulePolicy, len(rules))
+	names := make(map[uuid.UUID]string, len(rules))
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		names[rule.ID] = rule.Name
+		targets := targetsByRule[rule.ID]
+
+		winners := make(map[uuid.UUID]domain.RulePolicy)
+		seenSubjects := make(map[string]struct{}, len(targets.Include))
+		coversAll := false
+
+		var priority int32
+		for _, include := range targets.Include {
+			priority++
+			matched := matches(include.SubjectKind, include.SubjectID)
+
+			var wins int
+			for machineID := range matched {
+				if _, taken := winners[machineID]; !taken {
+					winners[machineID] = include.Policy
+					wins++
+				}
+			}
+
+			subject := string(include.SubjectKind)
+			if include.SubjectID != nil {
+				subject += ":" + include.SubjectID.String()
+			}
+			_, duplicate := seenSubjects[subject]
+			seenSubjects[subject] = struct{}{}
+
+			if coversAll || duplicate || (len(matched) > 0 && wins == 0) {
+				targetPriority := priority
+				findings = append(findings, domain.RuleFinding{
+					Kind:         domain.RuleFindingKindUnreachableTarget,
+					RuleID:       rule.ID,
+					RuleName:     rule.Name,
+					SubjectKind:  include.SubjectKind,
+					SubjectID:    include.SubjectID,
+					SubjectName:  include.SubjectName,
+					Priority:     &targetPriority,
+					MachineCount: countOf(matched),
+					Message: fmt.Sprintf(
+						"Include target %q at priority %d of rule %q never wins: a higher-priority target already matches every machine it matches.",
+						include.SubjectName,
+						targetPriority,
+						rule.Name,
+					),
+				})
+			}
+			if include.SubjectKind == domain.RuleTargetSubjectKindAllDevices {
+				coversAll = true
+			}
+		}
+
+		excluded := make(machineSet)
+		for _, exclude := range targets.Exclude {
+			for machineID := range matches(exclude.SubjectKind, &exclude.SubjectID) {
+				excluded[machineID] = struct{}{}
+			}
+		}
+
+		policies := make(map[uuid.UUID]domain.RulePolicy, len(winners))
+		for machineID, policy := range winners {
+			if _, ok := excluded[machineID]; !ok {
+				policies[machineID] = policy
+			}
+		}
+		resolved[rule.ID] = policies
+
+		if len(winners) > 0 && len(policies) == 0 {
+			findings = append(findings, domain.RuleFinding{
+				Kind:         domain.RuleFindingKindExcludedEverywhere,
+				RuleID:       rule.ID,
+				RuleName:     rule.Name,
+				MachineCount: countOf(winners),
+				Message: fmt.Sprintf(
+					"Excludes remove rule %q from all %d machines its includes match.",
+					rule.Name,
+					countOf(winners),
+				),
+			})
+		}
+	}
+
+	for _, overlap := range overlaps {
+		policies, ok := resolved[overlap.RuleID]
+		if !ok {
+			continue
+		}
+		otherPolicies, ok := resolved[overlap.OtherRuleID]
+		if !ok {
+			continue
+		}
+
+		var conflicts int32
+		for machineID, policy := range policies {
+			otherPolicy, onMachine := otherPolicies[machineID]
+			if onMachine && policiesConflict(policy, otherPolicy) {
+				conflicts++
+			}
+		}
+		if conflicts == 0 {
+			continue
+		}
+
+		otherRuleID := overlap.OtherRuleID
+		findings = append(findings, domain.RuleFinding{
+			Kind:            domain.RuleFindingKindConflictingPolicy,
+			RuleID:          overlap.RuleID,
+			RuleName:        names[overlap.RuleID],
+			OtherRuleID:     &otherRuleID,
+			OtherRuleName:   names[otherRuleID],
+			MachineCount:    conflicts,
+			ExecutableCount: overlap.ExecutableCount,
+			Message: fmt.Sprintf(
+				"Rules %q and %q match %d of the same executables but one allows and the other blocks on %d machines.",
+				names[overlap.RuleID],
+				names[otherRuleID],
+				overlap.ExecutableCount,
+				conflicts,
+			),
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].RuleName != findings[j].RuleName {
+			return findings[i].RuleName < findings[j].RuleName
+		}
+		return findings[i].Kind < findings[j].Kind
+	})
+
+	return findings
+}
+
+// policiesConflict reports whether one policy allows and the other blocks. CEL rules decide at
+// execution time, so they never conflict.
+func policiesConflict(a, b domain.RulePolicy) bool {
+	allows := func(policy domain.RulePolicy) bool {
+		return policy == domain.RulePolicyAllowlist
+	}
+	blocks := func(policy domain.RulePolicy) bool {
+		return policy == domain.RulePolicyBlocklist || policy == domain.RulePolicySilentBlocklist
+	}
+
+	return (allows(a) && blocks(b)) || (blocks(a) && allows(b))
+}
+
+func countOf[K comparable, V any](set map[K]V) int32 {
+	return int32(len(set)) //nolint:gosec // machine counts stay far below MaxInt32
+}
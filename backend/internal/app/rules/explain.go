@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+// ExplainMachineRule traces how the rule for a rule type and identifier resolves on a machine,
+// following the same steps as rule resolution: includes match through the machine's effective
+// groups, the best-priority matching include wins, and any matching exclude removes the rule.
+func (s *Service) ExplainMachineRule(
+	ctx context.Context,
+	machineID uuid.UUID,
+	ruleType domain.RuleType,
+	identifier string,
+) (domain.MachineRuleExplanation, error) {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Rule explanation request is invalid.",
+	}
+	if _, err := domain.ParseRuleType(string(ruleType)); err != nil {
+		validationErr.Add("rule_type", "must be a valid rule type", "invalid")
+	}
+	if identifier == "" {
+		validationErr.Add("identifier", "must not be empty", "required")
+	}
+	if validationErr.HasFieldErrors() {
+		return domain.MachineRuleExplanation{}, validationErr
+	}
+
+	machine, err := s.store.GetMachine(ctx, machineID)
+	if err != nil {
+		return domain.MachineRuleExplanation{}, err
+	}
+
+	explanation := domain.MachineRuleExplanation{
+		MachineID:     machine.ID,
+		RuleType:      ruleType,
+		Identifier:    identifier,
+		PrimaryUser:   machine.PrimaryUser,
+		PrimaryUserID: machine.PrimaryUserID,
+		Outcome:       domain.RuleExplanationOutcomeNoRule,
+		Targets:       []domain.RuleTargetExplanation{},
+	}
+
+	rule, err := s.store.GetRuleByIdentifier(ctx, ruleType, identifier)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return explanation, nil
+	}
+	if err != nil {
+		return domain.MachineRuleExplanation{}, err
+	}
+
+	paths, err := s.store.ListMachineGroupPaths(ctx, machineID)
+	if err != nil {
+		return domain.MachineRuleExplanation{}, err
+	}
+
+	explainRule(&explanation, rule, paths)
+
+	return explanation, nil
+}
+
+func explainRule(explanation *domain.MachineRuleExplanation, rule domain.Rule, paths []domain.MachineGroupPath) {
+	explanation.Rule = &domain.RuleSummary{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		RuleType:    rule.RuleType,
+		Identifier:  rule.Identifier,
+		Enabled:     rule.Enabled,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+
+	groupPaths := make(map[uuid.UUID][]domain.MachineGroupPath, len(paths))
+	for _, path := range paths {
+		groupPaths[path.GroupID] = append(groupPaths[path.GroupID], path)
+	}
+
+	var (
+		winningPolicy *domain.RulePolicy
+		excluded      bool
+	)
+
+	targets := make([]domain.RuleTargetExplanation, 0, len(rule.Targets.Include)+len(rule.Targets.Exclude))
+	var priority int32
+	for _, include := range rule.Targets.Include {
+		// Include priorities are stored in list order starting at 1.
+		priority++
+		targetPriority := priority
+		policy := include.Policy
+
+		target := domain.RuleTargetExplanation{
+			Assignment:  domain.RuleTargetAssignmentInclude,
+			SubjectKind: include.SubjectKind,
+			SubjectID:   include.SubjectID,
+			SubjectName: include.SubjectName,
+			Priority:    &targetPriority,
+			Policy:      &policy,
+			Paths:       []domain.MachineGroupPath{},
+		}
+
+		switch include.SubjectKind {
+		case domain.RuleTargetSubjectKindAllDevices:
+			target.Matched = true
+		case domain.RuleTargetSubjectKindAllUsers:
+			target.Matched = explanation.PrimaryUserID != nil
+		case domain.RuleTargetSubjectKindGroup:
+			if include.SubjectID != nil {
+				target.Paths = append(target.Paths, groupPaths[*include.SubjectID]...)
+			}
+			target.Matched = len(target.Paths) > 0
+		case domain.RuleTargetSubjectKindMachine, domain.RuleTargetSubjectKindUser:
+			target.Matched = include.SubjectID != nil &&
+				subjectMatchesMachine(explanation, include.SubjectKind, *include.SubjectID)
+		}
+		if target.Matched && winningPolicy == nil {
+			target.Won = true
+			winningPolicy = &policy
+		}
+
+		targets = append(targets, target)
+	}
+
+	for _, exclude := range rule.Targets.Exclude {
+		subjectID := exclude.SubjectID
+		target := domain.RuleTargetExplanation{
+			Assignment:  domain.RuleTargetAssignmentExclude,
+			SubjectKind: exclude.SubjectKind,
+			SubjectID:   &subjectID,
+			SubjectName: exclude.SubjectName,
+			Paths:       []domain.MachineGroupPath{},
+		}
+		if exclude.SubjectKind == domain.RuleTargetSubjectKindGroup {
+			target.Paths = append(target.Paths, groupPaths[subjectID]...)
+			target.Matched = len(target.Paths) > 0
+		} else {
+			target.Matched = subjectMatchesMachine(explanation, exclude.SubjectKind, subjectID)
+		}
+		excluded = excluded || target.Matched
+
+		targets = append(targets, target)
+	}
+
+	explanation.Targets = targets
+
+	switch {
+	case !rule.Enabled:
+		explanation.Outcome = domain.RuleExplanationOutcomeDisabled
+	case winningPolicy == nil:
+		explanation.Outcome = domain.RuleExplanationOutcomeNotTargeted
+	case excluded:
+		explanation.Outcome = domain.RuleExplanationOutcomeExcluded
+	default:
+		explanation.Outcome = domain.RuleExplanationOutcomeApplied
+		explanation.Policy = winningPolicy
+	}
+}
+
+// subjectMatchesMachine reports whether a machine or user target names the explained machine or
+// its primary user.
+func subjectMatchesMachine(
+	explanation *domain.MachineRuleExplanation,
+	kind domain.RuleTargetSubjectKind,
+	subjectID uuid.UUID,
+) bool {
+	switch kind {
+	case domain.RuleTargetSubjectKindMachine:
+		return subjectID == explanation.MachineID
+	case domain.RuleTargetSubjectKindUser:
+		return explanation.PrimaryUserID != nil && subjectID == *explanation.PrimaryUserID
+	default:
+		return false
+	}
+}
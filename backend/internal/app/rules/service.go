@@ -3,6 +3,7 @@ package rules
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 
@@ -16,15 +17,24 @@ type Store interface {
 	UpdateRule(context.Context, uuid.UUID, domain.RuleWriteInput) (domain.Rule, error)
 	DeleteRule(context.Context, uuid.UUID) error
 	ListResolvedMachineRules(context.Context, uuid.UUID) ([]domain.MachineResolvedRule, error)
+	PreviewRuleWrite(context.Context, *uuid.UUID, domain.RuleWriteInput) (domain.PolicyImpactPreview, error)
+	GetRuleByIdentifier(context.Context, domain.RuleType, string) (domain.Rule, error)
+	GetMachine(context.Context, uuid.UUID) (domain.Machine, error)
+	ListMachineGroupPaths(context.Context, uuid.UUID) ([]domain.MachineGroupPath, error)
+	ListRuleTargetsByRuleID(context.Context, []uuid.UUID) (map[uuid.UUID]domain.RuleTargets, error)
+	GetMachineTargeting(context.Context, *domain.MachineTargetingScope) (domain.MachineTargeting, error)
+	ListRuleOverlaps(context.Context, *uuid.UUID) ([]domain.RuleOverlap, error)
+	ApplyRuleChangeProposal(context.Context, domain.RuleChangeProposal, domain.Actor, string) (uuid.UUID, bool, error)
 	UpdateAllMachineDesiredTargets(context.Context) error
 }
 
 type Service struct {
-	store Store
+	logger *slog.Logger
+	store  Store
 }
 
-func New(store Store) *Service {
-	return &Service{store: store}
+func New(logger *slog.Logger, store Store) *Service {
+	return &Service{logger: logger, store: store}
 }
 
 func (s *Service) ListRules(ctx context.Context, opts domain.RuleListOptions) ([]domain.RuleSummary, int32, error) {
@@ -35,6 +45,15 @@ func (s *Service) GetRule(ctx context.Context, id uuid.UUID) (domain.Rule, error
 	return s.store.GetRule(ctx, id)
 }
 
+// ValidateRule checks a rule write without applying it.
+func (s *Service) ValidateRule(input domain.RuleWriteInput) error {
+	if err := validateInput(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Service) CreateRule(ctx context.Context, input domain.RuleWriteInput) (domain.Rule, error) {
 	if err := validateInput(input); err != nil {
 		return domain.Rule{}, err
@@ -49,6 +68,8 @@ func (s *Service) CreateRule(ctx context.Context, input domain.RuleWriteInput) (
 		return domain.Rule{}, err
 	}
 
+	rule.Warnings = s.ruleWarnings(ctx, rule.ID)
+
 	return rule, nil
 }
 
@@ -66,9 +87,63 @@ func (s *Service) UpdateRule(ctx context.Context, id uuid.UUID, input domain.Rul
 		return domain.Rule{}, err
 	}
 
+	rule.Warnings = s.ruleWarnings(ctx, rule.ID)
+
 	return rule, nil
 }
 
+// ApplyProposal approves a pending rule change proposal and applies it like a direct write: the
+// proposed rule is validated, the store claims the proposal and writes the rule in one transaction,
+// desired targets are recomputed, and the saved rule's warnings are returned. It returns
+// ErrRuleChangeConflict when the proposal is no longer pending.
+func (s *Service) ApplyProposal(
+	ctx context.Context,
+	proposal domain.RuleChangeProposal,
+	reviewer domain.Actor,
+	comment string,
+) ([]string, error) {
+	if proposal.Action != domain.RuleChangeActionDelete {
+		if proposal.Proposed == nil {
+			return nil, fmt.Errorf("rule change proposal %s has no proposed rule", proposal.ID)
+		}
+		if err := s.ValidateRule(proposal.Proposed.WriteInput()); err != nil {
+			return nil, err
+		}
+	}
+
+	ruleID, claimed, err := s.store.ApplyRuleChangeProposal(ctx, proposal, reviewer, comment)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, domain.ErrRuleChangeConflict
+	}
+
+	if err = s.store.UpdateAllMachineDesiredTargets(ctx); err != nil {
+		return nil, err
+	}
+
+	if ruleID == uuid.Nil {
+		return nil, nil
+	}
+
+	return s.ruleWarnings(ctx, ruleID), nil
+}
+
+// PreviewRuleWrite reports how creating (nil id) or updating a rule would change the rules
+// resolved for each machine, without applying the write.
+func (s *Service) PreviewRuleWrite(
+	ctx context.Context,
+	id *uuid.UUID,
+	input domain.RuleWriteInput,
+) (domain.PolicyImpactPreview, error) {
+	if err := validateInput(input); err != nil {
+		return domain.PolicyImpactPreview{}, err
+	}
+
+	return s.store.PreviewRuleWrite(ctx, id, input)
+}
+
 func (s *Service) DeleteRule(ctx context.Context, id uuid.UUID) error {
 	if err := s.store.DeleteRule(ctx, id); err != nil {
 		return err
@@ -102,8 +177,8 @@ func validateInput(input domain.RuleWriteInput) *domain.ValidationError {
 	for index, target := range input.Targets.Include {
 		validateIncludeTarget(index, target, err)
 	}
-	for index, group := range input.Targets.Exclude {
-		validateExcludedGroup(index, group, err)
+	for index, target := range input.Targets.Exclude {
+		validateExcludeTarget(index, target, err)
 	}
 
 	if !err.HasFieldErrors() {
@@ -130,9 +205,15 @@ func validateIncludeTarget(index int, target domain.IncludeRuleTargetWriteInput,
 	}
 }
 
-func validateExcludedGroup(index int, group domain.ExcludedGroupWriteInput, err *domain.ValidationError) {
-	if group.GroupID == uuid.Nil {
-		err.Add(fmt.Sprintf("targets.exclude[%d].group_id", index), "is required", "required")
+func validateExcludeTarget(index int, target domain.ExcludeRuleTargetWriteInput, err *domain.ValidationError) {
+	prefix := fmt.Sprintf("targets.exclude[%d]", index)
+	switch target.SubjectKind {
+	case domain.RuleTargetSubjectKindGroup, domain.RuleTargetSubjectKindMachine, domain.RuleTargetSubjectKindUser:
+		if target.SubjectID == uuid.Nil {
+			err.Add(prefix+".subject_id", "is required", "required")
+		}
+	default:
+		err.Add(prefix+".subject_kind", "must be group, machine, or user", "invalid")
 	}
 }
 
@@ -143,15 +224,15 @@ func validateTargetSubject(
 	err *domain.ValidationError,
 ) {
 	switch subjectKind {
-	case domain.RuleTargetSubjectKindGroup:
+	case domain.RuleTargetSubjectKindGroup, domain.RuleTargetSubjectKindMachine, domain.RuleTargetSubjectKindUser:
 		if subjectID == nil {
-			err.Add(prefix+".subject_id", "is required for group targets", "required")
+			err.Add(prefix+".subject_id", "is required for group, machine, and user targets", "required")
 		}
 	case domain.RuleTargetSubjectKindAllDevices, domain.RuleTargetSubjectKindAllUsers:
 		if subjectID != nil {
-			err.Add(prefix+".subject_id", "must be empty unless subject_kind is group", "invalid")
+			err.Add(prefix+".subject_id", "must be empty for all_devices and all_users targets", "invalid")
 		}
 	default:
-		err.Add(prefix+".subject_kind", "must be group, all_devices, or all_users", "invalid")
+		err.Add(prefix+".subject_kind", "must be group, machine, user, all_devices, or all_users", "invalid")
 	}
 }
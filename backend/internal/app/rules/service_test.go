@@ -0,0 +1,547 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/app/rules"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	machine domain.Machine
+	rule    *domain.Rule
+	written *domain.Rule
+	paths   []domain.MachineGroupPath
+
+	rules     []domain.RuleSummary
+	targets   map[uuid.UUID]domain.RuleTargets
+	targeting domain.MachineTargeting
+	overlaps  []domain.RuleOverlap
+
+	overlapRuleID  *uuid.UUID
+	targetingScope *domain.MachineTargetingScope
+	targetingErr   error
+
+	appliedRuleID uuid.UUID
+	applyClaimed  bool
+	applyCalls    int
+	targetUpdates int
+}
+
+func (s *testStore) ListRules(_ context.Context, opts domain.RuleListOptions) ([]domain.RuleSummary, int32, error) {
+	if len(opts.IDs) == 0 {
+		return s.rules, 0, nil
+	}
+
+	selected := make([]domain.RuleSummary, 0, len(opts.IDs))
+	for _, rule := range s.rules {
+		if slices.Contains(opts.IDs, rule.ID) {
+			selected = append(selected, rule)
+		}
+	}
+	return selected, 0, nil
+}
+
+func (s *testStore) GetRule(context.Context, uuid.UUID) (domain.Rule, error) {
+	return domain.Rule{}, errors.New("unexpected GetRule call")
+}
+
+func (s *testStore) CreateRule(context.Context, domain.RuleWriteInput) (domain.Rule, error) {
+	if s.written == nil {
+		return domain.Rule{}, errors.New("unexpected CreateRule call")
+	}
+	return *s.written, nil
+}
+
+func (s *testStore) UpdateRule(context.Context, uuid.UUID, domain.RuleWriteInput) (domain.Rule, error) {
+	return domain.Rule{}, errors.New("unexpected UpdateRule call")
+}
+
+func (s *testStore) DeleteRule(context.Context, uuid.UUID) error {
+	return errors.New("unexpected DeleteRule call")
+}
+
+func (s *testStore) ListResolvedMachineRules(context.Context, uuid.UUID) ([]domain.MachineResolvedRule, error) {
+	return nil, errors.New("unexpected ListResolvedMachineRules call")
+}
+
+func (s *testStore) ApplyRuleChangeProposal(
+	context.Context,
+	domain.RuleChangeProposal,
+	domain.Actor,
+	string,
+) (uuid.UUID, bool, error) {
+	s.applyCalls++
+	return s.appliedRuleID, s.applyClaimed, nil
+}
+
+func (s *testStore) UpdateAllMachineDesiredTargets(context.Context) error {
+	s.targetUpdates++
+	return nil
+}
+
+func (s *testStore) PreviewRuleWrite(
+	context.Context,
+	*uuid.UUID,
+	domain.RuleWriteInput,
+) (domain.PolicyImpactPreview, error) {
+	return domain.PolicyImpactPreview{}, errors.New("unexpected PreviewRuleWrite call")
+}
+
+func (s *testStore) GetRuleByIdentifier(context.Context, domain.RuleType, string) (domain.Rule, error) {
+	if s.rule == nil {
+		return domain.Rule{}, pgx.ErrNoRows
+	}
+
+	return *s.rule, nil
+}
+
+func (s *testStore) GetMachine(context.Context, uuid.UUID) (domain.Machine, error) {
+	return s.machine, nil
+}
+
+func (s *testStore) ListMachineGroupPaths(context.Context, uuid.UUID) ([]domain.MachineGroupPath, error) {
+	return s.paths, nil
+}
+
+func (s *testStore) ListRuleTargetsByRuleID(
+	_ context.Context,
+	ruleIDs []uuid.UUID,
+) (map[uuid.UUID]domain.RuleTargets, error) {
+	if ruleIDs == nil {
+		return s.targets, nil
+	}
+
+	selected := make(map[uuid.UUID]domain.RuleTargets, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if targets, ok := s.targets[id]; ok {
+			selected[id] = targets
+		}
+	}
+	return selected, nil
+}
+
+func (s *testStore) GetMachineTargeting(
+	_ context.Context,
+	scope *domain.MachineTargetingScope,
+) (domain.MachineTargeting, error) {
+	s.targetingScope = scope
+	if s.targetingErr != nil {
+		return domain.MachineTargeting{}, s.targetingErr
+	}
+	return s.targeting, nil
+}
+
+func (s *testStore) ListRuleOverlaps(_ context.Context, ruleID *uuid.UUID) ([]domain.RuleOverlap, error) {
+	s.overlapRuleID = ruleID
+	return s.overlaps, nil
+}
+
+func newTestService(store *testStore) *rules.Service {
+	return rules.New(slog.New(slog.DiscardHandler), store)
+}
+
+func groupInclude(groupID uuid.UUID, policy domain.RulePolicy) domain.IncludeRuleTarget {
+	return domain.IncludeRuleTarget{
+		SubjectKind: domain.RuleTargetSubjectKindGroup,
+		SubjectID:   &groupID,
+		Policy:      policy,
+	}
+}
+
+func groupExclude(groupID uuid.UUID) domain.ExcludeRuleTarget {
+	return domain.ExcludeRuleTarget{SubjectKind: domain.RuleTargetSubjectKindGroup, SubjectID: groupID}
+}
+
+func TestExplainMachineRulePicksBestPriorityMatchingInclude(t *testing.T) {
+	userID := uuid.New()
+	students, music := uuid.New(), uuid.New()
+
+	store := &testStore{
+		machine: domain.Machine{ID: uuid.New(), PrimaryUser: "alice@example.com", PrimaryUserID: &userID},
+		rule: &domain.Rule{
+			ID:      uuid.New(),
+			Enabled: true,
+			Targets: domain.RuleTargets{
+				Include: []domain.IncludeRuleTarget{
+					groupInclude(uuid.New(), domain.RulePolicyAllowlist),
+					groupInclude(music, domain.RulePolicyAllowlist),
+					groupInclude(students, domain.RulePolicyBlocklist),
+				},
+			},
+		},
+		paths: []domain.MachineGroupPath{
+			{GroupID: music, Via: domain.GroupPathViaPrimaryUser},
+			{GroupID: students, Via: domain.GroupPathViaMachine},
+		},
+	}
+
+	explanation, err := newTestService(store).ExplainMachineRule(
+		context.Background(),
+		store.machine.ID,
+		domain.RuleTypeBinary,
+		"app-sha",
+	)
+	if err != nil {
+		t.Fatalf("ExplainMachineRule() error = %v", err)
+	}
+
+	if explanation.Outcome != domain.RuleExplanationOutcomeApplied {
+		t.Fatalf("Outcome = %s, want applied", explanation.Outcome)
+	}
+	if explanation.Policy == nil || *explanation.Policy != domain.RulePolicyAllowlist {
+		t.Fatalf("Policy = %v, want allowlist", explanation.Policy)
+	}
+	if len(explanation.Targets) != 3 {
+		t.Fatalf("len(Targets) = %d, want 3", len(explanation.Targets))
+	}
+	if explanation.Targets[0].Matched {
+		t.Fatal("Targets[0].Matched = true, want false")
+	}
+	musicTarget := explanation.Targets[1]
+	if !musicTarget.Won || len(musicTarget.Paths) != 1 || musicTarget.Paths[0].Via != domain.GroupPathViaPrimaryUser {
+		t.Fatalf("Targets[1] = %+v, want winning match via primary user", musicTarget)
+	}
+	if studentsTarget := explanation.Targets[2]; !studentsTarget.Matched || studentsTarget.Won {
+		t.Fatalf("Targets[2] = %+v, want matched but not winning", studentsTarget)
+	}
+}
+
+func TestExplainMachineRuleReportsAppliedExclusion(t *testing.T) {
+	staff := uuid.New()
+
+	store := &testStore{
+		machine: domain.Machine{ID: uuid.New()},
+		rule: &domain.Rule{
+			ID:      uuid.New(),
+			Enabled: true,
+			Targets: domain.RuleTargets{
+				Include: []domain.IncludeRuleTarget{
+					{SubjectKind: domain.RuleTargetSubjectKindAllDevices, Policy: domain.RulePolicyBlocklist},
+					{SubjectKind: domain.RuleTargetSubjectKindAllUsers, Policy: domain.RulePolicyAllowlist},
+				},
+				Exclude: []domain.ExcludeRuleTarget{groupExclude(staff)},
+			},
+		},
+		paths: []domain.MachineGroupPath{{GroupID: staff, Via: domain.GroupPathViaMachine}},
+	}
+
+	explanation, err := newTestService(store).ExplainMachineRule(
+		context.Background(),
+		store.machine.ID,
+		domain.RuleTypeTeamID,
+		"EQHXZ8M8AV",
+	)
+	if err != nil {
+		t.Fatalf("ExplainMachineRule() error = %v", err)
+	}
+
+	if explanation.Outcome != domain.RuleExplanationOutcomeExcluded || explanation.Policy != nil {
+		t.Fatalf("Outcome = %s with policy %v, want excluded without policy", explanation.Outcome, explanation.Policy)
+	}
+	// Without a resolved primary user, all_users does not match.
+	if explanation.Targets[1].Matched {
+		t.Fatal("all_users target matched without a primary user")
+	}
+	if exclude := explanation.Targets[2]; exclude.Assignment != domain.RuleTargetAssignmentExclude || !exclude.Matched {
+		t.Fatalf("Targets[2] = %+v, want matched exclude", exclude)
+	}
+}
+
+func TestExplainMachineRuleMatchesMachineAndUserTargets(t *testing.T) {
+	userID := uuid.New()
+	machineID := uuid.New()
+
+	store := &testStore{
+		machine: domain.Machine{ID: machineID, PrimaryUser: "alice@example.com", PrimaryUserID: &userID},
+		rule: &domain.Rule{
+			ID:      uuid.New(),
+			Enabled: true,
+			Targets: domain.RuleTargets{
+				Include: []domain.IncludeRuleTarget{
+					{
+						SubjectKind: domain.RuleTargetSubjectKindMachine,
+						SubjectID:   &machineID,
+						Policy:      domain.RulePolicyBlocklist,
+					},
+					{SubjectKind: domain.RuleTargetSubjectKindUser, SubjectID: &userID, Policy: domain.RulePolicyAllowlist},
+				},
+				Exclude: []domain.ExcludeRuleTarget{
+					{SubjectKind: domain.RuleTargetSubjectKindMachine, SubjectID: uuid.New()},
+				},
+			},
+		},
+	}
+
+	explanation, err := newTestService(store).ExplainMachineRule(
+		context.Background(),
+		machineID,
+		domain.RuleTypeBinary,
+		"app-sha",
+	)
+	if err != nil {
+		t.Fatalf("ExplainMachineRule() error = %v", err)
+	}
+
+	if explanation.Policy == nil || *explanation.Policy != domain.RulePolicyBlocklist {
+		t.Fatalf("Policy = %v, want blocklist from machine target", explanation.Policy)
+	}
+	if !explanation.Targets[0].Won || !explanation.Targets[1].Matched || explanation.Targets[1].Won {
+		t.Fatalf("Targets = %+v, want machine target winning over matched user target", explanation.Targets)
+	}
+	if explanation.Targets[2].Matched {
+		t.Fatal("exclude for another machine matched")
+	}
+}
+
+func TestExplainMachineRuleWithoutRule(t *testing.T) {
+	store := &testStore{machine: domain.Machine{ID: uuid.New()}}
+
+	explanation, err := newTestService(store).ExplainMachineRule(
+		context.Background(),
+		store.machine.ID,
+		domain.RuleTypeSigningID,
+		"EQHXZ8M8AV:com.example.app",
+	)
+	if err != nil {
+		t.Fatalf("ExplainMachineRule() error = %v", err)
+	}
+
+	if explanation.Outcome != domain.RuleExplanationOutcomeNoRule || explanation.Rule != nil {
+		t.Fatalf("explanation = %+v, want no_rule", explanation)
+	}
+}
+
+func TestAnalyzeRulesFindsConflictsAcrossRuleTypes(t *testing.T) {
+	laptop, desktop := uuid.New(), uuid.New()
+	music := uuid.New()
+	binaryAllow := domain.RuleSummary{ID: uuid.New(), Name: "Allow synth", Enabled: true}
+	teamBlock := domain.RuleSummary{ID: uuid.New(), Name: "Block vendor", Enabled: true}
+
+	store := &testStore{
+		rules: []domain.RuleSummary{binaryAllow, teamBlock},
+		targets: map[uuid.UUID]domain.RuleTargets{
+			binaryAllow.ID: {Include: []domain.IncludeRuleTarget{groupInclude(music, domain.RulePolicyAllowlist)}},
+			teamBlock.ID: {Include: []domain.IncludeRuleTarget{
+				{SubjectKind: domain.RuleTargetSubjectKindAllDevices, Policy: domain.RulePolicyBlocklist},
+			}},
+		},
+		targeting: domain.MachineTargeting{
+			MachineIDs:      []uuid.UUID{laptop, desktop},
+			GroupMachineIDs: map[uuid.UUID][]uuid.UUID{music: {laptop}},
+		},
+		overlaps: []domain.RuleOverlap{{RuleID: binaryAllow.ID, OtherRuleID: teamBlock.ID, ExecutableCount: 1}},
+	}
+
+	findings, err := newTestService(store).AnalyzeRules(context.Background(), &teamBlock.ID)
+	if err != nil {
+		t.Fatalf("AnalyzeRules() error = %v", err)
+	}
+
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want one conflict", findings)
+	}
+	finding := findings[0]
+	if finding.Kind != domain.RuleFindingKindConflictingPolicy || finding.MachineCount != 1 {
+		t.Fatalf("finding = %+v, want conflict on one machine", finding)
+	}
+	if finding.OtherRuleID == nil || *finding.OtherRuleID != teamBlock.ID {
+		t.Fatalf("OtherRuleID = %v, want %s", finding.OtherRuleID, teamBlock.ID)
+	}
+	if store.overlapRuleID == nil || *store.overlapRuleID != teamBlock.ID {
+		t.Fatalf("overlaps listed for %v, want only rule %s", store.overlapRuleID, teamBlock.ID)
+	}
+}
+
+func TestAnalyzeRulesFindsUnreachableTargetsAndBlanketExcludes(t *testing.T) {
+	laptop := uuid.New()
+	staff, everyone := uuid.New(), uuid.New()
+	shadowed := domain.RuleSummary{ID: uuid.New(), Name: "Shadowed", Enabled: true}
+	excluded := domain.RuleSummary{ID: uuid.New(), Name: "Excluded", Enabled: true}
+
+	store := &testStore{
+		rules: []domain.RuleSummary{excluded, shadowed},
+		targets: map[uuid.UUID]domain.RuleTargets{
+			shadowed.ID: {Include: []domain.IncludeRuleTarget{
+				groupInclude(everyone, domain.RulePolicyAllowlist),
+				groupInclude(staff, domain.RulePolicyBlocklist),
+			}},
+			excluded.ID: {
+				Include: []domain.IncludeRuleTarget{groupInclude(staff, domain.RulePolicyAllowlist)},
+				Exclude: []domain.ExcludeRuleTarget{groupExclude(everyone)},
+			},
+		},
+		targeting: domain.MachineTargeting{
+			MachineIDs: []uuid.UUID{laptop},
+			GroupMachineIDs: map[uuid.UUID][]uuid.UUID{
+				staff:    {laptop},
+				everyone: {laptop},
+			},
+		},
+	}
+
+	findings, err := newTestService(store).AnalyzeRules(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("AnalyzeRules() error = %v", err)
+	}
+
+	if len(findings) != 2 {
+		t.Fatalf("findings = %+v, want two", findings)
+	}
+	if findings[0].Kind != domain.RuleFindingKindExcludedEverywhere || findings[0].RuleID != excluded.ID {
+		t.Fatalf("findings[0] = %+v, want excluded everywhere", findings[0])
+	}
+	unreachable := findings[1]
+	if unreachable.Kind != domain.RuleFindingKindUnreachableTarget || unreachable.Priority == nil ||
+		*unreachable.Priority != 2 {
+		t.Fatalf("findings[1] = %+v, want unreachable target at priority 2", unreachable)
+	}
+}
+
+func TestAnalyzeRulesForRuleSkipsUninvolvedRules(t *testing.T) {
+	laptop := uuid.New()
+	everyone := uuid.New()
+	written := domain.RuleSummary{ID: uuid.New(), Name: "Written", Enabled: true}
+	unrelated := domain.RuleSummary{ID: uuid.New(), Name: "Unrelated", Enabled: true}
+
+	store := &testStore{
+		rules: []domain.RuleSummary{unrelated, written},
+		targets: map[uuid.UUID]domain.RuleTargets{
+			written.ID: {Include: []domain.IncludeRuleTarget{groupInclude(everyone, domain.RulePolicyAllowlist)}},
+			unrelated.ID: {
+				Include: []domain.IncludeRuleTarget{groupInclude(everyone, domain.RulePolicyAllowlist)},
+				Exclude: []domain.ExcludeRuleTarget{groupExclude(everyone)},
+			},
+		},
+		targeting: domain.MachineTargeting{
+			MachineIDs:      []uuid.UUID{laptop},
+			GroupMachineIDs: map[uuid.UUID][]uuid.UUID{everyone: {laptop}},
+		},
+	}
+
+	findings, err := newTestService(store).AnalyzeRules(context.Background(), &written.ID)
+	if err != nil {
+		t.Fatalf("AnalyzeRules() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Fatalf("findings = %+v, want none for the written rule", findings)
+	}
+
+	scope := store.targetingScope
+	if scope == nil || scope.AllDevices || !slices.Equal(scope.GroupIDs, []uuid.UUID{everyone}) {
+		t.Fatalf("targeting scope = %+v, want only the written rule's group", scope)
+	}
+
+	findings, err = newTestService(store).AnalyzeRules(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("AnalyzeRules() error = %v", err)
+	}
+	if len(findings) != 1 || findings[0].RuleID != unrelated.ID {
+		t.Fatalf("findings = %+v, want the unrelated rule excluded everywhere", findings)
+	}
+	if store.targetingScope != nil {
+		t.Fatalf("targeting scope = %+v, want the whole fleet", store.targetingScope)
+	}
+}
+
+func TestCreateRuleKeepsWriteWhenAnalysisFails(t *testing.T) {
+	written := domain.Rule{ID: uuid.New(), Name: "Chrome"}
+	store := &testStore{written: &written, targetingErr: errors.New("connection reset")}
+
+	rule, err := newTestService(store).CreateRule(context.Background(), domain.RuleWriteInput{
+		Name:       "Chrome",
+		RuleType:   domain.RuleTypeBinary,
+		Identifier: "chrome-sha",
+	})
+	if err != nil {
+		t.Fatalf("CreateRule() error = %v, want the saved rule", err)
+	}
+	if rule.ID != written.ID || rule.Warnings == nil || len(rule.Warnings) != 0 {
+		t.Fatalf("rule = %+v, want the saved rule with empty warnings", rule)
+	}
+	if store.targetUpdates != 1 {
+		t.Fatalf("targetUpdates = %d, want 1", store.targetUpdates)
+	}
+}
+
+func TestApplyProposal(t *testing.T) {
+	ruleID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	proposed := &domain.RuleSnapshot{
+		Name:       "Chrome",
+		RuleType:   domain.RuleTypeBinary,
+		Identifier: "chrome-sha",
+		Enabled:    true,
+	}
+
+	tests := []struct {
+		name            string
+		proposal        domain.RuleChangeProposal
+		claimed         bool
+		wantErr         error
+		wantApply       int
+		wantTargetCalls int
+	}{
+		{
+			name:            "create",
+			proposal:        domain.RuleChangeProposal{Action: domain.RuleChangeActionCreate, Proposed: proposed},
+			claimed:         true,
+			wantApply:       1,
+			wantTargetCalls: 1,
+		},
+		{
+			name:            "delete",
+			proposal:        domain.RuleChangeProposal{Action: domain.RuleChangeActionDelete, RuleID: &ruleID},
+			claimed:         true,
+			wantApply:       1,
+			wantTargetCalls: 1,
+		},
+		{
+			name:      "no longer pending",
+			proposal:  domain.RuleChangeProposal{Action: domain.RuleChangeActionCreate, Proposed: proposed},
+			wantErr:   domain.ErrRuleChangeConflict,
+			wantApply: 1,
+		},
+		{
+			name: "invalid proposed rule",
+			proposal: domain.RuleChangeProposal{
+				Action:   domain.RuleChangeActionCreate,
+				Proposed: &domain.RuleSnapshot{RuleType: domain.RuleTypeBinary},
+			},
+			wantErr: &domain.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{applyClaimed: tt.claimed}
+			if tt.proposal.Action == domain.RuleChangeActionCreate {
+				store.appliedRuleID = ruleID
+			}
+
+			_, err := newTestService(store).ApplyProposal(context.Background(), tt.proposal, domain.Actor{}, "")
+			var validationErr *domain.ValidationError
+			switch {
+			case errors.As(tt.wantErr, &validationErr):
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("ApplyProposal() error = %v, want validation error", err)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("ApplyProposal() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if store.applyCalls != tt.wantApply {
+				t.Fatalf("applyCalls = %d, want %d", store.applyCalls, tt.wantApply)
+			}
+			if store.targetUpdates != tt.wantTargetCalls {
+				t.Fatalf("targetUpdates = %d, want %d", store.targetUpdates, tt.wantTargetCalls)
+			}
+		})
+	}
+}
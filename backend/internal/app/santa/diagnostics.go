@@ -0,0 +1,104 @@
+package santa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/santa/model"
+	"github.com/woodleighschool/grinch/internal/santa/snapshot"
+)
+
+// MachineSyncDiagnostics reports a machine's stored sync state together with the payload its
+// next sync would receive. It does not change any sync state.
+func (s *Service) MachineSyncDiagnostics(
+	ctx context.Context,
+	machineID uuid.UUID,
+) (domain.MachineSyncDiagnostics, error) {
+	state, err := s.dataStore.GetMachineSyncState(ctx, machineID)
+	if err != nil {
+		return domain.MachineSyncDiagnostics{}, fmt.Errorf("get machine sync state: %w", err)
+	}
+
+	resolvedRules, err := s.ruleResolver.ResolveMachineRuleTargets(ctx, machineID)
+	if err != nil {
+		return domain.MachineSyncDiagnostics{}, fmt.Errorf("resolve machine rule targets: %w", err)
+	}
+
+	next := snapshot.PlanNextSnapshot(state, resolvedRules, time.Now().UTC())
+
+	diagnostics := domain.MachineSyncDiagnostics{
+		MachineID:                 machineID,
+		RuleSyncStatus:            state.RuleSyncStatus,
+		StatusReason:              snapshot.RuleSyncStatusReason(state),
+		RulesHash:                 state.RulesHash,
+		DesiredTargets:            syncRuleTargets(state.DesiredTargets),
+		AppliedTargets:            syncRuleTargets(state.AppliedTargets),
+		SentTargets:               syncRuleTargets(state.SentTargets),
+		PendingPreflightAt:        state.PendingPreflightAt,
+		RuleCounts:                snapshot.RuleCounts(state),
+		RulesReceived:             state.RulesReceived,
+		RulesProcessed:            state.RulesProcessed,
+		RequestedCleanSyncAt:      state.RequestedCleanSyncAt,
+		LastRuleSyncAttemptAt:     state.LastRuleSyncAttemptAt,
+		LastRuleSyncSuccessAt:     state.LastRuleSyncSuccessAt,
+		LastCleanSyncAt:           state.LastCleanSyncAt,
+		LastReportedCountsMatchAt: state.LastReportedCountsMatchAt,
+		NextSync:                  syncPayload(next),
+	}
+	if state.RequestedCleanSync != "" {
+		requested := state.RequestedCleanSync
+		diagnostics.RequestedCleanSync = &requested
+	}
+	if state.PendingPreflightAt != nil {
+		pending := syncPayload(snapshot.PendingSnapshot{
+			FullSync:         state.PendingFullSync,
+			CleanAll:         state.PendingCleanAll,
+			Payload:          state.PendingPayload,
+			PayloadRuleCount: state.PendingPayloadRuleCount,
+		})
+		diagnostics.Pending = &pending
+	}
+
+	return diagnostics, nil
+}
+
+func syncPayload(pending snapshot.PendingSnapshot) domain.MachineSyncPayload {
+	syncType := domain.SyncTypeNormal
+	switch {
+	case pending.CleanAll:
+		syncType = domain.SyncTypeCleanAll
+	case pending.FullSync:
+		syncType = domain.SyncTypeClean
+	}
+
+	rules := make([]domain.SyncPayloadRule, 0, len(pending.Payload))
+	for _, rule := range pending.Payload {
+		rules = append(rules, domain.SyncPayloadRule{
+			MachineRuleTarget: rule.MachineRuleTarget,
+			Removed:           rule.Removed,
+		})
+	}
+
+	return domain.MachineSyncPayload{
+		SyncType:  syncType,
+		RuleCount: pending.PayloadRuleCount,
+		Rules:     rules,
+	}
+}
+
+func syncRuleTargets(targets []model.AppliedRuleTarget) []domain.SyncRuleTarget {
+	converted := make([]domain.SyncRuleTarget, 0, len(targets))
+	for _, target := range targets {
+		converted = append(converted, domain.SyncRuleTarget{
+			RuleType:    target.RuleType,
+			Identifier:  target.Identifier,
+			PayloadHash: target.PayloadHash,
+		})
+	}
+
+	return converted
+}
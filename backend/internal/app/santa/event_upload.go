@@ -66,6 +66,8 @@ func (s *Service) HandleEventUpload(
 		return nil, err
 	}
 
+	s.addUserLookups(executionEvents)
+
 	fileAccessEvents, err := mapFileAccessEvents(req.GetFileAccessEvents())
 	if err != nil {
 		err = fmt.Errorf("%w: %w", ErrInvalidSyncRequest, err)
@@ -145,6 +147,18 @@ func mapExecutionEvents(
 	return writes, nil
 }
 
+// addUserLookups sets the names the executing and logged-in users may match, so the store can link
+// events to users the same way it resolves primary users.
+func (s *Service) addUserLookups(events []model.ExecutionEventWrite) {
+	for i := range events {
+		events[i].ExecutingUserLookups = s.userMatching.Lookups(events[i].ExecutingUser)
+		events[i].LoggedInUserLookups = make([][]string, len(events[i].LoggedInUsers))
+		for j, user := range events[i].LoggedInUsers {
+			events[i].LoggedInUserLookups[j] = s.userMatching.Lookups(user)
+		}
+	}
+}
+
 func mapFileAccessEvents(events []*syncv1.FileAccessEvent) ([]model.FileAccessEventWrite, error) {
 	writes := make([]model.FileAccessEventWrite, 0, len(events))
 
@@ -34,17 +34,18 @@ func (s *Service) HandlePreflight(
 	)
 
 	err := s.dataStore.UpsertMachine(ctx, model.MachineUpsert{
-		MachineID:         machineID,
-		SerialNumber:      req.GetSerialNumber(),
-		Hostname:          req.GetHostname(),
-		ModelIdentifier:   req.GetModelIdentifier(),
-		OSVersion:         req.GetOsVersion(),
-		OSBuild:           req.GetOsBuild(),
-		SantaVersion:      req.GetSantaVersion(),
-		PrimaryUser:       req.GetPrimaryUser(),
-		PrimaryUserGroups: normalizeStrings(req.GetPrimaryUserGroups()),
-		ClientMode:        snapshot.MachineClientModeFromProto(req.GetClientMode()),
-		LastSeenAt:        time.Now().UTC(),
+		MachineID:          machineID,
+		SerialNumber:       req.GetSerialNumber(),
+		Hostname:           req.GetHostname(),
+		ModelIdentifier:    req.GetModelIdentifier(),
+		OSVersion:          req.GetOsVersion(),
+		OSBuild:            req.GetOsBuild(),
+		SantaVersion:       req.GetSantaVersion(),
+		PrimaryUser:        req.GetPrimaryUser(),
+		PrimaryUserGroups:  normalizeStrings(req.GetPrimaryUserGroups()),
+		ClientMode:         snapshot.MachineClientModeFromProto(req.GetClientMode()),
+		LastSeenAt:         time.Now().UTC(),
+		PrimaryUserLookups: s.userMatching.Lookups(req.GetPrimaryUser()),
 	})
 	if err != nil {
 		s.logger.ErrorContext(
@@ -54,6 +55,14 @@ func (s *Service) HandlePreflight(
 		return nil, fmt.Errorf("upsert machine: %w", err)
 	}
 
+	if _, err = s.dataStore.SyncMachineComputedMemberships(ctx, machineID, time.Now().UTC()); err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"santa preflight sync computed memberships failed",
+			syncLogAttrs(ctx, machineID, "error", err)...)
+		return nil, fmt.Errorf("sync computed memberships: %w", err)
+	}
+
 	if err = s.dataStore.UpdateMachineDesiredTargets(ctx, machineID); err != nil {
 		s.logger.ErrorContext(
 			ctx,
@@ -78,7 +87,7 @@ func (s *Service) HandlePreflight(
 		return nil, fmt.Errorf("prepare pending rule snapshot: %w", err)
 	}
 
-	syncType := snapshot.SyncTypeFromPendingFullSync(pendingSnapshot.FullSync)
+	syncType := pendingSnapshot.SyncType()
 	s.logger.DebugContext(
 		ctx,
 		"santa preflight completed",
@@ -88,6 +97,7 @@ func (s *Service) HandlePreflight(
 			"sync_type", syncType.String(),
 			"payload_rule_count", pendingSnapshot.PayloadRuleCount,
 			"full_sync", pendingSnapshot.FullSync,
+			"clean_all", pendingSnapshot.CleanAll,
 		)...,
 	)
 
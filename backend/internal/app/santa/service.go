@@ -21,6 +21,7 @@ type Service struct {
 	dataStore      model.DataStore
 	eventAllowlist map[domain.ExecutionDecision]struct{}
 	ruleResolver   model.RuleResolver
+	userMatching   domain.PrimaryUserMatching
 }
 
 func New(
@@ -28,6 +29,7 @@ func New(
 	dataStore model.DataStore,
 	eventAllowlist []domain.ExecutionDecision,
 	ruleResolver model.RuleResolver,
+	userMatching domain.PrimaryUserMatching,
 ) *Service {
 	allowlist := make(map[domain.ExecutionDecision]struct{}, len(eventAllowlist))
 	for _, decision := range eventAllowlist {
@@ -39,6 +41,7 @@ func New(
 		dataStore:      dataStore,
 		eventAllowlist: allowlist,
 		ruleResolver:   ruleResolver,
+		userMatching:   userMatching,
 	}
 }
 
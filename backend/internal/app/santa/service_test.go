@@ -39,6 +39,10 @@ func (s *testStore) UpsertMachine(_ context.Context, machine santamodel.MachineU
 	return s.upsertErr
 }
 
+func (s *testStore) SyncMachineComputedMemberships(context.Context, uuid.UUID, time.Time) (bool, error) {
+	return false, nil
+}
+
 func (s *testStore) GetMachineSyncState(
 	_ context.Context,
 	machineID uuid.UUID,
@@ -66,7 +70,7 @@ func (s *testStore) UpdateMachineDesiredTargets(_ context.Context, machineID uui
 }
 
 func (s *testStore) ReplacePendingSnapshot(_ context.Context, pending santamodel.PendingSnapshotWrite) error {
-	s.ensureSyncState(pending.MachineID)
+	previous := s.ensureSyncState(pending.MachineID)
 
 	s.syncStates[pending.MachineID] = santamodel.MachineSyncState{
 		MachineID:                   pending.MachineID,
@@ -77,6 +81,10 @@ func (s *testStore) ReplacePendingSnapshot(_ context.Context, pending santamodel
 		PendingPayload:              slices.Clone(pending.PendingPayload),
 		PendingPayloadRuleCount:     pending.PendingPayloadRuleCount,
 		PendingFullSync:             pending.PendingFullSync,
+		PendingCleanAll:             pending.PendingCleanAll,
+		PendingCleanSyncRequestedAt: pending.PendingCleanSyncRequestedAt,
+		RequestedCleanSync:          previous.RequestedCleanSync,
+		RequestedCleanSyncAt:        previous.RequestedCleanSyncAt,
 		PendingPreflightAt:          &pending.PendingPreflightAt,
 		DesiredBinaryRuleCount:      pending.DesiredBinaryRuleCount,
 		DesiredCertificateRuleCount: pending.DesiredCertificateRuleCount,
@@ -127,6 +135,12 @@ func (s *testStore) PromotePendingSnapshot(
 	if pendingFullSync {
 		state.LastCleanSyncAt = &completedAt
 	}
+	if equalTimes(state.RequestedCleanSyncAt, state.PendingCleanSyncRequestedAt) {
+		state.RequestedCleanSync = ""
+		state.RequestedCleanSyncAt = nil
+	}
+	state.PendingCleanAll = false
+	state.PendingCleanSyncRequestedAt = nil
 
 	s.syncStates[machineID] = state
 	return nil
@@ -163,15 +177,55 @@ func (s *testStore) ensureSyncState(machineID uuid.UUID) santamodel.MachineSyncS
 	return state
 }
 
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func newTestService(store *testStore, resolver *testRuleResolver) *santa.Service {
 	store.resolver = resolver
-	return santa.New(newTestLogger(), store, nil, resolver)
+	return santa.New(newTestLogger(), store, nil, resolver, domain.PrimaryUserMatching{DefaultDomain: "example.com"})
 }
 
 func newTestLogger() *slog.Logger {
 	return slog.New(slog.DiscardHandler)
 }
 
+func TestHandlePreflight_PassesPrimaryUserLookups(t *testing.T) {
+	tests := []struct {
+		name        string
+		primaryUser string
+		want        []string
+	}{
+		{name: "short name gains default domain", primaryUser: " Alice ", want: []string{"alice", "alice@example.com"}},
+		{name: "netbios domain is dropped", primaryUser: `SCHOOL\alice`, want: []string{"alice", "alice@example.com"}},
+		{name: "upn is lowercased", primaryUser: "Alice@Example.com", want: []string{"alice@example.com"}},
+		{name: "empty has no lookups", primaryUser: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineID := uuid.New()
+			store := &testStore{}
+			service := newTestService(store, &testRuleResolver{})
+
+			req := syncv1.PreflightRequest_builder{
+				MachineId:   machineID.String(),
+				PrimaryUser: tt.primaryUser,
+			}.Build()
+			if _, err := service.HandlePreflight(context.Background(), machineID, req); err != nil {
+				t.Fatalf("HandlePreflight() error = %v", err)
+			}
+
+			if got := store.lastUpsert.PrimaryUserLookups; !slices.Equal(got, tt.want) {
+				t.Fatalf("PrimaryUserLookups = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandlePreflight_UpsertsMachineAndReturnsSyncSettings(t *testing.T) {
 	machineID := uuid.New()
 	store := &testStore{}
@@ -307,6 +361,44 @@ func TestHandleEventUploadRemovesNULBytesFromSessionArrays(t *testing.T) {
 	}
 }
 
+func TestHandleEventUpload_PassesEventUserLookups(t *testing.T) {
+	store := &testStore{}
+	service := newTestService(store, &testRuleResolver{})
+
+	_, err := service.HandleEventUpload(
+		context.Background(),
+		uuid.New(),
+		syncv1.EventUploadRequest_builder{
+			Events: []*syncv1.Event{
+				syncv1.Event_builder{
+					FileSha256:    "abc123",
+					FileName:      "Example",
+					ExecutingUser: "Alice",
+					LoggedInUsers: []string{"bob@Example.com", ""},
+					Decision:      syncv1.Decision_ALLOW_BINARY,
+				}.Build(),
+			},
+		}.Build(),
+	)
+	if err != nil {
+		t.Fatalf("HandleEventUpload() error = %v", err)
+	}
+
+	event := store.lastIngestedEvents[0]
+	if want := []string{"alice", "alice@example.com"}; !slices.Equal(event.ExecutingUserLookups, want) {
+		t.Fatalf("ExecutingUserLookups = %#v, want %#v", event.ExecutingUserLookups, want)
+	}
+	if len(event.LoggedInUserLookups) != 2 {
+		t.Fatalf("LoggedInUserLookups = %#v, want one per logged-in user", event.LoggedInUserLookups)
+	}
+	if want := []string{"bob@example.com"}; !slices.Equal(event.LoggedInUserLookups[0], want) {
+		t.Fatalf("LoggedInUserLookups[0] = %#v, want %#v", event.LoggedInUserLookups[0], want)
+	}
+	if event.LoggedInUserLookups[1] != nil {
+		t.Fatalf("LoggedInUserLookups[1] = %#v, want none for an empty name", event.LoggedInUserLookups[1])
+	}
+}
+
 func TestHandlePreflight_ReturnsNormalWhenDesiredRulesChangedEvenIfManagedCountsDiverge(t *testing.T) {
 	machineID := uuid.New()
 	store := &testStore{
@@ -381,6 +473,54 @@ func TestHandlePreflight_ReturnsCleanWhenAppliedMatchesDesiredAndReportedCountsD
 	}
 }
 
+func TestHandlePreflight_ReturnsRequestedCleanAllUntilPromoted(t *testing.T) {
+	machineID := uuid.New()
+	requestedAt := time.Date(2026, 5, 28, 10, 0, 0, 0, time.UTC)
+
+	store := &testStore{
+		syncStates: map[uuid.UUID]santamodel.MachineSyncState{
+			machineID: {
+				MachineID:            machineID,
+				RequestedCleanSync:   domain.CleanSyncTypeCleanAll,
+				RequestedCleanSyncAt: &requestedAt,
+			},
+		},
+	}
+	service := newTestService(store, &testRuleResolver{})
+	preflight := syncv1.PreflightRequest_builder{MachineId: machineID.String()}.Build()
+
+	resp, err := service.HandlePreflight(context.Background(), machineID, preflight)
+	if err != nil {
+		t.Fatalf("HandlePreflight() error = %v", err)
+	}
+	if resp.GetSyncType() != syncv1.SyncType_CLEAN_ALL {
+		t.Fatalf("SyncType = %v, want CLEAN_ALL", resp.GetSyncType())
+	}
+
+	if _, err = service.HandlePostflight(
+		context.Background(),
+		machineID,
+		syncv1.PostflightRequest_builder{
+			MachineId: machineID.String(),
+			SyncType:  syncv1.SyncType_CLEAN_ALL,
+		}.Build(),
+	); err != nil {
+		t.Fatalf("HandlePostflight() error = %v", err)
+	}
+
+	if state := store.syncStates[machineID]; state.RequestedCleanSync != "" || state.LastCleanSyncAt == nil {
+		t.Fatalf("state = %+v, want request cleared by a clean sync", state)
+	}
+
+	resp, err = service.HandlePreflight(context.Background(), machineID, preflight)
+	if err != nil {
+		t.Fatalf("HandlePreflight() error = %v", err)
+	}
+	if resp.GetSyncType() != syncv1.SyncType_NORMAL {
+		t.Fatalf("SyncType = %v, want NORMAL after the request was served", resp.GetSyncType())
+	}
+}
+
 func TestHandleRuleDownload_ReturnsChangedRulesAndRemovalsDuringNormalSync(t *testing.T) {
 	machineID := uuid.New()
 	removedTarget := appliedTargetFromRuleTarget(domain.MachineRuleTarget{
@@ -618,6 +758,57 @@ func TestHandlePostflight_PromotesEmptyPendingSnapshot(t *testing.T) {
 	}
 }
 
+func TestMachineSyncDiagnostics_ExplainsIssueAndPlansNextSync(t *testing.T) {
+	machineID := uuid.New()
+	cleanSyncAt := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	ruleTarget := domain.MachineRuleTarget{
+		RuleType:   domain.RuleTypeBinary,
+		Identifier: "com.example.binary",
+		Policy:     domain.RulePolicyAllowlist,
+	}
+	applied := []santamodel.AppliedRuleTarget{appliedTargetFromRuleTarget(ruleTarget)}
+
+	store := &testStore{
+		syncStates: map[uuid.UUID]santamodel.MachineSyncState{
+			machineID: {
+				MachineID:              machineID,
+				DesiredTargets:         applied,
+				AppliedTargets:         applied,
+				SentTargets:            applied,
+				DesiredBinaryRuleCount: 1,
+				BinaryRuleCount:        0,
+				LastCleanSyncAt:        &cleanSyncAt,
+				RuleSyncStatus:         domain.MachineRuleSyncStatusIssue,
+			},
+		},
+	}
+	service := newTestService(store, &testRuleResolver{
+		resolvedRules: []domain.MachineResolvedRule{
+			resolvedRule(uuid.New(), "Binary", ruleTarget),
+		},
+	})
+
+	diagnostics, err := service.MachineSyncDiagnostics(context.Background(), machineID)
+	if err != nil {
+		t.Fatalf("MachineSyncDiagnostics() error = %v", err)
+	}
+	if diagnostics.RuleSyncStatus != domain.MachineRuleSyncStatusIssue {
+		t.Fatalf("RuleSyncStatus = %q, want issue", diagnostics.RuleSyncStatus)
+	}
+	if !strings.Contains(diagnostics.StatusReason, "binary desired 1 reported 0") {
+		t.Fatalf("StatusReason = %q, want binary count mismatch", diagnostics.StatusReason)
+	}
+	if diagnostics.Pending != nil {
+		t.Fatalf("Pending = %+v, want nil without a pending preflight", diagnostics.Pending)
+	}
+	if diagnostics.NextSync.SyncType != domain.SyncTypeClean || diagnostics.NextSync.RuleCount != 1 {
+		t.Fatalf("NextSync = %+v, want one-rule clean sync", diagnostics.NextSync)
+	}
+	if state := store.syncStates[machineID]; state.PendingPreflightAt != nil {
+		t.Fatalf("PendingPreflightAt = %v, want diagnostics to leave sync state untouched", state.PendingPreflightAt)
+	}
+}
+
 func appliedTargetFromRuleTarget(target domain.MachineRuleTarget) santamodel.AppliedRuleTarget {
 	return santamodel.AppliedRuleTarget{
 		RuleType:    target.RuleType,
@@ -0,0 +1,223 @@
+package serviceaccounts
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const (
+	// TokenPrefix marks Grinch API tokens so they are recognisable in configs and secret scanners.
+	TokenPrefix = "grn_"
+
+	tokenSecretBytes  = 32
+	tokenDisplayChars = 8
+)
+
+var ErrInvalidToken = errors.New("invalid api token")
+
+type WriteInput struct {
+	Name        string
+	Description string
+}
+
+type TokenCreateInput struct {
+	ServiceAccountID uuid.UUID
+	Name             string
+	Scopes           []domain.Permission
+	ExpiresAt        *time.Time
+}
+
+type Store interface {
+	ListServiceAccounts(context.Context, domain.ListOptions) ([]domain.ServiceAccount, int32, error)
+	GetServiceAccount(context.Context, uuid.UUID) (domain.ServiceAccount, error)
+	CreateServiceAccount(context.Context, string, string) (domain.ServiceAccount, error)
+	UpdateServiceAccount(context.Context, uuid.UUID, string, string) (domain.ServiceAccount, error)
+	DeleteServiceAccount(context.Context, uuid.UUID) error
+	ListAPITokens(context.Context, domain.APITokenListOptions) ([]domain.APIToken, int32, error)
+	GetAPIToken(context.Context, uuid.UUID) (domain.APIToken, error)
+	CreateAPIToken(
+		context.Context,
+		uuid.UUID,
+		string,
+		string,
+		string,
+		[]domain.Permission,
+		*time.Time,
+	) (domain.APIToken, error)
+	RevokeAPIToken(context.Context, uuid.UUID) (domain.APIToken, error)
+	AuthenticateAPIToken(context.Context, string) (domain.APITokenPrincipal, error)
+}
+
+type Service struct {
+	store Store
+	now   func() time.Time
+}
+
+func New(store Store) *Service {
+	return &Service{store: store, now: time.Now}
+}
+
+func (s *Service) ListServiceAccounts(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.ServiceAccount, int32, error) {
+	return s.store.ListServiceAccounts(ctx, opts)
+}
+
+func (s *Service) GetServiceAccount(ctx context.Context, id uuid.UUID) (domain.ServiceAccount, error) {
+	return s.store.GetServiceAccount(ctx, id)
+}
+
+func (s *Service) CreateServiceAccount(ctx context.Context, input WriteInput) (domain.ServiceAccount, error) {
+	if err := validateServiceAccountInput(input); err != nil {
+		return domain.ServiceAccount{}, err
+	}
+
+	return s.store.CreateServiceAccount(ctx, strings.TrimSpace(input.Name), input.Description)
+}
+
+func (s *Service) UpdateServiceAccount(
+	ctx context.Context,
+	id uuid.UUID,
+	input WriteInput,
+) (domain.ServiceAccount, error) {
+	if err := validateServiceAccountInput(input); err != nil {
+		return domain.ServiceAccount{}, err
+	}
+
+	return s.store.UpdateServiceAccount(ctx, id, strings.TrimSpace(input.Name), input.Description)
+}
+
+func (s *Service) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	return s.store.DeleteServiceAccount(ctx, id)
+}
+
+func (s *Service) ListAPITokens(
+	ctx context.Context,
+	opts domain.APITokenListOptions,
+) ([]domain.APIToken, int32, error) {
+	return s.store.ListAPITokens(ctx, opts)
+}
+
+func (s *Service) GetAPIToken(ctx context.Context, id uuid.UUID) (domain.APIToken, error) {
+	return s.store.GetAPIToken(ctx, id)
+}
+
+// CreateAPIToken issues a new token. Only the hash is stored; the plaintext is returned once.
+func (s *Service) CreateAPIToken(ctx context.Context, input TokenCreateInput) (domain.CreatedAPIToken, error) {
+	if err := s.validateTokenInput(input); err != nil {
+		return domain.CreatedAPIToken{}, err
+	}
+
+	raw, err := generateToken()
+	if err != nil {
+		return domain.CreatedAPIToken{}, err
+	}
+
+	token, err := s.store.CreateAPIToken(
+		ctx,
+		input.ServiceAccountID,
+		strings.TrimSpace(input.Name),
+		raw[:len(TokenPrefix)+tokenDisplayChars],
+		HashToken(raw),
+		input.Scopes,
+		input.ExpiresAt,
+	)
+	if err != nil {
+		return domain.CreatedAPIToken{}, err
+	}
+
+	return domain.CreatedAPIToken{APIToken: token, Token: raw}, nil
+}
+
+func (s *Service) RevokeAPIToken(ctx context.Context, id uuid.UUID) (domain.APIToken, error) {
+	return s.store.RevokeAPIToken(ctx, id)
+}
+
+// AuthenticateToken resolves a bearer token to its service account and scopes.
+// Unknown, expired, and revoked tokens return ErrInvalidToken.
+func (s *Service) AuthenticateToken(ctx context.Context, raw string) (domain.APITokenPrincipal, error) {
+	if !strings.HasPrefix(raw, TokenPrefix) {
+		return domain.APITokenPrincipal{}, ErrInvalidToken
+	}
+
+	principal, err := s.store.AuthenticateAPIToken(ctx, HashToken(raw))
+	if err != nil {
+		return domain.APITokenPrincipal{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return principal, nil
+}
+
+// HashToken returns the stored representation of a plaintext token.
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	secret := make([]byte, tokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate api token: %w", err)
+	}
+
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func validateServiceAccountInput(input WriteInput) *domain.ValidationError {
+	err := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Service account is invalid.",
+	}
+
+	if strings.TrimSpace(input.Name) == "" {
+		err.Add("name", "must not be empty", "required")
+	}
+
+	if !err.HasFieldErrors() {
+		return nil
+	}
+	return err
+}
+
+func (s *Service) validateTokenInput(input TokenCreateInput) *domain.ValidationError {
+	err := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "API token is invalid.",
+	}
+
+	if input.ServiceAccountID == uuid.Nil {
+		err.Add("service_account_id", "is required", "required")
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		err.Add("name", "must not be empty", "required")
+	}
+	if len(input.Scopes) == 0 {
+		err.Add("scopes", "must include at least one scope", "required")
+	}
+	for _, scope := range input.Scopes {
+		if _, parseErr := domain.ParsePermission(string(scope)); parseErr != nil {
+			err.Add("scopes", "must be read, read_events, write_memberships, write_rules, or admin", "invalid")
+			break
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(s.now()) {
+		err.Add("expires_at", "must be in the future", "invalid")
+	}
+
+	if !err.HasFieldErrors() {
+		return nil
+	}
+	return err
+}
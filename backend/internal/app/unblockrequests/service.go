@@ -0,0 +1,475 @@
+package unblockrequests
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const (
+	maxReasonLength = 2000
+	sha256HexLength = 64
+)
+
+// Requester is the signed-in user raising an unblock request from the portal.
+type Requester struct {
+	UPN  string
+	Name string
+}
+
+type SubmitInput struct {
+	FileSHA256 string
+	MachineID  uuid.UUID
+	Reason     string
+}
+
+type ApproveInput struct {
+	Scope   domain.UnblockScope
+	GroupID *uuid.UUID
+	Comment string
+}
+
+type RejectInput struct {
+	Comment string
+}
+
+type Store interface {
+	ListUnblockRequests(context.Context, domain.UnblockRequestListOptions) ([]domain.UnblockRequest, int32, error)
+	GetUnblockRequest(context.Context, uuid.UUID) (domain.UnblockRequest, error)
+	GetUnblockTarget(context.Context, string, uuid.UUID, string) (domain.UnblockTarget, error)
+	CreateUnblockRequest(context.Context, domain.UnblockRequest) (domain.UnblockRequest, error)
+	ReviewUnblockRequest(context.Context, domain.UnblockRequest, domain.Actor) (bool, error)
+	ReopenUnblockRequest(context.Context, uuid.UUID) error
+	SetUnblockRequestOutcome(context.Context, uuid.UUID, *uuid.UUID, *uuid.UUID) error
+	GetRuleByIdentifier(context.Context, domain.RuleType, string) (domain.Rule, error)
+	GetGroup(context.Context, uuid.UUID) (domain.Group, error)
+	GetExecutionEvent(context.Context, uuid.UUID) (domain.ExecutionEvent, error)
+	GetExecutable(context.Context, uuid.UUID) (domain.Executable, error)
+}
+
+// Rules writes the allow rule for an approved request. It is satisfied by rules.Service.
+type Rules interface {
+	CreateRule(context.Context, domain.RuleWriteInput) (domain.Rule, error)
+	UpdateRule(context.Context, uuid.UUID, domain.RuleWriteInput) (domain.Rule, error)
+}
+
+// RuleChanges routes the allow rule through review when rule changes require approval. It is
+// satisfied by rulechanges.Service.
+type RuleChanges interface {
+	RequiresApproval() bool
+	ProposeCreate(context.Context, domain.Actor, domain.RuleWriteInput) (domain.RuleChangeProposal, error)
+	ProposeUpdate(context.Context, domain.Actor, uuid.UUID, domain.RuleWriteInput) (domain.RuleChangeProposal, error)
+}
+
+type Service struct {
+	store       Store
+	rules       Rules
+	ruleChanges RuleChanges
+}
+
+func New(store Store, rules Rules, ruleChanges RuleChanges) *Service {
+	return &Service{store: store, rules: rules, ruleChanges: ruleChanges}
+}
+
+func (s *Service) ListRequests(
+	ctx context.Context,
+	opts domain.UnblockRequestListOptions,
+) ([]domain.UnblockRequest, int32, error) {
+	return s.store.ListUnblockRequests(ctx, opts)
+}
+
+// ListOwnRequests lists the requests raised by the signed-in user.
+func (s *Service) ListOwnRequests(
+	ctx context.Context,
+	requester Requester,
+	opts domain.UnblockRequestListOptions,
+) ([]domain.UnblockRequest, int32, error) {
+	if strings.TrimSpace(requester.UPN) == "" {
+		return []domain.UnblockRequest{}, 0, nil
+	}
+
+	opts.RequesterUPN = requester.UPN
+	opts.UserID = nil
+	return s.store.ListUnblockRequests(ctx, opts)
+}
+
+// GetRequest returns a request with the execution event and executable it refers to, when they
+// are still retained.
+func (s *Service) GetRequest(ctx context.Context, id uuid.UUID) (domain.UnblockRequestDetail, error) {
+	request, err := s.store.GetUnblockRequest(ctx, id)
+	if err != nil {
+		return domain.UnblockRequestDetail{}, err
+	}
+
+	detail := domain.UnblockRequestDetail{UnblockRequest: request}
+
+	if request.ExecutionEventID != nil {
+		event, eventErr := s.store.GetExecutionEvent(ctx, *request.ExecutionEventID)
+		switch {
+		case eventErr == nil:
+			detail.ExecutionEvent = &event
+		case !errors.Is(eventErr, pgx.ErrNoRows):
+			return domain.UnblockRequestDetail{}, eventErr
+		}
+	}
+
+	if request.ExecutableID != nil {
+		executable, executableErr := s.store.GetExecutable(ctx, *request.ExecutableID)
+		switch {
+		case executableErr == nil:
+			detail.Executable = &executable
+		case !errors.Is(executableErr, pgx.ErrNoRows):
+			return domain.UnblockRequestDetail{}, executableErr
+		}
+	}
+
+	return detail, nil
+}
+
+// GetTarget describes the blocked execution a portal link points at. Only the machine's primary
+// user and the users the execution was reported for can see it.
+func (s *Service) GetTarget(
+	ctx context.Context,
+	requester Requester,
+	fileSHA256 string,
+	machineID uuid.UUID,
+) (domain.UnblockTarget, error) {
+	fileSHA256 = normalizeSHA256(fileSHA256)
+
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Unblock request is invalid.",
+	}
+	if !isSHA256Hex(fileSHA256) {
+		validationErr.Add("file_sha256", "must be a SHA-256 hex digest", "invalid")
+		return domain.UnblockTarget{}, validationErr
+	}
+	if strings.TrimSpace(requester.UPN) == "" {
+		return domain.UnblockTarget{}, pgx.ErrNoRows
+	}
+
+	return s.store.GetUnblockTarget(ctx, fileSHA256, machineID, strings.TrimSpace(requester.UPN))
+}
+
+func (s *Service) Submit(
+	ctx context.Context,
+	requester Requester,
+	input SubmitInput,
+) (domain.UnblockRequest, error) {
+	input.FileSHA256 = normalizeSHA256(input.FileSHA256)
+	input.Reason = strings.TrimSpace(input.Reason)
+
+	if err := validateSubmit(requester, input); err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	target, err := s.store.GetUnblockTarget(ctx, input.FileSHA256, input.MachineID, strings.TrimSpace(requester.UPN))
+	if errors.Is(err, pgx.ErrNoRows) {
+		validationErr := &domain.ValidationError{
+			Code:   "validation_error",
+			Detail: "Unblock request is invalid.",
+		}
+		validationErr.Add("machine_id", "machine does not exist or has not reported you using it", "not_found")
+		return domain.UnblockRequest{}, validationErr
+	}
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	return s.store.CreateUnblockRequest(ctx, domain.UnblockRequest{
+		FileSHA256:       input.FileSHA256,
+		MachineID:        target.MachineID,
+		ExecutableID:     target.ExecutableID,
+		ExecutionEventID: target.ExecutionEventID,
+		RequesterUPN:     strings.TrimSpace(requester.UPN),
+		RequesterName:    strings.TrimSpace(requester.Name),
+		Reason:           input.Reason,
+	})
+}
+
+// Approve allows the requested binary for the chosen scope: the requester, the machine, or an
+// existing group, each as a rule target of its own. An
+// existing binary rule for the hash gains the allow target ahead of its other targets, otherwise
+// a new rule is created. An existing rule that is disabled is left alone and the approval refused,
+// since enabling it would also re-enable its other targets. When rule changes require approval,
+// the rule write is proposed instead.
+func (s *Service) Approve(
+	ctx context.Context,
+	reviewer domain.Actor,
+	id uuid.UUID,
+	input ApproveInput,
+) (domain.UnblockRequest, error) {
+	request, err := s.reviewable(ctx, id)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	existing, err := s.store.GetRuleByIdentifier(ctx, domain.RuleTypeBinary, request.FileSHA256)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.UnblockRequest{}, err
+	}
+	var rule *domain.Rule
+	if err == nil {
+		rule = &existing
+	}
+
+	if err = s.validateApprove(ctx, request, input, rule); err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	target, err := scopeTarget(request, input)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	request.Status = domain.UnblockRequestStatusApproved
+	request.Scope = &input.Scope
+	request.GroupID = input.GroupID
+	request.ReviewComment = input.Comment
+
+	claimed, err := s.store.ReviewUnblockRequest(ctx, request, reviewer)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+	if !claimed {
+		return domain.UnblockRequest{}, domain.ErrUnblockRequestClosed
+	}
+
+	if err = s.allow(ctx, reviewer, request, rule, target); err != nil {
+		if reopenErr := s.store.ReopenUnblockRequest(ctx, id); reopenErr != nil {
+			return domain.UnblockRequest{}, errors.Join(err, fmt.Errorf("reopen unblock request: %w", reopenErr))
+		}
+		return domain.UnblockRequest{}, err
+	}
+
+	return s.store.GetUnblockRequest(ctx, id)
+}
+
+func (s *Service) Reject(
+	ctx context.Context,
+	reviewer domain.Actor,
+	id uuid.UUID,
+	input RejectInput,
+) (domain.UnblockRequest, error) {
+	request, err := s.reviewable(ctx, id)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	request.Status = domain.UnblockRequestStatusRejected
+	request.ReviewComment = input.Comment
+
+	claimed, err := s.store.ReviewUnblockRequest(ctx, request, reviewer)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+	if !claimed {
+		return domain.UnblockRequest{}, domain.ErrUnblockRequestClosed
+	}
+
+	return s.store.GetUnblockRequest(ctx, id)
+}
+
+func (s *Service) reviewable(ctx context.Context, id uuid.UUID) (domain.UnblockRequest, error) {
+	request, err := s.store.GetUnblockRequest(ctx, id)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	if request.Status != domain.UnblockRequestStatusPending {
+		return domain.UnblockRequest{}, domain.ErrUnblockRequestClosed
+	}
+
+	return request, nil
+}
+
+func (s *Service) validateApprove(
+	ctx context.Context,
+	request domain.UnblockRequest,
+	input ApproveInput,
+	rule *domain.Rule,
+) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Unblock approval is invalid.",
+	}
+
+	if rule != nil && !rule.Enabled {
+		validationErr.Add(
+			"file_sha256",
+			"has a disabled rule; enable the rule or change it by hand instead",
+			"rule_disabled",
+		)
+	}
+
+	switch input.Scope {
+	case domain.UnblockScopeUser:
+		if request.UserID == nil {
+			validationErr.Add("scope", "requester is not a known user", "invalid")
+		}
+	case domain.UnblockScopeMachine:
+	case domain.UnblockScopeGroup:
+		if input.GroupID == nil {
+			validationErr.Add("group_id", "is required for group scope", "required")
+			break
+		}
+		_, err := s.store.GetGroup(ctx, *input.GroupID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			validationErr.Add("group_id", "group does not exist", "not_found")
+		} else if err != nil {
+			return err
+		}
+	default:
+		validationErr.Add("scope", "must be user, machine, or group", "invalid")
+	}
+
+	if !validationErr.HasFieldErrors() {
+		return nil
+	}
+
+	return validationErr
+}
+
+// scopeTarget returns the allowlist target for the approved scope: the requester's user, the
+// requesting machine, or the chosen group.
+func scopeTarget(request domain.UnblockRequest, input ApproveInput) (domain.IncludeRuleTargetWriteInput, error) {
+	switch input.Scope {
+	case domain.UnblockScopeUser:
+		return allowTarget(domain.RuleTargetSubjectKindUser, *request.UserID), nil
+	case domain.UnblockScopeMachine:
+		return allowTarget(domain.RuleTargetSubjectKindMachine, request.MachineID), nil
+	case domain.UnblockScopeGroup:
+		return allowTarget(domain.RuleTargetSubjectKindGroup, *input.GroupID), nil
+	default:
+		return domain.IncludeRuleTargetWriteInput{}, fmt.Errorf("unsupported unblock scope %q", input.Scope)
+	}
+}
+
+// allow writes, or proposes, the binary rule granting the scope its allowlist target, on the
+// existing rule for the hash when there is one.
+func (s *Service) allow(
+	ctx context.Context,
+	reviewer domain.Actor,
+	request domain.UnblockRequest,
+	existing *domain.Rule,
+	target domain.IncludeRuleTargetWriteInput,
+) error {
+	var input domain.RuleWriteInput
+	if existing != nil {
+		input = allowTargetOnRule(*existing, target)
+	} else {
+		input = newAllowRule(request, target)
+	}
+
+	var err error
+	if s.ruleChanges.RequiresApproval() {
+		var proposal domain.RuleChangeProposal
+		if existing != nil {
+			proposal, err = s.ruleChanges.ProposeUpdate(ctx, reviewer, existing.ID, input)
+		} else {
+			proposal, err = s.ruleChanges.ProposeCreate(ctx, reviewer, input)
+		}
+		if err != nil {
+			return err
+		}
+		return s.store.SetUnblockRequestOutcome(ctx, request.ID, nil, &proposal.ID)
+	}
+
+	var rule domain.Rule
+	if existing != nil {
+		rule, err = s.rules.UpdateRule(ctx, existing.ID, input)
+	} else {
+		rule, err = s.rules.CreateRule(ctx, input)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.store.SetUnblockRequestOutcome(ctx, request.ID, &rule.ID, nil)
+}
+
+// allowTargetOnRule puts the allowlist target ahead of the rule's other targets and drops any
+// earlier target or exclusion for the same subject. The rule's enabled state is kept.
+func allowTargetOnRule(rule domain.Rule, target domain.IncludeRuleTargetWriteInput) domain.RuleWriteInput {
+	input := domain.RuleSnapshotFromRule(rule).WriteInput()
+	input.Targets = domain.MergeRuleTargets(input.Targets, domain.RuleTargetsWriteInput{
+		Include: []domain.IncludeRuleTargetWriteInput{target},
+	})
+
+	return input
+}
+
+func newAllowRule(request domain.UnblockRequest, target domain.IncludeRuleTargetWriteInput) domain.RuleWriteInput {
+	name := request.FileName
+	if name == "" {
+		name = request.FileSHA256
+	}
+
+	return domain.RuleWriteInput{
+		Name:        "Allow " + name,
+		Description: fmt.Sprintf("Unblock requested by %s: %s", request.RequesterUPN, request.Reason),
+		RuleType:    domain.RuleTypeBinary,
+		Identifier:  request.FileSHA256,
+		Enabled:     true,
+		Targets: domain.RuleTargetsWriteInput{
+			Include: []domain.IncludeRuleTargetWriteInput{target},
+		},
+	}
+}
+
+func allowTarget(kind domain.RuleTargetSubjectKind, subjectID uuid.UUID) domain.IncludeRuleTargetWriteInput {
+	return domain.IncludeRuleTargetWriteInput{
+		SubjectKind: kind,
+		SubjectID:   &subjectID,
+		Policy:      domain.RulePolicyAllowlist,
+	}
+}
+
+func validateSubmit(requester Requester, input SubmitInput) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Unblock request is invalid.",
+	}
+
+	if strings.TrimSpace(requester.UPN) == "" {
+		validationErr.Add("requester", "must sign in with a directory account", "invalid")
+	}
+	if !isSHA256Hex(input.FileSHA256) {
+		validationErr.Add("file_sha256", "must be a SHA-256 hex digest", "invalid")
+	}
+	if input.MachineID == uuid.Nil {
+		validationErr.Add("machine_id", "is required", "required")
+	}
+	switch {
+	case input.Reason == "":
+		validationErr.Add("reason", "must not be empty", "required")
+	case utf8.RuneCountInString(input.Reason) > maxReasonLength:
+		validationErr.Add("reason", fmt.Sprintf("must be at most %d characters", maxReasonLength), "too_long")
+	}
+
+	if !validationErr.HasFieldErrors() {
+		return nil
+	}
+
+	return validationErr
+}
+
+func normalizeSHA256(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func isSHA256Hex(value string) bool {
+	if len(value) != sha256HexLength {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
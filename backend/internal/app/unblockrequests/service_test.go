@@ -0,0 +1,380 @@
+package unblockrequests_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/app/unblockrequests"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	request     domain.UnblockRequest
+	rule        *domain.Rule
+	claimClosed bool
+
+	targetErr        error
+	targetRequesters []string
+
+	reopenCalls int
+	outcomeRule *uuid.UUID
+	outcomeProp *uuid.UUID
+}
+
+func (s *testStore) ListUnblockRequests(
+	context.Context,
+	domain.UnblockRequestListOptions,
+) ([]domain.UnblockRequest, int32, error) {
+	return nil, 0, errors.New("unexpected ListUnblockRequests call")
+}
+
+func (s *testStore) GetUnblockRequest(context.Context, uuid.UUID) (domain.UnblockRequest, error) {
+	return s.request, nil
+}
+
+func (s *testStore) GetUnblockTarget(
+	_ context.Context,
+	fileSHA256 string,
+	machineID uuid.UUID,
+	requesterUPN string,
+) (domain.UnblockTarget, error) {
+	s.targetRequesters = append(s.targetRequesters, requesterUPN)
+	if s.targetErr != nil {
+		return domain.UnblockTarget{}, s.targetErr
+	}
+	return domain.UnblockTarget{FileSHA256: fileSHA256, MachineID: machineID}, nil
+}
+
+func (s *testStore) CreateUnblockRequest(
+	_ context.Context,
+	request domain.UnblockRequest,
+) (domain.UnblockRequest, error) {
+	s.request = request
+	return request, nil
+}
+
+func (s *testStore) ReviewUnblockRequest(
+	_ context.Context,
+	request domain.UnblockRequest,
+	reviewer domain.Actor,
+) (bool, error) {
+	if s.claimClosed || s.request.Status != domain.UnblockRequestStatusPending {
+		return false, nil
+	}
+	s.request.Status = request.Status
+	s.request.Scope = request.Scope
+	s.request.GroupID = request.GroupID
+	s.request.ReviewedBy = reviewer.ID
+	return true, nil
+}
+
+func (s *testStore) ReopenUnblockRequest(context.Context, uuid.UUID) error {
+	s.reopenCalls++
+	s.request.Status = domain.UnblockRequestStatusPending
+	return nil
+}
+
+func (s *testStore) SetUnblockRequestOutcome(_ context.Context, _ uuid.UUID, ruleID, proposalID *uuid.UUID) error {
+	s.outcomeRule = ruleID
+	s.outcomeProp = proposalID
+	return nil
+}
+
+func (s *testStore) GetRuleByIdentifier(context.Context, domain.RuleType, string) (domain.Rule, error) {
+	if s.rule == nil {
+		return domain.Rule{}, pgx.ErrNoRows
+	}
+	return *s.rule, nil
+}
+
+func (s *testStore) GetGroup(_ context.Context, id uuid.UUID) (domain.Group, error) {
+	return domain.Group{ID: id}, nil
+}
+
+func (s *testStore) GetExecutionEvent(context.Context, uuid.UUID) (domain.ExecutionEvent, error) {
+	return domain.ExecutionEvent{}, pgx.ErrNoRows
+}
+
+func (s *testStore) GetExecutable(context.Context, uuid.UUID) (domain.Executable, error) {
+	return domain.Executable{}, pgx.ErrNoRows
+}
+
+type testRules struct {
+	created []domain.RuleWriteInput
+	updated []domain.RuleWriteInput
+}
+
+func (r *testRules) CreateRule(_ context.Context, input domain.RuleWriteInput) (domain.Rule, error) {
+	r.created = append(r.created, input)
+	return domain.Rule{ID: uuid.New()}, nil
+}
+
+func (r *testRules) UpdateRule(_ context.Context, id uuid.UUID, input domain.RuleWriteInput) (domain.Rule, error) {
+	r.updated = append(r.updated, input)
+	return domain.Rule{ID: id}, nil
+}
+
+type testRuleChanges struct {
+	requireApproval bool
+	proposed        []domain.RuleWriteInput
+}
+
+func (c *testRuleChanges) RequiresApproval() bool {
+	return c.requireApproval
+}
+
+func (c *testRuleChanges) ProposeCreate(
+	_ context.Context,
+	_ domain.Actor,
+	input domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	c.proposed = append(c.proposed, input)
+	return domain.RuleChangeProposal{ID: uuid.New()}, nil
+}
+
+func (c *testRuleChanges) ProposeUpdate(
+	_ context.Context,
+	_ domain.Actor,
+	_ uuid.UUID,
+	input domain.RuleWriteInput,
+) (domain.RuleChangeProposal, error) {
+	c.proposed = append(c.proposed, input)
+	return domain.RuleChangeProposal{ID: uuid.New()}, nil
+}
+
+func pendingRequest() domain.UnblockRequest {
+	userID := uuid.New()
+	return domain.UnblockRequest{
+		ID:           uuid.New(),
+		FileSHA256:   strings.Repeat("ab", 32),
+		FileName:     "Tool.app",
+		MachineID:    uuid.New(),
+		UserID:       &userID,
+		RequesterUPN: "student@example.com",
+		Reason:       "Needed for class",
+		Status:       domain.UnblockRequestStatusPending,
+	}
+}
+
+func TestApprove_UserScopeCreatesAllowRule(t *testing.T) {
+	store := &testStore{request: pendingRequest()}
+	rules := &testRules{}
+	service := unblockrequests.New(store, rules, &testRuleChanges{})
+
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, store.request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeUser},
+	)
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	if len(rules.created) != 1 {
+		t.Fatalf("created rules = %d, want 1", len(rules.created))
+	}
+	input := rules.created[0]
+	if input.RuleType != domain.RuleTypeBinary || input.Identifier != store.request.FileSHA256 {
+		t.Fatalf("rule = %s %s, want binary %s", input.RuleType, input.Identifier, store.request.FileSHA256)
+	}
+	include := input.Targets.Include
+	if len(include) != 1 || include[0].SubjectKind != domain.RuleTargetSubjectKindUser ||
+		*include[0].SubjectID != *store.request.UserID || include[0].Policy != domain.RulePolicyAllowlist {
+		t.Fatalf("targets = %+v, want allowlist for the requesting user", input.Targets)
+	}
+	if store.outcomeRule == nil || store.request.Status != domain.UnblockRequestStatusApproved {
+		t.Fatalf("outcome rule = %v, status = %s, want rule and approved", store.outcomeRule, store.request.Status)
+	}
+}
+
+func TestApprove_PrependsAllowTargetToExistingRule(t *testing.T) {
+	groupID := uuid.New()
+	otherID := uuid.New()
+	store := &testStore{
+		request: pendingRequest(),
+		rule: &domain.Rule{
+			ID:         uuid.New(),
+			Name:       "Block Tool",
+			RuleType:   domain.RuleTypeBinary,
+			Identifier: strings.Repeat("ab", 32),
+			Enabled:    true,
+			Targets: domain.RuleTargets{
+				Include: []domain.IncludeRuleTarget{
+					{SubjectKind: domain.RuleTargetSubjectKindAllDevices, Policy: domain.RulePolicyBlocklist},
+					{SubjectKind: domain.RuleTargetSubjectKindGroup, SubjectID: &groupID, Policy: domain.RulePolicyBlocklist},
+				},
+				Exclude: []domain.ExcludeRuleTarget{
+					{SubjectKind: domain.RuleTargetSubjectKindGroup, SubjectID: groupID},
+					{SubjectKind: domain.RuleTargetSubjectKindGroup, SubjectID: otherID},
+				},
+			},
+		},
+	}
+	rules := &testRules{}
+	service := unblockrequests.New(store, rules, &testRuleChanges{})
+
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, store.request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeGroup, GroupID: &groupID},
+	)
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	if len(rules.updated) != 1 {
+		t.Fatalf("updated rules = %d, want 1", len(rules.updated))
+	}
+	targets := rules.updated[0].Targets
+	if len(targets.Include) != 2 ||
+		*targets.Include[0].SubjectID != groupID || targets.Include[0].Policy != domain.RulePolicyAllowlist ||
+		targets.Include[1].SubjectKind != domain.RuleTargetSubjectKindAllDevices {
+		t.Fatalf("include = %+v, want group allowlist ahead of all devices", targets.Include)
+	}
+	if len(targets.Exclude) != 1 || targets.Exclude[0].SubjectID != otherID {
+		t.Fatalf("exclude = %+v, want only the unrelated group", targets.Exclude)
+	}
+}
+
+func TestApprove_RefusesDisabledExistingRule(t *testing.T) {
+	store := &testStore{
+		request: pendingRequest(),
+		rule: &domain.Rule{
+			ID:         uuid.New(),
+			RuleType:   domain.RuleTypeBinary,
+			Identifier: strings.Repeat("ab", 32),
+			Enabled:    false,
+		},
+	}
+	rules := &testRules{}
+	service := unblockrequests.New(store, rules, &testRuleChanges{})
+
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, store.request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeMachine},
+	)
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Approve() error = %v, want validation error", err)
+	}
+	if len(rules.updated) != 0 || store.request.Status != domain.UnblockRequestStatusPending {
+		t.Fatalf("updated = %d, status = %s, want 0 and pending", len(rules.updated), store.request.Status)
+	}
+}
+
+func TestApprove_ProposesWhenRuleChangesRequireApproval(t *testing.T) {
+	store := &testStore{request: pendingRequest()}
+	rules := &testRules{}
+	ruleChanges := &testRuleChanges{requireApproval: true}
+	service := unblockrequests.New(store, rules, ruleChanges)
+
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, store.request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeMachine},
+	)
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	if len(rules.created) != 0 || len(ruleChanges.proposed) != 1 {
+		t.Fatalf("created = %d, proposed = %d, want 0 and 1", len(rules.created), len(ruleChanges.proposed))
+	}
+	include := ruleChanges.proposed[0].Targets.Include
+	if len(include) != 1 || include[0].SubjectKind != domain.RuleTargetSubjectKindMachine ||
+		*include[0].SubjectID != store.request.MachineID {
+		t.Fatalf("include = %+v, want allowlist for the requesting machine", include)
+	}
+	if store.outcomeProp == nil || store.outcomeRule != nil {
+		t.Fatalf("outcome = rule %v proposal %v, want proposal only", store.outcomeRule, store.outcomeProp)
+	}
+}
+
+func TestApprove_RejectsUserScopeForUnknownRequester(t *testing.T) {
+	request := pendingRequest()
+	request.UserID = nil
+	store := &testStore{request: request}
+	service := unblockrequests.New(store, &testRules{}, &testRuleChanges{})
+
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeUser},
+	)
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Approve() error = %v, want validation error", err)
+	}
+	if store.request.Status != domain.UnblockRequestStatusPending {
+		t.Fatalf("status = %s, want pending", store.request.Status)
+	}
+}
+
+func TestApprove_ClosedRequestWritesNoRule(t *testing.T) {
+	request := pendingRequest()
+	store := &testStore{request: request}
+	rules := &testRules{}
+	service := unblockrequests.New(store, rules, &testRuleChanges{})
+
+	// Another reviewer closes the request between the pending check and the claim.
+	store.claimClosed = true
+	_, err := service.Approve(context.Background(), domain.Actor{ID: "reviewer"}, request.ID,
+		unblockrequests.ApproveInput{Scope: domain.UnblockScopeUser},
+	)
+	if !errors.Is(err, domain.ErrUnblockRequestClosed) {
+		t.Fatalf("Approve() error = %v, want %v", err, domain.ErrUnblockRequestClosed)
+	}
+	if len(rules.created) != 0 {
+		t.Fatalf("created = %d, want 0", len(rules.created))
+	}
+}
+
+func TestGetTarget_LooksUpTargetForRequester(t *testing.T) {
+	store := &testStore{}
+	service := unblockrequests.New(store, &testRules{}, &testRuleChanges{})
+
+	_, err := service.GetTarget(
+		context.Background(),
+		unblockrequests.Requester{UPN: " student@example.com "},
+		strings.Repeat("AB", 32),
+		uuid.New(),
+	)
+	if err != nil {
+		t.Fatalf("GetTarget() error = %v", err)
+	}
+	if len(store.targetRequesters) != 1 || store.targetRequesters[0] != "student@example.com" {
+		t.Fatalf("target requesters = %q, want [student@example.com]", store.targetRequesters)
+	}
+}
+
+func TestGetTarget_HidesTargetWithoutDirectoryAccount(t *testing.T) {
+	store := &testStore{}
+	service := unblockrequests.New(store, &testRules{}, &testRuleChanges{})
+
+	_, err := service.GetTarget(context.Background(), unblockrequests.Requester{}, strings.Repeat("ab", 32), uuid.New())
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("GetTarget() error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	if len(store.targetRequesters) != 0 {
+		t.Fatalf("target requesters = %q, want no lookup", store.targetRequesters)
+	}
+}
+
+func TestSubmit_RejectsTargetHiddenFromRequester(t *testing.T) {
+	store := &testStore{targetErr: pgx.ErrNoRows}
+	service := unblockrequests.New(store, &testRules{}, &testRuleChanges{})
+
+	_, err := service.Submit(context.Background(), unblockrequests.Requester{UPN: "student@example.com"},
+		unblockrequests.SubmitInput{
+			FileSHA256: strings.Repeat("ab", 32),
+			MachineID:  uuid.New(),
+			Reason:     "Needed for class",
+		},
+	)
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Submit() error = %v, want validation error", err)
+	}
+	if store.request.ID != uuid.Nil {
+		t.Fatalf("created request %v, want none", store.request.ID)
+	}
+}
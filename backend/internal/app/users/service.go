@@ -0,0 +1,216 @@
+// Package users owns user aliases, the matching of machines' reported primary users to users, and
+// admin overrides of that match.
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type Store interface {
+	GetUser(context.Context, uuid.UUID) (domain.User, error)
+	GetMachine(context.Context, uuid.UUID) (domain.Machine, error)
+	SetMachinePrimaryUserOverride(context.Context, uuid.UUID, *uuid.UUID) error
+	ClearMachinePrimaryUserOverride(context.Context, uuid.UUID) error
+	ListUserAliases(context.Context, uuid.UUID) ([]domain.UserAlias, int32, error)
+	GetUserAlias(context.Context, uuid.UUID, uuid.UUID) (domain.UserAlias, error)
+	CreateUserAlias(context.Context, uuid.UUID, string) (domain.UserAlias, error)
+	DeleteUserAlias(context.Context, uuid.UUID, uuid.UUID) error
+	ListMachinePrimaryUsers(context.Context) (map[uuid.UUID]string, error)
+	ResolveMachinePrimaryUsers(context.Context, map[uuid.UUID][]string) ([]uuid.UUID, error)
+	UpdateMachineDesiredTargetsByMachineIDs(context.Context, []uuid.UUID) error
+}
+
+type Service struct {
+	logger   *slog.Logger
+	store    Store
+	matching domain.PrimaryUserMatching
+}
+
+func New(logger *slog.Logger, store Store, matching domain.PrimaryUserMatching) *Service {
+	return &Service{logger: logger, store: store, matching: matching}
+}
+
+func (s *Service) ListUserAliases(ctx context.Context, userID uuid.UUID) ([]domain.UserAlias, int32, error) {
+	if _, err := s.store.GetUser(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.ListUserAliases(ctx, userID)
+}
+
+// CreateUserAlias adds a local alias for a user and re-resolves machines' primary users.
+func (s *Service) CreateUserAlias(ctx context.Context, userID uuid.UUID, alias string) (domain.UserAlias, error) {
+	alias = domain.NormalizeUserAlias(alias)
+	if err := validateAlias(alias); err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	if _, err := s.store.GetUser(ctx, userID); err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	created, err := s.store.CreateUserAlias(ctx, userID, alias)
+	if err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	if err = s.refreshPrimaryUsers(ctx); err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	return created, nil
+}
+
+// DeleteUserAlias removes a local alias. Aliases synced from Entra are replaced on every sync and
+// cannot be deleted by hand.
+func (s *Service) DeleteUserAlias(ctx context.Context, userID, aliasID uuid.UUID) error {
+	alias, err := s.store.GetUserAlias(ctx, userID, aliasID)
+	if err != nil {
+		return err
+	}
+	if alias.Source == domain.PrincipalSourceEntra {
+		return domain.ErrUserAliasManaged
+	}
+
+	if err = s.store.DeleteUserAlias(ctx, userID, aliasID); err != nil {
+		return err
+	}
+
+	return s.refreshPrimaryUsers(ctx)
+}
+
+// SetMachinePrimaryUser pins a machine's primary user in place of the one Santa reports. A nil
+// userID pins "no user".
+func (s *Service) SetMachinePrimaryUser(
+	ctx context.Context,
+	machineID uuid.UUID,
+	userID *uuid.UUID,
+) (domain.Machine, error) {
+	machine, err := s.store.GetMachine(ctx, machineID)
+	if err != nil {
+		return domain.Machine{}, err
+	}
+
+	if userID != nil {
+		_, err = s.store.GetUser(ctx, *userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			validationErr := &domain.ValidationError{
+				Code:   "validation_error",
+				Detail: "Primary user override is invalid.",
+			}
+			validationErr.Add("user_id", "user does not exist", "not_found")
+			return domain.Machine{}, validationErr
+		}
+		if err != nil {
+			return domain.Machine{}, err
+		}
+	}
+
+	if err = s.store.SetMachinePrimaryUserOverride(ctx, machineID, userID); err != nil {
+		return domain.Machine{}, err
+	}
+
+	return s.refreshMachinePrimaryUser(ctx, machine)
+}
+
+// ClearMachinePrimaryUser removes a machine's pinned primary user so the reported one applies again.
+func (s *Service) ClearMachinePrimaryUser(ctx context.Context, machineID uuid.UUID) (domain.Machine, error) {
+	machine, err := s.store.GetMachine(ctx, machineID)
+	if err != nil {
+		return domain.Machine{}, err
+	}
+
+	if err = s.store.ClearMachinePrimaryUserOverride(ctx, machineID); err != nil {
+		return domain.Machine{}, err
+	}
+
+	return s.refreshMachinePrimaryUser(ctx, machine)
+}
+
+// ResolvePrimaryUsers re-matches every machine's reported primary user and returns the machines
+// whose resolved user changed.
+func (s *Service) ResolvePrimaryUsers(ctx context.Context) ([]uuid.UUID, error) {
+	primaryUsers, err := s.store.ListMachinePrimaryUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lookups := make(map[uuid.UUID][]string, len(primaryUsers))
+	for machineID, primaryUser := range primaryUsers {
+		lookups[machineID] = s.matching.Lookups(primaryUser)
+	}
+
+	return s.store.ResolveMachinePrimaryUsers(ctx, lookups)
+}
+
+// RefreshPrimaryUsers re-resolves every machine's primary user and recomputes the rules of those
+// whose user changed. It runs at startup so changed matching settings take effect before each
+// machine's next preflight.
+func (s *Service) RefreshPrimaryUsers(ctx context.Context) {
+	start := time.Now()
+
+	if err := s.refreshPrimaryUsers(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "refresh primary users failed", "error", err, "duration", time.Since(start))
+		return
+	}
+
+	s.logger.InfoContext(ctx, "refresh primary users complete", "duration", time.Since(start))
+}
+
+func (s *Service) refreshPrimaryUsers(ctx context.Context) error {
+	changed, err := s.ResolvePrimaryUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve primary users: %w", err)
+	}
+
+	if err = s.store.UpdateMachineDesiredTargetsByMachineIDs(ctx, changed); err != nil {
+		return fmt.Errorf("sync machine desired rule targets: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) refreshMachinePrimaryUser(ctx context.Context, machine domain.Machine) (domain.Machine, error) {
+	changed, err := s.store.ResolveMachinePrimaryUsers(ctx, map[uuid.UUID][]string{
+		machine.ID: s.matching.Lookups(machine.PrimaryUser),
+	})
+	if err != nil {
+		return domain.Machine{}, fmt.Errorf("resolve primary user: %w", err)
+	}
+
+	if err = s.store.UpdateMachineDesiredTargetsByMachineIDs(ctx, changed); err != nil {
+		return domain.Machine{}, fmt.Errorf("sync machine desired rule targets: %w", err)
+	}
+
+	return s.store.GetMachine(ctx, machine.ID)
+}
+
+func validateAlias(alias string) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "User alias is invalid.",
+	}
+
+	switch {
+	case alias == "":
+		validationErr.Add("alias", "must not be empty", "required")
+	case strings.ContainsAny(alias, " \t\\"):
+		validationErr.Add("alias", "must not contain spaces or backslashes", "invalid")
+	}
+
+	if validationErr.HasFieldErrors() {
+		return validationErr
+	}
+
+	return nil
+}
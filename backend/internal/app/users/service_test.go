@@ -0,0 +1,217 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/app/users"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+type testStore struct {
+	machine        domain.Machine
+	missingUsers   map[uuid.UUID]bool
+	override       *uuid.UUID
+	overridden     bool
+	alias          domain.UserAlias
+	created        []string
+	deleted        []uuid.UUID
+	primaryUsers   map[uuid.UUID]string
+	lookups        map[uuid.UUID][]string
+	changed        []uuid.UUID
+	updatedTargets []uuid.UUID
+}
+
+func (s *testStore) GetUser(_ context.Context, id uuid.UUID) (domain.User, error) {
+	if s.missingUsers[id] {
+		return domain.User{}, pgx.ErrNoRows
+	}
+	return domain.User{ID: id}, nil
+}
+
+func (s *testStore) GetMachine(context.Context, uuid.UUID) (domain.Machine, error) {
+	return s.machine, nil
+}
+
+func (s *testStore) SetMachinePrimaryUserOverride(_ context.Context, _ uuid.UUID, userID *uuid.UUID) error {
+	s.override = userID
+	s.overridden = true
+	return nil
+}
+
+func (s *testStore) ClearMachinePrimaryUserOverride(context.Context, uuid.UUID) error {
+	s.override = nil
+	s.overridden = false
+	return nil
+}
+
+func (s *testStore) ListUserAliases(context.Context, uuid.UUID) ([]domain.UserAlias, int32, error) {
+	return nil, 0, nil
+}
+
+func (s *testStore) GetUserAlias(context.Context, uuid.UUID, uuid.UUID) (domain.UserAlias, error) {
+	return s.alias, nil
+}
+
+func (s *testStore) CreateUserAlias(_ context.Context, userID uuid.UUID, alias string) (domain.UserAlias, error) {
+	s.created = append(s.created, alias)
+	return domain.UserAlias{ID: uuid.New(), UserID: userID, Alias: alias, Source: domain.PrincipalSourceLocal}, nil
+}
+
+func (s *testStore) DeleteUserAlias(_ context.Context, _ uuid.UUID, id uuid.UUID) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func (s *testStore) ListMachinePrimaryUsers(context.Context) (map[uuid.UUID]string, error) {
+	return s.primaryUsers, nil
+}
+
+func (s *testStore) ResolveMachinePrimaryUsers(
+	_ context.Context,
+	lookups map[uuid.UUID][]string,
+) ([]uuid.UUID, error) {
+	s.lookups = lookups
+	return s.changed, nil
+}
+
+func (s *testStore) UpdateMachineDesiredTargetsByMachineIDs(_ context.Context, machineIDs []uuid.UUID) error {
+	s.updatedTargets = append(s.updatedTargets, machineIDs...)
+	return nil
+}
+
+func newTestService(store *testStore) *users.Service {
+	return users.New(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		store,
+		domain.PrimaryUserMatching{
+			DefaultDomain:  "school.org",
+			DomainRewrites: map[string]string{"school.local": "school.org"},
+		},
+	)
+}
+
+func TestCreateUserAlias_NormalizesAndRefreshesPrimaryUsers(t *testing.T) {
+	laptop := uuid.New()
+	store := &testStore{
+		primaryUsers: map[uuid.UUID]string{laptop: "ALICE@mac.School.Local"},
+		changed:      []uuid.UUID{laptop},
+	}
+
+	alias, err := newTestService(store).CreateUserAlias(context.Background(), uuid.New(), "  A.Smith ")
+	if err != nil {
+		t.Fatalf("CreateUserAlias() error = %v", err)
+	}
+
+	if alias.Alias != "a.smith" {
+		t.Fatalf("Alias = %q, want a.smith", alias.Alias)
+	}
+	want := []string{"alice@mac.school.local", "alice@mac.school.org"}
+	if got := store.lookups[laptop]; !slices.Equal(got, want) {
+		t.Fatalf("lookups = %#v, want %#v", got, want)
+	}
+	if !slices.Equal(store.updatedTargets, []uuid.UUID{laptop}) {
+		t.Fatalf("updated targets = %v, want the re-resolved machine", store.updatedTargets)
+	}
+}
+
+func TestCreateUserAlias_RejectsInvalidAliases(t *testing.T) {
+	tests := []struct {
+		name  string
+		alias string
+	}{
+		{name: "empty", alias: "  "},
+		{name: "space", alias: "alice smith"},
+		{name: "netbios domain", alias: `SCHOOL\alice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{}
+
+			_, err := newTestService(store).CreateUserAlias(context.Background(), uuid.New(), tt.alias)
+
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("error = %v, want validation error", err)
+			}
+			if len(store.created) != 0 {
+				t.Fatalf("created = %v, want none", store.created)
+			}
+		})
+	}
+}
+
+func TestDeleteUserAlias_RejectsEntraAlias(t *testing.T) {
+	store := &testStore{alias: domain.UserAlias{ID: uuid.New(), Source: domain.PrincipalSourceEntra}}
+
+	err := newTestService(store).DeleteUserAlias(context.Background(), uuid.New(), store.alias.ID)
+	if !errors.Is(err, domain.ErrUserAliasManaged) {
+		t.Fatalf("error = %v, want ErrUserAliasManaged", err)
+	}
+	if len(store.deleted) != 0 {
+		t.Fatalf("deleted = %v, want none", store.deleted)
+	}
+}
+
+func TestSetMachinePrimaryUser_PinsUserAndRecomputesTargets(t *testing.T) {
+	userID := uuid.New()
+	machine := domain.Machine{ID: uuid.New(), PrimaryUser: "Lab-Kiosk"}
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+	}{
+		{name: "user", userID: &userID},
+		{name: "no user", userID: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{machine: machine, changed: []uuid.UUID{machine.ID}}
+
+			if _, err := newTestService(store).SetMachinePrimaryUser(
+				context.Background(),
+				machine.ID,
+				tt.userID,
+			); err != nil {
+				t.Fatalf("SetMachinePrimaryUser() error = %v", err)
+			}
+
+			if !store.overridden || store.override != tt.userID {
+				t.Fatalf("override = %v (set %t), want %v", store.override, store.overridden, tt.userID)
+			}
+			want := []string{"lab-kiosk", "lab-kiosk@school.org"}
+			if got := store.lookups[machine.ID]; !slices.Equal(got, want) {
+				t.Fatalf("lookups = %#v, want %#v", got, want)
+			}
+			if !slices.Equal(store.updatedTargets, []uuid.UUID{machine.ID}) {
+				t.Fatalf("updated targets = %v, want the overridden machine", store.updatedTargets)
+			}
+		})
+	}
+}
+
+func TestSetMachinePrimaryUser_RejectsUnknownUser(t *testing.T) {
+	userID := uuid.New()
+	store := &testStore{
+		machine:      domain.Machine{ID: uuid.New()},
+		missingUsers: map[uuid.UUID]bool{userID: true},
+	}
+
+	_, err := newTestService(store).SetMachinePrimaryUser(context.Background(), store.machine.ID, &userID)
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want validation error", err)
+	}
+	if store.overridden {
+		t.Fatal("override was stored for an unknown user")
+	}
+}
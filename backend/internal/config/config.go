@@ -19,6 +19,8 @@ type Config struct {
 	Auth     AuthConfig
 	Entra    EntraSyncConfig
 	Events   EventsConfig
+	Rules    RulesConfig
+	Users    PrimaryUsersConfig
 }
 
 type HTTPConfig struct {
@@ -61,6 +63,30 @@ type EventsConfig struct {
 	DecisionAllowlist []domain.ExecutionDecision `env:"-"`
 }
 
+type RulesConfig struct {
+	RequireApproval bool `env:"RULE_CHANGE_APPROVAL" envDefault:"false"`
+}
+
+// PrimaryUsersConfig controls how the primary user Santa reports is matched to a user. Domain
+// rewrites are comma-separated reported=directory pairs, such as school.local=school.org.
+type PrimaryUsersConfig struct {
+	DefaultDomain  string            `env:"PRIMARY_USER_DEFAULT_DOMAIN"`
+	DomainRewrites map[string]string `env:"PRIMARY_USER_DOMAIN_REWRITES" envKeyValSeparator:"="`
+}
+
+// Matching returns the primary user matching settings with domains lowercased.
+func (c PrimaryUsersConfig) Matching() domain.PrimaryUserMatching {
+	rewrites := make(map[string]string, len(c.DomainRewrites))
+	for from, to := range c.DomainRewrites {
+		rewrites[normalizeDomain(from)] = normalizeDomain(to)
+	}
+
+	return domain.PrimaryUserMatching{
+		DefaultDomain:  normalizeDomain(c.DefaultDomain),
+		DomainRewrites: rewrites,
+	}
+}
+
 type envVar struct {
 	name  string
 	value string
@@ -94,6 +120,7 @@ func validateConfig(cfg Config) error {
 	problems = append(problems, validateAuth(cfg.HTTP, cfg.Auth)...)
 	problems = append(problems, validateEntraSync(cfg.Auth, cfg.Entra)...)
 	problems = append(problems, validateEvents(cfg.Events)...)
+	problems = append(problems, validatePrimaryUsers(cfg.Users)...)
 
 	if len(problems) == 0 {
 		return nil
@@ -208,6 +235,31 @@ func validateEvents(cfg EventsConfig) []string {
 	return nil
 }
 
+func validatePrimaryUsers(cfg PrimaryUsersConfig) []string {
+	var problems []string
+
+	if strings.Contains(cfg.DefaultDomain, "@") {
+		problems = append(problems, "PRIMARY_USER_DEFAULT_DOMAIN must be a domain without @")
+	}
+	for from, to := range cfg.DomainRewrites {
+		if !isValidDomain(from) || !isValidDomain(to) {
+			problems = append(problems, "PRIMARY_USER_DOMAIN_REWRITES must be comma-separated reported=directory domains")
+			break
+		}
+	}
+
+	return problems
+}
+
+func isValidDomain(value string) bool {
+	value = normalizeDomain(value)
+	return value != "" && !strings.ContainsAny(value, "@ ")
+}
+
+func normalizeDomain(value string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "@")
+}
+
 func envValue(name, value string) envVar {
 	return envVar{name: name, value: strings.TrimSpace(value)}
 }
@@ -96,3 +96,40 @@ func TestLoadFromEnv_RequiresBaseURLWhenAuthEnabled(t *testing.T) {
 		t.Fatalf("error = %v, want missing GRINCH_BASE_URL", err)
 	}
 }
+
+func TestLoadFromEnv_ParsesPrimaryUserMatching(t *testing.T) {
+	setBaseEnv(t)
+
+	t.Setenv("LOCAL_ADMIN_PASSWORD", "admin")
+	t.Setenv("JWT_SECRET", "jwt-secret")
+	t.Setenv("GRINCH_BASE_URL", "https://grinch.example.com")
+	t.Setenv("PRIMARY_USER_DEFAULT_DOMAIN", "School.org")
+	t.Setenv("PRIMARY_USER_DOMAIN_REWRITES", "school.local=school.org,@Students.School.org=school.org")
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+
+	matching := cfg.Users.Matching()
+	if matching.DefaultDomain != "school.org" {
+		t.Fatalf("DefaultDomain = %q, want school.org", matching.DefaultDomain)
+	}
+	if got := matching.DomainRewrites["students.school.org"]; got != "school.org" {
+		t.Fatalf("DomainRewrites = %v, want students.school.org rewritten to school.org", matching.DomainRewrites)
+	}
+}
+
+func TestLoadFromEnv_RejectsInvalidPrimaryUserDomainRewrites(t *testing.T) {
+	setBaseEnv(t)
+
+	t.Setenv("LOCAL_ADMIN_PASSWORD", "admin")
+	t.Setenv("JWT_SECRET", "jwt-secret")
+	t.Setenv("GRINCH_BASE_URL", "https://grinch.example.com")
+	t.Setenv("PRIMARY_USER_DOMAIN_REWRITES", "school.local=")
+
+	_, err := config.LoadFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "PRIMARY_USER_DOMAIN_REWRITES") {
+		t.Fatalf("error = %v, want invalid PRIMARY_USER_DOMAIN_REWRITES", err)
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+// Permission is a capability granted to a role.
+type Permission string
+
+const (
+	PermissionRead             Permission = "read"
+	PermissionReadEvents       Permission = "read_events"
+	PermissionWriteMemberships Permission = "write_memberships"
+	PermissionWriteRules       Permission = "write_rules"
+	PermissionAdmin            Permission = "admin"
+)
+
+//nolint:gochecknoglobals // package-level lookup table, not mutable state
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {
+		PermissionRead,
+	},
+	RoleHelpdesk: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteMemberships,
+	},
+	RoleRuleEditor: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteRules,
+	},
+	RoleAdmin: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteMemberships,
+		PermissionWriteRules,
+		PermissionAdmin,
+	},
+}
+
+// impliedPermissions mirrors the role table for token scopes: a scope grants what the narrowest
+// role holding it grants, so an admin scope covers reads and a write scope covers the reads that
+// go with it.
+//
+//nolint:gochecknoglobals // package-level lookup table, not mutable state
+var impliedPermissions = map[Permission][]Permission{
+	PermissionRead: {
+		PermissionRead,
+	},
+	PermissionReadEvents: {
+		PermissionRead,
+		PermissionReadEvents,
+	},
+	PermissionWriteMemberships: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteMemberships,
+	},
+	PermissionWriteRules: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteRules,
+	},
+	PermissionAdmin: {
+		PermissionRead,
+		PermissionReadEvents,
+		PermissionWriteMemberships,
+		PermissionWriteRules,
+		PermissionAdmin,
+	},
+}
+
+// RolesAllow reports whether any of the given roles grants the permission.
+func RolesAllow(roles []Role, permission Permission) bool {
+	for _, role := range roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ScopesAllow reports whether any of the given token scopes grants the permission.
+func ScopesAllow(scopes []Permission, permission Permission) bool {
+	for _, scope := range scopes {
+		for _, granted := range impliedPermissions[scope] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
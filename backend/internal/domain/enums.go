@@ -47,7 +47,35 @@ func ParsePrincipalSource(value string) (PrincipalSource, error) {
 }
 
 func ParseMemberKind(value string) (MemberKind, error) {
-	return parseEnum(value, "member kind", MemberKindUser, MemberKindMachine)
+	return parseEnum(value, "member kind", MemberKindUser, MemberKindMachine, MemberKindGroup)
+}
+
+func ParseMembershipOrigin(value string) (MembershipOrigin, error) {
+	return parseEnum(value, "membership origin",
+		MembershipOriginExplicit, MembershipOriginSynced, MembershipOriginDynamic,
+	)
+}
+
+func ParseMembershipImportKey(value string) (MembershipImportKey, error) {
+	return parseEnum(value, "membership import key",
+		MembershipImportKeyMachineID, MembershipImportKeySerialNumber, MembershipImportKeyHostname, MembershipImportKeyUPN,
+	)
+}
+
+func ParseUserCriteriaOperator(value string) (UserCriteriaOperator, error) {
+	return parseEnum(value, "user criteria operator",
+		UserCriteriaOperatorEquals, UserCriteriaOperatorNotEquals, UserCriteriaOperatorMatches,
+	)
+}
+
+func ParseRole(value string) (Role, error) {
+	return parseEnum(value, "role", RoleViewer, RoleHelpdesk, RoleRuleEditor, RoleAdmin)
+}
+
+func ParsePermission(value string) (Permission, error) {
+	return parseEnum(value, "permission",
+		PermissionRead, PermissionReadEvents, PermissionWriteMemberships, PermissionWriteRules, PermissionAdmin,
+	)
 }
 
 func ParseRuleType(value string) (RuleType, error) {
@@ -56,6 +84,28 @@ func ParseRuleType(value string) (RuleType, error) {
 	)
 }
 
+func ParseRuleChangeAction(value string) (RuleChangeAction, error) {
+	return parseEnum(value, "rule change action",
+		RuleChangeActionCreate, RuleChangeActionUpdate, RuleChangeActionDelete,
+	)
+}
+
+func ParseRuleChangeStatus(value string) (RuleChangeStatus, error) {
+	return parseEnum(value, "rule change status",
+		RuleChangeStatusPending, RuleChangeStatusApproved, RuleChangeStatusRejected,
+	)
+}
+
+func ParseUnblockRequestStatus(value string) (UnblockRequestStatus, error) {
+	return parseEnum(value, "unblock request status",
+		UnblockRequestStatusPending, UnblockRequestStatusApproved, UnblockRequestStatusRejected,
+	)
+}
+
+func ParseUnblockScope(value string) (UnblockScope, error) {
+	return parseEnum(value, "unblock scope", UnblockScopeUser, UnblockScopeMachine, UnblockScopeGroup)
+}
+
 func ParseRulePolicy(value string) (RulePolicy, error) {
 	return parseEnum(value, "rule policy",
 		RulePolicyAllowlist, RulePolicyBlocklist, RulePolicySilentBlocklist, RulePolicyCEL,
@@ -70,7 +120,8 @@ func ParseRuleTargetAssignment(value string) (RuleTargetAssignment, error) {
 
 func ParseRuleTargetSubjectKind(value string) (RuleTargetSubjectKind, error) {
 	return parseEnum(value, "rule target subject kind",
-		RuleTargetSubjectKindGroup, RuleTargetSubjectKindAllDevices, RuleTargetSubjectKindAllUsers,
+		RuleTargetSubjectKindGroup, RuleTargetSubjectKindMachine, RuleTargetSubjectKindUser,
+		RuleTargetSubjectKindAllDevices, RuleTargetSubjectKindAllUsers,
 	)
 }
 
@@ -87,14 +138,19 @@ func ParseMachineClientMode(value string) (MachineClientMode, error) {
 }
 
 func ParseExecutionDecision(value string) (ExecutionDecision, error) {
-	return parseEnum(value, "event decision",
+	return parseEnum(value, "event decision", ExecutionDecisions()...)
+}
+
+// ExecutionDecisions returns every execution decision Santa reports.
+func ExecutionDecisions() []ExecutionDecision {
+	return []ExecutionDecision{
 		ExecutionDecisionUnknown, ExecutionDecisionAllowUnknown, ExecutionDecisionAllowBinary,
 		ExecutionDecisionAllowCertificate, ExecutionDecisionAllowScope, ExecutionDecisionAllowTeamID,
 		ExecutionDecisionAllowSigningID, ExecutionDecisionAllowCDHash,
 		ExecutionDecisionBlockUnknown, ExecutionDecisionBlockBinary, ExecutionDecisionBlockCertificate,
 		ExecutionDecisionBlockScope, ExecutionDecisionBlockTeamID, ExecutionDecisionBlockSigningID,
 		ExecutionDecisionBlockCDHash, ExecutionDecisionBundleBinary,
-	)
+	}
 }
 
 func ParseFileAccessDecision(value string) (FileAccessDecision, error) {
@@ -103,3 +159,7 @@ func ParseFileAccessDecision(value string) (FileAccessDecision, error) {
 		FileAccessDecisionDeniedInvalidSignature, FileAccessDecisionAuditOnly,
 	)
 }
+
+func ParseCleanSyncType(value string) (CleanSyncType, error) {
+	return parseEnum(value, "clean sync type", CleanSyncTypeClean, CleanSyncTypeCleanAll)
+}
@@ -3,8 +3,15 @@ package domain
 import "errors"
 
 var (
-	ErrGroupReadOnly = errors.New("group read-only")
-	ErrInvalidSort   = errors.New("invalid sort")
+	ErrGroupReadOnly        = errors.New("group read-only")
+	ErrInvalidSort          = errors.New("invalid sort")
+	ErrMembershipCycle      = errors.New("membership cycle")
+	ErrMembershipManaged    = errors.New("membership managed")
+	ErrRoleGroupAdminOnly   = errors.New("role group admin only")
+	ErrRuleChangeConflict   = errors.New("rule change conflict")
+	ErrRuleChangeSelfReview = errors.New("rule change self-review")
+	ErrUnblockRequestClosed = errors.New("unblock request closed")
+	ErrUserAliasManaged     = errors.New("user alias managed")
 )
 
 type FieldError struct {
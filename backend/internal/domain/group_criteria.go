@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MachineGroupCriteria selects the machines of a dynamic group. Every predicate that is set must
+// match. Version bounds are inclusive, patterns are case-insensitive shell globs of which any may
+// match, and a machine must carry every listed tag.
+type MachineGroupCriteria struct {
+	OSVersionMin         string              `json:"os_version_min,omitempty"`
+	OSVersionMax         string              `json:"os_version_max,omitempty"`
+	SantaVersionMin      string              `json:"santa_version_min,omitempty"`
+	SantaVersionMax      string              `json:"santa_version_max,omitempty"`
+	ModelIdentifiers     []string            `json:"model_identifiers,omitempty"`
+	HostnamePatterns     []string            `json:"hostname_patterns,omitempty"`
+	SerialNumberPatterns []string            `json:"serial_number_patterns,omitempty"`
+	ClientModes          []MachineClientMode `json:"client_modes,omitempty"`
+	LastSeenWithinDays   int32               `json:"last_seen_within_days,omitempty"`
+	Tags                 []string            `json:"tags,omitempty"`
+}
+
+// MachineAttributes are the inventory fields dynamic group criteria match on.
+type MachineAttributes struct {
+	SerialNumber    string
+	Hostname        string
+	ModelIdentifier string
+	OSVersion       string
+	SantaVersion    string
+	ClientMode      MachineClientMode
+	Tags            []string
+	LastSeenAt      time.Time
+}
+
+// Matches reports whether a machine satisfies every predicate in the criteria at now.
+func (c MachineGroupCriteria) Matches(machine MachineAttributes, now time.Time) bool {
+	if !versionInRange(machine.OSVersion, c.OSVersionMin, c.OSVersionMax) ||
+		!versionInRange(machine.SantaVersion, c.SantaVersionMin, c.SantaVersionMax) {
+		return false
+	}
+
+	if !matchesAnyPattern(c.ModelIdentifiers, machine.ModelIdentifier) ||
+		!matchesAnyPattern(c.HostnamePatterns, machine.Hostname) ||
+		!matchesAnyPattern(c.SerialNumberPatterns, machine.SerialNumber) {
+		return false
+	}
+
+	if len(c.ClientModes) > 0 && !slices.Contains(c.ClientModes, machine.ClientMode) {
+		return false
+	}
+
+	if c.LastSeenWithinDays > 0 &&
+		machine.LastSeenAt.Before(now.AddDate(0, 0, -int(c.LastSeenWithinDays))) {
+		return false
+	}
+
+	for _, tag := range c.Tags {
+		if !containsFold(machine.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Directory attributes synced from Entra onto users, which user group criteria can match on.
+const (
+	UserAttributeDepartment     = "department"
+	UserAttributeOfficeLocation = "office_location"
+	UserAttributeCompanyName    = "company_name"
+	UserAttributeEmployeeID     = "employee_id"
+	UserAttributeJobTitle       = "job_title"
+)
+
+// ExtensionAttributeCount is the number of on-premises extension attributes Entra keeps per user.
+const ExtensionAttributeCount = 15
+
+// UserExtensionAttribute names on-premises extension attribute n, from 1 to ExtensionAttributeCount.
+func UserExtensionAttribute(n int) string {
+	return "extension_attribute_" + strconv.Itoa(n)
+}
+
+// UserAttributes lists the directory attributes synced onto users.
+func UserAttributes() []string {
+	attributes := []string{
+		UserAttributeCompanyName,
+		UserAttributeDepartment,
+		UserAttributeEmployeeID,
+		UserAttributeJobTitle,
+		UserAttributeOfficeLocation,
+	}
+	for n := 1; n <= ExtensionAttributeCount; n++ {
+		attributes = append(attributes, UserExtensionAttribute(n))
+	}
+
+	return attributes
+}
+
+type UserCriteriaOperator string
+
+const (
+	UserCriteriaOperatorEquals    UserCriteriaOperator = "equals"
+	UserCriteriaOperatorNotEquals UserCriteriaOperator = "not_equals"
+	UserCriteriaOperatorMatches   UserCriteriaOperator = "matches"
+)
+
+// UserGroupCriteria selects the users of a dynamic group from their directory attributes. Every
+// condition must match.
+type UserGroupCriteria struct {
+	Conditions []UserAttributeCondition `json:"conditions"`
+}
+
+// UserAttributeCondition compares one directory attribute, case-insensitively, with a list of
+// values. equals matches any value, not_equals matches none, and matches treats the values as
+// shell globs of which any may match. A missing attribute is compared as empty.
+type UserAttributeCondition struct {
+	Attribute string               `json:"attribute"`
+	Operator  UserCriteriaOperator `json:"operator"`
+	Values    []string             `json:"values"`
+}
+
+// Matches reports whether a user's directory attributes satisfy every condition.
+func (c UserGroupCriteria) Matches(attributes map[string]string) bool {
+	for _, condition := range c.Conditions {
+		value := attributes[condition.Attribute]
+
+		var matched bool
+		switch condition.Operator {
+		case UserCriteriaOperatorEquals:
+			matched = containsFold(condition.Values, value)
+		case UserCriteriaOperatorNotEquals:
+			matched = !containsFold(condition.Values, value)
+		case UserCriteriaOperatorMatches:
+			matched = len(condition.Values) > 0 && matchesAnyPattern(condition.Values, value)
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidVersion reports whether value is a dotted numeric version such as 14.4.1.
+func ValidVersion(value string) bool {
+	_, ok := parseVersion(value)
+	return ok
+}
+
+// ValidPattern reports whether value is a well-formed criteria glob.
+func ValidPattern(value string) bool {
+	_, err := path.Match(value, "")
+	return err == nil
+}
+
+// CompareVersions compares two dotted numeric versions, treating missing components as zero.
+// Versions that do not parse sort before every valid version.
+func CompareVersions(a, b string) int {
+	left, leftOK := parseVersion(a)
+	right, rightOK := parseVersion(b)
+
+	switch {
+	case !leftOK && !rightOK:
+		return 0
+	case !leftOK:
+		return -1
+	case !rightOK:
+		return 1
+	}
+
+	for i := range max(len(left), len(right)) {
+		var l, r int
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		if l != r {
+			if l < r {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// parseVersion reads the leading digits of each dot-separated component, stopping at the first
+// component without any, so "14.4.1 (23E224)" parses as 14.4.1.
+func parseVersion(value string) ([]int, bool) {
+	var parts []int
+	for component := range strings.SplitSeq(strings.TrimSpace(value), ".") {
+		end := 0
+		for end < len(component) && component[end] >= '0' && component[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+
+		part, err := strconv.Atoi(component[:end])
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, part)
+
+		if end < len(component) {
+			break
+		}
+	}
+
+	return parts, len(parts) > 0
+}
+
+func versionInRange(value string, minVersion string, maxVersion string) bool {
+	if minVersion == "" && maxVersion == "" {
+		return true
+	}
+	if !ValidVersion(value) {
+		return false
+	}
+
+	if minVersion != "" && CompareVersions(value, minVersion) < 0 {
+		return false
+	}
+
+	return maxVersion == "" || CompareVersions(value, maxVersion) <= 0
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(candidate string) bool {
+		return strings.EqualFold(candidate, value)
+	})
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	value = strings.ToLower(value)
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(pattern), value); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
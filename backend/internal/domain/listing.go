@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ListOptions struct {
 	IDs    []uuid.UUID
@@ -14,9 +18,12 @@ type ListOptions struct {
 type MembershipListOptions struct {
 	ListOptions
 
-	GroupID   *uuid.UUID
-	UserID    *uuid.UUID
-	MachineID *uuid.UUID
+	GroupID          *uuid.UUID
+	UserID           *uuid.UUID
+	MachineID        *uuid.UUID
+	MemberGroupID    *uuid.UUID
+	IncludeInherited bool
+	ExpiresBefore    *time.Time
 }
 
 type MachineListOptions struct {
@@ -39,13 +46,36 @@ type RuleMachineListOptions struct {
 	RuleID *uuid.UUID
 }
 
-type ExecutionEventListOptions struct {
+type ExecutableListOptions struct {
+	ListOptions
+
+	CertificateID   *uuid.UUID
+	TeamID          string
+	MinMachineCount *int32
+	MaxMachineCount *int32
+}
+
+type PublisherListOptions struct {
+	ListOptions
+
+	TeamID string
+}
+
+type CertificateListOptions struct {
 	ListOptions
 
-	MachineID    *uuid.UUID
-	UserID       *uuid.UUID
 	ExecutableID *uuid.UUID
-	Decisions    []ExecutionDecision
+}
+
+type ExecutionEventListOptions struct {
+	ListOptions
+
+	MachineID *uuid.UUID
+	// UserID matches events the user ran or was logged in for; ExecutingUserID only those they ran.
+	UserID          *uuid.UUID
+	ExecutingUserID *uuid.UUID
+	ExecutableID    *uuid.UUID
+	Decisions       []ExecutionDecision
 }
 
 type FileAccessEventListOptions struct {
@@ -55,9 +85,32 @@ type FileAccessEventListOptions struct {
 	Decisions []FileAccessDecision
 }
 
+type RuleChangeProposalListOptions struct {
+	ListOptions
+
+	RuleID   *uuid.UUID
+	Statuses []RuleChangeStatus
+}
+
+type UnblockRequestListOptions struct {
+	ListOptions
+
+	MachineID    *uuid.UUID
+	UserID       *uuid.UUID
+	RequesterUPN string
+	Statuses     []UnblockRequestStatus
+}
+
 type RuleListOptions struct {
 	ListOptions
 
-	Enabled   []bool
-	RuleTypes []RuleType
+	Enabled    []bool
+	RuleTypes  []RuleType
+	UnusedDays *int32
+}
+
+type APITokenListOptions struct {
+	ListOptions
+
+	ServiceAccountID *uuid.UUID
 }
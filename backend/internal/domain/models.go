@@ -15,6 +15,28 @@ const (
 	MachineClientModeStandalone MachineClientMode = "standalone"
 )
 
+type CleanSyncType string
+
+const (
+	CleanSyncTypeClean    CleanSyncType = "clean"
+	CleanSyncTypeCleanAll CleanSyncType = "clean_all"
+)
+
+type SyncType string
+
+const (
+	SyncTypeNormal   SyncType = "normal"
+	SyncTypeClean    SyncType = "clean"
+	SyncTypeCleanAll SyncType = "clean_all"
+)
+
+type GroupPathVia string
+
+const (
+	GroupPathViaMachine     GroupPathVia = "machine"
+	GroupPathViaPrimaryUser GroupPathVia = "primary_user"
+)
+
 type MachineRuleSyncStatus string
 
 const (
@@ -28,6 +50,31 @@ type MemberKind string
 const (
 	MemberKindMachine MemberKind = "machine"
 	MemberKindUser    MemberKind = "user"
+	MemberKindGroup   MemberKind = "group"
+)
+
+type MembershipChangeAction string
+
+const (
+	MembershipChangeActionAdd    MembershipChangeAction = "add"
+	MembershipChangeActionRemove MembershipChangeAction = "remove"
+)
+
+type MembershipImportMode string
+
+const (
+	MembershipImportModeAdd     MembershipImportMode = "add"
+	MembershipImportModeRemove  MembershipImportMode = "remove"
+	MembershipImportModeReplace MembershipImportMode = "replace"
+)
+
+type MembershipImportKey string
+
+const (
+	MembershipImportKeyMachineID    MembershipImportKey = "machine_id"
+	MembershipImportKeySerialNumber MembershipImportKey = "serial_number"
+	MembershipImportKeyHostname     MembershipImportKey = "hostname"
+	MembershipImportKeyUPN          MembershipImportKey = "upn"
 )
 
 type MembershipOrigin string
@@ -35,6 +82,7 @@ type MembershipOrigin string
 const (
 	MembershipOriginExplicit MembershipOrigin = "explicit"
 	MembershipOriginSynced   MembershipOrigin = "synced"
+	MembershipOriginDynamic  MembershipOrigin = "dynamic"
 )
 
 type PrincipalSource string
@@ -44,6 +92,49 @@ const (
 	PrincipalSourceLocal PrincipalSource = "local"
 )
 
+type Role string
+
+const (
+	RoleAdmin      Role = "admin"
+	RoleHelpdesk   Role = "helpdesk"
+	RoleRuleEditor Role = "rule_editor"
+	RoleViewer     Role = "viewer"
+)
+
+type RuleChangeAction string
+
+const (
+	RuleChangeActionCreate RuleChangeAction = "create"
+	RuleChangeActionDelete RuleChangeAction = "delete"
+	RuleChangeActionUpdate RuleChangeAction = "update"
+)
+
+type RuleChangeStatus string
+
+const (
+	RuleChangeStatusApproved RuleChangeStatus = "approved"
+	RuleChangeStatusPending  RuleChangeStatus = "pending"
+	RuleChangeStatusRejected RuleChangeStatus = "rejected"
+)
+
+type RuleExplanationOutcome string
+
+const (
+	RuleExplanationOutcomeApplied     RuleExplanationOutcome = "applied"
+	RuleExplanationOutcomeDisabled    RuleExplanationOutcome = "disabled"
+	RuleExplanationOutcomeExcluded    RuleExplanationOutcome = "excluded"
+	RuleExplanationOutcomeNoRule      RuleExplanationOutcome = "no_rule"
+	RuleExplanationOutcomeNotTargeted RuleExplanationOutcome = "not_targeted"
+)
+
+type RuleFindingKind string
+
+const (
+	RuleFindingKindConflictingPolicy  RuleFindingKind = "conflicting_policy"
+	RuleFindingKindExcludedEverywhere RuleFindingKind = "excluded_everywhere"
+	RuleFindingKindUnreachableTarget  RuleFindingKind = "unreachable_target"
+)
+
 type RulePolicy string
 
 const (
@@ -66,6 +157,8 @@ const (
 	RuleTargetSubjectKindAllDevices RuleTargetSubjectKind = "all_devices"
 	RuleTargetSubjectKindAllUsers   RuleTargetSubjectKind = "all_users"
 	RuleTargetSubjectKindGroup      RuleTargetSubjectKind = "group"
+	RuleTargetSubjectKindMachine    RuleTargetSubjectKind = "machine"
+	RuleTargetSubjectKindUser       RuleTargetSubjectKind = "user"
 )
 
 type RuleType string
@@ -78,26 +171,45 @@ const (
 	RuleTypeTeamID      RuleType = "team_id"
 )
 
+type UnblockRequestStatus string
+
+const (
+	UnblockRequestStatusApproved UnblockRequestStatus = "approved"
+	UnblockRequestStatusPending  UnblockRequestStatus = "pending"
+	UnblockRequestStatusRejected UnblockRequestStatus = "rejected"
+)
+
+type UnblockScope string
+
+const (
+	UnblockScopeGroup   UnblockScope = "group"
+	UnblockScopeMachine UnblockScope = "machine"
+	UnblockScopeUser    UnblockScope = "user"
+)
+
 type Machine struct {
-	ID                   uuid.UUID             `json:"id"`
-	SerialNumber         string                `json:"serial_number"`
-	Hostname             string                `json:"hostname"`
-	ModelIdentifier      string                `json:"model_identifier"`
-	OSVersion            string                `json:"os_version"`
-	OSBuild              string                `json:"os_build"`
-	SantaVersion         string                `json:"santa_version"`
-	PrimaryUser          string                `json:"primary_user"`
-	PrimaryUserID        *uuid.UUID            `json:"primary_user_id,omitempty"`
-	RuleSyncStatus       MachineRuleSyncStatus `json:"rule_sync_status"`
-	ClientMode           MachineClientMode     `json:"client_mode"`
-	BinaryRuleCount      int32                 `json:"binary_rule_count"`
-	CertificateRuleCount int32                 `json:"certificate_rule_count"`
-	TeamIDRuleCount      int32                 `json:"teamid_rule_count"`
-	SigningIDRuleCount   int32                 `json:"signingid_rule_count"`
-	CDHashRuleCount      int32                 `json:"cdhash_rule_count"`
-	LastSeenAt           time.Time             `json:"last_seen_at"`
-	CreatedAt            time.Time             `json:"created_at"`
-	UpdatedAt            time.Time             `json:"updated_at"`
+	ID                    uuid.UUID             `json:"id"`
+	SerialNumber          string                `json:"serial_number"`
+	Hostname              string                `json:"hostname"`
+	ModelIdentifier       string                `json:"model_identifier"`
+	OSVersion             string                `json:"os_version"`
+	OSBuild               string                `json:"os_build"`
+	SantaVersion          string                `json:"santa_version"`
+	PrimaryUser           string                `json:"primary_user"`
+	PrimaryUserID         *uuid.UUID            `json:"primary_user_id,omitempty"`
+	EffectivePrimaryUser  string                `json:"effective_primary_user"`
+	PrimaryUserOverridden bool                  `json:"primary_user_overridden"`
+	RuleSyncStatus        MachineRuleSyncStatus `json:"rule_sync_status"`
+	ClientMode            MachineClientMode     `json:"client_mode"`
+	Tags                  []string              `json:"tags"`
+	BinaryRuleCount       int32                 `json:"binary_rule_count"`
+	CertificateRuleCount  int32                 `json:"certificate_rule_count"`
+	TeamIDRuleCount       int32                 `json:"teamid_rule_count"`
+	SigningIDRuleCount    int32                 `json:"signingid_rule_count"`
+	CDHashRuleCount       int32                 `json:"cdhash_rule_count"`
+	LastSeenAt            time.Time             `json:"last_seen_at"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
 }
 
 type MachineSummary struct {
@@ -136,6 +248,8 @@ type RuleMachine struct {
 	MachineID uuid.UUID  `json:"machine_id"`
 	Policy    RulePolicy `json:"policy"`
 	Applied   bool       `json:"applied"`
+	HitCount  int64      `json:"hit_count"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
 }
 
 type Executable struct {
@@ -148,22 +262,30 @@ type Executable struct {
 	TeamID         string              `json:"team_id"`
 	CDHash         string              `json:"cdhash"`
 	Occurrences    int32               `json:"occurrences"`
+	MachineCount   int32               `json:"machine_count"`
+	UserCount      int32               `json:"user_count"`
+	FirstSeenAt    *time.Time          `json:"first_seen_at"`
+	LastSeenAt     *time.Time          `json:"last_seen_at"`
 	Entitlements   map[string]any      `json:"entitlements"`
 	SigningChain   []SigningChainEntry `json:"signing_chain"`
 	CreatedAt      time.Time           `json:"created_at"`
 }
 
 type ExecutableSummary struct {
-	ID             uuid.UUID `json:"id"`
-	FileSHA256     string    `json:"file_sha256"`
-	FileName       string    `json:"file_name"`
-	FileBundleID   string    `json:"file_bundle_id"`
-	FileBundlePath string    `json:"file_bundle_path"`
-	SigningID      string    `json:"signing_id"`
-	TeamID         string    `json:"team_id"`
-	CDHash         string    `json:"cdhash"`
-	Occurrences    int32     `json:"occurrences"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             uuid.UUID  `json:"id"`
+	FileSHA256     string     `json:"file_sha256"`
+	FileName       string     `json:"file_name"`
+	FileBundleID   string     `json:"file_bundle_id"`
+	FileBundlePath string     `json:"file_bundle_path"`
+	SigningID      string     `json:"signing_id"`
+	TeamID         string     `json:"team_id"`
+	CDHash         string     `json:"cdhash"`
+	Occurrences    int32      `json:"occurrences"`
+	MachineCount   int32      `json:"machine_count"`
+	UserCount      int32      `json:"user_count"`
+	FirstSeenAt    *time.Time `json:"first_seen_at"`
+	LastSeenAt     *time.Time `json:"last_seen_at"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 type SigningChainEntry struct {
@@ -175,6 +297,58 @@ type SigningChainEntry struct {
 	ValidUntil         time.Time `json:"valid_until"`
 }
 
+// Certificate is a signing certificate seen in an executable or process signing chain.
+type Certificate struct {
+	ID                 uuid.UUID `json:"id"`
+	SHA256             string    `json:"sha256"`
+	CommonName         string    `json:"common_name"`
+	Organization       string    `json:"organization"`
+	OrganizationalUnit string    `json:"organizational_unit"`
+	ValidFrom          time.Time `json:"valid_from"`
+	ValidUntil         time.Time `json:"valid_until"`
+	ExecutableCount    int32     `json:"executable_count"`
+	MachineCount       int32     `json:"machine_count"`
+	FirstSeenAt        time.Time `json:"first_seen_at"`
+	LastSeenAt         time.Time `json:"last_seen_at"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// CertificateDetail adds the certificate rule that references a certificate, if any.
+type CertificateDetail struct {
+	Certificate
+
+	Rule *Rule `json:"rule,omitempty"`
+}
+
+// Publisher aggregates the executables and activity seen for a Team ID.
+type Publisher struct {
+	TeamID          string     `json:"team_id"`
+	Organization    string     `json:"organization"`
+	SigningIDCount  int32      `json:"signing_id_count"`
+	ExecutableCount int32      `json:"executable_count"`
+	MachineCount    int32      `json:"machine_count"`
+	EventCount      int32      `json:"event_count"`
+	AllowedCount    int32      `json:"allowed_count"`
+	BlockedCount    int32      `json:"blocked_count"`
+	FirstSeenAt     time.Time  `json:"first_seen_at"`
+	LastSeenAt      *time.Time `json:"last_seen_at"`
+}
+
+// PublisherDetail adds signing IDs, per-decision event counts, and covering rules to a publisher.
+type PublisherDetail struct {
+	Publisher
+
+	SigningIDs []string                 `json:"signing_ids"`
+	Decisions  []ExecutionDecisionCount `json:"decisions"`
+	Rules      []RuleSummary            `json:"rules"`
+}
+
+type ExecutionDecisionCount struct {
+	Decision ExecutionDecision `json:"decision"`
+	Count    int32             `json:"count"`
+}
+
 type ExecutionEvent struct {
 	ID              uuid.UUID           `json:"id"`
 	MachineID       uuid.UUID           `json:"machine_id"`
@@ -189,7 +363,9 @@ type ExecutionEvent struct {
 	TeamID          string              `json:"team_id"`
 	CDHash          string              `json:"cdhash"`
 	ExecutingUser   string              `json:"executing_user"`
+	ExecutingUserID *uuid.UUID          `json:"executing_user_id,omitempty"`
 	LoggedInUsers   []string            `json:"logged_in_users"`
+	LoggedInUserIDs []uuid.UUID         `json:"logged_in_user_ids"`
 	CurrentSessions []string            `json:"current_sessions"`
 	SigningChain    []SigningChainEntry `json:"signing_chain"`
 	Entitlements    map[string]any      `json:"entitlements"`
@@ -198,15 +374,48 @@ type ExecutionEvent struct {
 }
 
 type ExecutionEventSummary struct {
-	ID           uuid.UUID         `json:"id"`
-	MachineID    uuid.UUID         `json:"machine_id"`
-	ExecutableID uuid.UUID         `json:"executable_id"`
-	Decision     ExecutionDecision `json:"decision"`
-	FilePath     string            `json:"file_path"`
-	FileName     string            `json:"file_name"`
-	SigningID    string            `json:"signing_id"`
-	OccurredAt   *time.Time        `json:"occurred_at,omitempty"`
-	CreatedAt    time.Time         `json:"created_at"`
+	ID              uuid.UUID         `json:"id"`
+	MachineID       uuid.UUID         `json:"machine_id"`
+	ExecutableID    uuid.UUID         `json:"executable_id"`
+	Decision        ExecutionDecision `json:"decision"`
+	FilePath        string            `json:"file_path"`
+	FileName        string            `json:"file_name"`
+	SigningID       string            `json:"signing_id"`
+	ExecutingUser   string            `json:"executing_user"`
+	ExecutingUserID *uuid.UUID        `json:"executing_user_id,omitempty"`
+	OccurredAt      *time.Time        `json:"occurred_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// UserActivity summarizes the execution events a user ran, as matched to them at ingest.
+type UserActivity struct {
+	UserID                uuid.UUID                `json:"user_id"`
+	EventCount            int32                    `json:"event_count"`
+	AllowedCount          int32                    `json:"allowed_count"`
+	BlockedCount          int32                    `json:"blocked_count"`
+	Decisions             []ExecutionDecisionCount `json:"decisions"`
+	Machines              []UserMachineActivity    `json:"machines"`
+	TopExecutables        []UserExecutableActivity `json:"top_executables"`
+	TopBlockedExecutables []UserExecutableActivity `json:"top_blocked_executables"`
+}
+
+type UserMachineActivity struct {
+	MachineID    uuid.UUID `json:"machine_id"`
+	Hostname     string    `json:"hostname"`
+	EventCount   int32     `json:"event_count"`
+	BlockedCount int32     `json:"blocked_count"`
+	LastEventAt  time.Time `json:"last_event_at"`
+}
+
+type UserExecutableActivity struct {
+	ExecutableID uuid.UUID `json:"executable_id"`
+	FileName     string    `json:"file_name"`
+	SigningID    string    `json:"signing_id"`
+	TeamID       string    `json:"team_id"`
+	MachineCount int32     `json:"machine_count"`
+	EventCount   int32     `json:"event_count"`
+	BlockedCount int32     `json:"blocked_count"`
+	LastEventAt  time.Time `json:"last_event_at"`
 }
 
 type FileAccessEventProcess struct {
@@ -251,6 +460,8 @@ type FileAccessEventSummary struct {
 	CreatedAt  time.Time          `json:"created_at"`
 }
 
+// Rule is a rule with its targets. Warnings is only set on create and update responses, from
+// rule analysis of the saved rule.
 type Rule struct {
 	ID            uuid.UUID   `json:"id"`
 	Name          string      `json:"name"`
@@ -261,24 +472,29 @@ type Rule struct {
 	CustomURL     string      `json:"custom_url"`
 	Enabled       bool        `json:"enabled"`
 	Targets       RuleTargets `json:"targets"`
+	Warnings      []string    `json:"warnings,omitempty"`
+	HitCount      int64       `json:"hit_count"`
+	LastHitAt     *time.Time  `json:"last_hit_at,omitempty"`
 	CreatedAt     time.Time   `json:"created_at"`
 	UpdatedAt     time.Time   `json:"updated_at"`
 }
 
 type RuleSummary struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	RuleType    RuleType  `json:"rule_type"`
-	Identifier  string    `json:"identifier"`
-	Enabled     bool      `json:"enabled"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	RuleType    RuleType   `json:"rule_type"`
+	Identifier  string     `json:"identifier"`
+	Enabled     bool       `json:"enabled"`
+	HitCount    int64      `json:"hit_count"`
+	LastHitAt   *time.Time `json:"last_hit_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 type RuleTargets struct {
 	Include []IncludeRuleTarget `json:"include"`
-	Exclude []ExcludedGroup     `json:"exclude"`
+	Exclude []ExcludeRuleTarget `json:"exclude"`
 }
 
 type IncludeRuleTarget struct {
@@ -289,9 +505,11 @@ type IncludeRuleTarget struct {
 	CELExpression string                `json:"cel_expression,omitempty"`
 }
 
-type ExcludedGroup struct {
-	GroupID   uuid.UUID `json:"group_id"`
-	GroupName string    `json:"group_name,omitempty"`
+// ExcludeRuleTarget removes a rule from the machines a group, machine or user subject matches.
+type ExcludeRuleTarget struct {
+	SubjectKind RuleTargetSubjectKind `json:"subject_kind"`
+	SubjectID   uuid.UUID             `json:"subject_id"`
+	SubjectName string                `json:"subject_name,omitempty"`
 }
 
 type MachineRuleTarget struct {
@@ -304,22 +522,45 @@ type MachineRuleTarget struct {
 }
 
 type User struct {
-	ID          uuid.UUID       `json:"id"`
-	UPN         string          `json:"upn"`
-	DisplayName string          `json:"display_name"`
-	Source      PrincipalSource `json:"source"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID                  uuid.UUID         `json:"id"`
+	UPN                 string            `json:"upn"`
+	DisplayName         string            `json:"display_name"`
+	Source              PrincipalSource   `json:"source"`
+	DirectoryAttributes map[string]string `json:"directory_attributes"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+// UserAlias is another name a machine's reported primary user can match for a user. Entra aliases
+// are replaced on every sync; local aliases are managed by hand.
+type UserAlias struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Alias     string          `json:"alias"`
+	Source    PrincipalSource `json:"source"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// UnresolvedPrimaryUser is a machine whose reported primary user matches no user, or matches
+// several equally well.
+type UnresolvedPrimaryUser struct {
+	MachineID    uuid.UUID `json:"machine_id"`
+	Hostname     string    `json:"hostname"`
+	SerialNumber string    `json:"serial_number"`
+	PrimaryUser  string    `json:"primary_user"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
 }
 
 type Group struct {
-	ID          uuid.UUID       `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Source      PrincipalSource `json:"source"`
-	MemberCount int32           `json:"member_count"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID              uuid.UUID             `json:"id"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	Source          PrincipalSource       `json:"source"`
+	MachineCriteria *MachineGroupCriteria `json:"machine_criteria,omitempty"`
+	UserCriteria    *UserGroupCriteria    `json:"user_criteria,omitempty"`
+	MemberCount     int32                 `json:"member_count"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
 }
 
 type MembershipGroup struct {
@@ -334,12 +575,466 @@ type MembershipMember struct {
 	Name string     `json:"name,omitempty"`
 }
 
+// Membership is a member's direct membership of a group, or an inherited one when Path is not
+// empty. Path lists the nested groups the member reaches the group through, starting with the
+// group it is a direct member of; inherited memberships carry the ID of that direct membership.
+// A pending membership has a StartsAt the membership sweeper has not reached yet and no effect.
 type Membership struct {
-	ID        uuid.UUID        `json:"id"`
-	Group     MembershipGroup  `json:"group"`
-	Member    MembershipMember `json:"member"`
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
+	ID        uuid.UUID         `json:"id"`
+	Group     MembershipGroup   `json:"group"`
+	Member    MembershipMember  `json:"member"`
+	Origin    MembershipOrigin  `json:"origin"`
+	Path      []MembershipGroup `json:"path"`
+	StartsAt  *time.Time        `json:"starts_at,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Pending   bool              `json:"pending"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// LocalGroupMapping makes machines whose primary user is in a macOS local group, as reported by
+// Santa at preflight, synced members of a Grinch group.
+type LocalGroupMapping struct {
+	ID         uuid.UUID `json:"id"`
+	LocalGroup string    `json:"local_group"`
+	GroupID    uuid.UUID `json:"group_id"`
+	GroupName  string    `json:"group_name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type RoleMapping struct {
+	ID        uuid.UUID       `json:"id"`
+	GroupID   uuid.UUID       `json:"group_id"`
+	GroupName string          `json:"group_name"`
+	Source    PrincipalSource `json:"source"`
+	Role      Role            `json:"role"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// RuleChangeProposal is a rule create, update, or delete awaiting review by a second user.
+// Warnings is only set on approve responses, from rule analysis of the applied rule.
+type RuleChangeProposal struct {
+	ID             uuid.UUID         `json:"id"`
+	Action         RuleChangeAction  `json:"action"`
+	Status         RuleChangeStatus  `json:"status"`
+	RuleID         *uuid.UUID        `json:"rule_id,omitempty"`
+	RuleName       string            `json:"rule_name"`
+	BaseUpdatedAt  *time.Time        `json:"-"`
+	Current        *RuleSnapshot     `json:"current,omitempty"`
+	Proposed       *RuleSnapshot     `json:"proposed,omitempty"`
+	Changes        []RuleFieldChange `json:"changes"`
+	ProposedBy     string            `json:"proposed_by"`
+	ProposedByName string            `json:"proposed_by_name"`
+	ReviewedBy     string            `json:"reviewed_by,omitempty"`
+	ReviewedByName string            `json:"reviewed_by_name,omitempty"`
+	ReviewComment  string            `json:"review_comment,omitempty"`
+	ReviewedAt     *time.Time        `json:"reviewed_at,omitempty"`
+	Warnings       []string          `json:"warnings,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// RuleSnapshot is the reviewable state of a rule before or after a proposed change.
+type RuleSnapshot struct {
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	RuleType      RuleType    `json:"rule_type"`
+	Identifier    string      `json:"identifier"`
+	CustomMessage string      `json:"custom_message"`
+	CustomURL     string      `json:"custom_url"`
+	Enabled       bool        `json:"enabled"`
+	Targets       RuleTargets `json:"targets"`
+}
+
+type RuleFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Actor identifies the authenticated user or service account performing an action. Admin reports
+// whether it holds the admin permission, which changes to groups that grant a role require.
+type Actor struct {
+	ID    string
+	Name  string
+	Admin bool
+}
+
+type ServiceAccount struct {
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	ActiveTokenCount int32     `json:"active_token_count"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type APIToken struct {
+	ID                 uuid.UUID    `json:"id"`
+	ServiceAccountID   uuid.UUID    `json:"service_account_id"`
+	ServiceAccountName string       `json:"service_account_name"`
+	Name               string       `json:"name"`
+	Prefix             string       `json:"prefix"`
+	Scopes             []Permission `json:"scopes"`
+	ExpiresAt          *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt         *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
+}
+
+// CreatedAPIToken carries the plaintext token, which is only available at creation time.
+type CreatedAPIToken struct {
+	APIToken
+
+	Token string `json:"token"`
+}
+
+// APITokenPrincipal is the identity an authenticated API token acts as.
+type APITokenPrincipal struct {
+	TokenID            uuid.UUID
+	ServiceAccountID   uuid.UUID
+	ServiceAccountName string
+	Scopes             []Permission
+}
+
+// UnblockRequest asks administrators to allow an executable blocked on a machine. It is raised
+// by the signed-in user from the unblock portal linked in Santa's block dialog.
+type UnblockRequest struct {
+	ID                   uuid.UUID            `json:"id"`
+	FileSHA256           string               `json:"file_sha256"`
+	FileName             string               `json:"file_name"`
+	MachineID            uuid.UUID            `json:"machine_id"`
+	MachineHostname      string               `json:"machine_hostname"`
+	ExecutableID         *uuid.UUID           `json:"executable_id,omitempty"`
+	ExecutionEventID     *uuid.UUID           `json:"execution_event_id,omitempty"`
+	UserID               *uuid.UUID           `json:"user_id,omitempty"`
+	RequesterUPN         string               `json:"requester_upn"`
+	RequesterName        string               `json:"requester_name"`
+	Reason               string               `json:"reason"`
+	Status               UnblockRequestStatus `json:"status"`
+	Scope                *UnblockScope        `json:"scope,omitempty"`
+	GroupID              *uuid.UUID           `json:"group_id,omitempty"`
+	RuleID               *uuid.UUID           `json:"rule_id,omitempty"`
+	RuleChangeProposalID *uuid.UUID           `json:"rule_change_proposal_id,omitempty"`
+	ReviewedBy           string               `json:"reviewed_by,omitempty"`
+	ReviewedByName       string               `json:"reviewed_by_name,omitempty"`
+	ReviewComment        string               `json:"review_comment,omitempty"`
+	ReviewedAt           *time.Time           `json:"reviewed_at,omitempty"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+}
+
+// UnblockRequestDetail adds the execution event and executable an unblock request refers to.
+type UnblockRequestDetail struct {
+	UnblockRequest
+
+	ExecutionEvent *ExecutionEvent `json:"execution_event,omitempty"`
+	Executable     *Executable     `json:"executable,omitempty"`
+}
+
+// UnblockTarget describes the blocked execution shown to a user before they request an unblock.
+type UnblockTarget struct {
+	FileSHA256       string            `json:"file_sha256"`
+	FileName         string            `json:"file_name"`
+	MachineID        uuid.UUID         `json:"machine_id"`
+	MachineHostname  string            `json:"machine_hostname"`
+	ExecutableID     *uuid.UUID        `json:"executable_id,omitempty"`
+	ExecutionEventID *uuid.UUID        `json:"execution_event_id,omitempty"`
+	Decision         ExecutionDecision `json:"decision,omitempty"`
+	OccurredAt       *time.Time        `json:"occurred_at,omitempty"`
+}
+
+// ObservedRuleResult reports the rule created, extended, or proposed from an observed executable.
+type ObservedRuleResult struct {
+	RuleType   RuleType            `json:"rule_type"`
+	Identifier string              `json:"identifier"`
+	Created    bool                `json:"created"`
+	Rule       *Rule               `json:"rule,omitempty"`
+	Proposal   *RuleChangeProposal `json:"proposal,omitempty"`
+	Warnings   []string            `json:"warnings"`
+}
+
+// UnknownExecution aggregates the allow_unknown executions of one executable by one user on one
+// machine.
+type UnknownExecution struct {
+	MachineID      uuid.UUID
+	Hostname       string
+	ExecutableID   uuid.UUID
+	FileName       string
+	Identity       ExecutableIdentity
+	ExecutingUser  string
+	ExecutionCount int32
+	LastExecutedAt time.Time
+}
+
+// LockdownReadinessReport lists what lockdown would block on a set of machines, based on the
+// allow_unknown executions they reported since a point in time.
+type LockdownReadinessReport struct {
+	Since                 time.Time                  `json:"since"`
+	MachineCount          int32                      `json:"machine_count"`
+	BlockedExecutionCount int32                      `json:"blocked_execution_count"`
+	Executables           []LockdownExecutableImpact `json:"executables"`
+	Users                 []LockdownUserImpact       `json:"users"`
+	Machines              []LockdownMachineImpact    `json:"machines"`
+}
+
+// LockdownExecutableImpact is an executable lockdown would block. RuleID is set when a blocklist
+// rule would block it rather than the default deny.
+type LockdownExecutableImpact struct {
+	ExecutableID   uuid.UUID                `json:"executable_id"`
+	FileName       string                   `json:"file_name"`
+	FileSHA256     string                   `json:"file_sha256"`
+	SigningID      string                   `json:"signing_id"`
+	TeamID         string                   `json:"team_id"`
+	MachineCount   int32                    `json:"machine_count"`
+	UserCount      int32                    `json:"user_count"`
+	ExecutionCount int32                    `json:"execution_count"`
+	LastExecutedAt time.Time                `json:"last_executed_at"`
+	RuleID         *uuid.UUID               `json:"rule_id,omitempty"`
+	SuggestedRules []LockdownRuleSuggestion `json:"suggested_rules"`
+}
+
+// LockdownRuleSuggestion is a rule that would allow a blocked executable. RuleID is set when a
+// rule with the identifier already exists and only needs targeting at the affected machines.
+type LockdownRuleSuggestion struct {
+	RuleType   RuleType   `json:"rule_type"`
+	Identifier string     `json:"identifier"`
+	RuleID     *uuid.UUID `json:"rule_id,omitempty"`
+}
+
+type LockdownUserImpact struct {
+	ExecutingUser   string `json:"executing_user"`
+	ExecutableCount int32  `json:"executable_count"`
+	MachineCount    int32  `json:"machine_count"`
+	ExecutionCount  int32  `json:"execution_count"`
+}
+
+type LockdownMachineImpact struct {
+	MachineID       uuid.UUID `json:"machine_id"`
+	Hostname        string    `json:"hostname"`
+	ExecutableCount int32     `json:"executable_count"`
+	UserCount       int32     `json:"user_count"`
+	ExecutionCount  int32     `json:"execution_count"`
+}
+
+// CleanSyncResult reports how many machines were flagged for a clean sync on their next preflight.
+type CleanSyncResult struct {
+	SyncType     CleanSyncType `json:"sync_type"`
+	MachineCount int32         `json:"machine_count"`
+	RequestedAt  time.Time     `json:"requested_at"`
+}
+
+// MachineSyncDiagnostics is a machine's stored sync state, why it has its rule sync status, and
+// what its next sync would send.
+type MachineSyncDiagnostics struct {
+	MachineID                 uuid.UUID             `json:"machine_id"`
+	RuleSyncStatus            MachineRuleSyncStatus `json:"rule_sync_status"`
+	StatusReason              string                `json:"status_reason"`
+	RulesHash                 string                `json:"rules_hash"`
+	DesiredTargets            []SyncRuleTarget      `json:"desired_targets"`
+	AppliedTargets            []SyncRuleTarget      `json:"applied_targets"`
+	SentTargets               []SyncRuleTarget      `json:"sent_targets"`
+	Pending                   *MachineSyncPayload   `json:"pending,omitempty"`
+	PendingPreflightAt        *time.Time            `json:"pending_preflight_at,omitempty"`
+	RuleCounts                []SyncRuleCount       `json:"rule_counts"`
+	RulesReceived             int32                 `json:"rules_received"`
+	RulesProcessed            int32                 `json:"rules_processed"`
+	RequestedCleanSync        *CleanSyncType        `json:"requested_clean_sync,omitempty"`
+	RequestedCleanSyncAt      *time.Time            `json:"requested_clean_sync_at,omitempty"`
+	LastRuleSyncAttemptAt     *time.Time            `json:"last_rule_sync_attempt_at,omitempty"`
+	LastRuleSyncSuccessAt     *time.Time            `json:"last_rule_sync_success_at,omitempty"`
+	LastCleanSyncAt           *time.Time            `json:"last_clean_sync_at,omitempty"`
+	LastReportedCountsMatchAt *time.Time            `json:"last_reported_counts_match_at,omitempty"`
+	NextSync                  MachineSyncPayload    `json:"next_sync"`
+}
+
+// SyncRuleTarget is a rule fingerprint in a machine's sync state.
+type SyncRuleTarget struct {
+	RuleType    RuleType `json:"rule_type"`
+	Identifier  string   `json:"identifier"`
+	PayloadHash string   `json:"payload_hash"`
+}
+
+// SyncRuleCount compares the rules of one type a machine should have with the count it last
+// reported at preflight.
+type SyncRuleCount struct {
+	RuleType RuleType `json:"rule_type"`
+	Desired  int32    `json:"desired"`
+	Reported int32    `json:"reported"`
+}
+
+// MachineSyncPayload is the rule download a sync sends.
+type MachineSyncPayload struct {
+	SyncType  SyncType          `json:"sync_type"`
+	RuleCount int64             `json:"rule_count"`
+	Rules     []SyncPayloadRule `json:"rules"`
+}
+
+type SyncPayloadRule struct {
+	MachineRuleTarget
+
+	Removed bool `json:"removed"`
+}
+
+// MembershipChange is a proposed membership add or remove, identified by group and member.
+type MembershipChange struct {
+	Action     MembershipChangeAction
+	GroupID    uuid.UUID
+	MemberKind MemberKind
+	MemberID   uuid.UUID
+}
+
+// MembershipImportRow is one CSV row of a bulk membership import, identified by the key it
+// matches a machine or user on. Row is the CSV line number.
+type MembershipImportRow struct {
+	Row   int
+	Key   MembershipImportKey
+	Value string
+}
+
+// MembershipImportUnmatchedRow is an import row that did not resolve to exactly one member.
+// Reason is not_found, ambiguous or missing_key.
+type MembershipImportUnmatchedRow struct {
+	Row    int                 `json:"row"`
+	Key    MembershipImportKey `json:"key,omitempty"`
+	Value  string              `json:"value"`
+	Reason string              `json:"reason"`
+}
+
+// MembershipImportResult reports what a bulk membership import changed. Matched counts distinct
+// members the CSV resolved to; Added and Removed count explicit memberships created and deleted.
+type MembershipImportResult struct {
+	Mode      MembershipImportMode           `json:"mode"`
+	Matched   int                            `json:"matched"`
+	Added     int                            `json:"added"`
+	Removed   int                            `json:"removed"`
+	Unmatched []MembershipImportUnmatchedRow `json:"unmatched"`
+}
+
+// PolicyImpactPreview is the effect a proposed rule or membership change would have on the
+// rules resolved for each machine. Only machines whose resolved rules change are listed.
+type PolicyImpactPreview struct {
+	AffectedMachineCount int32                 `json:"affected_machine_count"`
+	Machines             []MachinePolicyImpact `json:"machines"`
+	Groups               []GroupPolicyImpact   `json:"groups"`
+}
+
+type MachinePolicyImpact struct {
+	MachineID     uuid.UUID          `json:"machine_id"`
+	Hostname      string             `json:"hostname"`
+	Added         []PolicyImpactRule `json:"added"`
+	Removed       []PolicyImpactRule `json:"removed"`
+	PolicyChanged []PolicyImpactRule `json:"policy_changed"`
+}
+
+// PolicyImpactRule is a rule whose resolution changes on a machine. Policy is unset for removed
+// rules and PreviousPolicy is unset for added ones.
+type PolicyImpactRule struct {
+	RuleID         uuid.UUID   `json:"rule_id"`
+	Name           string      `json:"name"`
+	RuleType       RuleType    `json:"rule_type"`
+	Identifier     string      `json:"identifier"`
+	Policy         *RulePolicy `json:"policy,omitempty"`
+	PreviousPolicy *RulePolicy `json:"previous_policy,omitempty"`
+}
+
+// GroupPolicyImpact totals the changes on the affected machines in one group, counting a machine
+// in every group it is an effective member of before or after the change.
+type GroupPolicyImpact struct {
+	GroupID            uuid.UUID `json:"group_id"`
+	GroupName          string    `json:"group_name"`
+	MachineCount       int32     `json:"machine_count"`
+	AddedCount         int32     `json:"added_count"`
+	RemovedCount       int32     `json:"removed_count"`
+	PolicyChangedCount int32     `json:"policy_changed_count"`
+}
+
+// MachineGroupPath is one way a machine reaches a group: a membership of the machine itself or of
+// its primary user, either of the group or, through the nested groups in Path, of a group it
+// contains.
+type MachineGroupPath struct {
+	GroupID      uuid.UUID         `json:"group_id"`
+	GroupName    string            `json:"group_name"`
+	Via          GroupPathVia      `json:"via"`
+	MembershipID uuid.UUID         `json:"membership_id"`
+	Origin       MembershipOrigin  `json:"origin"`
+	Path         []MembershipGroup `json:"path"`
+}
+
+// MachineRuleExplanation traces how the rule for a rule type and identifier resolves on a machine.
+// Rule is nil when no rule has the identifier. Policy is set when the outcome is applied.
+type MachineRuleExplanation struct {
+	MachineID     uuid.UUID               `json:"machine_id"`
+	RuleType      RuleType                `json:"rule_type"`
+	Identifier    string                  `json:"identifier"`
+	PrimaryUser   string                  `json:"primary_user"`
+	PrimaryUserID *uuid.UUID              `json:"primary_user_id,omitempty"`
+	Rule          *RuleSummary            `json:"rule,omitempty"`
+	Outcome       RuleExplanationOutcome  `json:"outcome"`
+	Policy        *RulePolicy             `json:"policy,omitempty"`
+	Targets       []RuleTargetExplanation `json:"targets"`
+}
+
+// RuleTargetExplanation is one candidate target of the explained rule. Paths lists how the machine
+// reaches a group subject. Won marks the matching include with the best priority; an applied
+// exclude still removes the rule.
+type RuleTargetExplanation struct {
+	Assignment  RuleTargetAssignment  `json:"assignment"`
+	SubjectKind RuleTargetSubjectKind `json:"subject_kind"`
+	SubjectID   *uuid.UUID            `json:"subject_id,omitempty"`
+	SubjectName string                `json:"subject_name,omitempty"`
+	Priority    *int32                `json:"priority,omitempty"`
+	Policy      *RulePolicy           `json:"policy,omitempty"`
+	Matched     bool                  `json:"matched"`
+	Paths       []MachineGroupPath    `json:"paths"`
+	Won         bool                  `json:"won"`
+}
+
+// MachineTargeting holds the machine sets rule targets resolve against: every machine, the machines
+// whose primary user resolves to a user, each group's machines, direct or through their primary
+// user, and each user's machines as primary user.
+type MachineTargeting struct {
+	MachineIDs            []uuid.UUID
+	PrimaryUserMachineIDs []uuid.UUID
+	GroupMachineIDs       map[uuid.UUID][]uuid.UUID
+	UserMachineIDs        map[uuid.UUID][]uuid.UUID
+}
+
+// MachineTargetingScope names the rule target subjects a MachineTargeting lookup must resolve, so
+// analysis of a few rules does not load the whole fleet.
+type MachineTargetingScope struct {
+	AllDevices bool
+	AllUsers   bool
+	MachineIDs []uuid.UUID
+	UserIDs    []uuid.UUID
+	GroupIDs   []uuid.UUID
+}
+
+// RuleOverlap is a pair of rules that match at least one common known executable.
+type RuleOverlap struct {
+	RuleID          uuid.UUID
+	OtherRuleID     uuid.UUID
+	ExecutableCount int32
+}
+
+// RuleFinding is a targeting problem found by rule analysis. OtherRule fields are set for
+// conflicting policies and Subject fields for unreachable targets. MachineCount is the number of
+// machines the finding affects.
+type RuleFinding struct {
+	Kind            RuleFindingKind       `json:"kind"`
+	RuleID          uuid.UUID             `json:"rule_id"`
+	RuleName        string                `json:"rule_name"`
+	OtherRuleID     *uuid.UUID            `json:"other_rule_id,omitempty"`
+	OtherRuleName   string                `json:"other_rule_name,omitempty"`
+	SubjectKind     RuleTargetSubjectKind `json:"subject_kind,omitempty"`
+	SubjectID       *uuid.UUID            `json:"subject_id,omitempty"`
+	SubjectName     string                `json:"subject_name,omitempty"`
+	Priority        *int32                `json:"priority,omitempty"`
+	MachineCount    int32                 `json:"machine_count"`
+	ExecutableCount int32                 `json:"executable_count,omitempty"`
+	Message         string                `json:"message"`
 }
 
 type RuleWriteInput struct {
@@ -355,7 +1050,7 @@ type RuleWriteInput struct {
 
 type RuleTargetsWriteInput struct {
 	Include []IncludeRuleTargetWriteInput
-	Exclude []ExcludedGroupWriteInput
+	Exclude []ExcludeRuleTargetWriteInput
 }
 
 type IncludeRuleTargetWriteInput struct {
@@ -365,8 +1060,22 @@ type IncludeRuleTargetWriteInput struct {
 	CELExpression string
 }
 
-type ExcludedGroupWriteInput struct {
-	GroupID uuid.UUID
+type ExcludeRuleTargetWriteInput struct {
+	SubjectKind RuleTargetSubjectKind
+	SubjectID   uuid.UUID
+}
+
+// ObservedRuleInput creates or extends a rule from an executable or execution event. Exactly one
+// source ID is set; the identifier is derived from it for the rule type.
+type ObservedRuleInput struct {
+	ExecutableID     *uuid.UUID
+	ExecutionEventID *uuid.UUID
+	RuleType         RuleType
+	Name             string
+	Description      string
+	CustomMessage    string
+	CustomURL        string
+	Targets          RuleTargetsWriteInput
 }
 
 type EntraSyncResult struct {
@@ -0,0 +1,62 @@
+package domain
+
+import "strings"
+
+// PrimaryUserMatching configures how the primary user Santa reports for a machine, and the users
+// it reports on execution events, are matched to users. Matching is case-insensitive against user
+// UPNs and aliases.
+type PrimaryUserMatching struct {
+	// DefaultDomain is tried for reported names without a domain, such as a short username.
+	DefaultDomain string
+	// DomainRewrites maps a reported domain suffix to the directory domain that replaces it.
+	DomainRewrites map[string]string
+}
+
+// Lookups returns the lowercased names a reported primary user may match, in order: the reported
+// name itself, then the name with its domain rewritten or the default domain added. A leading
+// NetBIOS domain, as in DOMAIN\user, is dropped.
+func (m PrimaryUserMatching) Lookups(reported string) []string {
+	name := NormalizeUserAlias(reported)
+	if index := strings.LastIndex(name, `\`); index >= 0 {
+		name = name[index+1:]
+	}
+	if name == "" {
+		return nil
+	}
+
+	lookups := []string{name}
+	local, userDomain, hasDomain := strings.Cut(name, "@")
+	switch {
+	case !hasDomain && m.DefaultDomain != "":
+		lookups = append(lookups, name+"@"+m.DefaultDomain)
+	case hasDomain:
+		if rewritten, ok := m.rewriteDomain(userDomain); ok && rewritten != userDomain {
+			lookups = append(lookups, local+"@"+rewritten)
+		}
+	}
+
+	return lookups
+}
+
+// rewriteDomain replaces the longest configured suffix of userDomain, matching whole labels.
+func (m PrimaryUserMatching) rewriteDomain(userDomain string) (string, bool) {
+	bestSuffix := ""
+	for suffix := range m.DomainRewrites {
+		if len(suffix) <= len(bestSuffix) {
+			continue
+		}
+		if userDomain == suffix || strings.HasSuffix(userDomain, "."+suffix) {
+			bestSuffix = suffix
+		}
+	}
+	if bestSuffix == "" {
+		return "", false
+	}
+
+	return strings.TrimSuffix(userDomain, bestSuffix) + m.DomainRewrites[bestSuffix], true
+}
+
+// NormalizeUserAlias returns the stored form of a user alias or reported primary user.
+func NormalizeUserAlias(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// RuleSnapshotFromRule captures the reviewable state of a stored rule.
+func RuleSnapshotFromRule(rule Rule) RuleSnapshot {
+	return RuleSnapshot{
+		Name:          rule.Name,
+		Description:   rule.Description,
+		RuleType:      rule.RuleType,
+		Identifier:    rule.Identifier,
+		CustomMessage: rule.CustomMessage,
+		CustomURL:     rule.CustomURL,
+		Enabled:       rule.Enabled,
+		Targets:       rule.Targets,
+	}
+}
+
+// RuleSnapshotFromInput captures the reviewable state of a rule write.
+func RuleSnapshotFromInput(input RuleWriteInput) RuleSnapshot {
+	targets := RuleTargets{
+		Include: make([]IncludeRuleTarget, 0, len(input.Targets.Include)),
+		Exclude: make([]ExcludeRuleTarget, 0, len(input.Targets.Exclude)),
+	}
+	for _, target := range input.Targets.Include {
+		targets.Include = append(targets.Include, IncludeRuleTarget{
+			SubjectKind:   target.SubjectKind,
+			SubjectID:     target.SubjectID,
+			Policy:        target.Policy,
+			CELExpression: target.CELExpression,
+		})
+	}
+	for _, target := range input.Targets.Exclude {
+		targets.Exclude = append(targets.Exclude, ExcludeRuleTarget{
+			SubjectKind: target.SubjectKind,
+			SubjectID:   target.SubjectID,
+		})
+	}
+
+	return RuleSnapshot{
+		Name:          input.Name,
+		Description:   input.Description,
+		RuleType:      input.RuleType,
+		Identifier:    input.Identifier,
+		CustomMessage: input.CustomMessage,
+		CustomURL:     input.CustomURL,
+		Enabled:       input.Enabled,
+		Targets:       targets,
+	}
+}
+
+// WriteInput converts the snapshot back into the input applied when a proposal is approved.
+func (s RuleSnapshot) WriteInput() RuleWriteInput {
+	include := make([]IncludeRuleTargetWriteInput, 0, len(s.Targets.Include))
+	for _, target := range s.Targets.Include {
+		include = append(include, IncludeRuleTargetWriteInput{
+			SubjectKind:   target.SubjectKind,
+			SubjectID:     target.SubjectID,
+			Policy:        target.Policy,
+			CELExpression: target.CELExpression,
+		})
+	}
+
+	exclude := make([]ExcludeRuleTargetWriteInput, 0, len(s.Targets.Exclude))
+	for _, target := range s.Targets.Exclude {
+		exclude = append(exclude, ExcludeRuleTargetWriteInput{
+			SubjectKind: target.SubjectKind,
+			SubjectID:   target.SubjectID,
+		})
+	}
+
+	return RuleWriteInput{
+		Name:          s.Name,
+		Description:   s.Description,
+		RuleType:      s.RuleType,
+		Identifier:    s.Identifier,
+		CustomMessage: s.CustomMessage,
+		CustomURL:     s.CustomURL,
+		Enabled:       s.Enabled,
+		Targets: RuleTargetsWriteInput{
+			Include: include,
+			Exclude: exclude,
+		},
+	}
+}
+
+// DiffRuleSnapshots lists the fields that differ between two rule states.
+// A nil before describes a create and a nil after describes a delete.
+func DiffRuleSnapshots(before *RuleSnapshot, after *RuleSnapshot) []RuleFieldChange {
+	changes := make([]RuleFieldChange, 0)
+
+	addChange := func(field string, value func(RuleSnapshot) any, equal bool) {
+		if before != nil && after != nil && equal {
+			return
+		}
+
+		change := RuleFieldChange{Field: field}
+		if before != nil {
+			change.Before = value(*before)
+		}
+		if after != nil {
+			change.After = value(*after)
+		}
+		changes = append(changes, change)
+	}
+
+	var b, a RuleSnapshot
+	if before != nil {
+		b = *before
+	}
+	if after != nil {
+		a = *after
+	}
+
+	addChange("name", func(s RuleSnapshot) any { return s.Name }, b.Name == a.Name)
+	addChange("description", func(s RuleSnapshot) any { return s.Description }, b.Description == a.Description)
+	addChange("rule_type", func(s RuleSnapshot) any { return s.RuleType }, b.RuleType == a.RuleType)
+	addChange("identifier", func(s RuleSnapshot) any { return s.Identifier }, b.Identifier == a.Identifier)
+	addChange(
+		"custom_message",
+		func(s RuleSnapshot) any { return s.CustomMessage },
+		b.CustomMessage == a.CustomMessage,
+	)
+	addChange("custom_url", func(s RuleSnapshot) any { return s.CustomURL }, b.CustomURL == a.CustomURL)
+	addChange("enabled", func(s RuleSnapshot) any { return s.Enabled }, b.Enabled == a.Enabled)
+	addChange(
+		"targets",
+		func(s RuleSnapshot) any { return s.Targets },
+		slices.Equal(ruleTargetKeys(b.Targets), ruleTargetKeys(a.Targets)),
+	)
+
+	return changes
+}
+
+// ruleTargetKeys returns order-independent keys for targets, ignoring display names.
+func ruleTargetKeys(targets RuleTargets) []string {
+	keys := make([]string, 0, len(targets.Include)+len(targets.Exclude))
+	for _, target := range targets.Include {
+		subjectID := ""
+		if target.SubjectID != nil {
+			subjectID = target.SubjectID.String()
+		}
+		keys = append(keys, strings.Join([]string{
+			string(RuleTargetAssignmentInclude),
+			string(target.SubjectKind),
+			subjectID,
+			string(target.Policy),
+			target.CELExpression,
+		}, machineRuleTargetHashSeparator))
+	}
+	for _, target := range targets.Exclude {
+		keys = append(keys, strings.Join([]string{
+			string(RuleTargetAssignmentExclude),
+			string(target.SubjectKind),
+			target.SubjectID.String(),
+		}, machineRuleTargetHashSeparator))
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,147 @@
+package domain
+
+// ExecutableIdentity holds the identifiers Santa matches rules against for one executable.
+// CertificateSHA256 is the leaf certificate of the signing chain.
+type ExecutableIdentity struct {
+	FileSHA256        string
+	CDHash            string
+	SigningID         string
+	TeamID            string
+	CertificateSHA256 string
+}
+
+// Identifier returns the identifier a rule of the given type would need to match the executable.
+func (identity ExecutableIdentity) Identifier(ruleType RuleType) string {
+	switch ruleType {
+	case RuleTypeBinary:
+		return identity.FileSHA256
+	case RuleTypeCDHash:
+		return identity.CDHash
+	case RuleTypeSigningID:
+		return identity.SigningID
+	case RuleTypeTeamID:
+		return identity.TeamID
+	case RuleTypeCertificate:
+		return identity.CertificateSHA256
+	default:
+		return ""
+	}
+}
+
+// RuleTypesByPrecedence returns rule types in the order Santa evaluates them, most specific first.
+func RuleTypesByPrecedence() []RuleType {
+	return []RuleType{
+		RuleTypeCDHash,
+		RuleTypeBinary,
+		RuleTypeSigningID,
+		RuleTypeCertificate,
+		RuleTypeTeamID,
+	}
+}
+
+// MachineRuleIndex looks up a machine's resolved rules by rule type and identifier.
+type MachineRuleIndex map[string]MachineResolvedRule
+
+func NewMachineRuleIndex(rules []MachineResolvedRule) MachineRuleIndex {
+	index := make(MachineRuleIndex, len(rules))
+	for _, rule := range rules {
+		index[MachineRuleTargetKey(rule.MachineRuleTarget)] = rule
+	}
+	return index
+}
+
+// Match returns the rule Santa would apply to the executable: the match for the most specific
+// rule type.
+func (index MachineRuleIndex) Match(identity ExecutableIdentity) (MachineResolvedRule, bool) {
+	for _, ruleType := range RuleTypesByPrecedence() {
+		identifier := identity.Identifier(ruleType)
+		if identifier == "" {
+			continue
+		}
+
+		rule, ok := index[MachineRuleTargetKey(MachineRuleTarget{RuleType: ruleType, Identifier: identifier})]
+		if ok {
+			return rule, true
+		}
+	}
+
+	return MachineResolvedRule{}, false
+}
+
+// RuleType returns the type of rule Santa matched to reach the decision. Decisions not made by a
+// rule, such as unknown or scope decisions, report false.
+func (decision ExecutionDecision) RuleType() (RuleType, bool) {
+	switch decision {
+	case ExecutionDecisionAllowBinary, ExecutionDecisionBlockBinary:
+		return RuleTypeBinary, true
+	case ExecutionDecisionAllowCDHash, ExecutionDecisionBlockCDHash:
+		return RuleTypeCDHash, true
+	case ExecutionDecisionAllowSigningID, ExecutionDecisionBlockSigningID:
+		return RuleTypeSigningID, true
+	case ExecutionDecisionAllowTeamID, ExecutionDecisionBlockTeamID:
+		return RuleTypeTeamID, true
+	case ExecutionDecisionAllowCertificate, ExecutionDecisionBlockCertificate:
+		return RuleTypeCertificate, true
+	case ExecutionDecisionUnknown,
+		ExecutionDecisionAllowUnknown,
+		ExecutionDecisionAllowScope,
+		ExecutionDecisionBlockUnknown,
+		ExecutionDecisionBlockScope,
+		ExecutionDecisionBundleBinary:
+		return "", false
+	default:
+		return "", false
+	}
+}
+
+// Allowed reports whether Santa let the execution run.
+func (decision ExecutionDecision) Allowed() bool {
+	switch decision {
+	case ExecutionDecisionAllowUnknown,
+		ExecutionDecisionAllowBinary,
+		ExecutionDecisionAllowCertificate,
+		ExecutionDecisionAllowScope,
+		ExecutionDecisionAllowTeamID,
+		ExecutionDecisionAllowSigningID,
+		ExecutionDecisionAllowCDHash:
+		return true
+	case ExecutionDecisionUnknown,
+		ExecutionDecisionBlockUnknown,
+		ExecutionDecisionBlockBinary,
+		ExecutionDecisionBlockCertificate,
+		ExecutionDecisionBlockScope,
+		ExecutionDecisionBlockTeamID,
+		ExecutionDecisionBlockSigningID,
+		ExecutionDecisionBlockCDHash,
+		ExecutionDecisionBundleBinary:
+		return false
+	default:
+		return false
+	}
+}
+
+// Blocked reports whether Santa stopped the execution.
+func (decision ExecutionDecision) Blocked() bool {
+	switch decision {
+	case ExecutionDecisionBlockUnknown,
+		ExecutionDecisionBlockBinary,
+		ExecutionDecisionBlockCertificate,
+		ExecutionDecisionBlockScope,
+		ExecutionDecisionBlockTeamID,
+		ExecutionDecisionBlockSigningID,
+		ExecutionDecisionBlockCDHash:
+		return true
+	case ExecutionDecisionUnknown,
+		ExecutionDecisionAllowUnknown,
+		ExecutionDecisionAllowBinary,
+		ExecutionDecisionAllowCertificate,
+		ExecutionDecisionAllowScope,
+		ExecutionDecisionAllowTeamID,
+		ExecutionDecisionAllowSigningID,
+		ExecutionDecisionAllowCDHash,
+		ExecutionDecisionBundleBinary:
+		return false
+	default:
+		return false
+	}
+}
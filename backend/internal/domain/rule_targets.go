@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -63,3 +65,40 @@ func machineRuleTargetHashInput(target MachineRuleTarget) string {
 		target.CELExpression,
 	}, machineRuleTargetHashSeparator)
 }
+
+// MergeRuleTargets adds requested targets to a rule's existing ones. Requested include targets
+// come first so they take precedence, and existing targets for the same subjects are dropped.
+func MergeRuleTargets(existing RuleTargetsWriteInput, requested RuleTargetsWriteInput) RuleTargetsWriteInput {
+	claimed := make(map[string]struct{}, len(requested.Include)+len(requested.Exclude))
+	for _, target := range requested.Include {
+		claimed[ruleTargetSubjectKey(target.SubjectKind, target.SubjectID)] = struct{}{}
+	}
+	for _, target := range requested.Exclude {
+		claimed[ruleTargetSubjectKey(target.SubjectKind, &target.SubjectID)] = struct{}{}
+	}
+
+	include := make([]IncludeRuleTargetWriteInput, 0, len(requested.Include)+len(existing.Include))
+	include = append(include, requested.Include...)
+	for _, target := range existing.Include {
+		if _, ok := claimed[ruleTargetSubjectKey(target.SubjectKind, target.SubjectID)]; !ok {
+			include = append(include, target)
+		}
+	}
+
+	exclude := make([]ExcludeRuleTargetWriteInput, 0, len(requested.Exclude)+len(existing.Exclude))
+	exclude = append(exclude, requested.Exclude...)
+	for _, target := range existing.Exclude {
+		if _, ok := claimed[ruleTargetSubjectKey(target.SubjectKind, &target.SubjectID)]; !ok {
+			exclude = append(exclude, target)
+		}
+	}
+
+	return RuleTargetsWriteInput{Include: include, Exclude: exclude}
+}
+
+func ruleTargetSubjectKey(kind RuleTargetSubjectKind, subjectID *uuid.UUID) string {
+	if subjectID == nil {
+		return string(kind)
+	}
+	return string(kind) + machineRuleTargetKeySeparator + subjectID.String()
+}
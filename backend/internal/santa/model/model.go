@@ -25,6 +25,8 @@ type MachineUpsert struct {
 	PrimaryUserGroups []string
 	ClientMode        domain.MachineClientMode
 	LastSeenAt        time.Time
+	// PrimaryUserLookups are the names PrimaryUser may match as a user UPN or alias.
+	PrimaryUserLookups []string
 }
 
 // MachineSyncState is the persisted two-phase sync state for a machine.
@@ -41,6 +43,21 @@ type MachineSyncState struct {
 	PendingFullSync         bool
 	PendingPreflightAt      *time.Time
 
+	// RuleSyncStatus is read from the machine_rule_sync_status SQL function when the state is
+	// loaded. It is never written back.
+	RuleSyncStatus domain.MachineRuleSyncStatus
+
+	// PendingCleanAll marks a pending full sync as clean-all. PendingCleanSyncRequestedAt is the
+	// RequestedCleanSyncAt the pending snapshot was planned from, so promotion only clears the
+	// admin request it served.
+	PendingCleanAll             bool
+	PendingCleanSyncRequestedAt *time.Time
+
+	// RequestedCleanSync is an admin request for the next preflight to return a clean or clean-all
+	// sync. It is empty when nothing is requested.
+	RequestedCleanSync   domain.CleanSyncType
+	RequestedCleanSyncAt *time.Time
+
 	DesiredBinaryRuleCount      int32
 	DesiredCertificateRuleCount int32
 	DesiredTeamIDRuleCount      int32
@@ -96,9 +113,11 @@ type PendingSnapshotWrite struct {
 	SentTargets    []AppliedRuleTarget
 	PendingPayload []SyncRule
 
-	PendingPayloadRuleCount int64
-	PendingFullSync         bool
-	PendingPreflightAt      time.Time
+	PendingPayloadRuleCount     int64
+	PendingFullSync             bool
+	PendingCleanAll             bool
+	PendingCleanSyncRequestedAt *time.Time
+	PendingPreflightAt          time.Time
 
 	DesiredBinaryRuleCount      int32
 	DesiredCertificateRuleCount int32
@@ -168,6 +187,10 @@ type ExecutionEventWrite struct {
 	CurrentSessions []string
 	Decision        domain.ExecutionDecision
 	OccurredAt      *time.Time
+	// ExecutingUserLookups and LoggedInUserLookups are the names each reported user may match a
+	// user by. LoggedInUserLookups is parallel to LoggedInUsers.
+	ExecutingUserLookups []string
+	LoggedInUserLookups  [][]string
 }
 
 // FileAccessEventWrite is a decoded file access event ready for storage.
@@ -183,6 +206,7 @@ type FileAccessEventWrite struct {
 // DataStore stores two-phase sync state and ingested Santa events.
 type DataStore interface {
 	UpsertMachine(context.Context, MachineUpsert) error
+	SyncMachineComputedMemberships(context.Context, uuid.UUID, time.Time) (bool, error)
 	UpdateMachineDesiredTargets(context.Context, uuid.UUID) error
 	GetMachineSyncState(context.Context, uuid.UUID) (MachineSyncState, error)
 	ReplacePendingSnapshot(context.Context, PendingSnapshotWrite) error
@@ -27,13 +27,16 @@ func BuildRuleDownloadResponse(rules []model.SyncRule) (*syncv1.RuleDownloadResp
 	}.Build(), nil
 }
 
-// SyncTypeFromPendingFullSync maps the stored pending sync mode to the Santa
-// wire enum.
-func SyncTypeFromPendingFullSync(fullSync bool) syncv1.SyncType {
-	if fullSync {
+// SyncType maps the pending sync mode to the Santa wire enum.
+func (s PendingSnapshot) SyncType() syncv1.SyncType {
+	switch {
+	case s.CleanAll:
+		return syncv1.SyncType_CLEAN_ALL
+	case s.FullSync:
 		return syncv1.SyncType_CLEAN
+	default:
+		return syncv1.SyncType_NORMAL
 	}
-	return syncv1.SyncType_NORMAL
 }
 
 // MachineClientModeFromProto maps the Santa client mode enum to the internal
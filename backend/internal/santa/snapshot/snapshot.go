@@ -27,6 +27,7 @@ var ErrPendingSnapshotNotFound = errors.New("pending rule sync snapshot not foun
 // reused for the rest of the sync cycle.
 type PendingSnapshot struct {
 	FullSync         bool
+	CleanAll         bool
 	Payload          []model.SyncRule
 	PayloadRuleCount int64
 }
@@ -53,7 +54,14 @@ func PreparePendingSnapshot(
 	}
 
 	pendingTargets := pendingRuleTargets(resolvedRules)
-	snapshot, write := planPendingSnapshot(state, pendingTargets, request, preparedAt, machineID)
+	snapshot, write := planPendingSnapshot(
+		state,
+		pendingTargets,
+		preflightRuleCounts(request),
+		request.GetRequestCleanSync(),
+		preparedAt,
+		machineID,
+	)
 
 	if err = store.ReplacePendingSnapshot(ctx, write); err != nil {
 		return PendingSnapshot{}, fmt.Errorf("replace pending snapshot: %w", err)
@@ -80,6 +88,7 @@ func LoadPendingSnapshot(
 
 	snapshot := PendingSnapshot{
 		FullSync:         state.PendingFullSync,
+		CleanAll:         state.PendingCleanAll,
 		Payload:          slices.Clone(state.PendingPayload),
 		PayloadRuleCount: state.PendingPayloadRuleCount,
 	}
@@ -87,10 +96,39 @@ func LoadPendingSnapshot(
 	return snapshot, state, nil
 }
 
+// PlanNextSnapshot returns the snapshot a preflight would freeze right now, assuming the machine
+// reports the same rule counts as its last preflight and does not ask for a clean sync itself.
+// Nothing is stored.
+func PlanNextSnapshot(
+	state model.MachineSyncState,
+	resolvedRules []domain.MachineResolvedRule,
+	now time.Time,
+) PendingSnapshot {
+	reportedCounts := domain.ExecutionRuleCounts{
+		Binary:      state.BinaryRuleCount,
+		Certificate: state.CertificateRuleCount,
+		TeamID:      state.TeamIDRuleCount,
+		SigningID:   state.SigningIDRuleCount,
+		CDHash:      state.CDHashRuleCount,
+	}
+
+	snapshot, _ := planPendingSnapshot(
+		state,
+		pendingRuleTargets(resolvedRules),
+		reportedCounts,
+		false,
+		now,
+		state.MachineID,
+	)
+
+	return snapshot
+}
+
 func planPendingSnapshot(
 	state model.MachineSyncState,
 	pendingTargets []model.PendingRuleTarget,
-	request *syncv1.PreflightRequest,
+	reportedCounts domain.ExecutionRuleCounts,
+	clientRequestedCleanSync bool,
 	preparedAt time.Time,
 	machineID uuid.UUID,
 ) (PendingSnapshot, model.PendingSnapshotWrite) {
@@ -100,7 +138,6 @@ func planPendingSnapshot(
 	appliedTargets := sortAppliedRuleTargets(slices.Clone(state.AppliedTargets))
 
 	desiredCounts := pendingRuleTargetCounts(pendingTargets)
-	reportedCounts := preflightRuleCounts(request)
 	reportedCountsMatch := desiredCounts == reportedCounts
 
 	reportedCountsMatchAt := state.LastReportedCountsMatchAt
@@ -109,7 +146,10 @@ func planPendingSnapshot(
 	}
 
 	targetsMatch := slices.Equal(desiredTargets, appliedTargets)
-	fullSync := request.GetRequestCleanSync() || (targetsMatch && !reportedCountsMatch)
+	cleanAll := state.RequestedCleanSync == domain.CleanSyncTypeCleanAll
+	fullSync := clientRequestedCleanSync ||
+		state.RequestedCleanSync != "" ||
+		(targetsMatch && !reportedCountsMatch)
 
 	payload := buildIncrementalPayload(pendingTargets, appliedTargets)
 	if fullSync {
@@ -119,6 +159,7 @@ func planPendingSnapshot(
 	payloadRuleCount := int64(len(payload))
 	snapshot := PendingSnapshot{
 		FullSync:         fullSync,
+		CleanAll:         cleanAll,
 		Payload:          payload,
 		PayloadRuleCount: payloadRuleCount,
 	}
@@ -132,17 +173,19 @@ func planPendingSnapshot(
 		PendingPayload:              slices.Clone(payload),
 		PendingPayloadRuleCount:     payloadRuleCount,
 		PendingFullSync:             fullSync,
+		PendingCleanAll:             cleanAll,
+		PendingCleanSyncRequestedAt: state.RequestedCleanSyncAt,
 		PendingPreflightAt:          preparedAt,
 		DesiredBinaryRuleCount:      desiredCounts.Binary,
 		DesiredCertificateRuleCount: desiredCounts.Certificate,
 		DesiredTeamIDRuleCount:      desiredCounts.TeamID,
 		DesiredSigningIDRuleCount:   desiredCounts.SigningID,
 		DesiredCDHashRuleCount:      desiredCounts.CDHash,
-		BinaryRuleCount:             ClampRuleCount(request.GetBinaryRuleCount()),
-		CertificateRuleCount:        ClampRuleCount(request.GetCertificateRuleCount()),
-		TeamIDRuleCount:             ClampRuleCount(request.GetTeamidRuleCount()),
-		SigningIDRuleCount:          ClampRuleCount(request.GetSigningidRuleCount()),
-		CDHashRuleCount:             ClampRuleCount(request.GetCdhashRuleCount()),
+		BinaryRuleCount:             reportedCounts.Binary,
+		CertificateRuleCount:        reportedCounts.Certificate,
+		TeamIDRuleCount:             reportedCounts.TeamID,
+		SigningIDRuleCount:          reportedCounts.SigningID,
+		CDHashRuleCount:             reportedCounts.CDHash,
 		RulesReceived:               state.RulesReceived,
 		RulesProcessed:              state.RulesProcessed,
 		LastRuleSyncAttemptAt:       state.LastRuleSyncAttemptAt,
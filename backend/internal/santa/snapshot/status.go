@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/santa/model"
+)
+
+// RuleCounts returns the stored desired and reported rule counts in rule type
+// order.
+func RuleCounts(state model.MachineSyncState) []domain.SyncRuleCount {
+	return []domain.SyncRuleCount{
+		{RuleType: domain.RuleTypeBinary, Desired: state.DesiredBinaryRuleCount, Reported: state.BinaryRuleCount},
+		{RuleType: domain.RuleTypeCDHash, Desired: state.DesiredCDHashRuleCount, Reported: state.CDHashRuleCount},
+		{
+			RuleType: domain.RuleTypeCertificate,
+			Desired:  state.DesiredCertificateRuleCount,
+			Reported: state.CertificateRuleCount,
+		},
+		{
+			RuleType: domain.RuleTypeSigningID,
+			Desired:  state.DesiredSigningIDRuleCount,
+			Reported: state.SigningIDRuleCount,
+		},
+		{RuleType: domain.RuleTypeTeamID, Desired: state.DesiredTeamIDRuleCount, Reported: state.TeamIDRuleCount},
+	}
+}
+
+// RuleSyncStatusReason explains the rule sync status the machine_rule_sync_status SQL
+// function reported for a machine's stored sync state.
+func RuleSyncStatusReason(state model.MachineSyncState) string {
+	mismatches := strings.Join(ruleCountMismatches(RuleCounts(state)), ", ")
+
+	switch state.RuleSyncStatus {
+	case domain.MachineRuleSyncStatusSynced:
+		return "Reported rule counts match the desired rules."
+	case domain.MachineRuleSyncStatusIssue:
+		if state.LastCleanSyncAt == nil {
+			return fmt.Sprintf("Reported rule counts still differ after a clean sync: %s.", mismatches)
+		}
+		return fmt.Sprintf(
+			"Reported rule counts still differ after the clean sync at %s: %s.",
+			state.LastCleanSyncAt.UTC().Format(time.RFC3339),
+			mismatches,
+		)
+	case domain.MachineRuleSyncStatusPending:
+		switch {
+		case state.PendingPreflightAt != nil:
+			return fmt.Sprintf(
+				"Waiting for postflight to acknowledge the sync prepared at %s.",
+				state.PendingPreflightAt.UTC().Format(time.RFC3339),
+			)
+		case !slices.Equal(state.DesiredTargets, state.AppliedTargets):
+			return "Desired rules differ from the rules the machine last acknowledged; " +
+				"the next sync will send the difference."
+		default:
+			return fmt.Sprintf(
+				"Reported rule counts differ (%s); the next preflight will request a clean sync.",
+				mismatches,
+			)
+		}
+	default:
+		return ""
+	}
+}
+
+func ruleCountMismatches(counts []domain.SyncRuleCount) []string {
+	var mismatches []string
+	for _, count := range counts {
+		if count.Desired == count.Reported {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf(
+			"%s desired %d reported %d",
+			count.RuleType,
+			count.Desired,
+			count.Reported,
+		))
+	}
+
+	return mismatches
+}
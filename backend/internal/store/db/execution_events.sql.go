@@ -21,7 +21,8 @@ INSERT INTO execution_events (
   executing_user,
   logged_in_users,
   current_sessions,
-  occurred_at
+  occurred_at,
+  executing_user_id
 )
 VALUES (
   $1,
@@ -31,7 +32,8 @@ VALUES (
   $5,
   $6,
   $7,
-  $8
+  $8,
+  $9
 )
 RETURNING
   id,
@@ -43,7 +45,8 @@ RETURNING
   logged_in_users,
   current_sessions,
   occurred_at,
-  created_at
+  created_at,
+  executing_user_id
 `
 
 type CreateExecutionEventParams struct {
@@ -55,6 +58,7 @@ type CreateExecutionEventParams struct {
 	LoggedInUsers   []string
 	CurrentSessions []string
 	OccurredAt      *time.Time
+	ExecutingUserID *uuid.UUID
 }
 
 func (q *Queries) CreateExecutionEvent(ctx context.Context, arg CreateExecutionEventParams) (ExecutionEvent, error) {
@@ -67,6 +71,7 @@ func (q *Queries) CreateExecutionEvent(ctx context.Context, arg CreateExecutionE
 		arg.LoggedInUsers,
 		arg.CurrentSessions,
 		arg.OccurredAt,
+		arg.ExecutingUserID,
 	)
 	var i ExecutionEvent
 	err := row.Scan(
@@ -80,6 +85,7 @@ func (q *Queries) CreateExecutionEvent(ctx context.Context, arg CreateExecutionE
 		&i.CurrentSessions,
 		&i.OccurredAt,
 		&i.CreatedAt,
+		&i.ExecutingUserID,
 	)
 	return i, err
 }
@@ -127,7 +133,14 @@ SELECT
   x.signing_chain,
   x.entitlements,
   ee.occurred_at,
-  ee.created_at
+  ee.created_at,
+  ee.executing_user_id,
+  ARRAY(
+    SELECT eelu.user_id
+    FROM execution_event_logged_in_users AS eelu
+    WHERE eelu.execution_event_id = ee.id
+    ORDER BY eelu.user_id
+  )::UUID[] AS logged_in_user_ids
 FROM execution_events AS ee
 JOIN executables AS x
   ON x.id = ee.executable_id
@@ -154,6 +167,8 @@ type GetExecutionEventRow struct {
 	Entitlements    []byte
 	OccurredAt      *time.Time
 	CreatedAt       time.Time
+	ExecutingUserID *uuid.UUID
+	LoggedInUserIds []uuid.UUID
 }
 
 func (q *Queries) GetExecutionEvent(ctx context.Context, id uuid.UUID) (GetExecutionEventRow, error) {
@@ -179,6 +194,29 @@ func (q *Queries) GetExecutionEvent(ctx context.Context, id uuid.UUID) (GetExecu
 		&i.Entitlements,
 		&i.OccurredAt,
 		&i.CreatedAt,
+		&i.ExecutingUserID,
+		&i.LoggedInUserIds,
 	)
 	return i, err
 }
+
+const insertExecutionEventLoggedInUsers = `-- name: InsertExecutionEventLoggedInUsers :exec
+INSERT INTO execution_event_logged_in_users (
+  execution_event_id,
+  user_id
+)
+SELECT
+  UNNEST($1::UUID[]),
+  UNNEST($2::UUID[])
+ON CONFLICT DO NOTHING
+`
+
+type InsertExecutionEventLoggedInUsersParams struct {
+	ExecutionEventIds []uuid.UUID
+	UserIds           []uuid.UUID
+}
+
+func (q *Queries) InsertExecutionEventLoggedInUsers(ctx context.Context, arg InsertExecutionEventLoggedInUsersParams) error {
+	_, err := q.db.Exec(ctx, insertExecutionEventLoggedInUsers, arg.ExecutionEventIds, arg.UserIds)
+	return err
+}
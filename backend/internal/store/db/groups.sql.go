@@ -46,6 +46,8 @@ SELECT
   g.name,
   g.description,
   g.source,
+  g.machine_criteria,
+  g.user_criteria,
   (
     SELECT COUNT(*)::INT4
     FROM group_memberships AS gm
@@ -58,13 +60,15 @@ WHERE g.id = $1
 `
 
 type GetGroupRow struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Source      PrincipalSource
-	MemberCount int32
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	Source          PrincipalSource
+	MachineCriteria []byte
+	UserCriteria    []byte
+	MemberCount     int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func (q *Queries) GetGroup(ctx context.Context, id uuid.UUID) (GetGroupRow, error) {
@@ -75,6 +79,8 @@ func (q *Queries) GetGroup(ctx context.Context, id uuid.UUID) (GetGroupRow, erro
 		&i.Name,
 		&i.Description,
 		&i.Source,
+		&i.MachineCriteria,
+		&i.UserCriteria,
 		&i.MemberCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -82,6 +88,39 @@ func (q *Queries) GetGroup(ctx context.Context, id uuid.UUID) (GetGroupRow, erro
 	return i, err
 }
 
+const listGroupNamesByIDs = `-- name: ListGroupNamesByIDs :many
+SELECT
+  g.id,
+  g.name
+FROM groups AS g
+WHERE g.id = ANY($1::UUID[])
+`
+
+type ListGroupNamesByIDsRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) ListGroupNamesByIDs(ctx context.Context, ids []uuid.UUID) ([]ListGroupNamesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, listGroupNamesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGroupNamesByIDsRow
+	for rows.Next() {
+		var i ListGroupNamesByIDsRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGroups = `-- name: ListGroups :many
 SELECT
   id,
@@ -101,15 +140,24 @@ type ListGroupsParams struct {
 	LimitCount  int32
 }
 
-func (q *Queries) ListGroups(ctx context.Context, arg ListGroupsParams) ([]Group, error) {
+type ListGroupsRow struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Source      PrincipalSource
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) ListGroups(ctx context.Context, arg ListGroupsParams) ([]ListGroupsRow, error) {
 	rows, err := q.db.Query(ctx, listGroups, arg.OffsetCount, arg.LimitCount)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Group
+	var items []ListGroupsRow
 	for rows.Next() {
-		var i Group
+		var i ListGroupsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -138,7 +186,9 @@ updated AS (
   UPDATE groups AS g
   SET
     name = $2,
-    description = $3
+    description = $3,
+    machine_criteria = $4,
+    user_criteria = $5
   WHERE g.id = $1
     AND g.source = 'local'
   RETURNING
@@ -146,6 +196,8 @@ updated AS (
     g.name,
     g.description,
     g.source,
+    g.machine_criteria,
+    g.user_criteria,
     (
       SELECT COUNT(*)::INT4
       FROM group_memberships AS gm
@@ -164,6 +216,8 @@ SELECT
   u.name,
   u.description,
   u.source,
+  u.machine_criteria,
+  u.user_criteria,
   COALESCE(u.member_count, 0)::INT4 AS member_count,
   u.created_at,
   u.updated_at
@@ -173,24 +227,34 @@ LEFT JOIN updated AS u
 `
 
 type UpdateGroupParams struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	MachineCriteria []byte
+	UserCriteria    []byte
 }
 
 type UpdateGroupRow struct {
-	Status      string
-	ID          *uuid.UUID
-	Name        pgtype.Text
-	Description pgtype.Text
-	Source      NullPrincipalSource
-	MemberCount int32
-	CreatedAt   *time.Time
-	UpdatedAt   *time.Time
+	Status          string
+	ID              *uuid.UUID
+	Name            pgtype.Text
+	Description     pgtype.Text
+	Source          NullPrincipalSource
+	MachineCriteria []byte
+	UserCriteria    []byte
+	MemberCount     int32
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
 }
 
 func (q *Queries) UpdateGroup(ctx context.Context, arg UpdateGroupParams) (UpdateGroupRow, error) {
-	row := q.db.QueryRow(ctx, updateGroup, arg.ID, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, updateGroup,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.MachineCriteria,
+		arg.UserCriteria,
+	)
 	var i UpdateGroupRow
 	err := row.Scan(
 		&i.Status,
@@ -198,6 +262,8 @@ func (q *Queries) UpdateGroup(ctx context.Context, arg UpdateGroupParams) (Updat
 		&i.Name,
 		&i.Description,
 		&i.Source,
+		&i.MachineCriteria,
+		&i.UserCriteria,
 		&i.MemberCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -228,6 +294,8 @@ RETURNING
   name,
   description,
   source,
+  machine_criteria,
+  user_criteria,
   0::INT4 AS member_count,
   created_at,
   updated_at
@@ -241,13 +309,15 @@ type UpsertGroupParams struct {
 }
 
 type UpsertGroupRow struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Source      PrincipalSource
-	MemberCount int32
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	Source          PrincipalSource
+	MachineCriteria []byte
+	UserCriteria    []byte
+	MemberCount     int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func (q *Queries) UpsertGroup(ctx context.Context, arg UpsertGroupParams) (UpsertGroupRow, error) {
@@ -263,6 +333,8 @@ func (q *Queries) UpsertGroup(ctx context.Context, arg UpsertGroupParams) (Upser
 		&i.Name,
 		&i.Description,
 		&i.Source,
+		&i.MachineCriteria,
+		&i.UserCriteria,
 		&i.MemberCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
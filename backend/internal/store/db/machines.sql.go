@@ -22,6 +22,16 @@ func (q *Queries) DeleteMachine(ctx context.Context, machineID uuid.UUID) error
 	return err
 }
 
+const deleteMachinePrimaryUserOverride = `-- name: DeleteMachinePrimaryUserOverride :exec
+DELETE FROM machine_primary_user_overrides
+WHERE machine_id = $1
+`
+
+func (q *Queries) DeleteMachinePrimaryUserOverride(ctx context.Context, machineID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteMachinePrimaryUserOverride, machineID)
+	return err
+}
+
 const getMachine = `-- name: GetMachine :one
 SELECT
   m.id,
@@ -51,6 +61,7 @@ SELECT
     ms.last_reported_counts_match_at
   ) AS rule_sync_status,
   m.client_mode,
+  m.tags,
   COALESCE(ms.binary_rule_count, 0)::INT4 AS binary_rule_count,
   COALESCE(ms.certificate_rule_count, 0)::INT4 AS certificate_rule_count,
   COALESCE(ms.teamid_rule_count, 0)::INT4 AS teamid_rule_count,
@@ -59,36 +70,43 @@ SELECT
   m.last_seen_at,
   m.created_at,
   m.updated_at,
-  u.id AS primary_user_id
+  m.primary_user_id,
+  COALESCE(u.upn, '')::TEXT AS effective_primary_user,
+  (o.machine_id IS NOT NULL)::BOOL AS primary_user_overridden
 FROM machines AS m
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
 LEFT JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
+  ON u.id = m.primary_user_id
+LEFT JOIN machine_primary_user_overrides AS o
+  ON o.machine_id = m.id
 WHERE m.id = $1
 `
 
 type GetMachineRow struct {
-	ID                   uuid.UUID
-	SerialNumber         string
-	Hostname             string
-	ModelIdentifier      string
-	OsVersion            string
-	OsBuild              string
-	SantaVersion         string
-	PrimaryUser          string
-	PrimaryUserGroups    []string
-	RuleSyncStatus       string
-	ClientMode           SantaClientMode
-	BinaryRuleCount      int32
-	CertificateRuleCount int32
-	TeamIDRuleCount      int32
-	SigningIDRuleCount   int32
-	CDHashRuleCount      int32
-	LastSeenAt           time.Time
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
-	PrimaryUserID        *uuid.UUID
+	ID                    uuid.UUID
+	SerialNumber          string
+	Hostname              string
+	ModelIdentifier       string
+	OsVersion             string
+	OsBuild               string
+	SantaVersion          string
+	PrimaryUser           string
+	PrimaryUserGroups     []string
+	RuleSyncStatus        string
+	ClientMode            SantaClientMode
+	Tags                  []string
+	BinaryRuleCount       int32
+	CertificateRuleCount  int32
+	TeamIDRuleCount       int32
+	SigningIDRuleCount    int32
+	CDHashRuleCount       int32
+	LastSeenAt            time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	PrimaryUserID         *uuid.UUID
+	EffectivePrimaryUser  string
+	PrimaryUserOverridden bool
 }
 
 func (q *Queries) GetMachine(ctx context.Context, machineID uuid.UUID) (GetMachineRow, error) {
@@ -106,6 +124,7 @@ func (q *Queries) GetMachine(ctx context.Context, machineID uuid.UUID) (GetMachi
 		&i.PrimaryUserGroups,
 		&i.RuleSyncStatus,
 		&i.ClientMode,
+		&i.Tags,
 		&i.BinaryRuleCount,
 		&i.CertificateRuleCount,
 		&i.TeamIDRuleCount,
@@ -115,10 +134,99 @@ func (q *Queries) GetMachine(ctx context.Context, machineID uuid.UUID) (GetMachi
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PrimaryUserID,
+		&i.EffectivePrimaryUser,
+		&i.PrimaryUserOverridden,
 	)
 	return i, err
 }
 
+const listEffectiveGroupMachines = `-- name: ListEffectiveGroupMachines :many
+WITH direct_group_machines AS (
+  SELECT
+    gmm.group_id,
+    gmm.machine_id
+  FROM group_machine_memberships AS gmm
+  WHERE NOT gmm.pending
+
+  UNION
+
+  SELECT
+    gum.group_id,
+    m.id AS machine_id
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+)
+SELECT DISTINCT
+  ga.ancestor_id AS group_id,
+  dgm.machine_id
+FROM direct_group_machines AS dgm
+JOIN group_ancestors AS ga
+  ON ga.group_id = dgm.group_id
+WHERE $1::UUID[] IS NULL
+  OR ga.ancestor_id = ANY($1::UUID[])
+ORDER BY ga.ancestor_id ASC, dgm.machine_id ASC
+`
+
+type ListEffectiveGroupMachinesRow struct {
+	GroupID   uuid.UUID
+	MachineID uuid.UUID
+}
+
+func (q *Queries) ListEffectiveGroupMachines(ctx context.Context, groupIds []uuid.UUID) ([]ListEffectiveGroupMachinesRow, error) {
+	rows, err := q.db.Query(ctx, listEffectiveGroupMachines, groupIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEffectiveGroupMachinesRow
+	for rows.Next() {
+		var i ListEffectiveGroupMachinesRow
+		if err := rows.Scan(&i.GroupID, &i.MachineID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMachineHostnamesByIDs = `-- name: ListMachineHostnamesByIDs :many
+SELECT
+  m.id,
+  m.hostname
+FROM machines AS m
+WHERE m.id = ANY($1::UUID[])
+`
+
+type ListMachineHostnamesByIDsRow struct {
+	ID       uuid.UUID
+	Hostname string
+}
+
+func (q *Queries) ListMachineHostnamesByIDs(ctx context.Context, ids []uuid.UUID) ([]ListMachineHostnamesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, listMachineHostnamesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMachineHostnamesByIDsRow
+	for rows.Next() {
+		var i ListMachineHostnamesByIDsRow
+		if err := rows.Scan(&i.ID, &i.Hostname); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMachineIDs = `-- name: ListMachineIDs :many
 SELECT id
 FROM machines
@@ -145,12 +253,82 @@ func (q *Queries) ListMachineIDs(ctx context.Context) ([]uuid.UUID, error) {
 	return items, nil
 }
 
+const listMachineIDsByEffectiveGroupID = `-- name: ListMachineIDsByEffectiveGroupID :many
+WITH member_groups AS (
+  SELECT ga.group_id
+  FROM group_ancestors AS ga
+  WHERE ga.ancestor_id = $1
+)
+SELECT gmm.machine_id AS id
+FROM group_machine_memberships AS gmm
+JOIN member_groups AS mg
+  ON mg.group_id = gmm.group_id
+WHERE NOT gmm.pending
+
+UNION
+
+SELECT m.id
+FROM machines AS m
+JOIN group_user_memberships AS gum
+  ON gum.user_id = m.primary_user_id
+  AND NOT gum.pending
+JOIN member_groups AS mg
+  ON mg.group_id = gum.group_id
+
+ORDER BY id ASC
+`
+
+func (q *Queries) ListMachineIDsByEffectiveGroupID(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listMachineIDsByEffectiveGroupID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMachineIDsByIDs = `-- name: ListMachineIDsByIDs :many
+SELECT id
+FROM machines
+WHERE id = ANY($1::UUID[])
+ORDER BY id ASC
+`
+
+func (q *Queries) ListMachineIDsByIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listMachineIDsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMachineIDsByPrimaryUserID = `-- name: ListMachineIDsByPrimaryUserID :many
 SELECT m.id
 FROM machines AS m
-JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
-WHERE u.id = $1
+WHERE m.primary_user_id = $1::UUID
 ORDER BY m.id ASC
 `
 
@@ -174,6 +352,33 @@ func (q *Queries) ListMachineIDsByPrimaryUserID(ctx context.Context, primaryUser
 	return items, nil
 }
 
+const listMachineIDsByPrimaryUserIDs = `-- name: ListMachineIDsByPrimaryUserIDs :many
+SELECT m.id
+FROM machines AS m
+WHERE m.primary_user_id = ANY($1::UUID[])
+ORDER BY m.id ASC
+`
+
+func (q *Queries) ListMachineIDsByPrimaryUserIDs(ctx context.Context, userIds []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listMachineIDsByPrimaryUserIDs, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMachines = `-- name: ListMachines :many
 SELECT
   m.id,
@@ -188,10 +393,8 @@ SELECT
   m.last_seen_at,
   m.created_at,
   m.updated_at,
-  u.id AS primary_user_id
+  m.primary_user_id
 FROM machines AS m
-LEFT JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
 ORDER BY m.last_seen_at DESC
 LIMIT $2
 OFFSET $1
@@ -252,6 +455,41 @@ func (q *Queries) ListMachines(ctx context.Context, arg ListMachinesParams) ([]L
 	return items, nil
 }
 
+const listPrimaryUserMachines = `-- name: ListPrimaryUserMachines :many
+SELECT
+  m.primary_user_id::UUID AS user_id,
+  m.id AS machine_id
+FROM machines AS m
+WHERE m.primary_user_id IS NOT NULL
+  AND ($1::UUID[] IS NULL OR m.primary_user_id = ANY($1::UUID[]))
+ORDER BY m.id ASC
+`
+
+type ListPrimaryUserMachinesRow struct {
+	UserID    uuid.UUID
+	MachineID uuid.UUID
+}
+
+func (q *Queries) ListPrimaryUserMachines(ctx context.Context, userIds []uuid.UUID) ([]ListPrimaryUserMachinesRow, error) {
+	rows, err := q.db.Query(ctx, listPrimaryUserMachines, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPrimaryUserMachinesRow
+	for rows.Next() {
+		var i ListPrimaryUserMachinesRow
+		if err := rows.Scan(&i.UserID, &i.MachineID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertMachine = `-- name: UpsertMachine :one
 INSERT INTO machines (
   id,
@@ -321,7 +559,23 @@ type UpsertMachineParams struct {
 	LastSeenAt        time.Time
 }
 
-func (q *Queries) UpsertMachine(ctx context.Context, arg UpsertMachineParams) (Machine, error) {
+type UpsertMachineRow struct {
+	ID                uuid.UUID
+	SerialNumber      string
+	Hostname          string
+	ModelIdentifier   string
+	OsVersion         string
+	OsBuild           string
+	SantaVersion      string
+	PrimaryUser       string
+	PrimaryUserGroups []string
+	ClientMode        SantaClientMode
+	LastSeenAt        time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (q *Queries) UpsertMachine(ctx context.Context, arg UpsertMachineParams) (UpsertMachineRow, error) {
 	row := q.db.QueryRow(ctx, upsertMachine,
 		arg.MachineID,
 		arg.SerialNumber,
@@ -335,7 +589,7 @@ func (q *Queries) UpsertMachine(ctx context.Context, arg UpsertMachineParams) (M
 		arg.ClientMode,
 		arg.LastSeenAt,
 	)
-	var i Machine
+	var i UpsertMachineRow
 	err := row.Scan(
 		&i.ID,
 		&i.SerialNumber,
@@ -353,3 +607,28 @@ func (q *Queries) UpsertMachine(ctx context.Context, arg UpsertMachineParams) (M
 	)
 	return i, err
 }
+
+const upsertMachinePrimaryUserOverride = `-- name: UpsertMachinePrimaryUserOverride :exec
+INSERT INTO machine_primary_user_overrides (
+  machine_id,
+  user_id
+)
+VALUES (
+  $1,
+  $2
+)
+ON CONFLICT (machine_id) DO UPDATE
+SET
+  user_id = EXCLUDED.user_id,
+  updated_at = NOW()
+`
+
+type UpsertMachinePrimaryUserOverrideParams struct {
+	MachineID uuid.UUID
+	UserID    *uuid.UUID
+}
+
+func (q *Queries) UpsertMachinePrimaryUserOverride(ctx context.Context, arg UpsertMachinePrimaryUserOverrideParams) error {
+	_, err := q.db.Exec(ctx, upsertMachinePrimaryUserOverride, arg.MachineID, arg.UserID)
+	return err
+}
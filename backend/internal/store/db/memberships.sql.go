@@ -12,6 +12,73 @@ import (
 	uuid "github.com/google/uuid"
 )
 
+const activateDueMemberships = `-- name: ActivateDueMemberships :many
+WITH activated_users AS (
+  UPDATE group_user_memberships AS gum
+  SET pending = FALSE
+  WHERE gum.pending
+    AND gum.starts_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'user'::membership_member_kind AS member_kind,
+    gum.user_id AS member_id
+),
+activated_machines AS (
+  UPDATE group_machine_memberships AS gmm
+  SET pending = FALSE
+  WHERE gmm.pending
+    AND gmm.starts_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'machine'::membership_member_kind AS member_kind,
+    gmm.machine_id AS member_id
+),
+activated_groups AS (
+  UPDATE group_group_memberships AS ggm
+  SET pending = FALSE
+  WHERE ggm.pending
+    AND ggm.starts_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'group'::membership_member_kind AS member_kind,
+    ggm.member_group_id AS member_id
+)
+SELECT a.member_kind, a.member_id
+FROM activated_users AS a
+
+UNION
+
+SELECT am.member_kind, am.member_id
+FROM activated_machines AS am
+
+UNION
+
+SELECT ag.member_kind, ag.member_id
+FROM activated_groups AS ag
+`
+
+type ActivateDueMembershipsRow struct {
+	MemberKind MembershipMemberKind
+	MemberID   uuid.UUID
+}
+
+func (q *Queries) ActivateDueMemberships(ctx context.Context, now time.Time) ([]ActivateDueMembershipsRow, error) {
+	rows, err := q.db.Query(ctx, activateDueMemberships, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ActivateDueMembershipsRow
+	for rows.Next() {
+		var i ActivateDueMembershipsRow
+		if err := rows.Scan(&i.MemberKind, &i.MemberID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const addSyncedUserMembership = `-- name: AddSyncedUserMembership :exec
 INSERT INTO group_user_memberships (
   id,
@@ -41,18 +108,91 @@ func (q *Queries) AddSyncedUserMembership(ctx context.Context, arg AddSyncedUser
 	return err
 }
 
+const createGroupMembership = `-- name: CreateGroupMembership :one
+INSERT INTO group_group_memberships (
+  id,
+  group_id,
+  member_group_id,
+  origin,
+  starts_at,
+  expires_at,
+  pending
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  COALESCE($5::TIMESTAMPTZ > NOW(), FALSE)
+)
+RETURNING
+  id,
+  group_id,
+  member_group_id,
+  origin,
+  created_at,
+  updated_at
+`
+
+type CreateGroupMembershipParams struct {
+	ID            uuid.UUID
+	GroupID       uuid.UUID
+	MemberGroupID uuid.UUID
+	Origin        MembershipOrigin
+	StartsAt      *time.Time
+	ExpiresAt     *time.Time
+}
+
+type CreateGroupMembershipRow struct {
+	ID            uuid.UUID
+	GroupID       uuid.UUID
+	MemberGroupID uuid.UUID
+	Origin        MembershipOrigin
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) CreateGroupMembership(ctx context.Context, arg CreateGroupMembershipParams) (CreateGroupMembershipRow, error) {
+	row := q.db.QueryRow(ctx, createGroupMembership,
+		arg.ID,
+		arg.GroupID,
+		arg.MemberGroupID,
+		arg.Origin,
+		arg.StartsAt,
+		arg.ExpiresAt,
+	)
+	var i CreateGroupMembershipRow
+	err := row.Scan(
+		&i.ID,
+		&i.GroupID,
+		&i.MemberGroupID,
+		&i.Origin,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createMachineMembership = `-- name: CreateMachineMembership :one
 INSERT INTO group_machine_memberships (
   id,
   group_id,
   machine_id,
-  origin
+  origin,
+  starts_at,
+  expires_at,
+  pending
 )
 VALUES (
   $1,
   $2,
   $3,
-  $4
+  $4,
+  $5,
+  $6,
+  COALESCE($5::TIMESTAMPTZ > NOW(), FALSE)
 )
 RETURNING
   id,
@@ -68,16 +208,29 @@ type CreateMachineMembershipParams struct {
 	GroupID   uuid.UUID
 	MachineID uuid.UUID
 	Origin    MembershipOrigin
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
 }
 
-func (q *Queries) CreateMachineMembership(ctx context.Context, arg CreateMachineMembershipParams) (GroupMachineMembership, error) {
+type CreateMachineMembershipRow struct {
+	ID        uuid.UUID
+	GroupID   uuid.UUID
+	MachineID uuid.UUID
+	Origin    MembershipOrigin
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (q *Queries) CreateMachineMembership(ctx context.Context, arg CreateMachineMembershipParams) (CreateMachineMembershipRow, error) {
 	row := q.db.QueryRow(ctx, createMachineMembership,
 		arg.ID,
 		arg.GroupID,
 		arg.MachineID,
 		arg.Origin,
+		arg.StartsAt,
+		arg.ExpiresAt,
 	)
-	var i GroupMachineMembership
+	var i CreateMachineMembershipRow
 	err := row.Scan(
 		&i.ID,
 		&i.GroupID,
@@ -94,13 +247,19 @@ INSERT INTO group_user_memberships (
   id,
   group_id,
   user_id,
-  origin
+  origin,
+  starts_at,
+  expires_at,
+  pending
 )
 VALUES (
   $1,
   $2,
   $3,
-  $4
+  $4,
+  $5,
+  $6,
+  COALESCE($5::TIMESTAMPTZ > NOW(), FALSE)
 )
 RETURNING
   id,
@@ -112,20 +271,33 @@ RETURNING
 `
 
 type CreateUserMembershipParams struct {
-	ID      uuid.UUID
-	GroupID uuid.UUID
-	UserID  uuid.UUID
-	Origin  MembershipOrigin
+	ID        uuid.UUID
+	GroupID   uuid.UUID
+	UserID    uuid.UUID
+	Origin    MembershipOrigin
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
+}
+
+type CreateUserMembershipRow struct {
+	ID        uuid.UUID
+	GroupID   uuid.UUID
+	UserID    uuid.UUID
+	Origin    MembershipOrigin
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
-func (q *Queries) CreateUserMembership(ctx context.Context, arg CreateUserMembershipParams) (GroupUserMembership, error) {
+func (q *Queries) CreateUserMembership(ctx context.Context, arg CreateUserMembershipParams) (CreateUserMembershipRow, error) {
 	row := q.db.QueryRow(ctx, createUserMembership,
 		arg.ID,
 		arg.GroupID,
 		arg.UserID,
 		arg.Origin,
+		arg.StartsAt,
+		arg.ExpiresAt,
 	)
-	var i GroupUserMembership
+	var i CreateUserMembershipRow
 	err := row.Scan(
 		&i.ID,
 		&i.GroupID,
@@ -137,6 +309,99 @@ func (q *Queries) CreateUserMembership(ctx context.Context, arg CreateUserMember
 	return i, err
 }
 
+const deleteExpiredMemberships = `-- name: DeleteExpiredMemberships :many
+WITH expired_users AS (
+  DELETE FROM group_user_memberships AS gum
+  WHERE gum.expires_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'user'::membership_member_kind AS member_kind,
+    gum.user_id AS member_id
+),
+expired_machines AS (
+  DELETE FROM group_machine_memberships AS gmm
+  WHERE gmm.expires_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'machine'::membership_member_kind AS member_kind,
+    gmm.machine_id AS member_id
+),
+expired_groups AS (
+  DELETE FROM group_group_memberships AS ggm
+  WHERE ggm.expires_at <= $1::TIMESTAMPTZ
+  RETURNING
+    'group'::membership_member_kind AS member_kind,
+    ggm.member_group_id AS member_id
+)
+SELECT eu.member_kind, eu.member_id
+FROM expired_users AS eu
+
+UNION
+
+SELECT em.member_kind, em.member_id
+FROM expired_machines AS em
+
+UNION
+
+SELECT eg.member_kind, eg.member_id
+FROM expired_groups AS eg
+`
+
+type DeleteExpiredMembershipsRow struct {
+	MemberKind MembershipMemberKind
+	MemberID   uuid.UUID
+}
+
+func (q *Queries) DeleteExpiredMemberships(ctx context.Context, now time.Time) ([]DeleteExpiredMembershipsRow, error) {
+	rows, err := q.db.Query(ctx, deleteExpiredMemberships, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeleteExpiredMembershipsRow
+	for rows.Next() {
+		var i DeleteExpiredMembershipsRow
+		if err := rows.Scan(&i.MemberKind, &i.MemberID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteGroupMembership = `-- name: DeleteGroupMembership :execrows
+DELETE FROM group_group_memberships
+WHERE id = $1
+`
+
+func (q *Queries) DeleteGroupMembership(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteGroupMembership, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteGroupMembershipByMember = `-- name: DeleteGroupMembershipByMember :execrows
+DELETE FROM group_group_memberships
+WHERE group_id = $1
+  AND member_group_id = $2
+`
+
+type DeleteGroupMembershipByMemberParams struct {
+	GroupID       uuid.UUID
+	MemberGroupID uuid.UUID
+}
+
+func (q *Queries) DeleteGroupMembershipByMember(ctx context.Context, arg DeleteGroupMembershipByMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteGroupMembershipByMember, arg.GroupID, arg.MemberGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteMachineMembership = `-- name: DeleteMachineMembership :execrows
 DELETE FROM group_machine_memberships
 WHERE id = $1
@@ -150,6 +415,25 @@ func (q *Queries) DeleteMachineMembership(ctx context.Context, id uuid.UUID) (in
 	return result.RowsAffected(), nil
 }
 
+const deleteMachineMembershipByMember = `-- name: DeleteMachineMembershipByMember :execrows
+DELETE FROM group_machine_memberships
+WHERE group_id = $1
+  AND machine_id = $2
+`
+
+type DeleteMachineMembershipByMemberParams struct {
+	GroupID   uuid.UUID
+	MachineID uuid.UUID
+}
+
+func (q *Queries) DeleteMachineMembershipByMember(ctx context.Context, arg DeleteMachineMembershipByMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteMachineMembershipByMember, arg.GroupID, arg.MachineID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteUserMembership = `-- name: DeleteUserMembership :execrows
 DELETE FROM group_user_memberships
 WHERE id = $1
@@ -163,6 +447,25 @@ func (q *Queries) DeleteUserMembership(ctx context.Context, id uuid.UUID) (int64
 	return result.RowsAffected(), nil
 }
 
+const deleteUserMembershipByMember = `-- name: DeleteUserMembershipByMember :execrows
+DELETE FROM group_user_memberships
+WHERE group_id = $1
+  AND user_id = $2
+`
+
+type DeleteUserMembershipByMemberParams struct {
+	GroupID uuid.UUID
+	UserID  uuid.UUID
+}
+
+func (q *Queries) DeleteUserMembershipByMember(ctx context.Context, arg DeleteUserMembershipByMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserMembershipByMember, arg.GroupID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getPersistedMembershipView = `-- name: GetPersistedMembershipView :one
 SELECT
   gm.id,
@@ -174,10 +477,15 @@ SELECT
   COALESCE(
     CASE
       WHEN gm.member_kind = 'user' THEN NULLIF(u.display_name, '')
+      WHEN gm.member_kind = 'group' THEN NULLIF(mg.name, '')
       ELSE NULLIF(m.hostname, '')
     END,
     ''
   )::TEXT AS member_name,
+  gm.origin,
+  gm.starts_at,
+  gm.expires_at,
+  gm.pending,
   gm.created_at,
   gm.updated_at
 FROM group_memberships AS gm
@@ -189,6 +497,9 @@ LEFT JOIN users AS u
 LEFT JOIN machines AS m
   ON gm.member_kind = 'machine'
   AND m.id = gm.member_id
+LEFT JOIN groups AS mg
+  ON gm.member_kind = 'group'
+  AND mg.id = gm.member_id
 WHERE gm.id = $1
 `
 
@@ -200,6 +511,10 @@ type GetPersistedMembershipViewRow struct {
 	MemberKind  MembershipMemberKind
 	MemberID    uuid.UUID
 	MemberName  string
+	Origin      MembershipOrigin
+	StartsAt    *time.Time
+	ExpiresAt   *time.Time
+	Pending     bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -215,46 +530,192 @@ func (q *Queries) GetPersistedMembershipView(ctx context.Context, id uuid.UUID)
 		&i.MemberKind,
 		&i.MemberID,
 		&i.MemberName,
+		&i.Origin,
+		&i.StartsAt,
+		&i.ExpiresAt,
+		&i.Pending,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const listEffectiveGroupIDsForMachine = `-- name: ListEffectiveGroupIDsForMachine :many
-SELECT gmm.group_id
-FROM group_machine_memberships AS gmm
-WHERE gmm.machine_id = $1
+const groupHasAncestor = `-- name: GroupHasAncestor :one
+WITH RECURSIVE ancestors (ancestor_id) AS (
+  SELECT $2::UUID
 
-UNION
+  UNION
+
+  SELECT ggm.group_id
+  FROM ancestors AS a
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = a.ancestor_id
+)
+SELECT EXISTS (
+  SELECT 1
+  FROM ancestors
+  WHERE ancestor_id = $1::UUID
+)::BOOLEAN AS has_ancestor
+`
+
+type GroupHasAncestorParams struct {
+	AncestorID uuid.UUID
+	GroupID    uuid.UUID
+}
+
+// Walks pending nestings too, so activating one later cannot close a cycle.
+func (q *Queries) GroupHasAncestor(ctx context.Context, arg GroupHasAncestorParams) (bool, error) {
+	row := q.db.QueryRow(ctx, groupHasAncestor, arg.AncestorID, arg.GroupID)
+	var has_ancestor bool
+	err := row.Scan(&has_ancestor)
+	return has_ancestor, err
+}
 
-SELECT gum.group_id
-FROM machines AS m
-JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
-JOIN group_user_memberships AS gum
-  ON gum.user_id = u.id
-WHERE m.id = $1
+const listEffectiveGroupIDsForMachines = `-- name: ListEffectiveGroupIDsForMachines :many
+WITH direct_groups AS (
+  SELECT
+    gmm.machine_id,
+    gmm.group_id
+  FROM group_machine_memberships AS gmm
+  WHERE gmm.machine_id = ANY($1::UUID[])
+    AND NOT gmm.pending
 
-ORDER BY group_id ASC
+  UNION
+
+  SELECT
+    m.id AS machine_id,
+    gum.group_id
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+  WHERE m.id = ANY($1::UUID[])
+)
+SELECT DISTINCT
+  dg.machine_id,
+  ga.ancestor_id AS group_id
+FROM direct_groups AS dg
+JOIN group_ancestors AS ga
+  ON ga.group_id = dg.group_id
+ORDER BY dg.machine_id ASC, ga.ancestor_id ASC
 `
 
-func (q *Queries) ListEffectiveGroupIDsForMachine(ctx context.Context, machineID uuid.UUID) ([]uuid.UUID, error) {
-	rows, err := q.db.Query(ctx, listEffectiveGroupIDsForMachine, machineID)
+type ListEffectiveGroupIDsForMachinesRow struct {
+	MachineID uuid.UUID
+	GroupID   uuid.UUID
+}
+
+func (q *Queries) ListEffectiveGroupIDsForMachines(ctx context.Context, machineIds []uuid.UUID) ([]ListEffectiveGroupIDsForMachinesRow, error) {
+	rows, err := q.db.Query(ctx, listEffectiveGroupIDsForMachines, machineIds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []uuid.UUID
+	var items []ListEffectiveGroupIDsForMachinesRow
 	for rows.Next() {
-		var group_id uuid.UUID
-		if err := rows.Scan(&group_id); err != nil {
+		var i ListEffectiveGroupIDsForMachinesRow
+		if err := rows.Scan(&i.MachineID, &i.GroupID); err != nil {
 			return nil, err
 		}
-		items = append(items, group_id)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
+
+const listMachineGroupPaths = `-- name: ListMachineGroupPaths :many
+WITH direct_paths AS (
+  SELECT
+    gmm.group_id,
+    'machine'::TEXT AS via,
+    gmm.id AS membership_id,
+    gmm.origin
+  FROM group_machine_memberships AS gmm
+  WHERE gmm.machine_id = $1
+    AND NOT gmm.pending
+
+  UNION ALL
+
+  SELECT
+    gum.group_id,
+    'primary_user'::TEXT AS via,
+    gum.id AS membership_id,
+    gum.origin
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+  WHERE m.id = $1
+)
+SELECT
+  gap.ancestor_id AS group_id,
+  g.name AS group_name,
+  dp.via,
+  dp.membership_id,
+  dp.origin,
+  COALESCE(
+    (
+      SELECT jsonb_agg(
+        jsonb_build_object('id', pg.id, 'name', pg.name, 'source', pg.source)
+        ORDER BY p.ordinality
+      )
+      FROM UNNEST(gap.path) WITH ORDINALITY AS p (group_id, ordinality)
+      JOIN groups AS pg
+        ON pg.id = p.group_id
+    ),
+    '[]'::JSONB
+  )::JSONB AS path
+FROM direct_paths AS dp
+JOIN group_ancestor_paths AS gap
+  ON gap.group_id = dp.group_id
+JOIN groups AS g
+  ON g.id = gap.ancestor_id
+ORDER BY group_name ASC, via ASC, CARDINALITY(gap.path) ASC
+`
+
+type ListMachineGroupPathsRow struct {
+	GroupID      uuid.UUID
+	GroupName    string
+	Via          string
+	MembershipID uuid.UUID
+	Origin       MembershipOrigin
+	Path         []byte
+}
+
+func (q *Queries) ListMachineGroupPaths(ctx context.Context, machineID uuid.UUID) ([]ListMachineGroupPathsRow, error) {
+	rows, err := q.db.Query(ctx, listMachineGroupPaths, machineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMachineGroupPathsRow
+	for rows.Next() {
+		var i ListMachineGroupPathsRow
+		if err := rows.Scan(
+			&i.GroupID,
+			&i.GroupName,
+			&i.Via,
+			&i.MembershipID,
+			&i.Origin,
+			&i.Path,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockGroupMemberships = `-- name: LockGroupMemberships :exec
+LOCK TABLE group_group_memberships IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockGroupMemberships(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockGroupMemberships)
+	return err
+}
@@ -13,6 +13,92 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccessRole string
+
+const (
+	AccessRoleViewer     AccessRole = "viewer"
+	AccessRoleHelpdesk   AccessRole = "helpdesk"
+	AccessRoleRuleEditor AccessRole = "rule_editor"
+	AccessRoleAdmin      AccessRole = "admin"
+)
+
+func (e *AccessRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccessRole(s)
+	case string:
+		*e = AccessRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccessRole: %T", src)
+	}
+	return nil
+}
+
+type NullAccessRole struct {
+	AccessRole AccessRole
+	Valid      bool // Valid is true if AccessRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccessRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccessRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccessRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccessRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccessRole), nil
+}
+
+type CleanSyncType string
+
+const (
+	CleanSyncTypeClean    CleanSyncType = "clean"
+	CleanSyncTypeCleanAll CleanSyncType = "clean_all"
+)
+
+func (e *CleanSyncType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CleanSyncType(s)
+	case string:
+		*e = CleanSyncType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CleanSyncType: %T", src)
+	}
+	return nil
+}
+
+type NullCleanSyncType struct {
+	CleanSyncType CleanSyncType
+	Valid         bool // Valid is true if CleanSyncType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCleanSyncType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CleanSyncType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CleanSyncType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCleanSyncType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CleanSyncType), nil
+}
+
 type ExecutionDecision string
 
 const (
@@ -118,6 +204,7 @@ type MembershipMemberKind string
 const (
 	MembershipMemberKindUser    MembershipMemberKind = "user"
 	MembershipMemberKindMachine MembershipMemberKind = "machine"
+	MembershipMemberKindGroup   MembershipMemberKind = "group"
 )
 
 func (e *MembershipMemberKind) Scan(src interface{}) error {
@@ -160,6 +247,7 @@ type MembershipOrigin string
 const (
 	MembershipOriginExplicit MembershipOrigin = "explicit"
 	MembershipOriginSynced   MembershipOrigin = "synced"
+	MembershipOriginDynamic  MembershipOrigin = "dynamic"
 )
 
 func (e *MembershipOrigin) Scan(src interface{}) error {
@@ -239,6 +327,92 @@ func (ns NullPrincipalSource) Value() (driver.Value, error) {
 	return string(ns.PrincipalSource), nil
 }
 
+type RuleChangeAction string
+
+const (
+	RuleChangeActionCreate RuleChangeAction = "create"
+	RuleChangeActionUpdate RuleChangeAction = "update"
+	RuleChangeActionDelete RuleChangeAction = "delete"
+)
+
+func (e *RuleChangeAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RuleChangeAction(s)
+	case string:
+		*e = RuleChangeAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RuleChangeAction: %T", src)
+	}
+	return nil
+}
+
+type NullRuleChangeAction struct {
+	RuleChangeAction RuleChangeAction
+	Valid            bool // Valid is true if RuleChangeAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRuleChangeAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.RuleChangeAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RuleChangeAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRuleChangeAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RuleChangeAction), nil
+}
+
+type RuleChangeStatus string
+
+const (
+	RuleChangeStatusPending  RuleChangeStatus = "pending"
+	RuleChangeStatusApproved RuleChangeStatus = "approved"
+	RuleChangeStatusRejected RuleChangeStatus = "rejected"
+)
+
+func (e *RuleChangeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RuleChangeStatus(s)
+	case string:
+		*e = RuleChangeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RuleChangeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullRuleChangeStatus struct {
+	RuleChangeStatus RuleChangeStatus
+	Valid            bool // Valid is true if RuleChangeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRuleChangeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.RuleChangeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RuleChangeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRuleChangeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RuleChangeStatus), nil
+}
+
 type RulePolicy string
 
 const (
@@ -331,6 +505,8 @@ const (
 	RuleTargetSubjectKindGroup      RuleTargetSubjectKind = "group"
 	RuleTargetSubjectKindAllDevices RuleTargetSubjectKind = "all_devices"
 	RuleTargetSubjectKindAllUsers   RuleTargetSubjectKind = "all_users"
+	RuleTargetSubjectKindMachine    RuleTargetSubjectKind = "machine"
+	RuleTargetSubjectKindUser       RuleTargetSubjectKind = "user"
 )
 
 func (e *RuleTargetSubjectKind) Scan(src interface{}) error {
@@ -457,6 +633,119 @@ func (ns NullSantaClientMode) Value() (driver.Value, error) {
 	return string(ns.SantaClientMode), nil
 }
 
+type UnblockRequestStatus string
+
+const (
+	UnblockRequestStatusPending  UnblockRequestStatus = "pending"
+	UnblockRequestStatusApproved UnblockRequestStatus = "approved"
+	UnblockRequestStatusRejected UnblockRequestStatus = "rejected"
+)
+
+func (e *UnblockRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UnblockRequestStatus(s)
+	case string:
+		*e = UnblockRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UnblockRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullUnblockRequestStatus struct {
+	UnblockRequestStatus UnblockRequestStatus
+	Valid                bool // Valid is true if UnblockRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUnblockRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.UnblockRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UnblockRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUnblockRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UnblockRequestStatus), nil
+}
+
+type UnblockScope string
+
+const (
+	UnblockScopeUser    UnblockScope = "user"
+	UnblockScopeMachine UnblockScope = "machine"
+	UnblockScopeGroup   UnblockScope = "group"
+)
+
+func (e *UnblockScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UnblockScope(s)
+	case string:
+		*e = UnblockScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UnblockScope: %T", src)
+	}
+	return nil
+}
+
+type NullUnblockScope struct {
+	UnblockScope UnblockScope
+	Valid        bool // Valid is true if UnblockScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUnblockScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.UnblockScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UnblockScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUnblockScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UnblockScope), nil
+}
+
+type ApiToken struct {
+	ID               uuid.UUID
+	ServiceAccountID uuid.UUID
+	Name             string
+	TokenPrefix      string
+	TokenHash        string
+	Scopes           []string
+	ExpiresAt        *time.Time
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+}
+
+type Certificate struct {
+	ID                 uuid.UUID
+	Sha256             string
+	CommonName         string
+	Organization       string
+	OrganizationalUnit string
+	ValidFrom          time.Time
+	ValidUntil         time.Time
+	FirstSeenAt        time.Time
+	LastSeenAt         time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
 type Executable struct {
 	ID             uuid.UUID
 	FileSHA256     string
@@ -471,6 +760,23 @@ type Executable struct {
 	CreatedAt      time.Time
 }
 
+type ExecutableCertificate struct {
+	ExecutableID  uuid.UUID
+	CertificateID uuid.UUID
+	ChainPosition int32
+}
+
+type ExecutableObservation struct {
+	ExecutableID   uuid.UUID
+	MachineID      uuid.UUID
+	FirstSeenAt    time.Time
+	LastSeenAt     time.Time
+	EventCount     int32
+	ExecutingUsers []string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
 type ExecutionEvent struct {
 	ID              uuid.UUID
 	MachineID       uuid.UUID
@@ -482,6 +788,12 @@ type ExecutionEvent struct {
 	CurrentSessions []string
 	OccurredAt      *time.Time
 	CreatedAt       time.Time
+	ExecutingUserID *uuid.UUID
+}
+
+type ExecutionEventLoggedInUser struct {
+	ExecutionEventID uuid.UUID
+	UserID           uuid.UUID
 }
 
 type FileAccessEvent struct {
@@ -497,12 +809,37 @@ type FileAccessEvent struct {
 }
 
 type Group struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Source      PrincipalSource
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	Source          PrincipalSource
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	MachineCriteria []byte
+	UserCriteria    []byte
+}
+
+type GroupAncestor struct {
+	GroupID    uuid.UUID
+	AncestorID uuid.UUID
+}
+
+type GroupAncestorPath struct {
+	GroupID    uuid.UUID
+	AncestorID uuid.UUID
+	Path       []uuid.UUID
+}
+
+type GroupGroupMembership struct {
+	ID            uuid.UUID
+	GroupID       uuid.UUID
+	MemberGroupID uuid.UUID
+	Origin        MembershipOrigin
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	StartsAt      *time.Time
+	ExpiresAt     *time.Time
+	Pending       bool
 }
 
 type GroupMachineMembership struct {
@@ -512,6 +849,17 @@ type GroupMachineMembership struct {
 	Origin    MembershipOrigin
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
+	Pending   bool
+}
+
+type GroupRoleMapping struct {
+	ID        uuid.UUID
+	GroupID   uuid.UUID
+	Role      AccessRole
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type GroupUserMembership struct {
@@ -521,6 +869,17 @@ type GroupUserMembership struct {
 	Origin    MembershipOrigin
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
+	Pending   bool
+}
+
+type LocalGroupMapping struct {
+	ID         uuid.UUID
+	LocalGroup string
+	GroupID    uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type Machine struct {
@@ -537,6 +896,15 @@ type Machine struct {
 	LastSeenAt        time.Time
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
+	Tags              []string
+	PrimaryUserID     *uuid.UUID
+}
+
+type MachinePrimaryUserOverride struct {
+	MachineID uuid.UUID
+	UserID    *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type MachineSyncState struct {
@@ -567,6 +935,10 @@ type MachineSyncState struct {
 	LastReportedCountsMatchAt   *time.Time
 	CreatedAt                   time.Time
 	UpdatedAt                   time.Time
+	RequestedCleanSync          NullCleanSyncType
+	RequestedCleanSyncAt        *time.Time
+	PendingCleanAll             bool
+	PendingCleanSyncRequestedAt *time.Time
 }
 
 type Membership struct {
@@ -577,6 +949,9 @@ type Membership struct {
 	Origin     MembershipOrigin
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	StartsAt   *time.Time
+	ExpiresAt  *time.Time
+	Pending    bool
 }
 
 type Rule struct {
@@ -592,24 +967,96 @@ type Rule struct {
 	UpdatedAt     time.Time
 }
 
+type RuleChangeProposal struct {
+	ID             uuid.UUID
+	Action         RuleChangeAction
+	Status         RuleChangeStatus
+	RuleID         *uuid.UUID
+	RuleName       string
+	BaseUpdatedAt  *time.Time
+	CurrentRule    []byte
+	ProposedRule   []byte
+	ProposedBy     string
+	ProposedByName string
+	ReviewedBy     string
+	ReviewedByName string
+	ReviewComment  string
+	ReviewedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type RuleHit struct {
+	RuleID     uuid.UUID
+	MachineID  uuid.UUID
+	FirstHitAt time.Time
+	LastHitAt  time.Time
+	HitCount   int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
 type RuleTarget struct {
-	ID            uuid.UUID
-	RuleID        uuid.UUID
-	SubjectKind   RuleTargetSubjectKind
-	SubjectID     *uuid.UUID
-	Assignment    RuleTargetAssignment
-	Priority      pgtype.Int4
-	Policy        NullRulePolicy
-	CelExpression string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID               uuid.UUID
+	RuleID           uuid.UUID
+	SubjectKind      RuleTargetSubjectKind
+	SubjectID        *uuid.UUID
+	Assignment       RuleTargetAssignment
+	Priority         pgtype.Int4
+	Policy           NullRulePolicy
+	CelExpression    string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	SubjectGroupID   *uuid.UUID
+	SubjectMachineID *uuid.UUID
+	SubjectUserID    *uuid.UUID
 }
 
-type User struct {
+type ServiceAccount struct {
 	ID          uuid.UUID
-	Upn         string
-	DisplayName string
-	Source      PrincipalSource
+	Name        string
+	Description string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+type UnblockRequest struct {
+	ID                   uuid.UUID
+	FileSHA256           string
+	MachineID            uuid.UUID
+	ExecutableID         *uuid.UUID
+	ExecutionEventID     *uuid.UUID
+	UserID               *uuid.UUID
+	RequesterUpn         string
+	RequesterName        string
+	Reason               string
+	Status               UnblockRequestStatus
+	Scope                NullUnblockScope
+	GroupID              *uuid.UUID
+	RuleID               *uuid.UUID
+	RuleChangeProposalID *uuid.UUID
+	ReviewedBy           string
+	ReviewedByName       string
+	ReviewComment        string
+	ReviewedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type User struct {
+	ID                  uuid.UUID
+	Upn                 string
+	DisplayName         string
+	Source              PrincipalSource
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DirectoryAttributes []byte
+}
+
+type UserAlias struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Alias     string
+	Source    PrincipalSource
+	CreatedAt time.Time
+}
@@ -0,0 +1,63 @@
+-- name: UpsertCertificate :one
+INSERT INTO certificates (
+  id,
+  sha256,
+  common_name,
+  organization,
+  organizational_unit,
+  valid_from,
+  valid_until
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(sha256),
+  sqlc.arg(common_name),
+  sqlc.arg(organization),
+  sqlc.arg(organizational_unit),
+  sqlc.arg(valid_from),
+  sqlc.arg(valid_until)
+)
+ON CONFLICT (sha256) DO UPDATE
+SET last_seen_at = NOW()
+RETURNING id;
+
+-- name: LinkExecutableCertificate :exec
+INSERT INTO executable_certificates (
+  executable_id,
+  certificate_id,
+  chain_position
+)
+VALUES (
+  sqlc.arg(executable_id),
+  sqlc.arg(certificate_id),
+  sqlc.arg(chain_position)
+)
+ON CONFLICT (executable_id, certificate_id) DO NOTHING;
+
+-- name: GetCertificate :one
+SELECT
+  c.id,
+  c.sha256,
+  c.common_name,
+  c.organization,
+  c.organizational_unit,
+  c.valid_from,
+  c.valid_until,
+  (
+    SELECT COUNT(*)::INT4
+    FROM executable_certificates AS ec
+    WHERE ec.certificate_id = c.id
+  ) AS executable_count,
+  (
+    SELECT COUNT(DISTINCT eo.machine_id)::INT4
+    FROM executable_certificates AS ec
+    JOIN executable_observations AS eo
+      ON eo.executable_id = ec.executable_id
+    WHERE ec.certificate_id = c.id
+  ) AS machine_count,
+  c.first_seen_at,
+  c.last_seen_at,
+  c.created_at,
+  c.updated_at
+FROM certificates AS c
+WHERE c.id = sqlc.arg(id);
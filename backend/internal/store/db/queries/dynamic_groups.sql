@@ -0,0 +1,133 @@
+-- name: ListDynamicMachineGroups :many
+SELECT
+  g.id,
+  g.machine_criteria
+FROM groups AS g
+WHERE g.source = 'local'
+  AND g.machine_criteria IS NOT NULL
+ORDER BY g.id ASC;
+
+-- name: ListDynamicMachineCandidates :many
+SELECT
+  m.id,
+  m.serial_number,
+  m.hostname,
+  m.model_identifier,
+  m.os_version,
+  m.santa_version,
+  m.client_mode,
+  m.tags,
+  m.last_seen_at
+FROM machines AS m
+WHERE sqlc.narg(machine_id)::UUID IS NULL
+  OR m.id = sqlc.narg(machine_id)::UUID
+ORDER BY m.id ASC;
+
+-- name: DeleteStaleComputedMachineMemberships :many
+-- Dynamic criteria and local group mappings share (group_id, machine_id) rows, so their wanted
+-- memberships are reconciled together and a row is only removed when neither still wants it.
+DELETE FROM group_machine_memberships AS gmm
+WHERE gmm.origin IN ('dynamic', 'synced')
+  AND (sqlc.narg(machine_id)::UUID IS NULL OR gmm.machine_id = sqlc.narg(machine_id)::UUID)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM (
+      SELECT
+        UNNEST(sqlc.arg(group_ids)::UUID[]) AS group_id,
+        UNNEST(sqlc.arg(machine_ids)::UUID[]) AS machine_id
+    ) AS wanted
+    WHERE wanted.group_id = gmm.group_id
+      AND wanted.machine_id = gmm.machine_id
+  )
+RETURNING gmm.machine_id;
+
+-- name: ReassignComputedMachineMemberships :exec
+WITH wanted AS (
+  SELECT
+    UNNEST(sqlc.arg(group_ids)::UUID[]) AS group_id,
+    UNNEST(sqlc.arg(machine_ids)::UUID[]) AS machine_id,
+    UNNEST(sqlc.arg(origins)::TEXT[])::membership_origin AS origin
+)
+UPDATE group_machine_memberships AS gmm
+SET origin = wanted.origin
+FROM wanted
+WHERE wanted.group_id = gmm.group_id
+  AND wanted.machine_id = gmm.machine_id
+  AND gmm.origin IN ('dynamic', 'synced')
+  AND NOT EXISTS (
+    SELECT 1
+    FROM wanted AS kept
+    WHERE kept.group_id = gmm.group_id
+      AND kept.machine_id = gmm.machine_id
+      AND kept.origin = gmm.origin
+  );
+
+-- name: InsertComputedMachineMemberships :many
+INSERT INTO group_machine_memberships (
+  group_id,
+  machine_id,
+  origin
+)
+SELECT
+  UNNEST(sqlc.arg(group_ids)::UUID[]),
+  UNNEST(sqlc.arg(machine_ids)::UUID[]),
+  UNNEST(sqlc.arg(origins)::TEXT[])::membership_origin
+ON CONFLICT (group_id, machine_id) DO NOTHING
+RETURNING machine_id;
+
+-- name: ListDynamicUserGroups :many
+SELECT
+  g.id,
+  g.user_criteria
+FROM groups AS g
+WHERE g.source = 'local'
+  AND g.user_criteria IS NOT NULL
+ORDER BY g.id ASC;
+
+-- name: ListDynamicUserCandidates :many
+SELECT
+  u.id,
+  u.directory_attributes
+FROM users AS u
+ORDER BY u.id ASC;
+
+-- name: DeleteStaleDynamicUserMemberships :many
+DELETE FROM group_user_memberships AS gum
+WHERE gum.origin = 'dynamic'
+  AND NOT EXISTS (
+    SELECT 1
+    FROM (
+      SELECT
+        UNNEST(sqlc.arg(group_ids)::UUID[]) AS group_id,
+        UNNEST(sqlc.arg(user_ids)::UUID[]) AS user_id
+    ) AS wanted
+    WHERE wanted.group_id = gum.group_id
+      AND wanted.user_id = gum.user_id
+  )
+RETURNING gum.user_id;
+
+-- name: InsertDynamicUserMemberships :many
+INSERT INTO group_user_memberships (
+  group_id,
+  user_id,
+  origin
+)
+SELECT
+  UNNEST(sqlc.arg(group_ids)::UUID[]),
+  UNNEST(sqlc.arg(user_ids)::UUID[]),
+  'dynamic'
+ON CONFLICT (group_id, user_id) DO NOTHING
+RETURNING user_id;
+
+-- name: SetGroupCriteria :exec
+UPDATE groups
+SET
+  machine_criteria = sqlc.narg(machine_criteria),
+  user_criteria = sqlc.narg(user_criteria)
+WHERE id = sqlc.arg(id)
+  AND source = 'local';
+
+-- name: UpdateMachineTags :execrows
+UPDATE machines
+SET tags = sqlc.arg(tags)::TEXT[]
+WHERE id = sqlc.arg(machine_id);
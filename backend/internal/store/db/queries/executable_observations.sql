@@ -0,0 +1,42 @@
+-- name: UpsertExecutableObservation :exec
+INSERT INTO executable_observations AS eo (
+  executable_id,
+  machine_id,
+  first_seen_at,
+  last_seen_at,
+  event_count,
+  executing_users
+)
+VALUES (
+  sqlc.arg(executable_id),
+  sqlc.arg(machine_id),
+  sqlc.arg(first_seen_at),
+  sqlc.arg(last_seen_at),
+  sqlc.arg(event_count),
+  sqlc.arg(executing_users)::TEXT[]
+)
+ON CONFLICT (executable_id, machine_id) DO UPDATE
+SET
+  first_seen_at = LEAST(eo.first_seen_at, EXCLUDED.first_seen_at),
+  last_seen_at = GREATEST(eo.last_seen_at, EXCLUDED.last_seen_at),
+  event_count = eo.event_count + EXCLUDED.event_count,
+  executing_users = ARRAY(
+    SELECT DISTINCT executing_user
+    FROM unnest(eo.executing_users || EXCLUDED.executing_users) AS executing_user
+    ORDER BY executing_user
+  );
+
+-- name: GetExecutablePrevalence :one
+SELECT
+  COUNT(*)::INT4 AS machine_count,
+  (
+    SELECT COUNT(DISTINCT observed_user.executing_user)::INT4
+    FROM executable_observations AS user_eo
+    CROSS JOIN LATERAL unnest(user_eo.executing_users) AS observed_user (executing_user)
+    WHERE user_eo.executable_id = sqlc.arg(executable_id)
+  ) AS user_count,
+  MIN(eo.first_seen_at)::TIMESTAMPTZ AS first_seen_at,
+  MAX(eo.last_seen_at)::TIMESTAMPTZ AS last_seen_at
+FROM executable_observations AS eo
+WHERE eo.executable_id = sqlc.arg(executable_id)
+GROUP BY eo.executable_id;
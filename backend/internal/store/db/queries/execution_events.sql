@@ -7,7 +7,8 @@ INSERT INTO execution_events (
   executing_user,
   logged_in_users,
   current_sessions,
-  occurred_at
+  occurred_at,
+  executing_user_id
 )
 VALUES (
   sqlc.arg(machine_id),
@@ -17,7 +18,8 @@ VALUES (
   sqlc.arg(executing_user),
   sqlc.arg(logged_in_users),
   sqlc.arg(current_sessions),
-  sqlc.arg(occurred_at)
+  sqlc.arg(occurred_at),
+  sqlc.narg(executing_user_id)
 )
 RETURNING
   id,
@@ -29,7 +31,18 @@ RETURNING
   logged_in_users,
   current_sessions,
   occurred_at,
-  created_at;
+  created_at,
+  executing_user_id;
+
+-- name: InsertExecutionEventLoggedInUsers :exec
+INSERT INTO execution_event_logged_in_users (
+  execution_event_id,
+  user_id
+)
+SELECT
+  UNNEST(sqlc.arg(execution_event_ids)::UUID[]),
+  UNNEST(sqlc.arg(user_ids)::UUID[])
+ON CONFLICT DO NOTHING;
 
 -- name: GetExecutionEvent :one
 SELECT
@@ -51,7 +64,14 @@ SELECT
   x.signing_chain,
   x.entitlements,
   ee.occurred_at,
-  ee.created_at
+  ee.created_at,
+  ee.executing_user_id,
+  ARRAY(
+    SELECT eelu.user_id
+    FROM execution_event_logged_in_users AS eelu
+    WHERE eelu.execution_event_id = ee.id
+    ORDER BY eelu.user_id
+  )::UUID[] AS logged_in_user_ids
 FROM execution_events AS ee
 JOIN executables AS x
   ON x.id = ee.executable_id
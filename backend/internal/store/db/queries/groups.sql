@@ -21,6 +21,8 @@ RETURNING
   name,
   description,
   source,
+  machine_criteria,
+  user_criteria,
   0::INT4 AS member_count,
   created_at,
   updated_at;
@@ -35,7 +37,9 @@ updated AS (
   UPDATE groups AS g
   SET
     name = sqlc.arg(name),
-    description = sqlc.arg(description)
+    description = sqlc.arg(description),
+    machine_criteria = sqlc.narg(machine_criteria),
+    user_criteria = sqlc.narg(user_criteria)
   WHERE g.id = sqlc.arg(id)
     AND g.source = 'local'
   RETURNING
@@ -43,6 +47,8 @@ updated AS (
     g.name,
     g.description,
     g.source,
+    g.machine_criteria,
+    g.user_criteria,
     (
       SELECT COUNT(*)::INT4
       FROM group_memberships AS gm
@@ -61,6 +67,8 @@ SELECT
   u.name,
   u.description,
   u.source,
+  u.machine_criteria,
+  u.user_criteria,
   COALESCE(u.member_count, 0)::INT4 AS member_count,
   u.created_at,
   u.updated_at
@@ -74,6 +82,8 @@ SELECT
   g.name,
   g.description,
   g.source,
+  g.machine_criteria,
+  g.user_criteria,
   (
     SELECT COUNT(*)::INT4
     FROM group_memberships AS gm
@@ -97,6 +107,13 @@ ORDER BY name ASC, id ASC
 LIMIT sqlc.arg(limit_count)
 OFFSET sqlc.arg(offset_count);
 
+-- name: ListGroupNamesByIDs :many
+SELECT
+  g.id,
+  g.name
+FROM groups AS g
+WHERE g.id = ANY(sqlc.arg(ids)::UUID[]);
+
 -- name: DeleteGroup :one
 WITH matched AS (
   SELECT g.source
@@ -0,0 +1,52 @@
+-- name: GetLocalGroupMapping :one
+SELECT
+  lgm.id,
+  lgm.local_group,
+  lgm.group_id,
+  g.name AS group_name,
+  lgm.created_at,
+  lgm.updated_at
+FROM local_group_mappings AS lgm
+JOIN groups AS g
+  ON g.id = lgm.group_id
+WHERE lgm.id = sqlc.arg(id);
+
+-- name: CreateLocalGroupMapping :one
+INSERT INTO local_group_mappings (
+  id,
+  local_group,
+  group_id
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(local_group),
+  sqlc.arg(group_id)
+)
+RETURNING id;
+
+-- name: UpdateLocalGroupMapping :execrows
+UPDATE local_group_mappings
+SET
+  local_group = sqlc.arg(local_group),
+  group_id = sqlc.arg(group_id)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteLocalGroupMapping :execrows
+DELETE FROM local_group_mappings
+WHERE id = sqlc.arg(id);
+
+-- name: ListLocalGroupMappingTargets :many
+SELECT
+  lgm.local_group,
+  lgm.group_id
+FROM local_group_mappings AS lgm
+ORDER BY lgm.local_group ASC, lgm.group_id ASC;
+
+-- name: ListLocalGroupCandidates :many
+SELECT
+  m.id,
+  m.primary_user_groups
+FROM machines AS m
+WHERE sqlc.narg(machine_id)::UUID IS NULL
+  OR m.id = sqlc.narg(machine_id)::UUID
+ORDER BY m.id ASC;
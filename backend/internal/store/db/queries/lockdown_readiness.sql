@@ -0,0 +1,39 @@
+-- name: ListUnknownExecutionsForMachines :many
+SELECT
+  ee.machine_id,
+  m.hostname,
+  ee.executable_id,
+  e.file_name,
+  e.file_sha256,
+  e.signing_id,
+  e.team_id,
+  e.cdhash,
+  COALESCE(leaf.sha256, '')::TEXT AS leaf_certificate_sha256,
+  ee.executing_user,
+  COUNT(*)::INT4 AS execution_count,
+  MAX(COALESCE(ee.occurred_at, ee.created_at))::TIMESTAMPTZ AS last_executed_at
+FROM execution_events AS ee
+JOIN machines AS m
+  ON m.id = ee.machine_id
+JOIN executables AS e
+  ON e.id = ee.executable_id
+LEFT JOIN executable_certificates AS ec
+  ON ec.executable_id = e.id
+  AND ec.chain_position = 0
+LEFT JOIN certificates AS leaf
+  ON leaf.id = ec.certificate_id
+WHERE ee.machine_id = ANY(sqlc.arg(machine_ids)::UUID[])
+  AND ee.decision = 'allow_unknown'
+  AND COALESCE(ee.occurred_at, ee.created_at) >= sqlc.arg(since)::TIMESTAMPTZ
+GROUP BY
+  ee.machine_id,
+  m.hostname,
+  ee.executable_id,
+  e.file_name,
+  e.file_sha256,
+  e.signing_id,
+  e.team_id,
+  e.cdhash,
+  leaf.sha256,
+  ee.executing_user
+ORDER BY ee.machine_id ASC, ee.executable_id ASC, ee.executing_user ASC;
@@ -81,6 +81,7 @@ SELECT
     ms.last_reported_counts_match_at
   ) AS rule_sync_status,
   m.client_mode,
+  m.tags,
   COALESCE(ms.binary_rule_count, 0)::INT4 AS binary_rule_count,
   COALESCE(ms.certificate_rule_count, 0)::INT4 AS certificate_rule_count,
   COALESCE(ms.teamid_rule_count, 0)::INT4 AS teamid_rule_count,
@@ -89,12 +90,16 @@ SELECT
   m.last_seen_at,
   m.created_at,
   m.updated_at,
-  u.id AS primary_user_id
+  m.primary_user_id,
+  COALESCE(u.upn, '')::TEXT AS effective_primary_user,
+  (o.machine_id IS NOT NULL)::BOOL AS primary_user_overridden
 FROM machines AS m
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
 LEFT JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
+  ON u.id = m.primary_user_id
+LEFT JOIN machine_primary_user_overrides AS o
+  ON o.machine_id = m.id
 WHERE m.id = sqlc.arg(machine_id);
 
 -- name: ListMachineIDs :many
@@ -102,12 +107,38 @@ SELECT id
 FROM machines
 ORDER BY id ASC;
 
+-- name: ListMachineIDsByIDs :many
+SELECT id
+FROM machines
+WHERE id = ANY(sqlc.arg(ids)::UUID[])
+ORDER BY id ASC;
+
 -- name: ListMachineIDsByPrimaryUserID :many
 SELECT m.id
 FROM machines AS m
-JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
-WHERE u.id = sqlc.arg(primary_user_id)
+WHERE m.primary_user_id = sqlc.arg(primary_user_id)::UUID
+ORDER BY m.id ASC;
+
+-- name: ListMachineIDsByPrimaryUserIDs :many
+SELECT m.id
+FROM machines AS m
+WHERE m.primary_user_id = ANY(sqlc.arg(user_ids)::UUID[])
+ORDER BY m.id ASC;
+
+-- name: ListMachineHostnamesByIDs :many
+SELECT
+  m.id,
+  m.hostname
+FROM machines AS m
+WHERE m.id = ANY(sqlc.arg(ids)::UUID[]);
+
+-- name: ListPrimaryUserMachines :many
+SELECT
+  m.primary_user_id::UUID AS user_id,
+  m.id AS machine_id
+FROM machines AS m
+WHERE m.primary_user_id IS NOT NULL
+  AND (sqlc.narg(user_ids)::UUID[] IS NULL OR m.primary_user_id = ANY(sqlc.narg(user_ids)::UUID[]))
 ORDER BY m.id ASC;
 
 -- name: ListMachines :many
@@ -124,14 +155,82 @@ SELECT
   m.last_seen_at,
   m.created_at,
   m.updated_at,
-  u.id AS primary_user_id
+  m.primary_user_id
 FROM machines AS m
-LEFT JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
 ORDER BY m.last_seen_at DESC
 LIMIT sqlc.arg(limit_count)
 OFFSET sqlc.arg(offset_count);
 
+-- name: UpsertMachinePrimaryUserOverride :exec
+INSERT INTO machine_primary_user_overrides (
+  machine_id,
+  user_id
+)
+VALUES (
+  sqlc.arg(machine_id),
+  sqlc.narg(user_id)
+)
+ON CONFLICT (machine_id) DO UPDATE
+SET
+  user_id = EXCLUDED.user_id,
+  updated_at = NOW();
+
+-- name: DeleteMachinePrimaryUserOverride :exec
+DELETE FROM machine_primary_user_overrides
+WHERE machine_id = sqlc.arg(machine_id);
+
 -- name: DeleteMachine :exec
 DELETE FROM machines
 WHERE id = sqlc.arg(machine_id);
+
+-- name: ListMachineIDsByEffectiveGroupID :many
+WITH member_groups AS (
+  SELECT ga.group_id
+  FROM group_ancestors AS ga
+  WHERE ga.ancestor_id = sqlc.arg(group_id)
+)
+SELECT gmm.machine_id AS id
+FROM group_machine_memberships AS gmm
+JOIN member_groups AS mg
+  ON mg.group_id = gmm.group_id
+WHERE NOT gmm.pending
+
+UNION
+
+SELECT m.id
+FROM machines AS m
+JOIN group_user_memberships AS gum
+  ON gum.user_id = m.primary_user_id
+  AND NOT gum.pending
+JOIN member_groups AS mg
+  ON mg.group_id = gum.group_id
+
+ORDER BY id ASC;
+
+-- name: ListEffectiveGroupMachines :many
+WITH direct_group_machines AS (
+  SELECT
+    gmm.group_id,
+    gmm.machine_id
+  FROM group_machine_memberships AS gmm
+  WHERE NOT gmm.pending
+
+  UNION
+
+  SELECT
+    gum.group_id,
+    m.id AS machine_id
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+)
+SELECT DISTINCT
+  ga.ancestor_id AS group_id,
+  dgm.machine_id
+FROM direct_group_machines AS dgm
+JOIN group_ancestors AS ga
+  ON ga.group_id = dgm.group_id
+WHERE sqlc.narg(group_ids)::UUID[] IS NULL
+  OR ga.ancestor_id = ANY(sqlc.narg(group_ids)::UUID[])
+ORDER BY ga.ancestor_id ASC, dgm.machine_id ASC;
@@ -0,0 +1,76 @@
+-- name: ListMachinesByIDs :many
+SELECT
+  m.id,
+  m.id::TEXT AS match_value
+FROM machines AS m
+WHERE m.id = ANY(sqlc.arg(ids)::UUID[])
+ORDER BY m.id ASC;
+
+-- name: ListMachinesBySerialNumbers :many
+SELECT
+  m.id,
+  lower(m.serial_number)::TEXT AS match_value
+FROM machines AS m
+WHERE lower(m.serial_number) = ANY(sqlc.arg(serial_numbers)::TEXT[])
+ORDER BY m.id ASC;
+
+-- name: ListMachinesByHostnames :many
+SELECT
+  m.id,
+  lower(m.hostname)::TEXT AS match_value
+FROM machines AS m
+WHERE lower(m.hostname) = ANY(sqlc.arg(hostnames)::TEXT[])
+ORDER BY m.id ASC;
+
+-- name: ListUsersByUPNs :many
+SELECT
+  u.id,
+  lower(u.upn)::TEXT AS match_value
+FROM users AS u
+WHERE u.upn <> ''
+  AND lower(u.upn) = ANY(sqlc.arg(upns)::TEXT[])
+ORDER BY u.id ASC;
+
+-- name: InsertExplicitMachineMemberships :many
+INSERT INTO group_machine_memberships (
+  group_id,
+  machine_id,
+  origin
+)
+SELECT
+  sqlc.arg(group_id)::UUID,
+  UNNEST(sqlc.arg(machine_ids)::UUID[]),
+  'explicit'
+ON CONFLICT (group_id, machine_id) DO NOTHING
+RETURNING machine_id;
+
+-- name: InsertExplicitUserMemberships :many
+INSERT INTO group_user_memberships (
+  group_id,
+  user_id,
+  origin
+)
+SELECT
+  sqlc.arg(group_id)::UUID,
+  UNNEST(sqlc.arg(user_ids)::UUID[]),
+  'explicit'
+ON CONFLICT (group_id, user_id) DO NOTHING
+RETURNING user_id;
+
+-- name: DeleteExplicitMachineMemberships :many
+-- Deletes the listed machines' explicit memberships of a group, or with exclude set, every other
+-- machine's.
+DELETE FROM group_machine_memberships AS gmm
+WHERE gmm.group_id = sqlc.arg(group_id)
+  AND gmm.origin = 'explicit'
+  AND (gmm.machine_id = ANY(sqlc.arg(machine_ids)::UUID[])) <> sqlc.arg(exclude)::BOOLEAN
+RETURNING gmm.machine_id;
+
+-- name: DeleteExplicitUserMemberships :many
+-- Deletes the listed users' explicit memberships of a group, or with exclude set, every other
+-- user's.
+DELETE FROM group_user_memberships AS gum
+WHERE gum.group_id = sqlc.arg(group_id)
+  AND gum.origin = 'explicit'
+  AND (gum.user_id = ANY(sqlc.arg(user_ids)::UUID[])) <> sqlc.arg(exclude)::BOOLEAN
+RETURNING gum.user_id;
@@ -3,13 +3,19 @@ INSERT INTO group_user_memberships (
   id,
   group_id,
   user_id,
-  origin
+  origin,
+  starts_at,
+  expires_at,
+  pending
 )
 VALUES (
   sqlc.arg(id),
   sqlc.arg(group_id),
   sqlc.arg(user_id),
-  sqlc.arg(origin)
+  sqlc.arg(origin),
+  sqlc.narg(starts_at),
+  sqlc.narg(expires_at),
+  COALESCE(sqlc.narg(starts_at)::TIMESTAMPTZ > NOW(), FALSE)
 )
 RETURNING
   id,
@@ -24,13 +30,19 @@ INSERT INTO group_machine_memberships (
   id,
   group_id,
   machine_id,
-  origin
+  origin,
+  starts_at,
+  expires_at,
+  pending
 )
 VALUES (
   sqlc.arg(id),
   sqlc.arg(group_id),
   sqlc.arg(machine_id),
-  sqlc.arg(origin)
+  sqlc.arg(origin),
+  sqlc.narg(starts_at),
+  sqlc.narg(expires_at),
+  COALESCE(sqlc.narg(starts_at)::TIMESTAMPTZ > NOW(), FALSE)
 )
 RETURNING
   id,
@@ -40,6 +52,54 @@ RETURNING
   created_at,
   updated_at;
 
+-- name: CreateGroupMembership :one
+INSERT INTO group_group_memberships (
+  id,
+  group_id,
+  member_group_id,
+  origin,
+  starts_at,
+  expires_at,
+  pending
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(group_id),
+  sqlc.arg(member_group_id),
+  sqlc.arg(origin),
+  sqlc.narg(starts_at),
+  sqlc.narg(expires_at),
+  COALESCE(sqlc.narg(starts_at)::TIMESTAMPTZ > NOW(), FALSE)
+)
+RETURNING
+  id,
+  group_id,
+  member_group_id,
+  origin,
+  created_at,
+  updated_at;
+
+-- name: LockGroupMemberships :exec
+LOCK TABLE group_group_memberships IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: GroupHasAncestor :one
+-- Walks pending nestings too, so activating one later cannot close a cycle.
+WITH RECURSIVE ancestors (ancestor_id) AS (
+  SELECT sqlc.arg(group_id)::UUID
+
+  UNION
+
+  SELECT ggm.group_id
+  FROM ancestors AS a
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = a.ancestor_id
+)
+SELECT EXISTS (
+  SELECT 1
+  FROM ancestors
+  WHERE ancestor_id = sqlc.arg(ancestor_id)::UUID
+)::BOOLEAN AS has_ancestor;
+
 -- name: AddSyncedUserMembership :exec
 INSERT INTO group_user_memberships (
   id,
@@ -68,10 +128,15 @@ SELECT
   COALESCE(
     CASE
       WHEN gm.member_kind = 'user' THEN NULLIF(u.display_name, '')
+      WHEN gm.member_kind = 'group' THEN NULLIF(mg.name, '')
       ELSE NULLIF(m.hostname, '')
     END,
     ''
   )::TEXT AS member_name,
+  gm.origin,
+  gm.starts_at,
+  gm.expires_at,
+  gm.pending,
   gm.created_at,
   gm.updated_at
 FROM group_memberships AS gm
@@ -83,6 +148,9 @@ LEFT JOIN users AS u
 LEFT JOIN machines AS m
   ON gm.member_kind = 'machine'
   AND m.id = gm.member_id
+LEFT JOIN groups AS mg
+  ON gm.member_kind = 'group'
+  AND mg.id = gm.member_id
 WHERE gm.id = sqlc.arg(id);
 
 -- name: DeleteUserMembership :execrows
@@ -93,19 +161,174 @@ WHERE id = sqlc.arg(id);
 DELETE FROM group_machine_memberships
 WHERE id = sqlc.arg(id);
 
--- name: ListEffectiveGroupIDsForMachine :many
-SELECT gmm.group_id
-FROM group_machine_memberships AS gmm
-WHERE gmm.machine_id = sqlc.arg(machine_id)
+-- name: DeleteGroupMembership :execrows
+DELETE FROM group_group_memberships
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteUserMembershipByMember :execrows
+DELETE FROM group_user_memberships
+WHERE group_id = sqlc.arg(group_id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteMachineMembershipByMember :execrows
+DELETE FROM group_machine_memberships
+WHERE group_id = sqlc.arg(group_id)
+  AND machine_id = sqlc.arg(machine_id);
+
+-- name: DeleteGroupMembershipByMember :execrows
+DELETE FROM group_group_memberships
+WHERE group_id = sqlc.arg(group_id)
+  AND member_group_id = sqlc.arg(member_group_id);
+
+-- name: ActivateDueMemberships :many
+WITH activated_users AS (
+  UPDATE group_user_memberships AS gum
+  SET pending = FALSE
+  WHERE gum.pending
+    AND gum.starts_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'user'::membership_member_kind AS member_kind,
+    gum.user_id AS member_id
+),
+activated_machines AS (
+  UPDATE group_machine_memberships AS gmm
+  SET pending = FALSE
+  WHERE gmm.pending
+    AND gmm.starts_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'machine'::membership_member_kind AS member_kind,
+    gmm.machine_id AS member_id
+),
+activated_groups AS (
+  UPDATE group_group_memberships AS ggm
+  SET pending = FALSE
+  WHERE ggm.pending
+    AND ggm.starts_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'group'::membership_member_kind AS member_kind,
+    ggm.member_group_id AS member_id
+)
+SELECT a.member_kind, a.member_id
+FROM activated_users AS a
+
+UNION
+
+SELECT am.member_kind, am.member_id
+FROM activated_machines AS am
 
 UNION
 
-SELECT gum.group_id
-FROM machines AS m
-JOIN users AS u
-  ON u.upn = NULLIF(m.primary_user, '')
-JOIN group_user_memberships AS gum
-  ON gum.user_id = u.id
-WHERE m.id = sqlc.arg(machine_id)
+SELECT ag.member_kind, ag.member_id
+FROM activated_groups AS ag;
+
+-- name: DeleteExpiredMemberships :many
+WITH expired_users AS (
+  DELETE FROM group_user_memberships AS gum
+  WHERE gum.expires_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'user'::membership_member_kind AS member_kind,
+    gum.user_id AS member_id
+),
+expired_machines AS (
+  DELETE FROM group_machine_memberships AS gmm
+  WHERE gmm.expires_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'machine'::membership_member_kind AS member_kind,
+    gmm.machine_id AS member_id
+),
+expired_groups AS (
+  DELETE FROM group_group_memberships AS ggm
+  WHERE ggm.expires_at <= sqlc.arg(now)::TIMESTAMPTZ
+  RETURNING
+    'group'::membership_member_kind AS member_kind,
+    ggm.member_group_id AS member_id
+)
+SELECT eu.member_kind, eu.member_id
+FROM expired_users AS eu
 
-ORDER BY group_id ASC;
+UNION
+
+SELECT em.member_kind, em.member_id
+FROM expired_machines AS em
+
+UNION
+
+SELECT eg.member_kind, eg.member_id
+FROM expired_groups AS eg;
+
+-- name: ListEffectiveGroupIDsForMachines :many
+WITH direct_groups AS (
+  SELECT
+    gmm.machine_id,
+    gmm.group_id
+  FROM group_machine_memberships AS gmm
+  WHERE gmm.machine_id = ANY(sqlc.arg(machine_ids)::UUID[])
+    AND NOT gmm.pending
+
+  UNION
+
+  SELECT
+    m.id AS machine_id,
+    gum.group_id
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+  WHERE m.id = ANY(sqlc.arg(machine_ids)::UUID[])
+)
+SELECT DISTINCT
+  dg.machine_id,
+  ga.ancestor_id AS group_id
+FROM direct_groups AS dg
+JOIN group_ancestors AS ga
+  ON ga.group_id = dg.group_id
+ORDER BY dg.machine_id ASC, ga.ancestor_id ASC;
+
+-- name: ListMachineGroupPaths :many
+WITH direct_paths AS (
+  SELECT
+    gmm.group_id,
+    'machine'::TEXT AS via,
+    gmm.id AS membership_id,
+    gmm.origin
+  FROM group_machine_memberships AS gmm
+  WHERE gmm.machine_id = sqlc.arg(machine_id)
+    AND NOT gmm.pending
+
+  UNION ALL
+
+  SELECT
+    gum.group_id,
+    'primary_user'::TEXT AS via,
+    gum.id AS membership_id,
+    gum.origin
+  FROM machines AS m
+  JOIN group_user_memberships AS gum
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
+  WHERE m.id = sqlc.arg(machine_id)
+)
+SELECT
+  gap.ancestor_id AS group_id,
+  g.name AS group_name,
+  dp.via,
+  dp.membership_id,
+  dp.origin,
+  COALESCE(
+    (
+      SELECT jsonb_agg(
+        jsonb_build_object('id', pg.id, 'name', pg.name, 'source', pg.source)
+        ORDER BY p.ordinality
+      )
+      FROM UNNEST(gap.path) WITH ORDINALITY AS p (group_id, ordinality)
+      JOIN groups AS pg
+        ON pg.id = p.group_id
+    ),
+    '[]'::JSONB
+  )::JSONB AS path
+FROM direct_paths AS dp
+JOIN group_ancestor_paths AS gap
+  ON gap.group_id = dp.group_id
+JOIN groups AS g
+  ON g.id = gap.ancestor_id
+ORDER BY group_name ASC, via ASC, CARDINALITY(gap.path) ASC;
@@ -0,0 +1,45 @@
+-- name: ListPublisherSigningIDs :many
+SELECT DISTINCT signing_id
+FROM executables
+WHERE team_id = sqlc.arg(team_id)
+  AND signing_id <> ''
+ORDER BY signing_id ASC;
+
+-- name: ListPublisherDecisionCounts :many
+SELECT
+  ee.decision,
+  COUNT(*)::INT4 AS count
+FROM execution_events AS ee
+JOIN executables AS e
+  ON e.id = ee.executable_id
+WHERE e.team_id = sqlc.arg(team_id)
+GROUP BY ee.decision
+ORDER BY ee.decision ASC;
+
+-- name: ListPublisherRules :many
+SELECT
+  r.id,
+  r.name,
+  r.description,
+  r.rule_type,
+  r.identifier,
+  r.custom_message,
+  r.custom_url,
+  r.enabled,
+  r.created_at,
+  r.updated_at
+FROM rules AS r
+WHERE (r.rule_type = 'team_id' AND r.identifier = sqlc.arg(team_id))
+  OR (
+    r.rule_type = 'signing_id'
+    AND (
+      r.identifier LIKE sqlc.arg(team_id) || ':%'
+      OR r.identifier IN (
+        SELECT e.signing_id
+        FROM executables AS e
+        WHERE e.team_id = sqlc.arg(team_id)
+          AND e.signing_id <> ''
+      )
+    )
+  )
+ORDER BY r.rule_type ASC, r.identifier ASC;
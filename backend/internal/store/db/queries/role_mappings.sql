@@ -0,0 +1,59 @@
+-- name: GetRoleMapping :one
+SELECT
+  grm.id,
+  grm.group_id,
+  g.name AS group_name,
+  g.source AS group_source,
+  grm.role,
+  grm.created_at,
+  grm.updated_at
+FROM group_role_mappings AS grm
+JOIN groups AS g
+  ON g.id = grm.group_id
+WHERE grm.id = sqlc.arg(id);
+
+-- name: CreateRoleMapping :one
+INSERT INTO group_role_mappings (
+  id,
+  group_id,
+  role
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(group_id),
+  sqlc.arg(role)
+)
+RETURNING id;
+
+-- name: UpdateRoleMapping :execrows
+UPDATE group_role_mappings
+SET
+  role = sqlc.arg(role)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteRoleMapping :execrows
+DELETE FROM group_role_mappings
+WHERE id = sqlc.arg(id);
+
+-- name: ListRolesForUserUPN :many
+SELECT DISTINCT grm.role
+FROM users AS u
+JOIN group_user_memberships AS gum
+  ON gum.user_id = u.id
+  AND NOT gum.pending
+JOIN group_ancestors AS ga
+  ON ga.group_id = gum.group_id
+JOIN group_role_mappings AS grm
+  ON grm.group_id = ga.ancestor_id
+WHERE u.upn <> ''
+  AND lower(u.upn) = lower(sqlc.arg(upn))
+ORDER BY grm.role ASC;
+
+-- name: GroupGrantsRole :one
+SELECT EXISTS (
+  SELECT 1
+  FROM group_ancestors AS ga
+  JOIN group_role_mappings AS grm
+    ON grm.group_id = ga.ancestor_id
+  WHERE ga.group_id = sqlc.arg(group_id)
+) AS grants_role;
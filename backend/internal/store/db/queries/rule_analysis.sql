@@ -0,0 +1,207 @@
+-- name: ListRuleTargetsForAnalysis :many
+SELECT
+  rt.rule_id,
+  rt.subject_kind,
+  rt.subject_id,
+  rt.assignment,
+  rt.priority,
+  rt.policy,
+  rt.cel_expression,
+  CASE
+    WHEN rt.subject_kind = 'group' THEN COALESCE(g.name, '')
+    WHEN rt.subject_kind = 'all_devices' THEN 'All Devices'
+    WHEN rt.subject_kind = 'all_users' THEN 'All Users'
+    WHEN rt.subject_kind = 'machine' THEN COALESCE(m.hostname, '')
+    WHEN rt.subject_kind = 'user' THEN COALESCE(NULLIF(u.display_name, ''), u.upn, '')
+    ELSE ''
+  END AS subject_name
+FROM rule_targets AS rt
+LEFT JOIN groups AS g
+  ON rt.subject_kind = 'group'
+  AND g.id = rt.subject_id
+LEFT JOIN machines AS m
+  ON rt.subject_kind = 'machine'
+  AND m.id = rt.subject_id
+LEFT JOIN users AS u
+  ON rt.subject_kind = 'user'
+  AND u.id = rt.subject_id
+WHERE sqlc.narg(rule_ids)::UUID[] IS NULL
+  OR rt.rule_id = ANY(sqlc.narg(rule_ids)::UUID[])
+ORDER BY
+  rt.rule_id ASC,
+  CASE WHEN rt.assignment = 'include' THEN 0 ELSE 1 END ASC,
+  rt.priority ASC NULLS LAST,
+  rt.subject_kind ASC,
+  rt.subject_id ASC;
+
+-- name: ListRuleOverlaps :many
+WITH executable_rules AS (
+  SELECT e.id AS executable_id, r.id AS rule_id
+  FROM executables AS e
+  JOIN rules AS r
+    ON r.rule_type = 'binary'
+    AND r.identifier = e.file_sha256
+
+  UNION
+
+  SELECT e.id AS executable_id, r.id AS rule_id
+  FROM executables AS e
+  JOIN rules AS r
+    ON r.rule_type = 'cd_hash'
+    AND r.identifier = e.cdhash
+  WHERE e.cdhash <> ''
+
+  UNION
+
+  SELECT e.id AS executable_id, r.id AS rule_id
+  FROM executables AS e
+  JOIN rules AS r
+    ON r.rule_type = 'signing_id'
+    AND r.identifier = e.signing_id
+  WHERE e.signing_id <> ''
+
+  UNION
+
+  SELECT e.id AS executable_id, r.id AS rule_id
+  FROM executables AS e
+  JOIN rules AS r
+    ON r.rule_type = 'team_id'
+    AND r.identifier = e.team_id
+  WHERE e.team_id <> ''
+
+  UNION
+
+  SELECT ec.executable_id, r.id AS rule_id
+  FROM executable_certificates AS ec
+  JOIN certificates AS c
+    ON c.id = ec.certificate_id
+  JOIN rules AS r
+    ON r.rule_type = 'certificate'
+    AND r.identifier = c.sha256
+  WHERE ec.chain_position = 0
+)
+SELECT
+  a.rule_id,
+  b.rule_id AS other_rule_id,
+  COUNT(*)::INT4 AS executable_count
+FROM executable_rules AS a
+JOIN executable_rules AS b
+  ON b.executable_id = a.executable_id
+  AND b.rule_id > a.rule_id
+GROUP BY a.rule_id, b.rule_id
+ORDER BY a.rule_id ASC, b.rule_id ASC;
+
+-- name: ListRuleOverlapsForRule :many
+WITH rule_executables AS (
+  SELECT e.id AS executable_id
+  FROM rules AS r
+  JOIN executables AS e
+    ON r.rule_type = 'binary'
+    AND e.file_sha256 = r.identifier
+  WHERE r.id = sqlc.arg(rule_id)
+
+  UNION
+
+  SELECT e.id AS executable_id
+  FROM rules AS r
+  JOIN executables AS e
+    ON r.rule_type = 'cd_hash'
+    AND e.cdhash = r.identifier
+  WHERE r.id = sqlc.arg(rule_id)
+    AND e.cdhash <> ''
+
+  UNION
+
+  SELECT e.id AS executable_id
+  FROM rules AS r
+  JOIN executables AS e
+    ON r.rule_type = 'signing_id'
+    AND e.signing_id = r.identifier
+  WHERE r.id = sqlc.arg(rule_id)
+    AND e.signing_id <> ''
+
+  UNION
+
+  SELECT e.id AS executable_id
+  FROM rules AS r
+  JOIN executables AS e
+    ON r.rule_type = 'team_id'
+    AND e.team_id = r.identifier
+  WHERE r.id = sqlc.arg(rule_id)
+    AND e.team_id <> ''
+
+  UNION
+
+  SELECT ec.executable_id
+  FROM rules AS r
+  JOIN certificates AS c
+    ON r.rule_type = 'certificate'
+    AND c.sha256 = r.identifier
+  JOIN executable_certificates AS ec
+    ON ec.certificate_id = c.id
+    AND ec.chain_position = 0
+  WHERE r.id = sqlc.arg(rule_id)
+),
+executable_rules AS (
+  SELECT re.executable_id, r.id AS rule_id
+  FROM rule_executables AS re
+  JOIN executables AS e
+    ON e.id = re.executable_id
+  JOIN rules AS r
+    ON r.rule_type = 'binary'
+    AND r.identifier = e.file_sha256
+
+  UNION
+
+  SELECT re.executable_id, r.id AS rule_id
+  FROM rule_executables AS re
+  JOIN executables AS e
+    ON e.id = re.executable_id
+  JOIN rules AS r
+    ON r.rule_type = 'cd_hash'
+    AND r.identifier = e.cdhash
+  WHERE e.cdhash <> ''
+
+  UNION
+
+  SELECT re.executable_id, r.id AS rule_id
+  FROM rule_executables AS re
+  JOIN executables AS e
+    ON e.id = re.executable_id
+  JOIN rules AS r
+    ON r.rule_type = 'signing_id'
+    AND r.identifier = e.signing_id
+  WHERE e.signing_id <> ''
+
+  UNION
+
+  SELECT re.executable_id, r.id AS rule_id
+  FROM rule_executables AS re
+  JOIN executables AS e
+    ON e.id = re.executable_id
+  JOIN rules AS r
+    ON r.rule_type = 'team_id'
+    AND r.identifier = e.team_id
+  WHERE e.team_id <> ''
+
+  UNION
+
+  SELECT re.executable_id, r.id AS rule_id
+  FROM rule_executables AS re
+  JOIN executable_certificates AS ec
+    ON ec.executable_id = re.executable_id
+    AND ec.chain_position = 0
+  JOIN certificates AS c
+    ON c.id = ec.certificate_id
+  JOIN rules AS r
+    ON r.rule_type = 'certificate'
+    AND r.identifier = c.sha256
+)
+SELECT
+  LEAST(sqlc.arg(rule_id)::UUID, er.rule_id)::UUID AS rule_id,
+  GREATEST(sqlc.arg(rule_id)::UUID, er.rule_id)::UUID AS other_rule_id,
+  COUNT(*)::INT4 AS executable_count
+FROM executable_rules AS er
+WHERE er.rule_id <> sqlc.arg(rule_id)
+GROUP BY er.rule_id
+ORDER BY 1 ASC, 2 ASC;
@@ -0,0 +1,61 @@
+-- name: GetRuleChangeProposal :one
+SELECT
+  id,
+  action,
+  status,
+  rule_id,
+  rule_name,
+  base_updated_at,
+  current_rule,
+  proposed_rule,
+  proposed_by,
+  proposed_by_name,
+  reviewed_by,
+  reviewed_by_name,
+  review_comment,
+  reviewed_at,
+  created_at,
+  updated_at
+FROM rule_change_proposals
+WHERE id = sqlc.arg(id);
+
+-- name: CreateRuleChangeProposal :one
+INSERT INTO rule_change_proposals (
+  id,
+  action,
+  rule_id,
+  rule_name,
+  base_updated_at,
+  current_rule,
+  proposed_rule,
+  proposed_by,
+  proposed_by_name
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(action),
+  sqlc.narg(rule_id),
+  sqlc.arg(rule_name),
+  sqlc.narg(base_updated_at),
+  sqlc.narg(current_rule),
+  sqlc.narg(proposed_rule),
+  sqlc.arg(proposed_by),
+  sqlc.arg(proposed_by_name)
+)
+RETURNING id;
+
+-- name: ReviewRuleChangeProposal :execrows
+UPDATE rule_change_proposals
+SET
+  status = sqlc.arg(status),
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_by_name = sqlc.arg(reviewed_by_name),
+  review_comment = sqlc.arg(review_comment),
+  reviewed_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'pending';
+
+-- name: SetRuleChangeProposalRule :execrows
+UPDATE rule_change_proposals
+SET rule_id = sqlc.arg(rule_id)
+WHERE id = sqlc.arg(id);
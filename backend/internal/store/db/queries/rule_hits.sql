@@ -0,0 +1,30 @@
+-- name: UpsertRuleHit :exec
+INSERT INTO rule_hits AS rh (
+  rule_id,
+  machine_id,
+  first_hit_at,
+  last_hit_at,
+  hit_count
+)
+SELECT
+  r.id,
+  sqlc.arg(machine_id),
+  sqlc.arg(first_hit_at),
+  sqlc.arg(last_hit_at),
+  sqlc.arg(hit_count)
+FROM rules AS r
+WHERE r.rule_type = sqlc.arg(rule_type)
+  AND r.identifier = sqlc.arg(identifier)
+ON CONFLICT (rule_id, machine_id) DO UPDATE
+SET
+  first_hit_at = LEAST(rh.first_hit_at, EXCLUDED.first_hit_at),
+  last_hit_at = GREATEST(rh.last_hit_at, EXCLUDED.last_hit_at),
+  hit_count = rh.hit_count + EXCLUDED.hit_count;
+
+-- name: GetRuleHitStats :one
+SELECT
+  SUM(rh.hit_count)::INT8 AS hit_count,
+  MAX(rh.last_hit_at)::TIMESTAMPTZ AS last_hit_at
+FROM rule_hits AS rh
+WHERE rh.rule_id = sqlc.arg(rule_id)
+GROUP BY rh.rule_id;
@@ -9,6 +9,23 @@ SELECT
   COALESCE(ms.pending_payload_rule_count, 0)::INT8 AS pending_payload_rule_count,
   COALESCE(ms.pending_full_sync, FALSE) AS pending_full_sync,
   ms.pending_preflight_at,
+  machine_rule_sync_status(
+    ms.pending_preflight_at,
+    ms.desired_targets,
+    ms.applied_targets,
+    ms.desired_binary_rule_count,
+    ms.binary_rule_count,
+    ms.desired_certificate_rule_count,
+    ms.certificate_rule_count,
+    ms.desired_teamid_rule_count,
+    ms.teamid_rule_count,
+    ms.desired_signingid_rule_count,
+    ms.signingid_rule_count,
+    ms.desired_cdhash_rule_count,
+    ms.cdhash_rule_count,
+    ms.last_clean_sync_at,
+    ms.last_reported_counts_match_at
+  ) AS rule_sync_status,
   COALESCE(ms.desired_binary_rule_count, 0)::INT4 AS desired_binary_rule_count,
   COALESCE(ms.desired_certificate_rule_count, 0)::INT4 AS desired_certificate_rule_count,
   COALESCE(ms.desired_teamid_rule_count, 0)::INT4 AS desired_teamid_rule_count,
@@ -24,7 +41,11 @@ SELECT
   ms.last_rule_sync_attempt_at,
   ms.last_rule_sync_success_at,
   ms.last_clean_sync_at,
-  ms.last_reported_counts_match_at
+  ms.last_reported_counts_match_at,
+  ms.requested_clean_sync,
+  ms.requested_clean_sync_at,
+  COALESCE(ms.pending_clean_all, FALSE) AS pending_clean_all,
+  ms.pending_clean_sync_requested_at
 FROM machines AS m
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
@@ -40,6 +61,8 @@ INSERT INTO machine_sync_states (
   pending_payload,
   pending_payload_rule_count,
   pending_full_sync,
+  pending_clean_all,
+  pending_clean_sync_requested_at,
   pending_preflight_at,
   desired_binary_rule_count,
   desired_certificate_rule_count,
@@ -66,6 +89,8 @@ VALUES (
   sqlc.arg(pending_payload),
   sqlc.arg(pending_payload_rule_count),
   sqlc.arg(pending_full_sync),
+  sqlc.arg(pending_clean_all),
+  sqlc.arg(pending_clean_sync_requested_at),
   sqlc.arg(pending_preflight_at),
   sqlc.arg(desired_binary_rule_count),
   sqlc.arg(desired_certificate_rule_count),
@@ -92,6 +117,8 @@ SET
   pending_payload = EXCLUDED.pending_payload,
   pending_payload_rule_count = EXCLUDED.pending_payload_rule_count,
   pending_full_sync = EXCLUDED.pending_full_sync,
+  pending_clean_all = EXCLUDED.pending_clean_all,
+  pending_clean_sync_requested_at = EXCLUDED.pending_clean_sync_requested_at,
   pending_preflight_at = EXCLUDED.pending_preflight_at,
   desired_binary_rule_count = EXCLUDED.desired_binary_rule_count,
   desired_certificate_rule_count = EXCLUDED.desired_certificate_rule_count,
@@ -169,10 +196,39 @@ SET
   pending_payload = '[]'::JSONB,
   pending_payload_rule_count = 0,
   pending_full_sync = FALSE,
+  pending_clean_all = FALSE,
+  pending_clean_sync_requested_at = NULL,
   pending_preflight_at = NULL,
   last_rule_sync_success_at = sqlc.arg(last_rule_sync_success_at),
   last_clean_sync_at = CASE
     WHEN pending_full_sync THEN sqlc.arg(last_rule_sync_success_at)
     ELSE last_clean_sync_at
+  END,
+  requested_clean_sync = CASE
+    WHEN requested_clean_sync_at IS NOT DISTINCT FROM pending_clean_sync_requested_at THEN NULL
+    ELSE requested_clean_sync
+  END,
+  requested_clean_sync_at = CASE
+    WHEN requested_clean_sync_at IS NOT DISTINCT FROM pending_clean_sync_requested_at THEN NULL
+    ELSE requested_clean_sync_at
   END
 WHERE machine_id = sqlc.arg(machine_id);
+
+-- name: RequestMachineCleanSync :execrows
+-- A clean request never downgrades an outstanding clean-all request; the enum orders clean
+-- before clean_all.
+INSERT INTO machine_sync_states AS ms (
+  machine_id,
+  requested_clean_sync,
+  requested_clean_sync_at
+)
+SELECT
+  m.id,
+  sqlc.arg(sync_type)::clean_sync_type,
+  sqlc.arg(requested_at)::TIMESTAMPTZ
+FROM machines AS m
+WHERE m.id = ANY(sqlc.arg(machine_ids)::UUID[])
+ON CONFLICT (machine_id) DO UPDATE
+SET
+  requested_clean_sync = GREATEST(ms.requested_clean_sync, EXCLUDED.requested_clean_sync),
+  requested_clean_sync_at = EXCLUDED.requested_clean_sync_at;
@@ -74,31 +74,44 @@ DELETE FROM rules
 WHERE id = sqlc.arg(id)
 RETURNING id;
 
--- name: ListResolvedRulesForMachine :many
-WITH machine_user AS (
-  SELECT u.id
+-- name: ListResolvedRulesForMachines :many
+WITH scoped_machines AS (
+  SELECT
+    m.id AS machine_id,
+    m.primary_user_id
   FROM machines AS m
-  JOIN users AS u
-    ON u.upn = NULLIF(m.primary_user, '')
-  WHERE m.id = sqlc.arg(machine_id)
+  WHERE m.id = ANY(sqlc.arg(machine_ids)::UUID[])
 ),
-effective_groups AS (
-  SELECT gmm.group_id
+direct_groups AS (
+  SELECT
+    gmm.machine_id,
+    gmm.group_id
   FROM group_machine_memberships AS gmm
-  WHERE gmm.machine_id = sqlc.arg(machine_id)
+  JOIN scoped_machines AS sm
+    ON sm.machine_id = gmm.machine_id
+  WHERE NOT gmm.pending
 
   UNION
 
-  SELECT gum.group_id
-  FROM machines AS m
-  JOIN users AS u
-    ON u.upn = NULLIF(m.primary_user, '')
+  SELECT
+    sm.machine_id,
+    gum.group_id
+  FROM scoped_machines AS sm
   JOIN group_user_memberships AS gum
-    ON gum.user_id = u.id
-  WHERE m.id = sqlc.arg(machine_id)
+    ON gum.user_id = sm.primary_user_id
+    AND NOT gum.pending
+),
+effective_groups AS (
+  SELECT DISTINCT
+    dg.machine_id,
+    ga.ancestor_id AS group_id
+  FROM direct_groups AS dg
+  JOIN group_ancestors AS ga
+    ON ga.group_id = dg.group_id
 ),
 matching_targets AS (
   SELECT
+    sm.machine_id,
     rt.rule_id,
     rt.subject_kind,
     rt.subject_id,
@@ -106,28 +119,41 @@ matching_targets AS (
     rt.priority,
     rt.policy,
     rt.cel_expression
-  FROM rule_targets AS rt
-  WHERE rt.subject_kind = 'all_devices'
+  FROM scoped_machines AS sm
+  JOIN rule_targets AS rt
+    ON rt.subject_kind = 'all_devices'
     OR (
       rt.subject_kind = 'all_users'
-      AND EXISTS (SELECT 1 FROM machine_user)
+      AND sm.primary_user_id IS NOT NULL
     )
     OR (
       rt.subject_kind = 'group'
       AND EXISTS (
         SELECT 1
         FROM effective_groups AS eg
-        WHERE eg.group_id = rt.subject_id
+        WHERE eg.machine_id = sm.machine_id
+          AND eg.group_id = rt.subject_id
       )
     )
+    OR (
+      rt.subject_kind = 'machine'
+      AND rt.subject_id = sm.machine_id
+    )
+    OR (
+      rt.subject_kind = 'user'
+      AND rt.subject_id = sm.primary_user_id
+    )
 ),
 matching_excludes AS (
-  SELECT DISTINCT mt.rule_id
+  SELECT DISTINCT
+    mt.machine_id,
+    mt.rule_id
   FROM matching_targets AS mt
   WHERE mt.assignment = 'exclude'
 ),
 matching_includes AS (
   SELECT
+    mt.machine_id,
     mt.rule_id,
     mt.subject_kind,
     mt.subject_id,
@@ -135,7 +161,7 @@ matching_includes AS (
     mt.policy,
     mt.cel_expression,
     ROW_NUMBER() OVER (
-      PARTITION BY mt.rule_id
+      PARTITION BY mt.machine_id, mt.rule_id
       ORDER BY mt.priority ASC, mt.subject_kind ASC, mt.subject_id ASC NULLS FIRST
     ) AS include_rank
   FROM matching_targets AS mt
@@ -143,6 +169,7 @@ matching_includes AS (
 ),
 winning_includes AS (
   SELECT
+    machine_id,
     rule_id,
     policy,
     cel_expression
@@ -150,6 +177,7 @@ winning_includes AS (
   WHERE include_rank = 1
 )
 SELECT
+  wi.machine_id,
   r.id,
   r.name,
   r.rule_type,
@@ -158,14 +186,15 @@ SELECT
   r.custom_url,
   wi.policy,
   wi.cel_expression
-FROM rules AS r
-JOIN winning_includes AS wi
-  ON wi.rule_id = r.id
+FROM winning_includes AS wi
+JOIN rules AS r
+  ON r.id = wi.rule_id
 LEFT JOIN matching_excludes AS me
-  ON me.rule_id = r.id
+  ON me.machine_id = wi.machine_id
+  AND me.rule_id = r.id
 WHERE me.rule_id IS NULL
   AND r.enabled = TRUE
-ORDER BY r.rule_type ASC, r.identifier ASC, r.id ASC;
+ORDER BY wi.machine_id ASC, r.rule_type ASC, r.identifier ASC, r.id ASC;
 
 -- name: CreateRuleTarget :exec
 INSERT INTO rule_targets (
@@ -201,12 +230,20 @@ SELECT
     WHEN rt.subject_kind = 'group' THEN COALESCE(g.name, '')
     WHEN rt.subject_kind = 'all_devices' THEN 'All Devices'
     WHEN rt.subject_kind = 'all_users' THEN 'All Users'
+    WHEN rt.subject_kind = 'machine' THEN COALESCE(m.hostname, '')
+    WHEN rt.subject_kind = 'user' THEN COALESCE(NULLIF(u.display_name, ''), u.upn, '')
     ELSE ''
   END AS subject_name
 FROM rule_targets AS rt
 LEFT JOIN groups AS g
   ON rt.subject_kind = 'group'
   AND g.id = rt.subject_id
+LEFT JOIN machines AS m
+  ON rt.subject_kind = 'machine'
+  AND m.id = rt.subject_id
+LEFT JOIN users AS u
+  ON rt.subject_kind = 'user'
+  AND u.id = rt.subject_id
 WHERE rt.rule_id = sqlc.arg(rule_id)
 ORDER BY
   CASE WHEN rt.assignment = 'include' THEN 0 ELSE 1 END ASC,
@@ -217,3 +254,19 @@ ORDER BY
 -- name: DeleteRuleTargetsByRule :exec
 DELETE FROM rule_targets
 WHERE rule_id = sqlc.arg(rule_id);
+
+-- name: GetRuleByIdentifier :one
+SELECT
+  id,
+  name,
+  description,
+  rule_type,
+  identifier,
+  custom_message,
+  custom_url,
+  enabled,
+  created_at,
+  updated_at
+FROM rules
+WHERE rule_type = sqlc.arg(rule_type)
+  AND identifier = sqlc.arg(identifier);
@@ -0,0 +1,98 @@
+-- name: GetServiceAccount :one
+SELECT
+  sa.id,
+  sa.name,
+  sa.description,
+  (
+    SELECT COUNT(*)::INT4
+    FROM api_tokens AS t
+    WHERE t.service_account_id = sa.id
+      AND t.revoked_at IS NULL
+  ) AS active_token_count,
+  sa.created_at,
+  sa.updated_at
+FROM service_accounts AS sa
+WHERE sa.id = sqlc.arg(id);
+
+-- name: CreateServiceAccount :one
+INSERT INTO service_accounts (
+  id,
+  name,
+  description
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(name),
+  sqlc.arg(description)
+)
+RETURNING id;
+
+-- name: UpdateServiceAccount :execrows
+UPDATE service_accounts
+SET
+  name = sqlc.arg(name),
+  description = sqlc.arg(description)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteServiceAccount :execrows
+DELETE FROM service_accounts
+WHERE id = sqlc.arg(id);
+
+-- name: GetAPIToken :one
+SELECT
+  t.id,
+  t.service_account_id,
+  sa.name AS service_account_name,
+  t.name,
+  t.token_prefix,
+  t.scopes,
+  t.expires_at,
+  t.last_used_at,
+  t.revoked_at,
+  t.created_at
+FROM api_tokens AS t
+JOIN service_accounts AS sa
+  ON sa.id = t.service_account_id
+WHERE t.id = sqlc.arg(id);
+
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (
+  id,
+  service_account_id,
+  name,
+  token_prefix,
+  token_hash,
+  scopes,
+  expires_at
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(service_account_id),
+  sqlc.arg(name),
+  sqlc.arg(token_prefix),
+  sqlc.arg(token_hash),
+  sqlc.arg(scopes),
+  sqlc.arg(expires_at)
+)
+RETURNING id;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens
+SET
+  revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = sqlc.arg(id);
+
+-- name: AuthenticateAPIToken :one
+UPDATE api_tokens AS t
+SET
+  last_used_at = NOW()
+FROM service_accounts AS sa
+WHERE sa.id = t.service_account_id
+  AND t.token_hash = sqlc.arg(token_hash)
+  AND t.revoked_at IS NULL
+  AND (t.expires_at IS NULL OR t.expires_at > NOW())
+RETURNING
+  t.id,
+  t.service_account_id,
+  sa.name AS service_account_name,
+  t.scopes;
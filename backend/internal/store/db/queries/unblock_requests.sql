@@ -0,0 +1,137 @@
+-- name: GetUnblockRequest :one
+SELECT
+  ur.id,
+  ur.file_sha256,
+  ur.machine_id,
+  m.hostname AS machine_hostname,
+  ur.executable_id,
+  COALESCE(e.file_name, '')::TEXT AS file_name,
+  ur.execution_event_id,
+  ur.user_id,
+  ur.requester_upn,
+  ur.requester_name,
+  ur.reason,
+  ur.status,
+  ur.scope,
+  ur.group_id,
+  ur.rule_id,
+  ur.rule_change_proposal_id,
+  ur.reviewed_by,
+  ur.reviewed_by_name,
+  ur.review_comment,
+  ur.reviewed_at,
+  ur.created_at,
+  ur.updated_at
+FROM unblock_requests AS ur
+JOIN machines AS m ON m.id = ur.machine_id
+LEFT JOIN executables AS e ON e.id = ur.executable_id
+WHERE ur.id = sqlc.arg(id);
+
+-- name: GetUnblockTarget :one
+SELECT
+  m.id AS machine_id,
+  m.hostname AS machine_hostname,
+  ev.id AS execution_event_id,
+  ev.decision,
+  ev.occurred_at,
+  e.id AS executable_id,
+  e.file_name
+FROM machines AS m
+JOIN execution_events AS ev ON ev.machine_id = m.id
+JOIN executables AS e ON e.id = ev.executable_id
+WHERE m.id = sqlc.arg(machine_id)
+  AND e.file_sha256 = sqlc.arg(file_sha256)
+ORDER BY
+  (ev.decision::TEXT LIKE 'block_%') DESC,
+  ev.occurred_at DESC NULLS LAST,
+  ev.created_at DESC
+LIMIT 1;
+
+-- name: IsUnblockTargetRequester :one
+SELECT EXISTS (
+  SELECT 1
+  FROM users AS u
+  WHERE u.upn <> ''
+    AND lower(u.upn) = lower(sqlc.arg(requester_upn))
+    AND (
+      EXISTS (
+        SELECT 1
+        FROM machines AS m
+        WHERE m.id = sqlc.arg(machine_id)
+          AND m.primary_user_id = u.id
+      )
+      OR EXISTS (
+        SELECT 1
+        FROM execution_events AS ev
+        JOIN executables AS e ON e.id = ev.executable_id
+        LEFT JOIN execution_event_logged_in_users AS eli
+          ON eli.execution_event_id = ev.id
+          AND eli.user_id = u.id
+        WHERE ev.machine_id = sqlc.arg(machine_id)
+          AND e.file_sha256 = sqlc.arg(file_sha256)
+          AND (ev.executing_user_id = u.id OR eli.user_id IS NOT NULL)
+      )
+    )
+) AS is_requester;
+
+-- name: CreateUnblockRequest :one
+INSERT INTO unblock_requests (
+  id,
+  file_sha256,
+  machine_id,
+  executable_id,
+  execution_event_id,
+  user_id,
+  requester_upn,
+  requester_name,
+  reason
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(file_sha256),
+  sqlc.arg(machine_id),
+  sqlc.narg(executable_id),
+  sqlc.narg(execution_event_id),
+  (
+    SELECT u.id
+    FROM users AS u
+    WHERE lower(u.upn) = lower(sqlc.arg(requester_upn)::TEXT)
+    LIMIT 1
+  ),
+  sqlc.arg(requester_upn),
+  sqlc.arg(requester_name),
+  sqlc.arg(reason)
+)
+RETURNING id;
+
+-- name: ReviewUnblockRequest :execrows
+UPDATE unblock_requests
+SET
+  status = sqlc.arg(status),
+  scope = sqlc.narg(scope),
+  group_id = sqlc.narg(group_id),
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_by_name = sqlc.arg(reviewed_by_name),
+  review_comment = sqlc.arg(review_comment),
+  reviewed_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'pending';
+
+-- name: ReopenUnblockRequest :execrows
+UPDATE unblock_requests
+SET
+  status = 'pending',
+  scope = NULL,
+  group_id = NULL,
+  reviewed_by = '',
+  reviewed_by_name = '',
+  review_comment = '',
+  reviewed_at = NULL
+WHERE id = sqlc.arg(id);
+
+-- name: SetUnblockRequestOutcome :execrows
+UPDATE unblock_requests
+SET
+  rule_id = sqlc.narg(rule_id),
+  rule_change_proposal_id = sqlc.narg(rule_change_proposal_id)
+WHERE id = sqlc.arg(id);
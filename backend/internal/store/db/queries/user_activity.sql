@@ -0,0 +1,50 @@
+-- name: GetUserActivityCounts :one
+SELECT
+  COUNT(*)::INT4 AS event_count,
+  COUNT(*) FILTER (WHERE ee.decision::TEXT LIKE 'allow%')::INT4 AS allowed_count,
+  COUNT(*) FILTER (WHERE ee.decision::TEXT LIKE 'block%')::INT4 AS blocked_count
+FROM execution_events AS ee
+WHERE ee.executing_user_id = sqlc.arg(user_id)::UUID;
+
+-- name: ListUserActivityDecisionCounts :many
+SELECT
+  ee.decision,
+  COUNT(*)::INT4 AS count
+FROM execution_events AS ee
+WHERE ee.executing_user_id = sqlc.arg(user_id)::UUID
+GROUP BY ee.decision
+ORDER BY ee.decision ASC;
+
+-- name: ListUserActivityMachines :many
+SELECT
+  m.id AS machine_id,
+  m.hostname,
+  COUNT(*)::INT4 AS event_count,
+  COUNT(*) FILTER (WHERE ee.decision::TEXT LIKE 'block%')::INT4 AS blocked_count,
+  MAX(ee.created_at)::TIMESTAMPTZ AS last_event_at
+FROM execution_events AS ee
+JOIN machines AS m
+  ON m.id = ee.machine_id
+WHERE ee.executing_user_id = sqlc.arg(user_id)::UUID
+GROUP BY m.id, m.hostname
+ORDER BY last_event_at DESC, m.id ASC;
+
+-- name: ListUserActivityExecutables :many
+-- Lists the executables a user ran most, or with blocked_only, those blocked most.
+SELECT
+  x.id AS executable_id,
+  x.file_name,
+  x.signing_id,
+  x.team_id,
+  COUNT(DISTINCT ee.machine_id)::INT4 AS machine_count,
+  COUNT(*)::INT4 AS event_count,
+  COUNT(*) FILTER (WHERE ee.decision::TEXT LIKE 'block%')::INT4 AS blocked_count,
+  MAX(ee.created_at)::TIMESTAMPTZ AS last_event_at
+FROM execution_events AS ee
+JOIN executables AS x
+  ON x.id = ee.executable_id
+WHERE ee.executing_user_id = sqlc.arg(user_id)::UUID
+  AND (NOT sqlc.arg(blocked_only)::BOOL OR ee.decision::TEXT LIKE 'block%')
+GROUP BY x.id, x.file_name, x.signing_id, x.team_id
+ORDER BY event_count DESC, last_event_at DESC, x.id ASC
+LIMIT sqlc.arg(limit_count);
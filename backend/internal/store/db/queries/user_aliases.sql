@@ -0,0 +1,189 @@
+-- name: ListUserAliases :many
+SELECT
+  ua.id,
+  ua.user_id,
+  ua.alias,
+  ua.source,
+  ua.created_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM user_aliases AS ua
+WHERE ua.user_id = sqlc.arg(user_id)
+ORDER BY ua.alias ASC;
+
+-- name: GetUserAlias :one
+SELECT
+  ua.id,
+  ua.user_id,
+  ua.alias,
+  ua.source,
+  ua.created_at
+FROM user_aliases AS ua
+WHERE ua.id = sqlc.arg(id)
+  AND ua.user_id = sqlc.arg(user_id);
+
+-- name: CreateUserAlias :one
+INSERT INTO user_aliases (
+  id,
+  user_id,
+  alias,
+  source
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(user_id),
+  sqlc.arg(alias),
+  sqlc.arg(source)
+)
+RETURNING
+  id,
+  user_id,
+  alias,
+  source,
+  created_at;
+
+-- name: DeleteUserAlias :execrows
+DELETE FROM user_aliases
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteEntraUserAliases :exec
+DELETE FROM user_aliases
+WHERE source = 'entra';
+
+-- name: InsertEntraUserAliases :exec
+INSERT INTO user_aliases (
+  id,
+  user_id,
+  alias,
+  source
+)
+SELECT
+  UNNEST(sqlc.arg(ids)::UUID[]),
+  UNNEST(sqlc.arg(user_ids)::UUID[]),
+  UNNEST(sqlc.arg(aliases)::TEXT[]),
+  'entra'::principal_source
+ON CONFLICT (alias) DO NOTHING;
+
+-- name: ListMachinePrimaryUsers :many
+SELECT
+  m.id,
+  m.primary_user
+FROM machines AS m
+ORDER BY m.id ASC;
+
+-- name: ResolveUserNames :many
+-- Matches reported user names to users from their candidate lookups, with the same precedence as
+-- ResolveMachinePrimaryUsers. Names that match no user, or several, are left out.
+WITH candidates AS (
+  SELECT
+    UNNEST(sqlc.arg(candidate_names)::TEXT[]) AS name,
+    UNNEST(sqlc.arg(candidate_lookups)::TEXT[]) AS lookup
+),
+matches AS (
+  SELECT
+    c.name,
+    u.id AS user_id,
+    1 AS preference
+  FROM candidates AS c
+  JOIN users AS u
+    ON u.upn <> ''
+    AND lower(u.upn) = c.lookup
+
+  UNION
+
+  SELECT
+    c.name,
+    ua.user_id,
+    2 AS preference
+  FROM candidates AS c
+  JOIN user_aliases AS ua
+    ON ua.alias = c.lookup
+),
+best_matches AS (
+  SELECT
+    mt.name,
+    mt.user_id
+  FROM matches AS mt
+  WHERE mt.preference = (
+    SELECT MIN(other.preference)
+    FROM matches AS other
+    WHERE other.name = mt.name
+  )
+)
+SELECT
+  bm.name::TEXT AS name,
+  (ARRAY_AGG(DISTINCT bm.user_id))[1]::UUID AS user_id
+FROM best_matches AS bm
+GROUP BY bm.name
+HAVING COUNT(DISTINCT bm.user_id) = 1;
+
+-- name: ResolveMachinePrimaryUsers :many
+-- Sets primary_user_id for the given machines from their candidate lookups. An admin override
+-- wins outright; otherwise a UPN match beats an alias match, and a machine whose best matches name
+-- several users is left unresolved.
+WITH candidates AS (
+  SELECT
+    UNNEST(sqlc.arg(candidate_machine_ids)::UUID[]) AS machine_id,
+    UNNEST(sqlc.arg(candidate_lookups)::TEXT[]) AS lookup
+),
+matches AS (
+  SELECT
+    c.machine_id,
+    u.id AS user_id,
+    1 AS preference
+  FROM candidates AS c
+  JOIN users AS u
+    ON u.upn <> ''
+    AND lower(u.upn) = c.lookup
+
+  UNION
+
+  SELECT
+    c.machine_id,
+    ua.user_id,
+    2 AS preference
+  FROM candidates AS c
+  JOIN user_aliases AS ua
+    ON ua.alias = c.lookup
+),
+best_matches AS (
+  SELECT
+    mt.machine_id,
+    mt.user_id
+  FROM matches AS mt
+  WHERE mt.preference = (
+    SELECT MIN(other.preference)
+    FROM matches AS other
+    WHERE other.machine_id = mt.machine_id
+  )
+),
+resolved AS (
+  SELECT
+    bm.machine_id,
+    (ARRAY_AGG(DISTINCT bm.user_id))[1]::UUID AS user_id
+  FROM best_matches AS bm
+  GROUP BY bm.machine_id
+  HAVING COUNT(DISTINCT bm.user_id) = 1
+),
+targets AS (
+  SELECT UNNEST(sqlc.arg(machine_ids)::UUID[]) AS machine_id
+),
+effective AS (
+  SELECT
+    t.machine_id,
+    CASE
+      WHEN o.machine_id IS NOT NULL THEN o.user_id
+      ELSE r.user_id
+    END AS user_id
+  FROM targets AS t
+  LEFT JOIN machine_primary_user_overrides AS o
+    ON o.machine_id = t.machine_id
+  LEFT JOIN resolved AS r
+    ON r.machine_id = t.machine_id
+)
+UPDATE machines AS m
+SET primary_user_id = e.user_id
+FROM effective AS e
+WHERE m.id = e.machine_id
+  AND m.primary_user_id IS DISTINCT FROM e.user_id
+RETURNING m.id;
@@ -3,24 +3,28 @@ INSERT INTO users (
   id,
   upn,
   display_name,
-  source
+  source,
+  directory_attributes
 )
 VALUES (
   sqlc.arg(id),
   sqlc.arg(upn),
   sqlc.arg(display_name),
-  sqlc.arg(source)
+  sqlc.arg(source),
+  sqlc.arg(directory_attributes)
 )
 ON CONFLICT (id) DO UPDATE
 SET
   upn = EXCLUDED.upn,
   display_name = EXCLUDED.display_name,
-  source = EXCLUDED.source
+  source = EXCLUDED.source,
+  directory_attributes = EXCLUDED.directory_attributes
 RETURNING
   id,
   upn,
   display_name,
   source,
+  directory_attributes,
   created_at,
   updated_at;
 
@@ -30,6 +34,7 @@ SELECT
   upn,
   display_name,
   source,
+  directory_attributes,
   created_at,
   updated_at
 FROM users
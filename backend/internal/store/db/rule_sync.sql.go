@@ -23,6 +23,23 @@ SELECT
   COALESCE(ms.pending_payload_rule_count, 0)::INT8 AS pending_payload_rule_count,
   COALESCE(ms.pending_full_sync, FALSE) AS pending_full_sync,
   ms.pending_preflight_at,
+  machine_rule_sync_status(
+    ms.pending_preflight_at,
+    ms.desired_targets,
+    ms.applied_targets,
+    ms.desired_binary_rule_count,
+    ms.binary_rule_count,
+    ms.desired_certificate_rule_count,
+    ms.certificate_rule_count,
+    ms.desired_teamid_rule_count,
+    ms.teamid_rule_count,
+    ms.desired_signingid_rule_count,
+    ms.signingid_rule_count,
+    ms.desired_cdhash_rule_count,
+    ms.cdhash_rule_count,
+    ms.last_clean_sync_at,
+    ms.last_reported_counts_match_at
+  ) AS rule_sync_status,
   COALESCE(ms.desired_binary_rule_count, 0)::INT4 AS desired_binary_rule_count,
   COALESCE(ms.desired_certificate_rule_count, 0)::INT4 AS desired_certificate_rule_count,
   COALESCE(ms.desired_teamid_rule_count, 0)::INT4 AS desired_teamid_rule_count,
@@ -38,7 +55,11 @@ SELECT
   ms.last_rule_sync_attempt_at,
   ms.last_rule_sync_success_at,
   ms.last_clean_sync_at,
-  ms.last_reported_counts_match_at
+  ms.last_reported_counts_match_at,
+  ms.requested_clean_sync,
+  ms.requested_clean_sync_at,
+  COALESCE(ms.pending_clean_all, FALSE) AS pending_clean_all,
+  ms.pending_clean_sync_requested_at
 FROM machines AS m
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
@@ -55,6 +76,7 @@ type GetMachineSyncStateRow struct {
 	PendingPayloadRuleCount     int64
 	PendingFullSync             bool
 	PendingPreflightAt          *time.Time
+	RuleSyncStatus              string
 	DesiredBinaryRuleCount      int32
 	DesiredCertificateRuleCount int32
 	DesiredTeamIDRuleCount      int32
@@ -71,6 +93,10 @@ type GetMachineSyncStateRow struct {
 	LastRuleSyncSuccessAt       *time.Time
 	LastCleanSyncAt             *time.Time
 	LastReportedCountsMatchAt   *time.Time
+	RequestedCleanSync          NullCleanSyncType
+	RequestedCleanSyncAt        *time.Time
+	PendingCleanAll             bool
+	PendingCleanSyncRequestedAt *time.Time
 }
 
 func (q *Queries) GetMachineSyncState(ctx context.Context, machineID uuid.UUID) (GetMachineSyncStateRow, error) {
@@ -86,6 +112,7 @@ func (q *Queries) GetMachineSyncState(ctx context.Context, machineID uuid.UUID)
 		&i.PendingPayloadRuleCount,
 		&i.PendingFullSync,
 		&i.PendingPreflightAt,
+		&i.RuleSyncStatus,
 		&i.DesiredBinaryRuleCount,
 		&i.DesiredCertificateRuleCount,
 		&i.DesiredTeamIDRuleCount,
@@ -102,6 +129,10 @@ func (q *Queries) GetMachineSyncState(ctx context.Context, machineID uuid.UUID)
 		&i.LastRuleSyncSuccessAt,
 		&i.LastCleanSyncAt,
 		&i.LastReportedCountsMatchAt,
+		&i.RequestedCleanSync,
+		&i.RequestedCleanSyncAt,
+		&i.PendingCleanAll,
+		&i.PendingCleanSyncRequestedAt,
 	)
 	return i, err
 }
@@ -114,11 +145,21 @@ SET
   pending_payload = '[]'::JSONB,
   pending_payload_rule_count = 0,
   pending_full_sync = FALSE,
+  pending_clean_all = FALSE,
+  pending_clean_sync_requested_at = NULL,
   pending_preflight_at = NULL,
   last_rule_sync_success_at = $1,
   last_clean_sync_at = CASE
     WHEN pending_full_sync THEN $1
     ELSE last_clean_sync_at
+  END,
+  requested_clean_sync = CASE
+    WHEN requested_clean_sync_at IS NOT DISTINCT FROM pending_clean_sync_requested_at THEN NULL
+    ELSE requested_clean_sync
+  END,
+  requested_clean_sync_at = CASE
+    WHEN requested_clean_sync_at IS NOT DISTINCT FROM pending_clean_sync_requested_at THEN NULL
+    ELSE requested_clean_sync_at
   END
 WHERE machine_id = $2
 `
@@ -168,6 +209,40 @@ func (q *Queries) RecordMachineSyncPostflight(ctx context.Context, arg RecordMac
 	return result.RowsAffected(), nil
 }
 
+const requestMachineCleanSync = `-- name: RequestMachineCleanSync :execrows
+INSERT INTO machine_sync_states AS ms (
+  machine_id,
+  requested_clean_sync,
+  requested_clean_sync_at
+)
+SELECT
+  m.id,
+  $1::clean_sync_type,
+  $2::TIMESTAMPTZ
+FROM machines AS m
+WHERE m.id = ANY($3::UUID[])
+ON CONFLICT (machine_id) DO UPDATE
+SET
+  requested_clean_sync = GREATEST(ms.requested_clean_sync, EXCLUDED.requested_clean_sync),
+  requested_clean_sync_at = EXCLUDED.requested_clean_sync_at
+`
+
+type RequestMachineCleanSyncParams struct {
+	SyncType    CleanSyncType
+	RequestedAt time.Time
+	MachineIds  []uuid.UUID
+}
+
+// A clean request never downgrades an outstanding clean-all request; the enum orders clean
+// before clean_all.
+func (q *Queries) RequestMachineCleanSync(ctx context.Context, arg RequestMachineCleanSyncParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requestMachineCleanSync, arg.SyncType, arg.RequestedAt, arg.MachineIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const upsertMachineDesiredTargets = `-- name: UpsertMachineDesiredTargets :exec
 INSERT INTO machine_sync_states (
   machine_id,
@@ -238,6 +313,8 @@ INSERT INTO machine_sync_states (
   pending_payload,
   pending_payload_rule_count,
   pending_full_sync,
+  pending_clean_all,
+  pending_clean_sync_requested_at,
   pending_preflight_at,
   desired_binary_rule_count,
   desired_certificate_rule_count,
@@ -279,7 +356,9 @@ VALUES (
   $21,
   $22,
   $23,
-  $24
+  $24,
+  $25,
+  $26
 )
 ON CONFLICT (machine_id) DO UPDATE
 SET
@@ -290,6 +369,8 @@ SET
   pending_payload = EXCLUDED.pending_payload,
   pending_payload_rule_count = EXCLUDED.pending_payload_rule_count,
   pending_full_sync = EXCLUDED.pending_full_sync,
+  pending_clean_all = EXCLUDED.pending_clean_all,
+  pending_clean_sync_requested_at = EXCLUDED.pending_clean_sync_requested_at,
   pending_preflight_at = EXCLUDED.pending_preflight_at,
   desired_binary_rule_count = EXCLUDED.desired_binary_rule_count,
   desired_certificate_rule_count = EXCLUDED.desired_certificate_rule_count,
@@ -324,6 +405,8 @@ type UpsertMachineSyncStateParams struct {
 	PendingPayload              []byte
 	PendingPayloadRuleCount     int64
 	PendingFullSync             bool
+	PendingCleanAll             bool
+	PendingCleanSyncRequestedAt *time.Time
 	PendingPreflightAt          *time.Time
 	DesiredBinaryRuleCount      int32
 	DesiredCertificateRuleCount int32
@@ -352,6 +435,8 @@ func (q *Queries) UpsertMachineSyncState(ctx context.Context, arg UpsertMachineS
 		arg.PendingPayload,
 		arg.PendingPayloadRuleCount,
 		arg.PendingFullSync,
+		arg.PendingCleanAll,
+		arg.PendingCleanSyncRequestedAt,
 		arg.PendingPreflightAt,
 		arg.DesiredBinaryRuleCount,
 		arg.DesiredCertificateRuleCount,
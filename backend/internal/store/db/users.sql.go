@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	uuid "github.com/google/uuid"
 )
@@ -27,20 +28,32 @@ SELECT
   upn,
   display_name,
   source,
+  directory_attributes,
   created_at,
   updated_at
 FROM users
 WHERE id = $1
 `
 
-func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+type GetUserRow struct {
+	ID                  uuid.UUID
+	Upn                 string
+	DisplayName         string
+	Source              PrincipalSource
+	DirectoryAttributes []byte
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (GetUserRow, error) {
 	row := q.db.QueryRow(ctx, getUser, id)
-	var i User
+	var i GetUserRow
 	err := row.Scan(
 		&i.ID,
 		&i.Upn,
 		&i.DisplayName,
 		&i.Source,
+		&i.DirectoryAttributes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -66,15 +79,24 @@ type ListUsersParams struct {
 	LimitCount  int32
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+type ListUsersRow struct {
+	ID          uuid.UUID
+	Upn         string
+	DisplayName string
+	Source      PrincipalSource
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
 	rows, err := q.db.Query(ctx, listUsers, arg.OffsetCount, arg.LimitCount)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []User
+	var items []ListUsersRow
 	for rows.Next() {
-		var i User
+		var i ListUsersRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Upn,
@@ -98,48 +120,65 @@ INSERT INTO users (
   id,
   upn,
   display_name,
-  source
+  source,
+  directory_attributes
 )
 VALUES (
   $1,
   $2,
   $3,
-  $4
+  $4,
+  $5
 )
 ON CONFLICT (id) DO UPDATE
 SET
   upn = EXCLUDED.upn,
   display_name = EXCLUDED.display_name,
-  source = EXCLUDED.source
+  source = EXCLUDED.source,
+  directory_attributes = EXCLUDED.directory_attributes
 RETURNING
   id,
   upn,
   display_name,
   source,
+  directory_attributes,
   created_at,
   updated_at
 `
 
 type UpsertUserParams struct {
-	ID          uuid.UUID
-	Upn         string
-	DisplayName string
-	Source      PrincipalSource
+	ID                  uuid.UUID
+	Upn                 string
+	DisplayName         string
+	Source              PrincipalSource
+	DirectoryAttributes []byte
+}
+
+type UpsertUserRow struct {
+	ID                  uuid.UUID
+	Upn                 string
+	DisplayName         string
+	Source              PrincipalSource
+	DirectoryAttributes []byte
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
-func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) (User, error) {
+func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) (UpsertUserRow, error) {
 	row := q.db.QueryRow(ctx, upsertUser,
 		arg.ID,
 		arg.Upn,
 		arg.DisplayName,
 		arg.Source,
+		arg.DirectoryAttributes,
 	)
-	var i User
+	var i UpsertUserRow
 	err := row.Scan(
 		&i.ID,
 		&i.Upn,
 		&i.DisplayName,
 		&i.Source,
+		&i.DirectoryAttributes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TYPE access_role AS ENUM ('viewer', 'helpdesk', 'rule_editor', 'admin');
+
+CREATE TABLE group_role_mappings (
+  id UUID PRIMARY KEY,
+  group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+  role access_role NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT group_role_mappings_group_unique UNIQUE (group_id)
+);
+
+CREATE TRIGGER group_role_mappings_set_updated_at
+  BEFORE UPDATE ON group_role_mappings
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
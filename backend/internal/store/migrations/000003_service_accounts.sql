@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE service_accounts (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT service_accounts_name_not_blank CHECK (btrim(name) <> ''),
+  CONSTRAINT service_accounts_name_unique UNIQUE (name)
+);
+
+CREATE TABLE api_tokens (
+  id UUID PRIMARY KEY,
+  service_account_id UUID NOT NULL REFERENCES service_accounts (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  token_prefix TEXT NOT NULL,
+  token_hash TEXT NOT NULL,
+  scopes TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+  expires_at TIMESTAMPTZ NULL,
+  last_used_at TIMESTAMPTZ NULL,
+  revoked_at TIMESTAMPTZ NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT api_tokens_name_not_blank CHECK (btrim(name) <> ''),
+  CONSTRAINT api_tokens_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX api_tokens_service_account_id_idx ON api_tokens (service_account_id);
+
+CREATE TRIGGER service_accounts_set_updated_at
+  BEFORE UPDATE ON service_accounts
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TYPE rule_change_action AS ENUM ('create', 'update', 'delete');
+
+CREATE TYPE rule_change_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE rule_change_proposals (
+  id UUID PRIMARY KEY,
+  action rule_change_action NOT NULL,
+  status rule_change_status NOT NULL DEFAULT 'pending',
+  rule_id UUID REFERENCES rules (id) ON DELETE SET NULL,
+  rule_name TEXT NOT NULL,
+  base_updated_at TIMESTAMPTZ,
+  current_rule JSONB,
+  proposed_rule JSONB,
+  proposed_by TEXT NOT NULL,
+  proposed_by_name TEXT NOT NULL DEFAULT '',
+  reviewed_by TEXT NOT NULL DEFAULT '',
+  reviewed_by_name TEXT NOT NULL DEFAULT '',
+  review_comment TEXT NOT NULL DEFAULT '',
+  reviewed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT rule_change_proposals_proposed_by_not_blank CHECK (btrim(proposed_by) <> ''),
+  CONSTRAINT rule_change_proposals_current_rule_shape
+    CHECK (current_rule IS NULL OR jsonb_typeof(current_rule) = 'object'),
+  CONSTRAINT rule_change_proposals_proposed_rule_shape
+    CHECK (proposed_rule IS NULL OR jsonb_typeof(proposed_rule) = 'object')
+);
+
+CREATE INDEX rule_change_proposals_status_idx ON rule_change_proposals (status, created_at);
+
+CREATE INDEX rule_change_proposals_rule_id_idx ON rule_change_proposals (rule_id);
+
+CREATE TRIGGER rule_change_proposals_set_updated_at
+  BEFORE UPDATE ON rule_change_proposals
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TYPE unblock_request_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TYPE unblock_scope AS ENUM ('user', 'machine', 'group');
+
+CREATE TABLE unblock_requests (
+  id UUID PRIMARY KEY,
+  file_sha256 TEXT NOT NULL,
+  machine_id UUID NOT NULL REFERENCES machines (id) ON DELETE CASCADE,
+  executable_id UUID REFERENCES executables (id) ON DELETE SET NULL,
+  execution_event_id UUID REFERENCES execution_events (id) ON DELETE SET NULL,
+  user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+  requester_upn TEXT NOT NULL,
+  requester_name TEXT NOT NULL DEFAULT '',
+  reason TEXT NOT NULL,
+  status unblock_request_status NOT NULL DEFAULT 'pending',
+  scope unblock_scope,
+  group_id UUID REFERENCES groups (id) ON DELETE SET NULL,
+  rule_id UUID REFERENCES rules (id) ON DELETE SET NULL,
+  rule_change_proposal_id UUID REFERENCES rule_change_proposals (id) ON DELETE SET NULL,
+  reviewed_by TEXT NOT NULL DEFAULT '',
+  reviewed_by_name TEXT NOT NULL DEFAULT '',
+  review_comment TEXT NOT NULL DEFAULT '',
+  reviewed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT unblock_requests_file_sha256_not_blank CHECK (btrim(file_sha256) <> ''),
+  CONSTRAINT unblock_requests_requester_upn_not_blank CHECK (btrim(requester_upn) <> ''),
+  CONSTRAINT unblock_requests_reason_not_blank CHECK (btrim(reason) <> '')
+);
+
+CREATE UNIQUE INDEX unblock_requests_pending_unique_idx
+  ON unblock_requests (file_sha256, machine_id, lower(requester_upn))
+  WHERE status = 'pending';
+CREATE INDEX unblock_requests_status_created_idx ON unblock_requests (status, created_at DESC);
+CREATE INDEX unblock_requests_machine_id_idx ON unblock_requests (machine_id);
+CREATE INDEX unblock_requests_requester_upn_idx ON unblock_requests (lower(requester_upn));
+
+CREATE TRIGGER unblock_requests_set_updated_at
+  BEFORE UPDATE ON unblock_requests
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,69 @@
+-- +goose Up
+CREATE TABLE certificates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  sha256 TEXT NOT NULL,
+  common_name TEXT NOT NULL DEFAULT '',
+  organization TEXT NOT NULL DEFAULT '',
+  organizational_unit TEXT NOT NULL DEFAULT '',
+  valid_from TIMESTAMPTZ NOT NULL,
+  valid_until TIMESTAMPTZ NOT NULL,
+  first_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT certificates_sha256_not_blank CHECK (btrim(sha256) <> ''),
+  CONSTRAINT certificates_sha256_unique UNIQUE (sha256)
+);
+
+CREATE INDEX certificates_common_name_idx ON certificates (common_name);
+CREATE INDEX certificates_organization_idx ON certificates (organization);
+
+-- Links each executable to the certificates in its signing chain. Position 0 is the leaf.
+CREATE TABLE executable_certificates (
+  executable_id UUID NOT NULL REFERENCES executables (id) ON DELETE CASCADE,
+  certificate_id UUID NOT NULL REFERENCES certificates (id) ON DELETE CASCADE,
+  chain_position INT4 NOT NULL,
+  PRIMARY KEY (executable_id, certificate_id)
+);
+
+CREATE INDEX executable_certificates_certificate_id_idx ON executable_certificates (certificate_id);
+
+CREATE TRIGGER certificates_set_updated_at
+  BEFORE UPDATE ON certificates
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
+
+INSERT INTO certificates (
+  sha256,
+  common_name,
+  organization,
+  organizational_unit,
+  valid_from,
+  valid_until,
+  first_seen_at,
+  last_seen_at
+)
+SELECT DISTINCT ON (chain.entry ->> 'sha256')
+  chain.entry ->> 'sha256',
+  COALESCE(chain.entry ->> 'common_name', ''),
+  COALESCE(chain.entry ->> 'organization', ''),
+  COALESCE(chain.entry ->> 'organizational_unit', ''),
+  COALESCE((chain.entry ->> 'valid_from')::TIMESTAMPTZ, 'epoch'::TIMESTAMPTZ),
+  COALESCE((chain.entry ->> 'valid_until')::TIMESTAMPTZ, 'epoch'::TIMESTAMPTZ),
+  MIN(e.created_at) OVER (PARTITION BY chain.entry ->> 'sha256'),
+  MAX(e.created_at) OVER (PARTITION BY chain.entry ->> 'sha256')
+FROM executables AS e
+CROSS JOIN LATERAL jsonb_array_elements(e.signing_chain) AS chain (entry)
+WHERE btrim(COALESCE(chain.entry ->> 'sha256', '')) <> ''
+ORDER BY chain.entry ->> 'sha256', e.created_at DESC;
+
+INSERT INTO executable_certificates (executable_id, certificate_id, chain_position)
+SELECT
+  e.id,
+  c.id,
+  MIN(chain.position - 1)::INT4
+FROM executables AS e
+CROSS JOIN LATERAL jsonb_array_elements(e.signing_chain) WITH ORDINALITY AS chain (entry, position)
+JOIN certificates AS c
+  ON c.sha256 = chain.entry ->> 'sha256'
+GROUP BY e.id, c.id;
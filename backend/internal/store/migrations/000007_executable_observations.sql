@@ -0,0 +1,43 @@
+-- +goose Up
+-- One row per executable per machine. Unlike execution_events, observations are not
+-- removed by event retention, so prevalence and first/last seen survive old events.
+CREATE TABLE executable_observations (
+  executable_id UUID NOT NULL REFERENCES executables (id) ON DELETE CASCADE,
+  machine_id UUID NOT NULL REFERENCES machines (id) ON DELETE CASCADE,
+  first_seen_at TIMESTAMPTZ NOT NULL,
+  last_seen_at TIMESTAMPTZ NOT NULL,
+  event_count INT4 NOT NULL DEFAULT 0,
+  executing_users TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (executable_id, machine_id)
+);
+
+CREATE INDEX executable_observations_machine_id_idx ON executable_observations (machine_id);
+
+CREATE TRIGGER executable_observations_set_updated_at
+  BEFORE UPDATE ON executable_observations
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
+
+INSERT INTO executable_observations (
+  executable_id,
+  machine_id,
+  first_seen_at,
+  last_seen_at,
+  event_count,
+  executing_users
+)
+SELECT
+  ee.executable_id,
+  ee.machine_id,
+  MIN(COALESCE(ee.occurred_at, ee.created_at)),
+  MAX(COALESCE(ee.occurred_at, ee.created_at)),
+  COUNT(*)::INT4,
+  COALESCE(
+    ARRAY_AGG(DISTINCT ee.executing_user ORDER BY ee.executing_user)
+      FILTER (WHERE ee.executing_user <> ''),
+    ARRAY[]::TEXT[]
+  )
+FROM execution_events AS ee
+GROUP BY ee.executable_id, ee.machine_id;
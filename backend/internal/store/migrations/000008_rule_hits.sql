@@ -0,0 +1,68 @@
+-- +goose Up
+-- One row per rule per machine that Santa reported a decision for. Like executable
+-- observations, hits are not removed by event retention.
+CREATE TABLE rule_hits (
+  rule_id UUID NOT NULL REFERENCES rules (id) ON DELETE CASCADE,
+  machine_id UUID NOT NULL REFERENCES machines (id) ON DELETE CASCADE,
+  first_hit_at TIMESTAMPTZ NOT NULL,
+  last_hit_at TIMESTAMPTZ NOT NULL,
+  hit_count INT8 NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (rule_id, machine_id)
+);
+
+CREATE INDEX rule_hits_machine_id_idx ON rule_hits (machine_id);
+
+CREATE TRIGGER rule_hits_set_updated_at
+  BEFORE UPDATE ON rule_hits
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
+
+WITH event_rules AS (
+  SELECT
+    ee.machine_id,
+    COALESCE(ee.occurred_at, ee.created_at) AS hit_at,
+    CASE
+      WHEN ee.decision IN ('allow_binary', 'block_binary') THEN 'binary'
+      WHEN ee.decision IN ('allow_cd_hash', 'block_cd_hash') THEN 'cd_hash'
+      WHEN ee.decision IN ('allow_signing_id', 'block_signing_id') THEN 'signing_id'
+      WHEN ee.decision IN ('allow_team_id', 'block_team_id') THEN 'team_id'
+      WHEN ee.decision IN ('allow_certificate', 'block_certificate') THEN 'certificate'
+    END AS rule_type,
+    CASE
+      WHEN ee.decision IN ('allow_binary', 'block_binary') THEN x.file_sha256
+      WHEN ee.decision IN ('allow_cd_hash', 'block_cd_hash') THEN x.cdhash
+      WHEN ee.decision IN ('allow_signing_id', 'block_signing_id') THEN x.signing_id
+      WHEN ee.decision IN ('allow_team_id', 'block_team_id') THEN x.team_id
+      WHEN ee.decision IN ('allow_certificate', 'block_certificate') THEN (
+        SELECT c.sha256
+        FROM executable_certificates AS ec
+        JOIN certificates AS c
+          ON c.id = ec.certificate_id
+        WHERE ec.executable_id = x.id
+          AND ec.chain_position = 0
+      )
+    END AS identifier
+  FROM execution_events AS ee
+  JOIN executables AS x
+    ON x.id = ee.executable_id
+)
+INSERT INTO rule_hits (
+  rule_id,
+  machine_id,
+  first_hit_at,
+  last_hit_at,
+  hit_count
+)
+SELECT
+  r.id,
+  er.machine_id,
+  MIN(er.hit_at),
+  MAX(er.hit_at),
+  COUNT(*)
+FROM event_rules AS er
+JOIN rules AS r
+  ON r.rule_type::TEXT = er.rule_type
+  AND r.identifier = er.identifier
+GROUP BY r.id, er.machine_id;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TYPE clean_sync_type AS ENUM ('clean', 'clean_all');
+
+-- requested_clean_sync is set by an admin and read by the next preflight, which freezes the
+-- request time into pending_clean_sync_requested_at. Promotion clears the request only if it
+-- has not been replaced since, so a request made mid-sync survives to the next preflight.
+ALTER TABLE machine_sync_states
+  ADD COLUMN requested_clean_sync clean_sync_type NULL,
+  ADD COLUMN requested_clean_sync_at TIMESTAMPTZ NULL,
+  ADD COLUMN pending_clean_all BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN pending_clean_sync_requested_at TIMESTAMPTZ NULL;
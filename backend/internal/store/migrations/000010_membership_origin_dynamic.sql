@@ -0,0 +1,5 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Runs on its own outside a transaction: Postgres rejects a new enum value until the statement
+-- adding it has committed, and the dynamic membership index in the next migration uses it.
+ALTER TYPE membership_origin ADD VALUE IF NOT EXISTS 'dynamic';
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Admin-assigned labels that dynamic group criteria can match on.
+ALTER TABLE machines
+  ADD COLUMN tags TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[];
+
+-- machine_criteria makes a local group dynamic: machines matching it are kept as members with
+-- origin 'dynamic', alongside any explicit members.
+ALTER TABLE groups
+  ADD COLUMN machine_criteria JSONB NULL;
+
+CREATE INDEX group_machine_memberships_dynamic_idx
+  ON group_machine_memberships (machine_id)
+  WHERE origin = 'dynamic';
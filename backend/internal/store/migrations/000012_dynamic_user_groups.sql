@@ -0,0 +1,13 @@
+-- +goose Up
+-- Directory attributes synced from Entra, keyed by snake_case attribute name.
+ALTER TABLE users
+  ADD COLUMN directory_attributes JSONB NOT NULL DEFAULT '{}'::JSONB;
+
+-- user_criteria makes a local group dynamic for users: users whose directory attributes match it
+-- are kept as members with origin 'dynamic'.
+ALTER TABLE groups
+  ADD COLUMN user_criteria JSONB NULL;
+
+CREATE INDEX group_user_memberships_dynamic_idx
+  ON group_user_memberships (user_id)
+  WHERE origin = 'dynamic';
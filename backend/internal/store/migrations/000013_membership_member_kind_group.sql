@@ -0,0 +1,5 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Runs on its own outside a transaction so the group_memberships view in the next migration can
+-- use the committed value.
+ALTER TYPE membership_member_kind ADD VALUE IF NOT EXISTS 'group';
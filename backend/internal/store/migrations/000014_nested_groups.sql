@@ -0,0 +1,106 @@
+-- +goose Up
+-- Local groups can include other groups. Members of member_group_id are effective members of
+-- group_id, transitively.
+CREATE TABLE group_group_memberships (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+  member_group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+  origin membership_origin NOT NULL DEFAULT 'explicit',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT group_group_memberships_unique UNIQUE (group_id, member_group_id),
+  CONSTRAINT group_group_memberships_not_self CHECK (group_id <> member_group_id)
+);
+
+CREATE INDEX group_group_memberships_member_group_id_idx ON group_group_memberships (member_group_id);
+
+CREATE TRIGGER group_group_memberships_set_updated_at
+  BEFORE UPDATE ON group_group_memberships
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
+
+CREATE OR REPLACE VIEW group_memberships AS
+SELECT
+  id,
+  group_id,
+  'user'::membership_member_kind AS member_kind,
+  user_id AS member_id,
+  origin,
+  created_at,
+  updated_at
+FROM group_user_memberships
+
+UNION ALL
+
+SELECT
+  id,
+  group_id,
+  'machine'::membership_member_kind AS member_kind,
+  machine_id AS member_id,
+  origin,
+  created_at,
+  updated_at
+FROM group_machine_memberships
+
+UNION ALL
+
+SELECT
+  id,
+  group_id,
+  'group'::membership_member_kind AS member_kind,
+  member_group_id AS member_id,
+  origin,
+  created_at,
+  updated_at
+FROM group_group_memberships;
+
+-- Every group a group belongs to, itself included. UNION keeps the expansion finite even if a
+-- cycle were ever stored.
+CREATE VIEW group_ancestors AS
+WITH RECURSIVE ancestors (group_id, ancestor_id) AS (
+  SELECT
+    g.id,
+    g.id
+  FROM groups AS g
+
+  UNION
+
+  SELECT
+    a.group_id,
+    ggm.group_id
+  FROM ancestors AS a
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = a.ancestor_id
+)
+SELECT
+  group_id,
+  ancestor_id
+FROM ancestors;
+
+-- Every path from a group to a group it belongs to. path lists the groups walked through, starting
+-- with group_id and excluding ancestor_id, so it is empty for the group itself.
+CREATE VIEW group_ancestor_paths AS
+WITH RECURSIVE paths (group_id, ancestor_id, path) AS (
+  SELECT
+    g.id,
+    g.id,
+    ARRAY[]::UUID[]
+  FROM groups AS g
+
+  UNION ALL
+
+  SELECT
+    p.group_id,
+    ggm.group_id,
+    p.path || p.ancestor_id
+  FROM paths AS p
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = p.ancestor_id
+  WHERE ggm.group_id <> p.group_id
+    AND NOT ggm.group_id = ANY (p.path)
+)
+SELECT
+  group_id,
+  ancestor_id,
+  path
+FROM paths;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Maps a macOS local group reported by Santa for a machine's primary user (for example admin or
+-- _developer) onto a Grinch group. Matching machines are kept as members with origin 'synced'.
+CREATE TABLE local_group_mappings (
+  id UUID PRIMARY KEY,
+  local_group TEXT NOT NULL,
+  group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT local_group_mappings_local_group_not_blank CHECK (btrim(local_group) <> ''),
+  CONSTRAINT local_group_mappings_unique UNIQUE (local_group, group_id)
+);
+
+CREATE INDEX local_group_mappings_group_id_idx ON local_group_mappings (group_id);
+
+CREATE TRIGGER local_group_mappings_set_updated_at
+  BEFORE UPDATE ON local_group_mappings
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at();
+
+CREATE INDEX group_machine_memberships_synced_idx
+  ON group_machine_memberships (machine_id)
+  WHERE origin = 'synced';
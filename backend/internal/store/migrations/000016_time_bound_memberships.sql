@@ -0,0 +1,151 @@
+-- +goose Up
+-- Explicit memberships can be limited to a time window. A membership created with a future
+-- starts_at is pending and has no effect until the membership sweeper activates it; one past its
+-- expires_at is deleted by the sweeper.
+ALTER TABLE group_user_memberships
+  ADD COLUMN starts_at TIMESTAMPTZ NULL,
+  ADD COLUMN expires_at TIMESTAMPTZ NULL,
+  ADD COLUMN pending BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD CONSTRAINT group_user_memberships_window CHECK (
+    starts_at IS NULL
+    OR expires_at IS NULL
+    OR expires_at > starts_at
+  );
+
+ALTER TABLE group_machine_memberships
+  ADD COLUMN starts_at TIMESTAMPTZ NULL,
+  ADD COLUMN expires_at TIMESTAMPTZ NULL,
+  ADD COLUMN pending BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD CONSTRAINT group_machine_memberships_window CHECK (
+    starts_at IS NULL
+    OR expires_at IS NULL
+    OR expires_at > starts_at
+  );
+
+ALTER TABLE group_group_memberships
+  ADD COLUMN starts_at TIMESTAMPTZ NULL,
+  ADD COLUMN expires_at TIMESTAMPTZ NULL,
+  ADD COLUMN pending BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD CONSTRAINT group_group_memberships_window CHECK (
+    starts_at IS NULL
+    OR expires_at IS NULL
+    OR expires_at > starts_at
+  );
+
+CREATE INDEX group_user_memberships_expires_at_idx
+  ON group_user_memberships (expires_at)
+  WHERE expires_at IS NOT NULL;
+
+CREATE INDEX group_machine_memberships_expires_at_idx
+  ON group_machine_memberships (expires_at)
+  WHERE expires_at IS NOT NULL;
+
+CREATE INDEX group_group_memberships_expires_at_idx
+  ON group_group_memberships (expires_at)
+  WHERE expires_at IS NOT NULL;
+
+CREATE INDEX group_user_memberships_pending_idx
+  ON group_user_memberships (starts_at)
+  WHERE pending;
+
+CREATE INDEX group_machine_memberships_pending_idx
+  ON group_machine_memberships (starts_at)
+  WHERE pending;
+
+CREATE INDEX group_group_memberships_pending_idx
+  ON group_group_memberships (starts_at)
+  WHERE pending;
+
+CREATE OR REPLACE VIEW group_memberships AS
+SELECT
+  id,
+  group_id,
+  'user'::membership_member_kind AS member_kind,
+  user_id AS member_id,
+  origin,
+  created_at,
+  updated_at,
+  starts_at,
+  expires_at,
+  pending
+FROM group_user_memberships
+
+UNION ALL
+
+SELECT
+  id,
+  group_id,
+  'machine'::membership_member_kind AS member_kind,
+  machine_id AS member_id,
+  origin,
+  created_at,
+  updated_at,
+  starts_at,
+  expires_at,
+  pending
+FROM group_machine_memberships
+
+UNION ALL
+
+SELECT
+  id,
+  group_id,
+  'group'::membership_member_kind AS member_kind,
+  member_group_id AS member_id,
+  origin,
+  created_at,
+  updated_at,
+  starts_at,
+  expires_at,
+  pending
+FROM group_group_memberships;
+
+-- Pending nestings do not make a group an ancestor yet.
+CREATE OR REPLACE VIEW group_ancestors AS
+WITH RECURSIVE ancestors (group_id, ancestor_id) AS (
+  SELECT
+    g.id,
+    g.id
+  FROM groups AS g
+
+  UNION
+
+  SELECT
+    a.group_id,
+    ggm.group_id
+  FROM ancestors AS a
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = a.ancestor_id
+    AND NOT ggm.pending
+)
+SELECT
+  group_id,
+  ancestor_id
+FROM ancestors;
+
+CREATE OR REPLACE VIEW group_ancestor_paths AS
+WITH RECURSIVE paths (group_id, ancestor_id, path) AS (
+  SELECT
+    g.id,
+    g.id,
+    ARRAY[]::UUID[]
+  FROM groups AS g
+
+  UNION ALL
+
+  SELECT
+    p.group_id,
+    ggm.group_id,
+    p.path || p.ancestor_id
+  FROM paths AS p
+  JOIN group_group_memberships AS ggm
+    ON ggm.member_group_id = p.ancestor_id
+    AND NOT ggm.pending
+  WHERE ggm.group_id <> p.group_id
+    AND NOT ggm.group_id = ANY (p.path)
+)
+SELECT
+  group_id,
+  ancestor_id,
+  path
+FROM paths;
@@ -0,0 +1,5 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Runs on its own outside a transaction so the machine target columns and constraints added in
+-- 000019 can use the committed value.
+ALTER TYPE rule_target_subject_kind ADD VALUE IF NOT EXISTS 'machine';
@@ -0,0 +1,4 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Runs on its own outside a transaction, like 000017, for the user targets added in 000019.
+ALTER TYPE rule_target_subject_kind ADD VALUE IF NOT EXISTS 'user';
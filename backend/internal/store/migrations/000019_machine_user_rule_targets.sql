@@ -0,0 +1,100 @@
+-- +goose Up
+-- subject_id now refers to a group, machine or user depending on subject_kind. The generated
+-- columns carry one foreign key per kind, so deleting the subject still deletes its targets.
+ALTER TABLE rule_targets
+  DROP CONSTRAINT rule_targets_subject_id_fkey,
+  DROP CONSTRAINT rule_targets_subject_id_check,
+  DROP CONSTRAINT rule_targets_include_requires_fields;
+
+ALTER TABLE rule_targets
+  ADD COLUMN subject_group_id UUID GENERATED ALWAYS AS (
+    CASE WHEN subject_kind = 'group' THEN subject_id END
+  ) STORED REFERENCES groups (id) ON DELETE CASCADE,
+  ADD COLUMN subject_machine_id UUID GENERATED ALWAYS AS (
+    CASE WHEN subject_kind = 'machine' THEN subject_id END
+  ) STORED REFERENCES machines (id) ON DELETE CASCADE,
+  ADD COLUMN subject_user_id UUID GENERATED ALWAYS AS (
+    CASE WHEN subject_kind = 'user' THEN subject_id END
+  ) STORED REFERENCES users (id) ON DELETE CASCADE,
+  ADD CONSTRAINT rule_targets_subject_id_check CHECK (
+    (subject_kind IN ('group', 'machine', 'user') AND subject_id IS NOT NULL)
+    OR (subject_kind IN ('all_devices', 'all_users') AND subject_id IS NULL)
+  ),
+  ADD CONSTRAINT rule_targets_include_requires_fields CHECK (
+    (
+      assignment = 'include'
+      AND priority IS NOT NULL
+      AND policy IS NOT NULL
+    )
+    OR (
+      assignment = 'exclude'
+      AND subject_kind IN ('group', 'machine', 'user')
+      AND priority IS NULL
+      AND policy IS NULL
+    )
+  );
+
+CREATE INDEX rule_targets_subject_group_id_idx
+  ON rule_targets (subject_group_id)
+  WHERE subject_group_id IS NOT NULL;
+
+CREATE INDEX rule_targets_subject_machine_id_idx
+  ON rule_targets (subject_machine_id)
+  WHERE subject_machine_id IS NOT NULL;
+
+CREATE INDEX rule_targets_subject_user_id_idx
+  ON rule_targets (subject_user_id)
+  WHERE subject_user_id IS NOT NULL;
+
+DROP INDEX rule_targets_group_unique_idx;
+
+CREATE UNIQUE INDEX rule_targets_subject_unique_idx
+  ON rule_targets (rule_id, subject_kind, subject_id)
+  WHERE subject_id IS NOT NULL;
+
+-- Excludes are stored in proposal snapshots as subject targets, like includes.
+UPDATE rule_change_proposals
+SET current_rule = jsonb_set(
+  current_rule,
+  '{targets,exclude}',
+  (
+    SELECT COALESCE(
+      jsonb_agg(
+        jsonb_strip_nulls(
+          jsonb_build_object(
+            'subject_kind', 'group',
+            'subject_id', e.value->'group_id',
+            'subject_name', e.value->'group_name'
+          )
+        )
+        ORDER BY e.ordinality
+      ),
+      '[]'::JSONB
+    )
+    FROM jsonb_array_elements(current_rule->'targets'->'exclude') WITH ORDINALITY AS e (value, ordinality)
+  )
+)
+WHERE jsonb_typeof(current_rule->'targets'->'exclude') = 'array';
+
+UPDATE rule_change_proposals
+SET proposed_rule = jsonb_set(
+  proposed_rule,
+  '{targets,exclude}',
+  (
+    SELECT COALESCE(
+      jsonb_agg(
+        jsonb_strip_nulls(
+          jsonb_build_object(
+            'subject_kind', 'group',
+            'subject_id', e.value->'group_id',
+            'subject_name', e.value->'group_name'
+          )
+        )
+        ORDER BY e.ordinality
+      ),
+      '[]'::JSONB
+    )
+    FROM jsonb_array_elements(proposed_rule->'targets'->'exclude') WITH ORDINALITY AS e (value, ordinality)
+  )
+)
+WHERE jsonb_typeof(proposed_rule->'targets'->'exclude') = 'array';
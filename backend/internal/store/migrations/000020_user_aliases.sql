@@ -0,0 +1,32 @@
+-- +goose Up
+-- Alternative names a machine's reported primary user can match besides the UPN: directory
+-- nicknames synced from Entra (source 'entra') and names added by hand (source 'local').
+-- Aliases are stored lowercased so matching is case-insensitive.
+CREATE TABLE user_aliases (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  alias TEXT NOT NULL,
+  source principal_source NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT user_aliases_alias_normalized CHECK (alias <> '' AND alias = lower(btrim(alias)))
+);
+
+CREATE UNIQUE INDEX user_aliases_alias_unique_idx ON user_aliases (alias);
+CREATE INDEX user_aliases_user_id_idx ON user_aliases (user_id);
+
+CREATE INDEX users_upn_lower_idx ON users (lower(upn)) WHERE upn <> '';
+
+-- The resolved primary user is stored instead of joining users.upn = machines.primary_user on
+-- every read. It is refreshed at preflight and whenever users or aliases change.
+ALTER TABLE machines
+  ADD COLUMN primary_user_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX machines_primary_user_id_idx
+  ON machines (primary_user_id)
+  WHERE primary_user_id IS NOT NULL;
+
+UPDATE machines AS m
+SET primary_user_id = u.id
+FROM users AS u
+WHERE u.upn <> ''
+  AND u.upn = m.primary_user;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- An admin-pinned primary user replaces the one Santa reports when resolving machines'
+-- primary users. A NULL user_id pins "no user". Grinch never deletes users (users missing from
+-- Entra become local), but if the pinned user is deleted the pin is dropped and
+-- machines.primary_user_id is set to NULL; the machine resolves its reported primary user again on
+-- its next preflight.
+CREATE TABLE machine_primary_user_overrides (
+  machine_id UUID PRIMARY KEY REFERENCES machines (id) ON DELETE CASCADE,
+  user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX machine_primary_user_overrides_user_id_idx
+  ON machine_primary_user_overrides (user_id)
+  WHERE user_id IS NOT NULL;
@@ -0,0 +1,90 @@
+-- +goose Up
+-- Execution events link the users Santa reported to users records, matched at ingest the same
+-- way as machines' primary users. The reported names are kept as they were sent.
+ALTER TABLE execution_events
+  ADD COLUMN executing_user_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX execution_events_executing_user_created_idx
+  ON execution_events (executing_user_id, created_at DESC)
+  WHERE executing_user_id IS NOT NULL;
+
+CREATE TABLE execution_event_logged_in_users (
+  execution_event_id UUID NOT NULL REFERENCES execution_events (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  PRIMARY KEY (execution_event_id, user_id)
+);
+
+CREATE INDEX execution_event_logged_in_users_user_id_idx
+  ON execution_event_logged_in_users (user_id);
+
+-- Existing events are linked on an exact UPN or alias match only; domain rewrites and the
+-- default domain apply to events ingested from now on.
+CREATE TEMPORARY TABLE execution_event_user_matches AS
+WITH reported AS (
+  SELECT DISTINCT lower(btrim(ee.executing_user)) AS name
+  FROM execution_events AS ee
+  WHERE btrim(ee.executing_user) <> ''
+
+  UNION
+
+  SELECT DISTINCT lower(btrim(logged_in.name))
+  FROM execution_events AS ee
+  CROSS JOIN LATERAL unnest(ee.logged_in_users) AS logged_in (name)
+  WHERE btrim(logged_in.name) <> ''
+),
+matches AS (
+  SELECT
+    r.name,
+    u.id AS user_id,
+    1 AS preference
+  FROM reported AS r
+  JOIN users AS u
+    ON u.upn <> ''
+    AND lower(u.upn) = r.name
+
+  UNION
+
+  SELECT
+    r.name,
+    ua.user_id,
+    2 AS preference
+  FROM reported AS r
+  JOIN user_aliases AS ua
+    ON ua.alias = r.name
+),
+best_matches AS (
+  SELECT
+    mt.name,
+    mt.user_id
+  FROM matches AS mt
+  WHERE mt.preference = (
+    SELECT MIN(other.preference)
+    FROM matches AS other
+    WHERE other.name = mt.name
+  )
+)
+SELECT
+  bm.name,
+  (ARRAY_AGG(DISTINCT bm.user_id))[1] AS user_id
+FROM best_matches AS bm
+GROUP BY bm.name
+HAVING COUNT(DISTINCT bm.user_id) = 1;
+
+UPDATE execution_events AS ee
+SET executing_user_id = m.user_id
+FROM execution_event_user_matches AS m
+WHERE m.name = lower(btrim(ee.executing_user));
+
+INSERT INTO execution_event_logged_in_users (
+  execution_event_id,
+  user_id
+)
+SELECT DISTINCT
+  ee.id,
+  m.user_id
+FROM execution_events AS ee
+CROSS JOIN LATERAL unnest(ee.logged_in_users) AS logged_in (name)
+JOIN execution_event_user_matches AS m
+  ON m.name = lower(btrim(logged_in.name));
+
+DROP TABLE execution_event_user_matches;
@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	certificateListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":                  "c.id",
+		"sha256":              "c.sha256",
+		"common_name":         "c.common_name",
+		"organization":        "c.organization",
+		"organizational_unit": "c.organizational_unit",
+		"valid_from":          "c.valid_from",
+		"valid_until":         "c.valid_until",
+		"executable_count":    "executable_count",
+		"machine_count":       "machine_count",
+		"first_seen_at":       "c.first_seen_at",
+		"last_seen_at":        "c.last_seen_at",
+		sortFieldCreatedAt:    "c.created_at",
+	}
+
+	certificateListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"c.last_seen_at DESC",
+		"c.id DESC",
+	}
+)
+
+func (s *Store) ListCertificates(
+	ctx context.Context,
+	opts domain.CertificateListOptions,
+) ([]domain.Certificate, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		certificateListSortColumns,
+		certificateListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		`($1 = '' OR
+  c.sha256 ILIKE $1 OR
+  c.common_name ILIKE $1 OR
+  c.organization ILIKE $1 OR
+  c.organizational_unit ILIKE $1)`,
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("c.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+	if opts.ExecutableID != nil {
+		where = append(where, fmt.Sprintf(`EXISTS (
+  SELECT 1
+  FROM executable_certificates AS filter_ec
+  WHERE filter_ec.certificate_id = c.id
+    AND filter_ec.executable_id = $%d
+)`, len(args)+1))
+		args = append(args, *opts.ExecutableID)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(
+		certificateListQuery,
+		strings.Join(where, " AND "),
+		orderBy,
+		limitArg,
+		offsetArg,
+	)
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list certificates: %w", err)
+	}
+
+	return collectRows(rows, scanCertificateRow)
+}
+
+func (s *Store) GetCertificate(ctx context.Context, id uuid.UUID) (domain.CertificateDetail, error) {
+	row, err := s.Queries().GetCertificate(ctx, id)
+	if err != nil {
+		return domain.CertificateDetail{}, err
+	}
+
+	detail := domain.CertificateDetail{Certificate: mapCertificate(row)}
+
+	rule, err := s.GetRuleByIdentifier(ctx, domain.RuleTypeCertificate, row.Sha256)
+	switch {
+	case err == nil:
+		detail.Rule = &rule
+	case !errors.Is(err, pgx.ErrNoRows):
+		return domain.CertificateDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// upsertSigningChainCertificates records every certificate in a JSON-encoded signing chain and
+// returns their IDs in chain order, leaf first.
+func upsertSigningChainCertificates(
+	ctx context.Context,
+	queries *db.Queries,
+	rawChain []byte,
+) ([]uuid.UUID, error) {
+	chain, err := signingChainCertificates(rawChain)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateIDs := make([]uuid.UUID, 0, len(chain))
+	for _, entry := range chain {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate certificate id: %w", err)
+		}
+
+		certificateID, err := queries.UpsertCertificate(ctx, db.UpsertCertificateParams{
+			ID:                 id,
+			Sha256:             entry.SHA256,
+			CommonName:         entry.CommonName,
+			Organization:       entry.Organization,
+			OrganizationalUnit: entry.OrganizationalUnit,
+			ValidFrom:          entry.ValidFrom,
+			ValidUntil:         entry.ValidUntil,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("upsert certificate: %w", err)
+		}
+
+		certificateIDs = append(certificateIDs, certificateID)
+	}
+
+	return certificateIDs, nil
+}
+
+// signingChainCertificates decodes a JSON-encoded signing chain and drops entries without a
+// certificate hash.
+func signingChainCertificates(rawChain []byte) ([]domain.SigningChainEntry, error) {
+	chain, err := unmarshalSigningChain(rawChain)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := make([]domain.SigningChainEntry, 0, len(chain))
+	for _, entry := range chain {
+		entry.SHA256 = strings.TrimSpace(entry.SHA256)
+		if entry.SHA256 == "" {
+			continue
+		}
+		certificates = append(certificates, entry)
+	}
+
+	return certificates, nil
+}
+
+func linkExecutableCertificates(
+	ctx context.Context,
+	queries *db.Queries,
+	executableID uuid.UUID,
+	rawChain []byte,
+) error {
+	certificateIDs, err := upsertSigningChainCertificates(ctx, queries, rawChain)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range executableCertificateLinks(executableID, certificateIDs) {
+		if err = queries.LinkExecutableCertificate(ctx, link); err != nil {
+			return fmt.Errorf("link executable certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// executableCertificateLinks links each distinct certificate once, at the position of its first
+// appearance in the chain.
+func executableCertificateLinks(
+	executableID uuid.UUID,
+	certificateIDs []uuid.UUID,
+) []db.LinkExecutableCertificateParams {
+	links := make([]db.LinkExecutableCertificateParams, 0, len(certificateIDs))
+	seen := make(map[uuid.UUID]struct{}, len(certificateIDs))
+	for _, certificateID := range certificateIDs {
+		if _, ok := seen[certificateID]; ok {
+			continue
+		}
+		seen[certificateID] = struct{}{}
+
+		links = append(links, db.LinkExecutableCertificateParams{
+			ExecutableID:  executableID,
+			CertificateID: certificateID,
+			ChainPosition: int32(len(links)), //nolint:gosec // signing chains hold a handful of certificates
+		})
+	}
+
+	return links
+}
+
+func scanCertificateRow(rows pgx.Rows) (domain.Certificate, int32, error) {
+	var (
+		item  domain.Certificate
+		total int32
+	)
+
+	if err := rows.Scan(
+		&item.ID,
+		&item.SHA256,
+		&item.CommonName,
+		&item.Organization,
+		&item.OrganizationalUnit,
+		&item.ValidFrom,
+		&item.ValidUntil,
+		&item.ExecutableCount,
+		&item.MachineCount,
+		&item.FirstSeenAt,
+		&item.LastSeenAt,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.Certificate{}, 0, err
+	}
+
+	return item, total, nil
+}
+
+func mapCertificate(row db.GetCertificateRow) domain.Certificate {
+	return domain.Certificate{
+		ID:                 row.ID,
+		SHA256:             row.Sha256,
+		CommonName:         row.CommonName,
+		Organization:       row.Organization,
+		OrganizationalUnit: row.OrganizationalUnit,
+		ValidFrom:          row.ValidFrom,
+		ValidUntil:         row.ValidUntil,
+		ExecutableCount:    row.ExecutableCount,
+		MachineCount:       row.MachineCount,
+		FirstSeenAt:        row.FirstSeenAt,
+		LastSeenAt:         row.LastSeenAt,
+		CreatedAt:          row.CreatedAt,
+		UpdatedAt:          row.UpdatedAt,
+	}
+}
+
+const certificateListQuery = `
+SELECT
+  c.id,
+  c.sha256,
+  c.common_name,
+  c.organization,
+  c.organizational_unit,
+  c.valid_from,
+  c.valid_until,
+  COALESCE(certificate_usage.executable_count, 0)::INT4 AS executable_count,
+  COALESCE(certificate_usage.machine_count, 0)::INT4 AS machine_count,
+  c.first_seen_at,
+  c.last_seen_at,
+  c.created_at,
+  c.updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM certificates AS c
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(DISTINCT ec.executable_id)::INT4 AS executable_count,
+    COUNT(DISTINCT eo.machine_id)::INT4 AS machine_count
+  FROM executable_certificates AS ec
+  LEFT JOIN executable_observations AS eo
+    ON eo.executable_id = ec.executable_id
+  WHERE ec.certificate_id = c.id
+) AS certificate_usage
+  ON TRUE
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`
@@ -0,0 +1,128 @@
+package postgres //nolint:testpackage // exercises unexported signing chain handling.
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSigningChainCertificates(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty chain", raw: "", want: []string{}},
+		{name: "null chain", raw: "null", want: []string{}},
+		{
+			name: "keeps chain order leaf first",
+			raw:  `[{"sha256":"leaf","common_name":"Developer ID"},{"sha256":"intermediate"},{"sha256":"root"}]`,
+			want: []string{"leaf", "intermediate", "root"},
+		},
+		{
+			name: "drops entries without a hash",
+			raw:  `[{"sha256":"leaf"},{"sha256":"  "},{"common_name":"Missing"},{"sha256":"root"}]`,
+			want: []string{"leaf", "root"},
+		},
+		{name: "trims hashes", raw: `[{"sha256":" leaf\n"}]`, want: []string{"leaf"}},
+		{name: "invalid json", raw: `{"sha256":"leaf"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signingChainCertificates([]byte(tt.raw))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("signingChainCertificates() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("signingChainCertificates() error = %v", err)
+			}
+
+			hashes := make([]string, 0, len(got))
+			for _, entry := range got {
+				hashes = append(hashes, entry.SHA256)
+			}
+			if !slices.Equal(hashes, tt.want) {
+				t.Fatalf("signingChainCertificates() hashes = %v, want %v", hashes, tt.want)
+			}
+		})
+	}
+}
+
+func TestSigningChainCertificatesKeepsCertificateFields(t *testing.T) {
+	got, err := signingChainCertificates([]byte(
+		`[{"sha256":"leaf","common_name":"Developer ID Application","organization":"Example",` +
+			`"organizational_unit":"TEAM123456","valid_from":"2025-01-01T10:00:00+10:00",` +
+			`"valid_until":"2030-01-01T00:00:00Z"}]`,
+	))
+	if err != nil {
+		t.Fatalf("signingChainCertificates() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("signingChainCertificates() returned %d entries, want 1", len(got))
+	}
+
+	entry := got[0]
+	if entry.CommonName != "Developer ID Application" || entry.Organization != "Example" ||
+		entry.OrganizationalUnit != "TEAM123456" {
+		t.Fatalf("entry = %+v, want subject fields decoded", entry)
+	}
+	if entry.ValidFrom.Location().String() != "UTC" || entry.ValidFrom.Hour() != 0 {
+		t.Fatalf("ValidFrom = %s, want 2025-01-01T00:00:00Z", entry.ValidFrom)
+	}
+}
+
+func TestExecutableCertificateLinks(t *testing.T) {
+	executableID := uuid.MustParse("00000000-0000-0000-0000-0000000000e1")
+	leaf := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	intermediate := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	root := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	tests := []struct {
+		name           string
+		certificateIDs []uuid.UUID
+		want           []uuid.UUID
+	}{
+		{name: "no certificates", certificateIDs: nil, want: []uuid.UUID{}},
+		{
+			name:           "distinct chain",
+			certificateIDs: []uuid.UUID{leaf, intermediate, root},
+			want:           []uuid.UUID{leaf, intermediate, root},
+		},
+		{
+			name:           "repeated certificate keeps first position",
+			certificateIDs: []uuid.UUID{leaf, intermediate, leaf, root},
+			want:           []uuid.UUID{leaf, intermediate, root},
+		},
+		{
+			name:           "adjacent duplicates",
+			certificateIDs: []uuid.UUID{leaf, leaf, root, root},
+			want:           []uuid.UUID{leaf, root},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := executableCertificateLinks(executableID, tt.certificateIDs)
+
+			got := make([]uuid.UUID, 0, len(links))
+			for position, link := range links {
+				if link.ExecutableID != executableID {
+					t.Fatalf("links[%d].ExecutableID = %s, want %s", position, link.ExecutableID, executableID)
+				}
+				if int(link.ChainPosition) != position {
+					t.Fatalf("links[%d].ChainPosition = %d, want %d", position, link.ChainPosition, position)
+				}
+				got = append(got, link.CertificateID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("executableCertificateLinks() certificates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+type dynamicMachineGroup struct {
+	id       uuid.UUID
+	criteria domain.MachineGroupCriteria
+}
+
+type dynamicMachineCandidate struct {
+	id         uuid.UUID
+	attributes domain.MachineAttributes
+}
+
+type dynamicUserGroup struct {
+	id       uuid.UUID
+	criteria domain.UserGroupCriteria
+}
+
+type dynamicUserCandidate struct {
+	id         uuid.UUID
+	attributes map[string]string
+}
+
+// SyncMachineComputedMemberships re-evaluates dynamic group criteria and local group mappings for
+// one machine and reports whether its computed memberships changed.
+func (s *Store) SyncMachineComputedMemberships(
+	ctx context.Context,
+	machineID uuid.UUID,
+	now time.Time,
+) (bool, error) {
+	changed, err := s.syncComputedMachineMemberships(ctx, &machineID, now)
+	if err != nil {
+		return false, err
+	}
+
+	return len(changed) > 0, nil
+}
+
+// SyncComputedMachineMemberships re-evaluates every dynamic group and local group mapping against
+// every machine and returns the machines whose computed memberships changed.
+func (s *Store) SyncComputedMachineMemberships(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return s.syncComputedMachineMemberships(ctx, nil, now)
+}
+
+// SetMachineTags replaces the tags dynamic group criteria can match on.
+func (s *Store) SetMachineTags(ctx context.Context, machineID uuid.UUID, tags []string) error {
+	n, err := s.Queries().UpdateMachineTags(ctx, db.UpdateMachineTagsParams{
+		MachineID: machineID,
+		Tags:      tags,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// syncComputedMachineMemberships recomputes dynamic and local group machine memberships in one
+// pass. Both write the same (group, machine) rows, so a row is kept while either still wants it and
+// handed to the other origin when only that one does.
+func (s *Store) syncComputedMachineMemberships(
+	ctx context.Context,
+	machineID *uuid.UUID,
+	now time.Time,
+) ([]uuid.UUID, error) {
+	var changed []uuid.UUID
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		dynamicGroupIDs, dynamicMachineIDs, err := listDynamicMachineMemberships(ctx, q, machineID, now)
+		if err != nil {
+			return err
+		}
+
+		localGroupIDs, localMachineIDs, err := listLocalGroupMachineMemberships(ctx, q, machineID)
+		if err != nil {
+			return err
+		}
+
+		groupIDs, machineIDs, origins := computedMachineMemberships(
+			dynamicGroupIDs,
+			dynamicMachineIDs,
+			localGroupIDs,
+			localMachineIDs,
+		)
+
+		removed, err := q.DeleteStaleComputedMachineMemberships(ctx, db.DeleteStaleComputedMachineMembershipsParams{
+			MachineID:  machineID,
+			GroupIds:   groupIDs,
+			MachineIds: machineIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("delete stale computed machine memberships: %w", err)
+		}
+
+		if err = q.ReassignComputedMachineMemberships(ctx, db.ReassignComputedMachineMembershipsParams{
+			GroupIds:   groupIDs,
+			MachineIds: machineIDs,
+			Origins:    origins,
+		}); err != nil {
+			return fmt.Errorf("reassign computed machine memberships: %w", err)
+		}
+
+		added, err := q.InsertComputedMachineMemberships(ctx, db.InsertComputedMachineMembershipsParams{
+			GroupIds:   groupIDs,
+			MachineIds: machineIDs,
+			Origins:    origins,
+		})
+		if err != nil {
+			return fmt.Errorf("insert computed machine memberships: %w", err)
+		}
+
+		changed = compactIDs(append(removed, added...))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// listDynamicMachineMemberships evaluates dynamic group criteria against the machines, or every
+// machine when machineID is nil.
+func listDynamicMachineMemberships(
+	ctx context.Context,
+	q *db.Queries,
+	machineID *uuid.UUID,
+	now time.Time,
+) ([]uuid.UUID, []uuid.UUID, error) {
+	groupRows, err := q.ListDynamicMachineGroups(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list dynamic machine groups: %w", err)
+	}
+
+	groups := make([]dynamicMachineGroup, 0, len(groupRows))
+	for _, row := range groupRows {
+		criteria, decodeErr := decodeMachineCriteria(row.MachineCriteria)
+		if decodeErr != nil {
+			return nil, nil, fmt.Errorf("group %s: %w", row.ID, decodeErr)
+		}
+		groups = append(groups, dynamicMachineGroup{id: row.ID, criteria: *criteria})
+	}
+
+	machineRows, err := q.ListDynamicMachineCandidates(ctx, machineID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list dynamic machine candidates: %w", err)
+	}
+
+	candidates := make([]dynamicMachineCandidate, 0, len(machineRows))
+	for _, row := range machineRows {
+		clientMode, parseErr := domain.ParseMachineClientMode(string(row.ClientMode))
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("parse machine client mode: %w", parseErr)
+		}
+
+		candidates = append(candidates, dynamicMachineCandidate{
+			id: row.ID,
+			attributes: domain.MachineAttributes{
+				SerialNumber:    row.SerialNumber,
+				Hostname:        row.Hostname,
+				ModelIdentifier: row.ModelIdentifier,
+				OSVersion:       row.OsVersion,
+				SantaVersion:    row.SantaVersion,
+				ClientMode:      clientMode,
+				Tags:            row.Tags,
+				LastSeenAt:      row.LastSeenAt,
+			},
+		})
+	}
+
+	groupIDs, machineIDs := dynamicMachineMemberships(groups, candidates, now)
+
+	return groupIDs, machineIDs, nil
+}
+
+// SyncDynamicUserMemberships re-evaluates every dynamic user group against every user's directory
+// attributes and returns the users whose dynamic memberships changed.
+func (s *Store) SyncDynamicUserMemberships(ctx context.Context) ([]uuid.UUID, error) {
+	var changed []uuid.UUID
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		groupRows, err := q.ListDynamicUserGroups(ctx)
+		if err != nil {
+			return fmt.Errorf("list dynamic user groups: %w", err)
+		}
+
+		groups := make([]dynamicUserGroup, 0, len(groupRows))
+		for _, row := range groupRows {
+			criteria, decodeErr := decodeUserCriteria(row.UserCriteria)
+			if decodeErr != nil {
+				return fmt.Errorf("group %s: %w", row.ID, decodeErr)
+			}
+			groups = append(groups, dynamicUserGroup{id: row.ID, criteria: *criteria})
+		}
+
+		userRows, err := q.ListDynamicUserCandidates(ctx)
+		if err != nil {
+			return fmt.Errorf("list dynamic user candidates: %w", err)
+		}
+
+		candidates := make([]dynamicUserCandidate, 0, len(userRows))
+		for _, row := range userRows {
+			attributes, decodeErr := decodeDirectoryAttributes(row.DirectoryAttributes)
+			if decodeErr != nil {
+				return fmt.Errorf("user %s: %w", row.ID, decodeErr)
+			}
+			candidates = append(candidates, dynamicUserCandidate{id: row.ID, attributes: attributes})
+		}
+
+		groupIDs, userIDs := dynamicUserMemberships(groups, candidates)
+
+		removed, err := q.DeleteStaleDynamicUserMemberships(ctx, db.DeleteStaleDynamicUserMembershipsParams{
+			GroupIds: groupIDs,
+			UserIds:  userIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("delete stale dynamic user memberships: %w", err)
+		}
+
+		added, err := q.InsertDynamicUserMemberships(ctx, db.InsertDynamicUserMembershipsParams{
+			GroupIds: groupIDs,
+			UserIds:  userIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("insert dynamic user memberships: %w", err)
+		}
+
+		changed = compactIDs(append(removed, added...))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// dynamicMachineMemberships returns the group and machine ID pairs, as parallel slices, for every
+// candidate that matches a dynamic group's criteria.
+func dynamicMachineMemberships(
+	groups []dynamicMachineGroup,
+	candidates []dynamicMachineCandidate,
+	now time.Time,
+) ([]uuid.UUID, []uuid.UUID) {
+	groupIDs := []uuid.UUID{}
+	machineIDs := []uuid.UUID{}
+
+	for _, group := range groups {
+		for _, candidate := range candidates {
+			if group.criteria.Matches(candidate.attributes, now) {
+				groupIDs = append(groupIDs, group.id)
+				machineIDs = append(machineIDs, candidate.id)
+			}
+		}
+	}
+
+	return groupIDs, machineIDs
+}
+
+// dynamicUserMemberships returns the group and user ID pairs, as parallel slices, for every
+// candidate whose directory attributes match a dynamic group's criteria.
+func dynamicUserMemberships(
+	groups []dynamicUserGroup,
+	candidates []dynamicUserCandidate,
+) ([]uuid.UUID, []uuid.UUID) {
+	groupIDs := []uuid.UUID{}
+	userIDs := []uuid.UUID{}
+
+	for _, group := range groups {
+		for _, candidate := range candidates {
+			if group.criteria.Matches(candidate.attributes) {
+				groupIDs = append(groupIDs, group.id)
+				userIDs = append(userIDs, candidate.id)
+			}
+		}
+	}
+
+	return groupIDs, userIDs
+}
+
+// computedMachineMemberships merges the dynamic and local group pairs into parallel group, machine
+// and origin slices. A pair both want is listed once per origin.
+func computedMachineMemberships(
+	dynamicGroupIDs, dynamicMachineIDs, localGroupIDs, localMachineIDs []uuid.UUID,
+) ([]uuid.UUID, []uuid.UUID, []string) {
+	size := len(dynamicGroupIDs) + len(localGroupIDs)
+	groupIDs := make([]uuid.UUID, 0, size)
+	machineIDs := make([]uuid.UUID, 0, size)
+	origins := make([]string, 0, size)
+
+	groupIDs = append(groupIDs, dynamicGroupIDs...)
+	machineIDs = append(machineIDs, dynamicMachineIDs...)
+	for range dynamicGroupIDs {
+		origins = append(origins, string(db.MembershipOriginDynamic))
+	}
+
+	groupIDs = append(groupIDs, localGroupIDs...)
+	machineIDs = append(machineIDs, localMachineIDs...)
+	for range localGroupIDs {
+		origins = append(origins, string(db.MembershipOriginSynced))
+	}
+
+	return groupIDs, machineIDs, origins
+}
+
+// compactIDs sorts IDs and drops duplicates.
+func compactIDs(ids []uuid.UUID) []uuid.UUID {
+	slices.SortFunc(ids, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return slices.Compact(ids)
+}
+
+func encodeMachineCriteria(criteria *domain.MachineGroupCriteria) ([]byte, error) {
+	if criteria == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("marshal machine criteria: %w", err)
+	}
+
+	return encoded, nil
+}
+
+func decodeMachineCriteria(data []byte) (*domain.MachineGroupCriteria, error) {
+	if len(data) == 0 {
+		return nil, nil //nolint:nilnil // a group without criteria is not dynamic
+	}
+
+	var criteria domain.MachineGroupCriteria
+	if err := json.Unmarshal(data, &criteria); err != nil {
+		return nil, fmt.Errorf("unmarshal machine criteria: %w", err)
+	}
+
+	return &criteria, nil
+}
+
+func encodeUserCriteria(criteria *domain.UserGroupCriteria) ([]byte, error) {
+	if criteria == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("marshal user criteria: %w", err)
+	}
+
+	return encoded, nil
+}
+
+func decodeUserCriteria(data []byte) (*domain.UserGroupCriteria, error) {
+	if len(data) == 0 {
+		return nil, nil //nolint:nilnil // a group without criteria is not dynamic
+	}
+
+	var criteria domain.UserGroupCriteria
+	if err := json.Unmarshal(data, &criteria); err != nil {
+		return nil, fmt.Errorf("unmarshal user criteria: %w", err)
+	}
+
+	return &criteria, nil
+}
@@ -0,0 +1,152 @@
+package postgres //nolint:testpackage // exercises unexported dynamic membership planning.
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func TestDynamicMachineMembershipsMatchesEveryPredicate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	labs := dynamicMachineGroup{
+		id: uuid.New(),
+		criteria: domain.MachineGroupCriteria{
+			OSVersionMin:       "14.4",
+			OSVersionMax:       "15",
+			HostnamePatterns:   []string{"LAB-*"},
+			ClientModes:        []domain.MachineClientMode{domain.MachineClientModeLockdown},
+			LastSeenWithinDays: 7,
+			Tags:               []string{"science"},
+		},
+	}
+	matching := dynamicMachineCandidate{
+		id: uuid.New(),
+		attributes: domain.MachineAttributes{
+			Hostname:   "lab-014",
+			OSVersion:  "14.6.1",
+			ClientMode: domain.MachineClientModeLockdown,
+			Tags:       []string{"Science", "shared"},
+			LastSeenAt: now.Add(-48 * time.Hour),
+		},
+	}
+
+	oldOS := matching
+	oldOS.id = uuid.New()
+	oldOS.attributes.OSVersion = "14.3.1"
+
+	newerMajor := matching
+	newerMajor.id = uuid.New()
+	newerMajor.attributes.OSVersion = "15.0.1"
+
+	stale := matching
+	stale.id = uuid.New()
+	stale.attributes.LastSeenAt = now.AddDate(0, 0, -8)
+
+	untagged := matching
+	untagged.id = uuid.New()
+	untagged.attributes.Tags = []string{"shared"}
+
+	office := matching
+	office.id = uuid.New()
+	office.attributes.Hostname = "office-02"
+
+	groupIDs, machineIDs := dynamicMachineMemberships(
+		[]dynamicMachineGroup{labs},
+		[]dynamicMachineCandidate{matching, oldOS, newerMajor, stale, untagged, office},
+		now,
+	)
+
+	if !slices.Equal(groupIDs, []uuid.UUID{labs.id}) || !slices.Equal(machineIDs, []uuid.UUID{matching.id}) {
+		t.Fatalf("memberships = %v/%v, want only %v in %v", groupIDs, machineIDs, matching.id, labs.id)
+	}
+}
+
+func TestDynamicUserMembershipsMatchesEveryCondition(t *testing.T) {
+	science := dynamicUserGroup{
+		id: uuid.New(),
+		criteria: domain.UserGroupCriteria{
+			Conditions: []domain.UserAttributeCondition{
+				{
+					Attribute: domain.UserAttributeDepartment,
+					Operator:  domain.UserCriteriaOperatorEquals,
+					Values:    []string{"Science", "Mathematics"},
+				},
+				{
+					Attribute: domain.UserAttributeOfficeLocation,
+					Operator:  domain.UserCriteriaOperatorMatches,
+					Values:    []string{"senior *"},
+				},
+				{
+					Attribute: domain.UserAttributeCompanyName,
+					Operator:  domain.UserCriteriaOperatorNotEquals,
+					Values:    []string{"Contractors"},
+				},
+			},
+		},
+	}
+	matching := dynamicUserCandidate{
+		id: uuid.New(),
+		attributes: map[string]string{
+			domain.UserAttributeDepartment:     "science",
+			domain.UserAttributeOfficeLocation: "Senior Campus",
+		},
+	}
+	otherDepartment := dynamicUserCandidate{
+		id: uuid.New(),
+		attributes: map[string]string{
+			domain.UserAttributeDepartment:     "English",
+			domain.UserAttributeOfficeLocation: "Senior Campus",
+		},
+	}
+	juniorCampus := dynamicUserCandidate{
+		id: uuid.New(),
+		attributes: map[string]string{
+			domain.UserAttributeDepartment:     "Mathematics",
+			domain.UserAttributeOfficeLocation: "Junior Campus",
+		},
+	}
+	contractor := dynamicUserCandidate{
+		id: uuid.New(),
+		attributes: map[string]string{
+			domain.UserAttributeDepartment:     "Science",
+			domain.UserAttributeOfficeLocation: "Senior Campus",
+			domain.UserAttributeCompanyName:    "contractors",
+		},
+	}
+	unsynced := dynamicUserCandidate{id: uuid.New(), attributes: map[string]string{}}
+
+	groupIDs, userIDs := dynamicUserMemberships(
+		[]dynamicUserGroup{science},
+		[]dynamicUserCandidate{matching, otherDepartment, juniorCampus, contractor, unsynced},
+	)
+
+	if !slices.Equal(groupIDs, []uuid.UUID{science.id}) || !slices.Equal(userIDs, []uuid.UUID{matching.id}) {
+		t.Fatalf("memberships = %v/%v, want only %v in %v", groupIDs, userIDs, matching.id, science.id)
+	}
+}
+
+func TestComputedMachineMembershipsKeepsBothOrigins(t *testing.T) {
+	group := uuid.New()
+	laptop, desktop := uuid.New(), uuid.New()
+
+	groupIDs, machineIDs, origins := computedMachineMemberships(
+		[]uuid.UUID{group},
+		[]uuid.UUID{laptop},
+		[]uuid.UUID{group, group},
+		[]uuid.UUID{laptop, desktop},
+	)
+
+	if !slices.Equal(groupIDs, []uuid.UUID{group, group, group}) {
+		t.Fatalf("groupIDs = %v, want the group for every pair", groupIDs)
+	}
+	if !slices.Equal(machineIDs, []uuid.UUID{laptop, laptop, desktop}) {
+		t.Fatalf("machineIDs = %v, want laptop from both origins and desktop", machineIDs)
+	}
+	if !slices.Equal(origins, []string{"dynamic", "synced", "synced"}) {
+		t.Fatalf("origins = %v, want dynamic then synced", origins)
+	}
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	graphsync "github.com/woodleighschool/go-entrasync"
@@ -12,9 +14,11 @@ import (
 )
 
 // ReconcileSnapshot upserts current Entra objects and converts missing Entra objects to local.
+// userAttributes adds directory attributes the snapshot does not carry, keyed by user ID.
 func (s *Store) ReconcileSnapshot(
 	ctx context.Context,
 	snapshot *graphsync.Snapshot,
+	userAttributes map[uuid.UUID]map[string]string,
 ) (domain.EntraSyncResult, error) {
 	if snapshot == nil {
 		snapshot = &graphsync.Snapshot{
@@ -28,10 +32,14 @@ func (s *Store) ReconcileSnapshot(
 	groupIDs := collectGroupIDs(snapshot.Groups)
 
 	err := s.RunInTx(ctx, func(queries *db.Queries) error {
-		if upsertErr := upsertEntraUsers(ctx, queries, snapshot.Users); upsertErr != nil {
+		if upsertErr := upsertEntraUsers(ctx, queries, snapshot.Users, userAttributes); upsertErr != nil {
 			return upsertErr
 		}
 
+		if aliasErr := replaceEntraUserAliases(ctx, queries, snapshot.Users); aliasErr != nil {
+			return aliasErr
+		}
+
 		if upsertErr := upsertEntraGroups(ctx, queries, snapshot.Groups); upsertErr != nil {
 			return upsertErr
 		}
@@ -66,13 +74,24 @@ func (s *Store) ReconcileSnapshot(
 	}, nil
 }
 
-func upsertEntraUsers(ctx context.Context, queries *db.Queries, users []graphsync.User) error {
+func upsertEntraUsers(
+	ctx context.Context,
+	queries *db.Queries,
+	users []graphsync.User,
+	userAttributes map[uuid.UUID]map[string]string,
+) error {
 	for _, user := range users {
-		_, err := queries.UpsertUser(ctx, db.UpsertUserParams{
-			ID:          user.ID,
-			Upn:         user.UPN,
-			DisplayName: user.DisplayName,
-			Source:      db.PrincipalSource(domain.PrincipalSourceEntra),
+		attributes, err := json.Marshal(entraDirectoryAttributes(user, userAttributes[user.ID]))
+		if err != nil {
+			return fmt.Errorf("marshal directory attributes for user %q: %w", user.ID, err)
+		}
+
+		_, err = queries.UpsertUser(ctx, db.UpsertUserParams{
+			ID:                  user.ID,
+			Upn:                 user.UPN,
+			DisplayName:         user.DisplayName,
+			Source:              db.PrincipalSource(domain.PrincipalSourceEntra),
+			DirectoryAttributes: attributes,
 		})
 		if err != nil {
 			return fmt.Errorf("upsert user %q: %w", user.ID, err)
@@ -82,6 +101,31 @@ func upsertEntraUsers(ctx context.Context, queries *db.Queries, users []graphsyn
 	return nil
 }
 
+// entraDirectoryAttributes keeps the non-empty directory attributes user group criteria can match,
+// from the snapshot user and the attributes fetched alongside it.
+func entraDirectoryAttributes(user graphsync.User, extra map[string]string) map[string]string {
+	attributes := map[string]string{}
+	values := map[string]string{
+		domain.UserAttributeCompanyName:    user.CompanyName,
+		domain.UserAttributeDepartment:     user.Department,
+		domain.UserAttributeEmployeeID:     user.EmployeeID,
+		domain.UserAttributeOfficeLocation: user.OfficeLocation,
+	}
+	for _, name := range domain.UserAttributes() {
+		if value, ok := extra[name]; ok {
+			values[name] = value
+		}
+	}
+
+	for name, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			attributes[name] = value
+		}
+	}
+
+	return attributes
+}
+
 func upsertEntraGroups(ctx context.Context, queries *db.Queries, groups []graphsync.Group) error {
 	for _, group := range groups {
 		_, err := queries.UpsertGroup(ctx, db.UpsertGroupParams{
@@ -0,0 +1,31 @@
+package postgres //nolint:testpackage // exercises unexported Entra directory attribute mapping.
+
+import (
+	"maps"
+	"testing"
+
+	graphsync "github.com/woodleighschool/go-entrasync"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func TestEntraDirectoryAttributesMergesFetchedAttributes(t *testing.T) {
+	user := graphsync.User{Department: " Science ", CompanyName: "  ", OfficeLocation: "Senior Campus"}
+	extra := map[string]string{
+		domain.UserAttributeJobTitle:     "Teacher",
+		domain.UserExtensionAttribute(3): "Year 9",
+		domain.UserExtensionAttribute(4): " ",
+		"mail_nickname":                  "ignored",
+	}
+
+	got := entraDirectoryAttributes(user, extra)
+	want := map[string]string{
+		domain.UserAttributeDepartment:     "Science",
+		domain.UserAttributeOfficeLocation: "Senior Campus",
+		domain.UserAttributeJobTitle:       "Teacher",
+		domain.UserExtensionAttribute(3):   "Year 9",
+	}
+	if !maps.Equal(got, want) {
+		t.Fatalf("entraDirectoryAttributes() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/santa/model"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+func upsertExecutableObservations(
+	ctx context.Context,
+	queries *db.Queries,
+	observations []db.UpsertExecutableObservationParams,
+) error {
+	for _, observation := range observations {
+		if err := queries.UpsertExecutableObservation(ctx, observation); err != nil {
+			return fmt.Errorf("upsert executable observation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// planExecutableObservations folds a batch of execution events into one observation per
+// executable, ordered by executable ID so concurrent ingests lock rows in the same order.
+// Events without an occurrence time count as seen at receivedAt.
+func planExecutableObservations(
+	machineID uuid.UUID,
+	events []model.ExecutionEventWrite,
+	executableIDs map[executableIdentity]uuid.UUID,
+	receivedAt time.Time,
+) []db.UpsertExecutableObservationParams {
+	byExecutable := make(map[uuid.UUID]*db.UpsertExecutableObservationParams, len(executableIDs))
+	users := make(map[uuid.UUID]map[string]struct{}, len(executableIDs))
+
+	for _, event := range events {
+		executableID := executableIDs[identityForExecutable(event.Executable)]
+
+		seenAt := receivedAt
+		if event.OccurredAt != nil {
+			seenAt = *event.OccurredAt
+		}
+
+		observation, ok := byExecutable[executableID]
+		if !ok {
+			observation = &db.UpsertExecutableObservationParams{
+				ExecutableID: executableID,
+				MachineID:    machineID,
+				FirstSeenAt:  seenAt,
+				LastSeenAt:   seenAt,
+			}
+			byExecutable[executableID] = observation
+			users[executableID] = make(map[string]struct{})
+		}
+
+		observation.EventCount++
+		if seenAt.Before(observation.FirstSeenAt) {
+			observation.FirstSeenAt = seenAt
+		}
+		if seenAt.After(observation.LastSeenAt) {
+			observation.LastSeenAt = seenAt
+		}
+		if event.ExecutingUser != "" {
+			users[executableID][event.ExecutingUser] = struct{}{}
+		}
+	}
+
+	observations := make([]db.UpsertExecutableObservationParams, 0, len(byExecutable))
+	for executableID, observation := range byExecutable {
+		observation.ExecutingUsers = make([]string, 0, len(users[executableID]))
+		for user := range users[executableID] {
+			observation.ExecutingUsers = append(observation.ExecutingUsers, user)
+		}
+		sort.Strings(observation.ExecutingUsers)
+
+		observations = append(observations, *observation)
+	}
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].ExecutableID.String() < observations[j].ExecutableID.String()
+	})
+
+	return observations
+}
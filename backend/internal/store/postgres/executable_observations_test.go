@@ -0,0 +1,63 @@
+package postgres //nolint:testpackage // exercises unexported observation planning.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/santa/model"
+)
+
+func TestPlanExecutableObservationsFoldsEventsPerExecutable(t *testing.T) {
+	machineID := uuid.New()
+	first := model.ExecutableWrite{FileSHA256: "sha-a", FileName: "A"}
+	second := model.ExecutableWrite{FileSHA256: "sha-b", FileName: "B"}
+	executableIDs := map[executableIdentity]uuid.UUID{
+		identityForExecutable(first):  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		identityForExecutable(second): uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+	}
+
+	early := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+	receivedAt := early.Add(2 * time.Hour)
+
+	events := []model.ExecutionEventWrite{
+		{Executable: first, ExecutingUser: "bob", OccurredAt: &late},
+		{Executable: first, ExecutingUser: "alice", OccurredAt: &early},
+		{Executable: first, ExecutingUser: "bob"},
+		{Executable: second},
+	}
+
+	got := planExecutableObservations(machineID, events, executableIDs, receivedAt)
+
+	if len(got) != 2 {
+		t.Fatalf("planExecutableObservations() returned %d observations, want 2", len(got))
+	}
+	if got[0].ExecutableID != executableIDs[identityForExecutable(second)] {
+		t.Fatalf("planExecutableObservations()[0].ExecutableID = %s, want lowest executable ID first", got[0].ExecutableID)
+	}
+
+	observation := got[1]
+	if observation.MachineID != machineID {
+		t.Fatalf("MachineID = %s, want %s", observation.MachineID, machineID)
+	}
+	if observation.EventCount != 3 {
+		t.Fatalf("EventCount = %d, want 3", observation.EventCount)
+	}
+	if !observation.FirstSeenAt.Equal(early) {
+		t.Fatalf("FirstSeenAt = %s, want %s", observation.FirstSeenAt, early)
+	}
+	if !observation.LastSeenAt.Equal(receivedAt) {
+		t.Fatalf("LastSeenAt = %s, want receivedAt %s for an event without an occurrence time", observation.LastSeenAt, receivedAt)
+	}
+	if len(observation.ExecutingUsers) != 2 ||
+		observation.ExecutingUsers[0] != "alice" ||
+		observation.ExecutingUsers[1] != "bob" {
+		t.Fatalf("ExecutingUsers = %q, want [alice bob]", observation.ExecutingUsers)
+	}
+
+	if users := got[0].ExecutingUsers; len(users) != 0 {
+		t.Fatalf("ExecutingUsers = %q, want none for events without a user", users)
+	}
+}
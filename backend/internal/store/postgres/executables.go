@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,6 +19,10 @@ var (
 		"file_name":        "e.file_name",
 		"file_sha256":      "e.file_sha256",
 		"occurrences":      "occurrences",
+		"machine_count":    "machine_count",
+		"user_count":       "user_count",
+		"first_seen_at":    "prevalence.first_seen_at",
+		"last_seen_at":     "prevalence.last_seen_at",
 		sortFieldCreatedAt: "e.created_at",
 		"signing_id":       "e.signing_id",
 		"team_id":          "e.team_id",
@@ -31,7 +36,7 @@ var (
 
 func (s *Store) ListExecutables(
 	ctx context.Context,
-	opts domain.ListOptions,
+	opts domain.ExecutableListOptions,
 ) ([]domain.ExecutableSummary, int32, error) {
 	orderBy, err := orderBy(
 		opts.Sort,
@@ -57,6 +62,27 @@ func (s *Store) ListExecutables(
 		where = append(where, fmt.Sprintf("e.id = ANY($%d)", len(args)+1))
 		args = append(args, opts.IDs)
 	}
+	if opts.CertificateID != nil {
+		where = append(where, fmt.Sprintf(`EXISTS (
+  SELECT 1
+  FROM executable_certificates AS filter_ec
+  WHERE filter_ec.executable_id = e.id
+    AND filter_ec.certificate_id = $%d
+)`, len(args)+1))
+		args = append(args, *opts.CertificateID)
+	}
+	if opts.TeamID != "" {
+		where = append(where, fmt.Sprintf("e.team_id = $%d", len(args)+1))
+		args = append(args, opts.TeamID)
+	}
+	if opts.MinMachineCount != nil {
+		where = append(where, fmt.Sprintf("COALESCE(prevalence.machine_count, 0) >= $%d", len(args)+1))
+		args = append(args, *opts.MinMachineCount)
+	}
+	if opts.MaxMachineCount != nil {
+		where = append(where, fmt.Sprintf("COALESCE(prevalence.machine_count, 0) <= $%d", len(args)+1))
+		args = append(args, *opts.MaxMachineCount)
+	}
 
 	limitArg := len(args) + 1
 	offsetArg := limitArg + 1
@@ -84,7 +110,23 @@ func (s *Store) GetExecutable(ctx context.Context, id uuid.UUID) (domain.Executa
 		return domain.Executable{}, err
 	}
 
-	return mapExecutable(row)
+	executable, err := mapExecutable(row)
+	if err != nil {
+		return domain.Executable{}, err
+	}
+
+	prevalence, err := s.Queries().GetExecutablePrevalence(ctx, id)
+	switch {
+	case err == nil:
+		executable.MachineCount = prevalence.MachineCount
+		executable.UserCount = prevalence.UserCount
+		executable.FirstSeenAt = &prevalence.FirstSeenAt
+		executable.LastSeenAt = &prevalence.LastSeenAt
+	case !errors.Is(err, pgx.ErrNoRows):
+		return domain.Executable{}, fmt.Errorf("get executable prevalence: %w", err)
+	}
+
+	return executable, nil
 }
 
 func scanExecutableSummaryRow(rows pgx.Rows) (domain.ExecutableSummary, int32, error) {
@@ -103,6 +145,10 @@ func scanExecutableSummaryRow(rows pgx.Rows) (domain.ExecutableSummary, int32, e
 		&item.TeamID,
 		&item.CDHash,
 		&item.Occurrences,
+		&item.MachineCount,
+		&item.UserCount,
+		&item.FirstSeenAt,
+		&item.LastSeenAt,
 		&item.CreatedAt,
 		&total,
 	); err != nil {
@@ -150,6 +196,10 @@ SELECT
   e.team_id,
   e.cdhash,
   COALESCE(event_counts.occurrences, 0)::INT4 AS occurrences,
+  COALESCE(prevalence.machine_count, 0)::INT4 AS machine_count,
+  COALESCE(prevalence.user_count, 0)::INT4 AS user_count,
+  prevalence.first_seen_at,
+  prevalence.last_seen_at,
   e.created_at,
   COUNT(*) OVER()::INT4 AS total
 FROM executables AS e
@@ -161,6 +211,19 @@ LEFT JOIN (
   GROUP BY executable_id
 ) AS event_counts
   ON event_counts.executable_id = e.id
+LEFT JOIN (
+  SELECT
+    eo.executable_id,
+    COUNT(DISTINCT eo.machine_id)::INT4 AS machine_count,
+    COUNT(DISTINCT observed_user.executing_user)::INT4 AS user_count,
+    MIN(eo.first_seen_at) AS first_seen_at,
+    MAX(eo.last_seen_at) AS last_seen_at
+  FROM executable_observations AS eo
+  LEFT JOIN LATERAL unnest(eo.executing_users) AS observed_user (executing_user)
+    ON TRUE
+  GROUP BY eo.executable_id
+) AS prevalence
+  ON prevalence.executable_id = e.id
 WHERE %s
 ORDER BY %s
 LIMIT NULLIF($%d::INT, 0)
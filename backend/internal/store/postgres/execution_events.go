@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -66,9 +69,18 @@ func (s *Store) ListExecutionEvents(
 		args = append(args, *opts.MachineID)
 	}
 	if opts.UserID != nil {
-		where = append(where, fmt.Sprintf("u.id = $%d::uuid", len(args)+1))
+		where = append(where, fmt.Sprintf(`(ee.executing_user_id = $%[1]d::uuid OR EXISTS (
+  SELECT 1
+  FROM execution_event_logged_in_users AS eelu
+  WHERE eelu.execution_event_id = ee.id
+    AND eelu.user_id = $%[1]d::uuid
+))`, len(args)+1))
 		args = append(args, *opts.UserID)
 	}
+	if opts.ExecutingUserID != nil {
+		where = append(where, fmt.Sprintf("ee.executing_user_id = $%d::uuid", len(args)+1))
+		args = append(args, *opts.ExecutingUserID)
+	}
 	if opts.ExecutableID != nil {
 		where = append(where, fmt.Sprintf("ee.executable_id = $%d::uuid", len(args)+1))
 		args = append(args, *opts.ExecutableID)
@@ -156,15 +168,47 @@ func (s *Store) ingestEventsOnce(
 			if err != nil {
 				return err
 			}
+			if err = linkExecutableCertificates(ctx, q, executableID, executable.SigningChain); err != nil {
+				return err
+			}
 
 			executableIDs[identityForExecutable(executable)] = executableID
 		}
 
+		eventUsers, err := resolveEventUsers(ctx, q, events)
+		if err != nil {
+			return err
+		}
+
+		eventIDs := make([]uuid.UUID, 0, len(events))
 		for _, event := range events {
 			executableID := executableIDs[identityForExecutable(event.Executable)]
-			if err := ingestExecutionEvent(ctx, q, machineID, executableID, event); err != nil {
-				return err
+			eventID, ingestErr := ingestExecutionEvent(ctx, q, machineID, executableID, eventUsers, event)
+			if ingestErr != nil {
+				return ingestErr
 			}
+			eventIDs = append(eventIDs, eventID)
+		}
+
+		loggedInUsers := loggedInUserLinks(events, eventIDs, eventUsers)
+		if len(loggedInUsers.ExecutionEventIds) > 0 {
+			if err = q.InsertExecutionEventLoggedInUsers(ctx, loggedInUsers); err != nil {
+				return fmt.Errorf("link execution event logged in users: %w", err)
+			}
+		}
+
+		receivedAt := time.Now().UTC()
+		observations := planExecutableObservations(machineID, events, executableIDs, receivedAt)
+		if err := upsertExecutableObservations(ctx, q, observations); err != nil {
+			return err
+		}
+
+		hits, err := planRuleHits(machineID, events, receivedAt)
+		if err != nil {
+			return err
+		}
+		if err = upsertRuleHits(ctx, q, hits); err != nil {
+			return err
 		}
 
 		for _, event := range fileAccessEvents {
@@ -246,6 +290,8 @@ func scanExecutionEventSummaryRow(rows pgx.Rows) (domain.ExecutionEventSummary,
 		&item.FilePath,
 		&item.FileName,
 		&item.SigningID,
+		&item.ExecutingUser,
+		&item.ExecutingUserID,
 		&item.OccurredAt,
 		&item.CreatedAt,
 		&total,
@@ -293,7 +339,9 @@ func mapExecutionEvent(row db.GetExecutionEventRow) (domain.ExecutionEvent, erro
 		TeamID:          row.TeamID,
 		CDHash:          row.Cdhash,
 		ExecutingUser:   row.ExecutingUser,
+		ExecutingUserID: row.ExecutingUserID,
 		LoggedInUsers:   row.LoggedInUsers,
+		LoggedInUserIDs: row.LoggedInUserIds,
 		CurrentSessions: row.CurrentSessions,
 		SigningChain:    signingChain,
 		Entitlements:    entitlements,
@@ -336,9 +384,15 @@ func ingestExecutionEvent(
 	queries *db.Queries,
 	machineID uuid.UUID,
 	executableID uuid.UUID,
+	eventUsers map[string]uuid.UUID,
 	event model.ExecutionEventWrite,
-) error {
-	_, err := queries.CreateExecutionEvent(ctx, db.CreateExecutionEventParams{
+) (uuid.UUID, error) {
+	var executingUserID *uuid.UUID
+	if userID, ok := eventUsers[event.ExecutingUser]; ok {
+		executingUserID = &userID
+	}
+
+	row, err := queries.CreateExecutionEvent(ctx, db.CreateExecutionEventParams{
 		MachineID:       machineID,
 		ExecutableID:    executableID,
 		Decision:        db.ExecutionDecision(event.Decision),
@@ -347,12 +401,92 @@ func ingestExecutionEvent(
 		LoggedInUsers:   event.LoggedInUsers,
 		CurrentSessions: event.CurrentSessions,
 		OccurredAt:      event.OccurredAt,
+		ExecutingUserID: executingUserID,
 	})
 	if err != nil {
-		return fmt.Errorf("ingest execution event: %w", err)
+		return uuid.Nil, fmt.Errorf("ingest execution event: %w", err)
 	}
 
-	return nil
+	return row.ID, nil
+}
+
+// resolveEventUsers matches the executing and logged-in users reported across events to users,
+// keyed by the name as reported. Names that match no user, or several, are left out.
+func resolveEventUsers(
+	ctx context.Context,
+	queries *db.Queries,
+	events []model.ExecutionEventWrite,
+) (map[string]uuid.UUID, error) {
+	params := eventUserLookups(events)
+	if len(params.CandidateNames) == 0 {
+		return nil, nil
+	}
+
+	rows, err := queries.ResolveUserNames(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("resolve event users: %w", err)
+	}
+
+	users := make(map[string]uuid.UUID, len(rows))
+	for _, row := range rows {
+		users[row.Name] = row.UserID
+	}
+
+	return users, nil
+}
+
+// eventUserLookups pairs each executing and logged-in user name reported across events with its
+// candidate lookups, as the parallel slices ResolveUserNames takes, ordered by name.
+func eventUserLookups(events []model.ExecutionEventWrite) db.ResolveUserNamesParams {
+	lookups := make(map[string][]string)
+	for _, event := range events {
+		lookups[event.ExecutingUser] = event.ExecutingUserLookups
+		for i, user := range event.LoggedInUsers {
+			if i < len(event.LoggedInUserLookups) {
+				lookups[user] = event.LoggedInUserLookups[i]
+			}
+		}
+	}
+
+	var params db.ResolveUserNamesParams
+	for _, name := range slices.Sorted(maps.Keys(lookups)) {
+		for _, lookup := range lookups[name] {
+			params.CandidateNames = append(params.CandidateNames, name)
+			params.CandidateLookups = append(params.CandidateLookups, lookup)
+		}
+	}
+
+	return params
+}
+
+// loggedInUserLinks pairs each ingested event, by position in events, with the distinct users its
+// logged-in users resolved to.
+func loggedInUserLinks(
+	events []model.ExecutionEventWrite,
+	eventIDs []uuid.UUID,
+	eventUsers map[string]uuid.UUID,
+) db.InsertExecutionEventLoggedInUsersParams {
+	var links db.InsertExecutionEventLoggedInUsersParams
+	for i, event := range events {
+		for _, userID := range loggedInUserIDs(eventUsers, event.LoggedInUsers) {
+			links.ExecutionEventIds = append(links.ExecutionEventIds, eventIDs[i])
+			links.UserIds = append(links.UserIds, userID)
+		}
+	}
+
+	return links
+}
+
+// loggedInUserIDs returns the distinct users the reported logged-in users resolved to.
+func loggedInUserIDs(eventUsers map[string]uuid.UUID, loggedInUsers []string) []uuid.UUID {
+	userIDs := make([]uuid.UUID, 0, len(loggedInUsers))
+	for _, user := range loggedInUsers {
+		if userID, ok := eventUsers[user]; ok && !slices.Contains(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs
 }
 
 const executionEventListQuery = `
@@ -364,6 +498,8 @@ SELECT
   ee.file_path,
   x.file_name,
   x.signing_id,
+  ee.executing_user,
+  ee.executing_user_id,
   ee.occurred_at,
   ee.created_at,
   COUNT(*) OVER()::INT4 AS total
@@ -372,9 +508,6 @@ JOIN machines AS m
   ON m.id = ee.machine_id
 JOIN executables AS x
   ON x.id = ee.executable_id
-LEFT JOIN users AS u
-  ON u.upn = m.primary_user
-  AND m.primary_user <> ''
 WHERE %s
 ORDER BY %s
 LIMIT NULLIF($%d::INT, 0)
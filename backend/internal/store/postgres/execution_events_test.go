@@ -1,10 +1,12 @@
-package postgres //nolint:testpackage // exercises unexported transaction planning and retry classification.
+package postgres //nolint:testpackage // exercises unexported transaction planning, user linking and retry classification.
 
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/woodleighschool/grinch/internal/santa/model"
@@ -64,6 +66,84 @@ func TestOrderedDistinctExecutablesKeepsFirstPayloadPerIdentity(t *testing.T) {
 	}
 }
 
+func TestLoggedInUserIDsSkipsUnresolvedAndDuplicateUsers(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+	eventUsers := map[string]uuid.UUID{
+		"alice":             alice,
+		"Alice@example.com": alice,
+		"bob":               bob,
+	}
+
+	got := loggedInUserIDs(eventUsers, []string{"alice", "guest", "Alice@example.com", "bob"})
+
+	if want := []uuid.UUID{alice, bob}; !slices.Equal(got, want) {
+		t.Fatalf("loggedInUserIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestEventUserLookupsPairsEachNameWithItsLookups(t *testing.T) {
+	events := []model.ExecutionEventWrite{
+		{
+			ExecutingUser:        "bob",
+			ExecutingUserLookups: []string{"bob", "bob@example.com"},
+			LoggedInUsers:        []string{"alice", "carol"},
+			LoggedInUserLookups:  [][]string{{"alice"}},
+		},
+		{
+			ExecutingUser:        "alice",
+			ExecutingUserLookups: []string{"alice"},
+		},
+	}
+
+	got := eventUserLookups(events)
+
+	// carol has no lookups, so she cannot resolve; alice is listed once despite being reported twice.
+	wantNames := []string{"alice", "bob", "bob"}
+	wantLookups := []string{"alice", "bob", "bob@example.com"}
+	if !slices.Equal(got.CandidateNames, wantNames) || !slices.Equal(got.CandidateLookups, wantLookups) {
+		t.Fatalf(
+			"eventUserLookups() = %v / %v, want %v / %v",
+			got.CandidateNames, got.CandidateLookups, wantNames, wantLookups,
+		)
+	}
+}
+
+func TestLoggedInUserLinksPairsEventsWithResolvedUsers(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+	firstEvent := uuid.New()
+	secondEvent := uuid.New()
+	thirdEvent := uuid.New()
+	eventUsers := map[string]uuid.UUID{"alice": alice, "bob": bob}
+	events := []model.ExecutionEventWrite{
+		{LoggedInUsers: []string{"alice", "bob", "alice"}},
+		{LoggedInUsers: []string{"guest"}},
+		{LoggedInUsers: []string{"bob"}},
+	}
+
+	got := loggedInUserLinks(events, []uuid.UUID{firstEvent, secondEvent, thirdEvent}, eventUsers)
+
+	wantEvents := []uuid.UUID{firstEvent, firstEvent, thirdEvent}
+	wantUsers := []uuid.UUID{alice, bob, bob}
+	if !slices.Equal(got.ExecutionEventIds, wantEvents) || !slices.Equal(got.UserIds, wantUsers) {
+		t.Fatalf(
+			"loggedInUserLinks() = %v / %v, want %v / %v",
+			got.ExecutionEventIds, got.UserIds, wantEvents, wantUsers,
+		)
+	}
+}
+
+func TestLoggedInUserLinksWithoutResolvedUsers(t *testing.T) {
+	events := []model.ExecutionEventWrite{{LoggedInUsers: []string{"guest"}}, {}}
+
+	got := loggedInUserLinks(events, []uuid.UUID{uuid.New(), uuid.New()}, map[string]uuid.UUID{})
+
+	if len(got.ExecutionEventIds) != 0 || len(got.UserIds) != 0 {
+		t.Fatalf("loggedInUserLinks() = %v / %v, want no links", got.ExecutionEventIds, got.UserIds)
+	}
+}
+
 func TestIsRetryableEventIngestError(t *testing.T) {
 	tests := []struct {
 		name string
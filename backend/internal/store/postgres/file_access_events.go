@@ -109,6 +109,10 @@ func ingestFileAccessEvent(
 ) error {
 	processChain := make([]domain.FileAccessEventProcess, 0, len(event.Processes))
 	for _, process := range event.Processes {
+		if _, err := upsertSigningChainCertificates(ctx, queries, process.SigningChain); err != nil {
+			return err
+		}
+
 		processChain = append(processChain, domain.FileAccessEventProcess{
 			Pid:        process.Pid,
 			FilePath:   process.FilePath,
@@ -72,6 +72,8 @@ SELECT
   g.name,
   g.description,
   g.source,
+  g.machine_criteria,
+  g.user_criteria,
   COALESCE(member_counts.member_count, 0)::INT4 AS member_count,
   g.created_at,
   g.updated_at,
@@ -112,6 +114,8 @@ func (s *Store) GetGroup(ctx context.Context, id uuid.UUID) (domain.Group, error
 		row.Name,
 		row.Description,
 		string(row.Source),
+		row.MachineCriteria,
+		row.UserCriteria,
 		row.MemberCount,
 		row.CreatedAt,
 		row.UpdatedAt,
@@ -122,31 +126,44 @@ func (s *Store) CreateLocalGroup(
 	ctx context.Context,
 	name string,
 	description string,
+	machineCriteria *domain.MachineGroupCriteria,
+	userCriteria *domain.UserGroupCriteria,
 ) (domain.Group, error) {
 	id, err := uuid.NewV7()
 	if err != nil {
 		return domain.Group{}, fmt.Errorf("create group id: %w", err)
 	}
 
-	row, err := s.Queries().UpsertGroup(ctx, db.UpsertGroupParams{
-		ID:          id,
-		Name:        name,
-		Description: description,
-		Source:      db.PrincipalSource(domain.PrincipalSourceLocal),
-	})
+	encodedMachineCriteria, err := encodeMachineCriteria(machineCriteria)
 	if err != nil {
 		return domain.Group{}, err
 	}
 
-	return mapGroupFields(
-		row.ID,
-		row.Name,
-		row.Description,
-		string(row.Source),
-		row.MemberCount,
-		row.CreatedAt,
-		row.UpdatedAt,
-	)
+	encodedUserCriteria, err := encodeUserCriteria(userCriteria)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	if err = s.RunInTx(ctx, func(q *db.Queries) error {
+		if _, err = q.UpsertGroup(ctx, db.UpsertGroupParams{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			Source:      db.PrincipalSource(domain.PrincipalSourceLocal),
+		}); err != nil {
+			return err
+		}
+
+		return q.SetGroupCriteria(ctx, db.SetGroupCriteriaParams{
+			ID:              id,
+			MachineCriteria: encodedMachineCriteria,
+			UserCriteria:    encodedUserCriteria,
+		})
+	}); err != nil {
+		return domain.Group{}, err
+	}
+
+	return s.GetGroup(ctx, id)
 }
 
 func (s *Store) UpdateGroup(
@@ -154,11 +171,25 @@ func (s *Store) UpdateGroup(
 	id uuid.UUID,
 	name string,
 	description string,
+	machineCriteria *domain.MachineGroupCriteria,
+	userCriteria *domain.UserGroupCriteria,
 ) (domain.Group, error) {
+	encodedMachineCriteria, err := encodeMachineCriteria(machineCriteria)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	encodedUserCriteria, err := encodeUserCriteria(userCriteria)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
 	row, err := s.Queries().UpdateGroup(ctx, db.UpdateGroupParams{
-		ID:          id,
-		Name:        name,
-		Description: description,
+		ID:              id,
+		Name:            name,
+		Description:     description,
+		MachineCriteria: encodedMachineCriteria,
+		UserCriteria:    encodedUserCriteria,
 	})
 	if err != nil {
 		return domain.Group{}, err
@@ -207,6 +238,8 @@ func scanGroupRow(rows pgx.Rows) (domain.Group, int32, error) {
 		&row.Name,
 		&row.Description,
 		&row.Source,
+		&row.MachineCriteria,
+		&row.UserCriteria,
 		&memberCount,
 		&row.CreatedAt,
 		&row.UpdatedAt,
@@ -229,6 +262,8 @@ func mapGroup(row db.Group, memberCount int32) (domain.Group, error) {
 		row.Name,
 		row.Description,
 		string(row.Source),
+		row.MachineCriteria,
+		row.UserCriteria,
 		memberCount,
 		row.CreatedAt,
 		row.UpdatedAt,
@@ -245,6 +280,8 @@ func mapUpdatedGroupRow(row db.UpdateGroupRow) (domain.Group, error) {
 		row.Name.String,
 		row.Description.String,
 		string(row.Source.PrincipalSource),
+		row.MachineCriteria,
+		row.UserCriteria,
 		row.MemberCount,
 		*row.CreatedAt,
 		*row.UpdatedAt,
@@ -256,6 +293,8 @@ func mapGroupFields(
 	name string,
 	description string,
 	sourceText string,
+	machineCriteria []byte,
+	userCriteria []byte,
 	memberCount int32,
 	createdAt time.Time,
 	updatedAt time.Time,
@@ -265,13 +304,25 @@ func mapGroupFields(
 		return domain.Group{}, fmt.Errorf("parse group source: %w", err)
 	}
 
+	decodedMachineCriteria, err := decodeMachineCriteria(machineCriteria)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	decodedUserCriteria, err := decodeUserCriteria(userCriteria)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
 	return domain.Group{
-		ID:          id,
-		Name:        name,
-		Description: description,
-		Source:      source,
-		MemberCount: memberCount,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:              id,
+		Name:            name,
+		Description:     description,
+		Source:          source,
+		MachineCriteria: decodedMachineCriteria,
+		UserCriteria:    decodedUserCriteria,
+		MemberCount:     memberCount,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
 	}, nil
 }
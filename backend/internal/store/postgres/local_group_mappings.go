@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	localGroupMappingListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":               "lgm.id",
+		"local_group":      "lgm.local_group",
+		"group_name":       "g.name",
+		sortFieldCreatedAt: "lgm.created_at",
+		sortFieldUpdatedAt: "lgm.updated_at",
+	}
+
+	localGroupMappingListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"lgm.local_group ASC",
+		"g.name ASC",
+		"lgm.id ASC",
+	}
+)
+
+func (s *Store) ListLocalGroupMappings(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.LocalGroupMapping, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		localGroupMappingListSortColumns,
+		localGroupMappingListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"($1 = '' OR lgm.local_group ILIKE $1 OR g.name ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("lgm.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  lgm.id,
+  lgm.local_group,
+  lgm.group_id,
+  g.name AS group_name,
+  lgm.created_at,
+  lgm.updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM local_group_mappings AS lgm
+JOIN groups AS g
+  ON g.id = lgm.group_id
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list local group mappings: %w", err)
+	}
+
+	return collectRows(rows, scanLocalGroupMappingRow)
+}
+
+func (s *Store) GetLocalGroupMapping(ctx context.Context, id uuid.UUID) (domain.LocalGroupMapping, error) {
+	row, err := s.Queries().GetLocalGroupMapping(ctx, id)
+	if err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	return mapLocalGroupMapping(row), nil
+}
+
+func (s *Store) CreateLocalGroupMapping(
+	ctx context.Context,
+	localGroup string,
+	groupID uuid.UUID,
+) (domain.LocalGroupMapping, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.LocalGroupMapping{}, fmt.Errorf("create local group mapping id: %w", err)
+	}
+
+	if _, err = s.Queries().CreateLocalGroupMapping(ctx, db.CreateLocalGroupMappingParams{
+		ID:         id,
+		LocalGroup: localGroup,
+		GroupID:    groupID,
+	}); err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+
+	return s.GetLocalGroupMapping(ctx, id)
+}
+
+func (s *Store) UpdateLocalGroupMapping(
+	ctx context.Context,
+	id uuid.UUID,
+	localGroup string,
+	groupID uuid.UUID,
+) (domain.LocalGroupMapping, error) {
+	n, err := s.Queries().UpdateLocalGroupMapping(ctx, db.UpdateLocalGroupMappingParams{
+		ID:         id,
+		LocalGroup: localGroup,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return domain.LocalGroupMapping{}, err
+	}
+	if n == 0 {
+		return domain.LocalGroupMapping{}, pgx.ErrNoRows
+	}
+
+	return s.GetLocalGroupMapping(ctx, id)
+}
+
+func (s *Store) DeleteLocalGroupMapping(ctx context.Context, id uuid.UUID) error {
+	n, err := s.Queries().DeleteLocalGroupMapping(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+type localGroupCandidate struct {
+	id          uuid.UUID
+	localGroups []string
+}
+
+// listLocalGroupMachineMemberships applies local group mappings to the machines' reported primary
+// user groups, or every machine's when machineID is nil.
+func listLocalGroupMachineMemberships(
+	ctx context.Context,
+	q *db.Queries,
+	machineID *uuid.UUID,
+) ([]uuid.UUID, []uuid.UUID, error) {
+	mappingRows, err := q.ListLocalGroupMappingTargets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list local group mappings: %w", err)
+	}
+
+	mappings := make(map[string][]uuid.UUID, len(mappingRows))
+	for _, row := range mappingRows {
+		mappings[row.LocalGroup] = append(mappings[row.LocalGroup], row.GroupID)
+	}
+
+	machineRows, err := q.ListLocalGroupCandidates(ctx, machineID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list local group candidates: %w", err)
+	}
+
+	candidates := make([]localGroupCandidate, 0, len(machineRows))
+	for _, row := range machineRows {
+		candidates = append(candidates, localGroupCandidate{id: row.ID, localGroups: row.PrimaryUserGroups})
+	}
+
+	groupIDs, machineIDs := localGroupMachineMemberships(mappings, candidates)
+
+	return groupIDs, machineIDs, nil
+}
+
+// localGroupMachineMemberships returns the group and machine ID pairs, as parallel slices, for
+// every local group a machine's primary user reported that is mapped onto a group. Local group
+// names match exactly; unmapped names are ignored.
+func localGroupMachineMemberships(
+	mappings map[string][]uuid.UUID,
+	candidates []localGroupCandidate,
+) ([]uuid.UUID, []uuid.UUID) {
+	groupIDs := []uuid.UUID{}
+	machineIDs := []uuid.UUID{}
+
+	for _, candidate := range candidates {
+		seen := make(map[uuid.UUID]struct{})
+		for _, localGroup := range candidate.localGroups {
+			for _, groupID := range mappings[localGroup] {
+				if _, ok := seen[groupID]; ok {
+					continue
+				}
+				seen[groupID] = struct{}{}
+
+				groupIDs = append(groupIDs, groupID)
+				machineIDs = append(machineIDs, candidate.id)
+			}
+		}
+	}
+
+	return groupIDs, machineIDs
+}
+
+func scanLocalGroupMappingRow(rows pgx.Rows) (domain.LocalGroupMapping, int32, error) {
+	var (
+		row   db.GetLocalGroupMappingRow
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.LocalGroup,
+		&row.GroupID,
+		&row.GroupName,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.LocalGroupMapping{}, 0, err
+	}
+
+	return mapLocalGroupMapping(row), total, nil
+}
+
+func mapLocalGroupMapping(row db.GetLocalGroupMappingRow) domain.LocalGroupMapping {
+	return domain.LocalGroupMapping{
+		ID:         row.ID,
+		LocalGroup: row.LocalGroup,
+		GroupID:    row.GroupID,
+		GroupName:  row.GroupName,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package postgres //nolint:testpackage // exercises unexported local group membership planning.
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLocalGroupMachineMemberships(t *testing.T) {
+	staff := uuid.MustParse("00000000-0000-0000-0000-0000000000a1")
+	music := uuid.MustParse("00000000-0000-0000-0000-0000000000a2")
+	admins := uuid.MustParse("00000000-0000-0000-0000-0000000000a3")
+	laptop := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	desktop := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	mappings := map[string][]uuid.UUID{
+		"staff":          {staff},
+		"music-students": {music},
+		"admin":          {admins, staff},
+	}
+
+	type membership struct {
+		group   uuid.UUID
+		machine uuid.UUID
+	}
+
+	tests := []struct {
+		name       string
+		candidates []localGroupCandidate
+		want       []membership
+	}{
+		{
+			name:       "mapped local group adds a membership",
+			candidates: []localGroupCandidate{{id: laptop, localGroups: []string{"staff"}}},
+			want:       []membership{{staff, laptop}},
+		},
+		{
+			name: "every mapped local group of every machine",
+			candidates: []localGroupCandidate{
+				{id: laptop, localGroups: []string{"staff", "music-students"}},
+				{id: desktop, localGroups: []string{"music-students"}},
+			},
+			want: []membership{{staff, laptop}, {music, laptop}, {music, desktop}},
+		},
+		{
+			name:       "unknown local group names are ignored",
+			candidates: []localGroupCandidate{{id: laptop, localGroups: []string{"everyone", "_developer", "staff"}}},
+			want:       []membership{{staff, laptop}},
+		},
+		{
+			name:       "local group names match exactly",
+			candidates: []localGroupCandidate{{id: laptop, localGroups: []string{"Staff", " staff"}}},
+			want:       []membership{},
+		},
+		{
+			name:       "one local group mapped onto several groups",
+			candidates: []localGroupCandidate{{id: laptop, localGroups: []string{"admin"}}},
+			want:       []membership{{admins, laptop}, {staff, laptop}},
+		},
+		{
+			name:       "group reached through two local groups is wanted once",
+			candidates: []localGroupCandidate{{id: laptop, localGroups: []string{"staff", "admin", "staff"}}},
+			want:       []membership{{staff, laptop}, {admins, laptop}},
+		},
+		{
+			name: "machine no longer reporting a mapped group wants no membership, so it is removed",
+			candidates: []localGroupCandidate{
+				{id: laptop, localGroups: []string{}},
+				{id: desktop, localGroups: nil},
+			},
+			want: []membership{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupIDs, machineIDs := localGroupMachineMemberships(mappings, tt.candidates)
+			if len(groupIDs) != len(machineIDs) {
+				t.Fatalf("got %d group IDs and %d machine IDs, want parallel slices", len(groupIDs), len(machineIDs))
+			}
+
+			got := make([]membership, 0, len(groupIDs))
+			for i := range groupIDs {
+				got = append(got, membership{groupIDs[i], machineIDs[i]})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("memberships = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalGroupMachineMembershipsWithoutMappings(t *testing.T) {
+	groupIDs, machineIDs := localGroupMachineMemberships(
+		map[string][]uuid.UUID{},
+		[]localGroupCandidate{{id: uuid.New(), localGroups: []string{"staff"}}},
+	)
+
+	// Empty, non-nil slices delete every synced membership and insert none.
+	if groupIDs == nil || machineIDs == nil || len(groupIDs) != 0 || len(machineIDs) != 0 {
+		t.Fatalf("got %v and %v, want empty non-nil slices", groupIDs, machineIDs)
+	}
+}
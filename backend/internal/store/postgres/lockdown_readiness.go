@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+func (s *Store) ListUnknownExecutions(
+	ctx context.Context,
+	machineIDs []uuid.UUID,
+	since time.Time,
+) ([]domain.UnknownExecution, error) {
+	rows, err := s.Queries().ListUnknownExecutionsForMachines(ctx, db.ListUnknownExecutionsForMachinesParams{
+		MachineIds: machineIDs,
+		Since:      since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list unknown executions: %w", err)
+	}
+
+	executions := make([]domain.UnknownExecution, 0, len(rows))
+	for _, row := range rows {
+		executions = append(executions, domain.UnknownExecution{
+			MachineID:    row.MachineID,
+			Hostname:     row.Hostname,
+			ExecutableID: row.ExecutableID,
+			FileName:     row.FileName,
+			Identity: domain.ExecutableIdentity{
+				FileSHA256:        row.FileSHA256,
+				CDHash:            row.Cdhash,
+				SigningID:         row.SigningID,
+				TeamID:            row.TeamID,
+				CertificateSHA256: row.LeafCertificateSha256,
+			},
+			ExecutingUser:  row.ExecutingUser,
+			ExecutionCount: row.ExecutionCount,
+			LastExecutedAt: row.LastExecutedAt,
+		})
+	}
+
+	return executions, nil
+}
@@ -37,6 +37,17 @@ func (s *Store) UpdateMachineDesiredTargetsByPrimaryUserID(
 	return s.updateMachineDesiredTargets(ctx, machineIDs)
 }
 
+// UpdateMachineDesiredTargetsByGroupID recomputes the machines that are effective members of a
+// group, directly, through their primary user or through nested groups.
+func (s *Store) UpdateMachineDesiredTargetsByGroupID(ctx context.Context, groupID uuid.UUID) error {
+	machineIDs, err := s.Queries().ListMachineIDsByEffectiveGroupID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("list machine ids by effective group id: %w", err)
+	}
+
+	return s.updateMachineDesiredTargets(ctx, machineIDs)
+}
+
 func (s *Store) updateMachineDesiredTargets(ctx context.Context, machineIDs []uuid.UUID) error {
 	if len(machineIDs) == 0 {
 		return nil
@@ -45,7 +56,7 @@ func (s *Store) updateMachineDesiredTargets(ctx context.Context, machineIDs []uu
 	queries := s.Queries()
 
 	for _, machineID := range machineIDs {
-		rows, err := queries.ListResolvedRulesForMachine(ctx, machineID)
+		rows, err := queries.ListResolvedRulesForMachines(ctx, []uuid.UUID{machineID})
 		if err != nil {
 			return fmt.Errorf("list resolved rules for machine %s: %w", machineID, err)
 		}
@@ -72,7 +83,7 @@ func (s *Store) updateMachineDesiredTargets(ctx context.Context, machineIDs []uu
 }
 
 func buildDesiredRuleTargets(
-	rows []db.ListResolvedRulesForMachineRow,
+	rows []db.ListResolvedRulesForMachinesRow,
 ) ([]byte, domain.ExecutionRuleCounts, error) {
 	targets := make([]model.AppliedRuleTarget, 0, len(rows))
 	rules := make([]domain.MachineRuleTarget, 0, len(rows))
@@ -99,7 +110,7 @@ func buildDesiredRuleTargets(
 	return encoded, domain.CountExecutionRules(rules), nil
 }
 
-func mapResolvedRuleTarget(row db.ListResolvedRulesForMachineRow) (domain.MachineRuleTarget, error) {
+func mapResolvedRuleTarget(row db.ListResolvedRulesForMachinesRow) (domain.MachineRuleTarget, error) {
 	ruleType, err := domain.ParseRuleType(string(row.RuleType))
 	if err != nil {
 		return domain.MachineRuleTarget{}, fmt.Errorf("parse rule type: %w", err)
@@ -123,29 +123,53 @@ func (s *Store) GetMachine(ctx context.Context, id uuid.UUID) (domain.Machine, e
 	return mapMachine(row)
 }
 
+// ListMachineIDsByEffectiveGroupID returns machines in a group directly or through their primary user.
+func (s *Store) ListMachineIDsByEffectiveGroupID(ctx context.Context, groupID uuid.UUID) ([]uuid.UUID, error) {
+	return s.Queries().ListMachineIDsByEffectiveGroupID(ctx, groupID)
+}
+
 func (s *Store) DeleteMachine(ctx context.Context, id uuid.UUID) error {
 	return s.Queries().DeleteMachine(ctx, id)
 }
 
-func (s *Store) UpsertMachine(ctx context.Context, machine model.MachineUpsert) error {
-	_, err := s.Queries().UpsertMachine(ctx, db.UpsertMachineParams{
-		MachineID:         machine.MachineID,
-		SerialNumber:      machine.SerialNumber,
-		Hostname:          machine.Hostname,
-		ModelIdentifier:   machine.ModelIdentifier,
-		OsVersion:         machine.OSVersion,
-		OsBuild:           machine.OSBuild,
-		SantaVersion:      machine.SantaVersion,
-		PrimaryUser:       machine.PrimaryUser,
-		PrimaryUserGroups: machine.PrimaryUserGroups,
-		ClientMode:        db.SantaClientMode(machine.ClientMode),
-		LastSeenAt:        machine.LastSeenAt,
+// SetMachinePrimaryUserOverride pins a machine's primary user. A nil userID pins "no user".
+func (s *Store) SetMachinePrimaryUserOverride(ctx context.Context, machineID uuid.UUID, userID *uuid.UUID) error {
+	return s.Queries().UpsertMachinePrimaryUserOverride(ctx, db.UpsertMachinePrimaryUserOverrideParams{
+		MachineID: machineID,
+		UserID:    userID,
 	})
-	if err != nil {
-		return fmt.Errorf("upsert machine: %w", err)
-	}
+}
 
-	return nil
+// ClearMachinePrimaryUserOverride removes a machine's pinned primary user, if any.
+func (s *Store) ClearMachinePrimaryUserOverride(ctx context.Context, machineID uuid.UUID) error {
+	return s.Queries().DeleteMachinePrimaryUserOverride(ctx, machineID)
+}
+
+// UpsertMachine stores the reported machine details and resolves its primary user.
+func (s *Store) UpsertMachine(ctx context.Context, machine model.MachineUpsert) error {
+	return s.RunInTx(ctx, func(q *db.Queries) error {
+		_, err := q.UpsertMachine(ctx, db.UpsertMachineParams{
+			MachineID:         machine.MachineID,
+			SerialNumber:      machine.SerialNumber,
+			Hostname:          machine.Hostname,
+			ModelIdentifier:   machine.ModelIdentifier,
+			OsVersion:         machine.OSVersion,
+			OsBuild:           machine.OSBuild,
+			SantaVersion:      machine.SantaVersion,
+			PrimaryUser:       machine.PrimaryUser,
+			PrimaryUserGroups: machine.PrimaryUserGroups,
+			ClientMode:        db.SantaClientMode(machine.ClientMode),
+			LastSeenAt:        machine.LastSeenAt,
+		})
+		if err != nil {
+			return fmt.Errorf("upsert machine: %w", err)
+		}
+
+		_, err = resolveMachinePrimaryUsers(ctx, q, map[uuid.UUID][]string{
+			machine.MachineID: machine.PrimaryUserLookups,
+		})
+		return err
+	})
 }
 
 func (s *Store) GetMachineSyncState(
@@ -193,6 +217,8 @@ func (s *Store) ReplacePendingSnapshot(
 		PendingPayload:              pendingPayload,
 		PendingPayloadRuleCount:     snapshot.PendingPayloadRuleCount,
 		PendingFullSync:             snapshot.PendingFullSync,
+		PendingCleanAll:             snapshot.PendingCleanAll,
+		PendingCleanSyncRequestedAt: snapshot.PendingCleanSyncRequestedAt,
 		PendingPreflightAt:          &snapshot.PendingPreflightAt,
 		DesiredBinaryRuleCount:      snapshot.DesiredBinaryRuleCount,
 		DesiredCertificateRuleCount: snapshot.DesiredCertificateRuleCount,
@@ -260,6 +286,62 @@ func (s *Store) PromotePendingSnapshot(
 	return nil
 }
 
+// RequestCleanSync flags machines so their next preflight returns a clean or clean-all sync. A
+// nil groupID and machineID flags every machine; a groupID flags the group's effective members.
+func (s *Store) RequestCleanSync(
+	ctx context.Context,
+	syncType domain.CleanSyncType,
+	machineID *uuid.UUID,
+	groupID *uuid.UUID,
+) (domain.CleanSyncResult, error) {
+	result := domain.CleanSyncResult{
+		SyncType:    syncType,
+		RequestedAt: time.Now().UTC(),
+	}
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		var (
+			machineIDs []uuid.UUID
+			err        error
+		)
+
+		switch {
+		case machineID != nil:
+			machineIDs = []uuid.UUID{*machineID}
+		case groupID != nil:
+			if _, err = q.GetGroup(ctx, *groupID); err != nil {
+				return err
+			}
+			machineIDs, err = q.ListMachineIDsByEffectiveGroupID(ctx, *groupID)
+		default:
+			machineIDs, err = q.ListMachineIDs(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("list clean sync machines: %w", err)
+		}
+
+		flagged, err := q.RequestMachineCleanSync(ctx, db.RequestMachineCleanSyncParams{
+			SyncType:    db.CleanSyncType(syncType),
+			RequestedAt: result.RequestedAt,
+			MachineIds:  machineIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("request machine clean sync: %w", err)
+		}
+		if machineID != nil && flagged == 0 {
+			return pgx.ErrNoRows
+		}
+
+		result.MachineCount = int32(flagged) //nolint:gosec // machine counts stay far below MaxInt32
+		return nil
+	})
+	if err != nil {
+		return domain.CleanSyncResult{}, err
+	}
+
+	return result, nil
+}
+
 func scanMachineSummaryRow(rows pgx.Rows) (domain.MachineSummary, int32, error) {
 	var (
 		item               domain.MachineSummary
@@ -307,25 +389,28 @@ func mapMachine(row db.GetMachineRow) (domain.Machine, error) {
 	}
 
 	return domain.Machine{
-		ID:                   row.ID,
-		SerialNumber:         row.SerialNumber,
-		Hostname:             row.Hostname,
-		ModelIdentifier:      row.ModelIdentifier,
-		OSVersion:            row.OsVersion,
-		OSBuild:              row.OsBuild,
-		SantaVersion:         row.SantaVersion,
-		PrimaryUser:          row.PrimaryUser,
-		PrimaryUserID:        row.PrimaryUserID,
-		RuleSyncStatus:       ruleSyncStatus,
-		ClientMode:           clientMode,
-		BinaryRuleCount:      row.BinaryRuleCount,
-		CertificateRuleCount: row.CertificateRuleCount,
-		TeamIDRuleCount:      row.TeamIDRuleCount,
-		SigningIDRuleCount:   row.SigningIDRuleCount,
-		CDHashRuleCount:      row.CDHashRuleCount,
-		LastSeenAt:           row.LastSeenAt,
-		CreatedAt:            row.CreatedAt,
-		UpdatedAt:            row.UpdatedAt,
+		ID:                    row.ID,
+		SerialNumber:          row.SerialNumber,
+		Hostname:              row.Hostname,
+		ModelIdentifier:       row.ModelIdentifier,
+		OSVersion:             row.OsVersion,
+		OSBuild:               row.OsBuild,
+		SantaVersion:          row.SantaVersion,
+		PrimaryUser:           row.PrimaryUser,
+		PrimaryUserID:         row.PrimaryUserID,
+		EffectivePrimaryUser:  row.EffectivePrimaryUser,
+		PrimaryUserOverridden: row.PrimaryUserOverridden,
+		RuleSyncStatus:        ruleSyncStatus,
+		ClientMode:            clientMode,
+		Tags:                  row.Tags,
+		BinaryRuleCount:       row.BinaryRuleCount,
+		CertificateRuleCount:  row.CertificateRuleCount,
+		TeamIDRuleCount:       row.TeamIDRuleCount,
+		SigningIDRuleCount:    row.SigningIDRuleCount,
+		CDHashRuleCount:       row.CDHashRuleCount,
+		LastSeenAt:            row.LastSeenAt,
+		CreatedAt:             row.CreatedAt,
+		UpdatedAt:             row.UpdatedAt,
 	}, nil
 }
 
@@ -350,6 +435,19 @@ func mapMachineSyncState(row db.GetMachineSyncStateRow) (model.MachineSyncState,
 		return model.MachineSyncState{}, fmt.Errorf("unmarshal pending payload: %w", err)
 	}
 
+	ruleSyncStatus, err := domain.ParseMachineRuleSyncStatus(row.RuleSyncStatus)
+	if err != nil {
+		return model.MachineSyncState{}, fmt.Errorf("parse machine rule sync status: %w", err)
+	}
+
+	var requestedCleanSync domain.CleanSyncType
+	if row.RequestedCleanSync.Valid {
+		requestedCleanSync, err = domain.ParseCleanSyncType(string(row.RequestedCleanSync.CleanSyncType))
+		if err != nil {
+			return model.MachineSyncState{}, fmt.Errorf("parse requested clean sync: %w", err)
+		}
+	}
+
 	return model.MachineSyncState{
 		MachineID:                   row.ID,
 		RulesHash:                   row.RulesHash,
@@ -360,6 +458,11 @@ func mapMachineSyncState(row db.GetMachineSyncStateRow) (model.MachineSyncState,
 		PendingPayloadRuleCount:     row.PendingPayloadRuleCount,
 		PendingFullSync:             row.PendingFullSync,
 		PendingPreflightAt:          row.PendingPreflightAt,
+		RuleSyncStatus:              ruleSyncStatus,
+		PendingCleanAll:             row.PendingCleanAll,
+		PendingCleanSyncRequestedAt: row.PendingCleanSyncRequestedAt,
+		RequestedCleanSync:          requestedCleanSync,
+		RequestedCleanSyncAt:        row.RequestedCleanSyncAt,
 		DesiredBinaryRuleCount:      row.DesiredBinaryRuleCount,
 		DesiredCertificateRuleCount: row.DesiredCertificateRuleCount,
 		DesiredTeamIDRuleCount:      row.DesiredTeamIDRuleCount,
@@ -427,8 +530,7 @@ FROM machines AS m
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
 LEFT JOIN users AS u
-  ON u.upn = m.primary_user
-  AND m.primary_user <> ''
+  ON u.id = m.primary_user_id
 WHERE %s
 ORDER BY %s
 LIMIT NULLIF($%d::INT, 0)
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+const (
+	membershipImportReasonNotFound   = "not_found"
+	membershipImportReasonAmbiguous  = "ambiguous"
+	membershipImportReasonMissingKey = "missing_key"
+)
+
+// membershipImportMatches maps each import key to the members every normalised value resolved to.
+type membershipImportMatches map[domain.MembershipImportKey]map[string][]uuid.UUID
+
+// ImportMemberships resolves CSV rows to machines and users and applies them to a group's explicit
+// memberships in one transaction. A replace is refused before anything changes when it matched no
+// member, or when rows went unmatched and allowUnmatched is false. It returns the machines whose
+// effective groups changed so the caller can recompute their desired targets once.
+func (s *Store) ImportMemberships(
+	ctx context.Context,
+	groupID uuid.UUID,
+	mode domain.MembershipImportMode,
+	allowUnmatched bool,
+	rows []domain.MembershipImportRow,
+) (domain.MembershipImportResult, []uuid.UUID, error) {
+	result := domain.MembershipImportResult{Mode: mode}
+
+	var changedMachineIDs []uuid.UUID
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		matches, err := resolveMembershipImportValues(ctx, q, rows)
+		if err != nil {
+			return err
+		}
+
+		machineIDs, userIDs, unmatched := matchMembershipImportRows(rows, matches)
+		result.Matched = len(machineIDs) + len(userIDs)
+		result.Unmatched = unmatched
+
+		if mode == domain.MembershipImportModeReplace {
+			if err = checkReplaceImport(result, allowUnmatched); err != nil {
+				return err
+			}
+		}
+
+		var addedMachines, addedUsers, removedMachines, removedUsers []uuid.UUID
+
+		if mode == domain.MembershipImportModeAdd || mode == domain.MembershipImportModeReplace {
+			if addedMachines, err = q.InsertExplicitMachineMemberships(ctx, db.InsertExplicitMachineMembershipsParams{
+				GroupID:    groupID,
+				MachineIds: machineIDs,
+			}); err != nil {
+				return fmt.Errorf("insert machine memberships: %w", err)
+			}
+			if addedUsers, err = q.InsertExplicitUserMemberships(ctx, db.InsertExplicitUserMembershipsParams{
+				GroupID: groupID,
+				UserIds: userIDs,
+			}); err != nil {
+				return fmt.Errorf("insert user memberships: %w", err)
+			}
+		}
+
+		if mode == domain.MembershipImportModeRemove || mode == domain.MembershipImportModeReplace {
+			exclude := mode == domain.MembershipImportModeReplace
+			if removedMachines, err = q.DeleteExplicitMachineMemberships(ctx, db.DeleteExplicitMachineMembershipsParams{
+				GroupID:    groupID,
+				MachineIds: machineIDs,
+				Exclude:    exclude,
+			}); err != nil {
+				return fmt.Errorf("delete machine memberships: %w", err)
+			}
+			if removedUsers, err = q.DeleteExplicitUserMemberships(ctx, db.DeleteExplicitUserMembershipsParams{
+				GroupID: groupID,
+				UserIds: userIDs,
+				Exclude: exclude,
+			}); err != nil {
+				return fmt.Errorf("delete user memberships: %w", err)
+			}
+		}
+
+		result.Added = len(addedMachines) + len(addedUsers)
+		result.Removed = len(removedMachines) + len(removedUsers)
+
+		changedMachineIDs = append(changedMachineIDs, addedMachines...)
+		changedMachineIDs = append(changedMachineIDs, removedMachines...)
+
+		changedUsers := slices.Concat(addedUsers, removedUsers)
+		if len(changedUsers) > 0 {
+			userMachines, listErr := q.ListMachineIDsByPrimaryUserIDs(ctx, changedUsers)
+			if listErr != nil {
+				return fmt.Errorf("list machine ids by primary user ids: %w", listErr)
+			}
+			changedMachineIDs = append(changedMachineIDs, userMachines...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.MembershipImportResult{}, nil, err
+	}
+
+	return result, compactIDs(changedMachineIDs), nil
+}
+
+// checkReplaceImport refuses a replace import that would remove members the CSV may have meant to
+// keep: one that matched nobody, which would empty the group, or one with unmatched rows that the
+// caller has not confirmed.
+func checkReplaceImport(result domain.MembershipImportResult, allowUnmatched bool) error {
+	validationErr := &domain.ValidationError{
+		Code:   "validation_error",
+		Detail: "Membership import is invalid.",
+	}
+
+	switch {
+	case result.Matched == 0:
+		validationErr.Add("csv", "must match at least one member to replace the group's memberships", "invalid")
+	case len(result.Unmatched) > 0 && !allowUnmatched:
+		validationErr.Add(
+			"allow_unmatched",
+			fmt.Sprintf(
+				"must be set to replace memberships while %d rows match no single member",
+				len(result.Unmatched),
+			),
+			"required",
+		)
+	default:
+		return nil
+	}
+
+	return validationErr
+}
+
+// UpdateMachineDesiredTargetsByMachineIDs recomputes desired targets for a set of machines.
+func (s *Store) UpdateMachineDesiredTargetsByMachineIDs(ctx context.Context, machineIDs []uuid.UUID) error {
+	return s.updateMachineDesiredTargets(ctx, machineIDs)
+}
+
+func resolveMembershipImportValues(
+	ctx context.Context,
+	q *db.Queries,
+	rows []domain.MembershipImportRow,
+) (membershipImportMatches, error) {
+	values := map[domain.MembershipImportKey][]string{}
+	var machineIDs []uuid.UUID
+
+	for _, row := range rows {
+		if row.Key == "" {
+			continue
+		}
+
+		value := normalizeMembershipImportValue(row.Key, row.Value)
+		if row.Key == domain.MembershipImportKeyMachineID {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				continue
+			}
+			machineIDs = append(machineIDs, id)
+			continue
+		}
+		values[row.Key] = append(values[row.Key], value)
+	}
+
+	matches := membershipImportMatches{}
+	add := func(key domain.MembershipImportKey, value string, id uuid.UUID) {
+		if matches[key] == nil {
+			matches[key] = map[string][]uuid.UUID{}
+		}
+		matches[key][value] = append(matches[key][value], id)
+	}
+
+	if len(machineIDs) > 0 {
+		found, err := q.ListMachinesByIDs(ctx, machineIDs)
+		if err != nil {
+			return nil, fmt.Errorf("list machines by ids: %w", err)
+		}
+		for _, row := range found {
+			add(domain.MembershipImportKeyMachineID, row.MatchValue, row.ID)
+		}
+	}
+
+	if serials := values[domain.MembershipImportKeySerialNumber]; len(serials) > 0 {
+		found, err := q.ListMachinesBySerialNumbers(ctx, serials)
+		if err != nil {
+			return nil, fmt.Errorf("list machines by serial numbers: %w", err)
+		}
+		for _, row := range found {
+			add(domain.MembershipImportKeySerialNumber, row.MatchValue, row.ID)
+		}
+	}
+
+	if hostnames := values[domain.MembershipImportKeyHostname]; len(hostnames) > 0 {
+		found, err := q.ListMachinesByHostnames(ctx, hostnames)
+		if err != nil {
+			return nil, fmt.Errorf("list machines by hostnames: %w", err)
+		}
+		for _, row := range found {
+			add(domain.MembershipImportKeyHostname, row.MatchValue, row.ID)
+		}
+	}
+
+	if upns := values[domain.MembershipImportKeyUPN]; len(upns) > 0 {
+		found, err := q.ListUsersByUPNs(ctx, upns)
+		if err != nil {
+			return nil, fmt.Errorf("list users by upns: %w", err)
+		}
+		for _, row := range found {
+			add(domain.MembershipImportKeyUPN, row.MatchValue, row.ID)
+		}
+	}
+
+	return matches, nil
+}
+
+// matchMembershipImportRows splits resolved rows into distinct machine and user IDs, and reports
+// rows that resolved to no member or to more than one.
+func matchMembershipImportRows(
+	rows []domain.MembershipImportRow,
+	matches membershipImportMatches,
+) ([]uuid.UUID, []uuid.UUID, []domain.MembershipImportUnmatchedRow) {
+	machineIDs := []uuid.UUID{}
+	userIDs := []uuid.UUID{}
+	unmatched := []domain.MembershipImportUnmatchedRow{}
+
+	for _, row := range rows {
+		if row.Key == "" {
+			unmatched = append(unmatched, domain.MembershipImportUnmatchedRow{
+				Row:    row.Row,
+				Value:  row.Value,
+				Reason: membershipImportReasonMissingKey,
+			})
+			continue
+		}
+
+		ids := matches[row.Key][normalizeMembershipImportValue(row.Key, row.Value)]
+		if len(ids) != 1 {
+			reason := membershipImportReasonNotFound
+			if len(ids) > 1 {
+				reason = membershipImportReasonAmbiguous
+			}
+			unmatched = append(unmatched, domain.MembershipImportUnmatchedRow{
+				Row:    row.Row,
+				Key:    row.Key,
+				Value:  row.Value,
+				Reason: reason,
+			})
+			continue
+		}
+
+		if row.Key == domain.MembershipImportKeyUPN {
+			userIDs = append(userIDs, ids[0])
+		} else {
+			machineIDs = append(machineIDs, ids[0])
+		}
+	}
+
+	return compactIDs(machineIDs), compactIDs(userIDs), unmatched
+}
+
+func normalizeMembershipImportValue(key domain.MembershipImportKey, value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if key == domain.MembershipImportKeyMachineID {
+		if id, err := uuid.Parse(value); err == nil {
+			return id.String()
+		}
+	}
+
+	return value
+}
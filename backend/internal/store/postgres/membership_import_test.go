@@ -0,0 +1,104 @@
+package postgres //nolint:testpackage // exercises unexported membership import matching.
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func TestMatchMembershipImportRowsReportsUnresolvedRows(t *testing.T) {
+	machineID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	otherMachineID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	rows := []domain.MembershipImportRow{
+		{Row: 2, Key: domain.MembershipImportKeySerialNumber, Value: " C02ABC "},
+		{Row: 3, Key: domain.MembershipImportKeyMachineID, Value: machineID.String()},
+		{Row: 4, Key: domain.MembershipImportKeyHostname, Value: "lab-mac"},
+		{Row: 5, Key: domain.MembershipImportKeyUPN, Value: "Student@Example.org"},
+		{Row: 6, Key: domain.MembershipImportKeyHostname, Value: "missing"},
+		{Row: 7, Value: ",,"},
+	}
+	matches := membershipImportMatches{
+		domain.MembershipImportKeySerialNumber: {"c02abc": {machineID}},
+		domain.MembershipImportKeyMachineID:    {machineID.String(): {machineID}},
+		domain.MembershipImportKeyHostname:     {"lab-mac": {machineID, otherMachineID}},
+		domain.MembershipImportKeyUPN:          {"student@example.org": {userID}},
+	}
+
+	machineIDs, userIDs, unmatched := matchMembershipImportRows(rows, matches)
+
+	if !slices.Equal(machineIDs, []uuid.UUID{machineID}) {
+		t.Fatalf("machineIDs = %v, want [%v]", machineIDs, machineID)
+	}
+	if !slices.Equal(userIDs, []uuid.UUID{userID}) {
+		t.Fatalf("userIDs = %v, want [%v]", userIDs, userID)
+	}
+
+	want := []domain.MembershipImportUnmatchedRow{
+		{Row: 4, Key: domain.MembershipImportKeyHostname, Value: "lab-mac", Reason: membershipImportReasonAmbiguous},
+		{Row: 6, Key: domain.MembershipImportKeyHostname, Value: "missing", Reason: membershipImportReasonNotFound},
+		{Row: 7, Value: ",,", Reason: membershipImportReasonMissingKey},
+	}
+	if !slices.Equal(unmatched, want) {
+		t.Fatalf("unmatched = %+v, want %+v", unmatched, want)
+	}
+}
+
+func TestCheckReplaceImport(t *testing.T) {
+	unmatched := []domain.MembershipImportUnmatchedRow{{Row: 3, Value: "missing", Reason: "not_found"}}
+
+	tests := []struct {
+		name           string
+		result         domain.MembershipImportResult
+		allowUnmatched bool
+		wantField      string
+	}{
+		{name: "every row matched", result: domain.MembershipImportResult{Matched: 2}},
+		{
+			name:      "nothing matched",
+			result:    domain.MembershipImportResult{Matched: 0, Unmatched: unmatched},
+			wantField: "csv",
+		},
+		{
+			name:           "nothing matched even when unmatched rows are confirmed",
+			result:         domain.MembershipImportResult{Matched: 0, Unmatched: unmatched},
+			allowUnmatched: true,
+			wantField:      "csv",
+		},
+		{
+			name:      "unmatched rows without confirmation",
+			result:    domain.MembershipImportResult{Matched: 2, Unmatched: unmatched},
+			wantField: "allow_unmatched",
+		},
+		{
+			name:           "unmatched rows confirmed",
+			result:         domain.MembershipImportResult{Matched: 2, Unmatched: unmatched},
+			allowUnmatched: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReplaceImport(tt.result, tt.allowUnmatched)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("checkReplaceImport() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("checkReplaceImport() error = %v, want validation error", err)
+			}
+			if len(validationErr.FieldErrors) != 1 || validationErr.FieldErrors[0].Field != tt.wantField {
+				t.Fatalf("FieldErrors = %+v, want one error on %s", validationErr.FieldErrors, tt.wantField)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,6 +18,7 @@ var (
 		"id":               "id",
 		"group_name":       "group_name",
 		"member_name":      "member_name",
+		"expires_at":       "expires_at",
 		sortFieldCreatedAt: sortFieldCreatedAt,
 		sortFieldUpdatedAt: sortFieldUpdatedAt,
 	}
@@ -66,6 +69,8 @@ func (s *Store) CreateMembership(
 	memberKind domain.MemberKind,
 	memberID uuid.UUID,
 	origin domain.MembershipOrigin,
+	startsAt *time.Time,
+	expiresAt *time.Time,
 ) (domain.Membership, error) {
 	id, err := uuid.NewV7()
 	if err != nil {
@@ -75,10 +80,12 @@ func (s *Store) CreateMembership(
 	switch memberKind {
 	case domain.MemberKindUser:
 		_, err = s.Queries().CreateUserMembership(ctx, db.CreateUserMembershipParams{
-			ID:      id,
-			GroupID: groupID,
-			UserID:  memberID,
-			Origin:  db.MembershipOrigin(origin),
+			ID:        id,
+			GroupID:   groupID,
+			UserID:    memberID,
+			Origin:    db.MembershipOrigin(origin),
+			StartsAt:  startsAt,
+			ExpiresAt: expiresAt,
 		})
 	case domain.MemberKindMachine:
 		_, err = s.Queries().CreateMachineMembership(ctx, db.CreateMachineMembershipParams{
@@ -86,6 +93,12 @@ func (s *Store) CreateMembership(
 			GroupID:   groupID,
 			MachineID: memberID,
 			Origin:    db.MembershipOrigin(origin),
+			StartsAt:  startsAt,
+			ExpiresAt: expiresAt,
+		})
+	case domain.MemberKindGroup:
+		err = s.RunInTx(ctx, func(q *db.Queries) error {
+			return createGroupMembership(ctx, q, id, groupID, memberID, origin, startsAt, expiresAt)
 		})
 	default:
 		return domain.Membership{}, fmt.Errorf("unsupported member kind %q", memberKind)
@@ -115,6 +128,14 @@ func (s *Store) DeleteMembership(ctx context.Context, id uuid.UUID, kind domain.
 		if n == 0 {
 			return pgx.ErrNoRows
 		}
+	case domain.MemberKindGroup:
+		n, err := s.Queries().DeleteGroupMembership(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return pgx.ErrNoRows
+		}
 	default:
 		return fmt.Errorf("unsupported member kind %q", kind)
 	}
@@ -122,14 +143,140 @@ func (s *Store) DeleteMembership(ctx context.Context, id uuid.UUID, kind domain.
 	return nil
 }
 
+// SweepMemberships activates pending memberships whose start has passed and deletes memberships
+// that have expired, returning the members whose effective groups changed.
+func (s *Store) SweepMemberships(ctx context.Context, now time.Time) ([]domain.MembershipMember, error) {
+	var members []domain.MembershipMember
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		activated, err := q.ActivateDueMemberships(ctx, now)
+		if err != nil {
+			return fmt.Errorf("activate due memberships: %w", err)
+		}
+
+		expired, err := q.DeleteExpiredMemberships(ctx, now)
+		if err != nil {
+			return fmt.Errorf("delete expired memberships: %w", err)
+		}
+
+		seen := make(map[domain.MembershipMember]struct{}, len(activated)+len(expired))
+		add := func(kind db.MembershipMemberKind, id uuid.UUID) {
+			member := domain.MembershipMember{Kind: domain.MemberKind(kind), ID: id}
+			if _, ok := seen[member]; ok {
+				return
+			}
+			seen[member] = struct{}{}
+			members = append(members, member)
+		}
+
+		for _, row := range activated {
+			add(row.MemberKind, row.MemberID)
+		}
+		for _, row := range expired {
+			add(row.MemberKind, row.MemberID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// ListMachineGroupPaths returns every membership through which a machine reaches a group,
+// directly or through nested groups.
+func (s *Store) ListMachineGroupPaths(ctx context.Context, machineID uuid.UUID) ([]domain.MachineGroupPath, error) {
+	rows, err := s.Queries().ListMachineGroupPaths(ctx, machineID)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]domain.MachineGroupPath, 0, len(rows))
+	for _, row := range rows {
+		path, decodeErr := decodeMembershipPath(row.Path)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+
+		paths = append(paths, domain.MachineGroupPath{
+			GroupID:      row.GroupID,
+			GroupName:    row.GroupName,
+			Via:          domain.GroupPathVia(row.Via),
+			MembershipID: row.MembershipID,
+			Origin:       domain.MembershipOrigin(row.Origin),
+			Path:         path,
+		})
+	}
+
+	return paths, nil
+}
+
+// createGroupMembership nests memberGroupID inside groupID unless groupID is already reachable
+// from memberGroupID. The table lock serialises nesting changes so two concurrent inserts cannot
+// close a cycle between them.
+func createGroupMembership(
+	ctx context.Context,
+	q *db.Queries,
+	id uuid.UUID,
+	groupID uuid.UUID,
+	memberGroupID uuid.UUID,
+	origin domain.MembershipOrigin,
+	startsAt *time.Time,
+	expiresAt *time.Time,
+) error {
+	if err := q.LockGroupMemberships(ctx); err != nil {
+		return fmt.Errorf("lock group memberships: %w", err)
+	}
+
+	cycle, err := q.GroupHasAncestor(ctx, db.GroupHasAncestorParams{
+		GroupID:    groupID,
+		AncestorID: memberGroupID,
+	})
+	if err != nil {
+		return fmt.Errorf("check group membership cycle: %w", err)
+	}
+	if cycle {
+		return domain.ErrMembershipCycle
+	}
+
+	_, err = q.CreateGroupMembership(ctx, db.CreateGroupMembershipParams{
+		ID:            id,
+		GroupID:       groupID,
+		MemberGroupID: memberGroupID,
+		Origin:        db.MembershipOrigin(origin),
+		StartsAt:      startsAt,
+		ExpiresAt:     expiresAt,
+	})
+
+	return err
+}
+
+func decodeMembershipPath(data []byte) ([]domain.MembershipGroup, error) {
+	path := []domain.MembershipGroup{}
+	if len(data) == 0 {
+		return path, nil
+	}
+
+	if err := json.Unmarshal(data, &path); err != nil {
+		return nil, fmt.Errorf("unmarshal membership path: %w", err)
+	}
+
+	return path, nil
+}
+
 func membershipListArgs(opts domain.MembershipListOptions) []any {
 	return []any{
 		nullableUUID(opts.GroupID),
 		nullableUUID(opts.UserID),
 		nullableUUID(opts.MachineID),
+		nullableUUID(opts.MemberGroupID),
+		opts.IncludeInherited,
 		searchPattern(opts.Search),
 		opts.Limit,
 		opts.Offset,
+		opts.ExpiresBefore,
 	}
 }
 
@@ -138,6 +285,8 @@ func scanMembershipRow(rows pgx.Rows) (domain.Membership, int32, error) {
 		item            domain.Membership
 		groupSourceText string
 		memberKindText  string
+		originText      string
+		path            []byte
 		total           int32
 	)
 
@@ -149,6 +298,11 @@ func scanMembershipRow(rows pgx.Rows) (domain.Membership, int32, error) {
 		&memberKindText,
 		&item.Member.ID,
 		&item.Member.Name,
+		&originText,
+		&path,
+		&item.StartsAt,
+		&item.ExpiresAt,
+		&item.Pending,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 		&total,
@@ -166,8 +320,18 @@ func scanMembershipRow(rows pgx.Rows) (domain.Membership, int32, error) {
 		return domain.Membership{}, 0, fmt.Errorf("parse member kind: %w", err)
 	}
 
+	origin, err := domain.ParseMembershipOrigin(originText)
+	if err != nil {
+		return domain.Membership{}, 0, fmt.Errorf("parse membership origin: %w", err)
+	}
+
+	if item.Path, err = decodeMembershipPath(path); err != nil {
+		return domain.Membership{}, 0, err
+	}
+
 	item.Group.Source = groupSource
 	item.Member.Kind = memberKind
+	item.Origin = origin
 
 	return item, total, nil
 }
@@ -183,6 +347,11 @@ func mapMembership(row db.GetPersistedMembershipViewRow) (domain.Membership, err
 		return domain.Membership{}, fmt.Errorf("parse member kind: %w", err)
 	}
 
+	origin, err := domain.ParseMembershipOrigin(string(row.Origin))
+	if err != nil {
+		return domain.Membership{}, fmt.Errorf("parse membership origin: %w", err)
+	}
+
 	return domain.Membership{
 		ID: row.ID,
 		Group: domain.MembershipGroup{
@@ -195,73 +364,144 @@ func mapMembership(row db.GetPersistedMembershipViewRow) (domain.Membership, err
 			ID:   row.MemberID,
 			Name: row.MemberName,
 		},
+		Origin:    origin,
+		Path:      []domain.MembershipGroup{},
+		StartsAt:  row.StartsAt,
+		ExpiresAt: row.ExpiresAt,
+		Pending:   row.Pending,
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
 	}, nil
 }
 
+// membershipListQuery lists direct memberships and, when $5 is set, the memberships inherited
+// through nested groups. Group filters apply to the group reached; member filters to the member.
+// $9 keeps only memberships expiring at or before it.
 const membershipListQuery = `
-WITH memberships AS (
+WITH direct_memberships AS (
   SELECT
     gum.id,
-    g.id AS group_id,
-    g.name AS group_name,
-    g.source::text AS group_source,
+    gum.group_id,
     'user'::text AS member_kind,
     gum.user_id AS member_id,
     NULLIF(u.display_name, '') AS member_name,
+    gum.origin::text AS origin,
+    gum.starts_at,
+    gum.expires_at,
+    gum.pending,
     gum.created_at,
     gum.updated_at
   FROM group_user_memberships AS gum
-  JOIN groups AS g
-    ON g.id = gum.group_id
   LEFT JOIN users AS u
     ON u.id = gum.user_id
-  WHERE ($1::uuid IS NULL OR gum.group_id = $1::uuid)
-    AND ($2::uuid IS NULL OR gum.user_id = $2::uuid)
+  WHERE ($2::uuid IS NULL OR gum.user_id = $2::uuid)
     AND $3::uuid IS NULL
+    AND $4::uuid IS NULL
 
   UNION ALL
 
   SELECT
     gmm.id,
-    g.id AS group_id,
-    g.name AS group_name,
-    g.source::text AS group_source,
+    gmm.group_id,
     'machine'::text AS member_kind,
     gmm.machine_id AS member_id,
     NULLIF(m.hostname, '') AS member_name,
+    gmm.origin::text AS origin,
+    gmm.starts_at,
+    gmm.expires_at,
+    gmm.pending,
     gmm.created_at,
     gmm.updated_at
   FROM group_machine_memberships AS gmm
-  JOIN groups AS g
-    ON g.id = gmm.group_id
   LEFT JOIN machines AS m
     ON m.id = gmm.machine_id
-  WHERE ($1::uuid IS NULL OR gmm.group_id = $1::uuid)
-    AND $2::uuid IS NULL
+  WHERE $2::uuid IS NULL
     AND ($3::uuid IS NULL OR gmm.machine_id = $3::uuid)
+    AND $4::uuid IS NULL
+
+  UNION ALL
+
+  SELECT
+    ggm.id,
+    ggm.group_id,
+    'group'::text AS member_kind,
+    ggm.member_group_id AS member_id,
+    NULLIF(mg.name, '') AS member_name,
+    ggm.origin::text AS origin,
+    ggm.starts_at,
+    ggm.expires_at,
+    ggm.pending,
+    ggm.created_at,
+    ggm.updated_at
+  FROM group_group_memberships AS ggm
+  LEFT JOIN groups AS mg
+    ON mg.id = ggm.member_group_id
+  WHERE $2::uuid IS NULL
+    AND $3::uuid IS NULL
+    AND ($4::uuid IS NULL OR ggm.member_group_id = $4::uuid)
+),
+memberships AS (
+  SELECT
+    dm.id,
+    g.id AS group_id,
+    g.name AS group_name,
+    g.source::text AS group_source,
+    dm.member_kind,
+    dm.member_id,
+    dm.member_name,
+    dm.origin,
+    gap.path,
+    dm.starts_at,
+    dm.expires_at,
+    dm.pending,
+    dm.created_at,
+    dm.updated_at
+  FROM direct_memberships AS dm
+  JOIN group_ancestor_paths AS gap
+    ON gap.group_id = dm.group_id
+  JOIN groups AS g
+    ON g.id = gap.ancestor_id
+  WHERE ($1::uuid IS NULL OR gap.ancestor_id = $1::uuid)
+    AND ($5::boolean OR CARDINALITY(gap.path) = 0)
+    AND (CARDINALITY(gap.path) = 0 OR NOT dm.pending)
+    AND ($9::timestamptz IS NULL OR dm.expires_at <= $9::timestamptz)
 )
 SELECT
-  id,
-  group_id,
-  group_name,
-  group_source,
-  member_kind,
-  member_id,
-  member_name,
-  created_at,
-  updated_at,
+  ms.id,
+  ms.group_id,
+  ms.group_name,
+  ms.group_source,
+  ms.member_kind,
+  ms.member_id,
+  ms.member_name,
+  ms.origin,
+  COALESCE(
+    (
+      SELECT jsonb_agg(
+        jsonb_build_object('id', pg.id, 'name', pg.name, 'source', pg.source)
+        ORDER BY p.ordinality
+      )
+      FROM UNNEST(ms.path) WITH ORDINALITY AS p (group_id, ordinality)
+      JOIN groups AS pg
+        ON pg.id = p.group_id
+    ),
+    '[]'::JSONB
+  ) AS path,
+  ms.starts_at,
+  ms.expires_at,
+  ms.pending,
+  ms.created_at,
+  ms.updated_at,
   COUNT(*) OVER()::INT4 AS total
-FROM memberships
+FROM memberships AS ms
 WHERE (
-  $4 = ''
-  OR group_name ILIKE $4
-  OR COALESCE(member_name, '') ILIKE $4
+  $6 = ''
+  OR ms.group_name ILIKE $6
+  OR COALESCE(ms.member_name, '') ILIKE $6
 )
 ORDER BY %s
-LIMIT NULLIF($5::INT, 0)
-OFFSET $6
+LIMIT NULLIF($7::INT, 0)
+OFFSET $8
 `
 
 func nullableUUID(id *uuid.UUID) any {
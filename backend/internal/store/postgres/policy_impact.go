@@ -0,0 +1,391 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+// PreviewRuleWrite reports how creating (nil ruleID) or updating a rule would change every
+// machine's resolved rules. The write runs in a transaction that is always rolled back.
+func (s *Store) PreviewRuleWrite(
+	ctx context.Context,
+	ruleID *uuid.UUID,
+	input domain.RuleWriteInput,
+) (domain.PolicyImpactPreview, error) {
+	scope := func(q *db.Queries) ([]uuid.UUID, error) {
+		return q.ListMachineIDs(ctx)
+	}
+
+	apply := func(q *db.Queries) error {
+		var (
+			id  uuid.UUID
+			err error
+		)
+		if ruleID == nil {
+			id, err = uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("create rule id: %w", err)
+			}
+			_, err = q.CreateRule(ctx, createRuleParams(id, input))
+		} else {
+			id = *ruleID
+			_, err = q.UpdateRule(ctx, updateRuleParams(id, input))
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.replaceRuleTargets(ctx, q, id, input.Targets)
+	}
+
+	return s.previewPolicyChange(ctx, scope, apply)
+}
+
+// PreviewMembershipChange reports how adding or removing a membership would change the resolved
+// rules of the machines it reaches: the machine itself, the machines a user is primary user of, or
+// the machines that are effective members of a nested group. The change runs in a transaction
+// that is always rolled back.
+func (s *Store) PreviewMembershipChange(
+	ctx context.Context,
+	change domain.MembershipChange,
+) (domain.PolicyImpactPreview, error) {
+	scope := func(q *db.Queries) ([]uuid.UUID, error) {
+		switch change.MemberKind {
+		case domain.MemberKindMachine:
+			return []uuid.UUID{change.MemberID}, nil
+		case domain.MemberKindUser:
+			return q.ListMachineIDsByPrimaryUserID(ctx, change.MemberID)
+		case domain.MemberKindGroup:
+			return q.ListMachineIDsByEffectiveGroupID(ctx, change.MemberID)
+		default:
+			return nil, fmt.Errorf("unsupported member kind %q", change.MemberKind)
+		}
+	}
+
+	apply := func(q *db.Queries) error {
+		switch change.Action {
+		case domain.MembershipChangeActionAdd:
+			return addPreviewMembership(ctx, q, change)
+		case domain.MembershipChangeActionRemove:
+			return removePreviewMembership(ctx, q, change)
+		default:
+			return fmt.Errorf("unsupported membership change action %q", change.Action)
+		}
+	}
+
+	return s.previewPolicyChange(ctx, scope, apply)
+}
+
+func addPreviewMembership(ctx context.Context, q *db.Queries, change domain.MembershipChange) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("create membership id: %w", err)
+	}
+
+	switch change.MemberKind {
+	case domain.MemberKindUser:
+		_, err = q.CreateUserMembership(ctx, db.CreateUserMembershipParams{
+			ID:      id,
+			GroupID: change.GroupID,
+			UserID:  change.MemberID,
+			Origin:  db.MembershipOrigin(domain.MembershipOriginExplicit),
+		})
+	case domain.MemberKindMachine:
+		_, err = q.CreateMachineMembership(ctx, db.CreateMachineMembershipParams{
+			ID:        id,
+			GroupID:   change.GroupID,
+			MachineID: change.MemberID,
+			Origin:    db.MembershipOrigin(domain.MembershipOriginExplicit),
+		})
+	case domain.MemberKindGroup:
+		err = createGroupMembership(
+			ctx,
+			q,
+			id,
+			change.GroupID,
+			change.MemberID,
+			domain.MembershipOriginExplicit,
+			nil,
+			nil,
+		)
+	default:
+		return fmt.Errorf("unsupported member kind %q", change.MemberKind)
+	}
+
+	return err
+}
+
+func removePreviewMembership(ctx context.Context, q *db.Queries, change domain.MembershipChange) error {
+	var (
+		n   int64
+		err error
+	)
+
+	switch change.MemberKind {
+	case domain.MemberKindUser:
+		n, err = q.DeleteUserMembershipByMember(ctx, db.DeleteUserMembershipByMemberParams{
+			GroupID: change.GroupID,
+			UserID:  change.MemberID,
+		})
+	case domain.MemberKindMachine:
+		n, err = q.DeleteMachineMembershipByMember(ctx, db.DeleteMachineMembershipByMemberParams{
+			GroupID:   change.GroupID,
+			MachineID: change.MemberID,
+		})
+	case domain.MemberKindGroup:
+		n, err = q.DeleteGroupMembershipByMember(ctx, db.DeleteGroupMembershipByMemberParams{
+			GroupID:       change.GroupID,
+			MemberGroupID: change.MemberID,
+		})
+	default:
+		return fmt.Errorf("unsupported member kind %q", change.MemberKind)
+	}
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// previewPolicyChange resolves the scoped machines' rules and effective groups, applies the change,
+// resolves them again and diffs the two, all inside a rolled-back transaction.
+func (s *Store) previewPolicyChange(
+	ctx context.Context,
+	scope func(*db.Queries) ([]uuid.UUID, error),
+	apply func(*db.Queries) error,
+) (domain.PolicyImpactPreview, error) {
+	var preview domain.PolicyImpactPreview
+
+	err := s.runInRolledBackTx(ctx, func(q *db.Queries) error {
+		machineIDs, err := scope(q)
+		if err != nil {
+			return fmt.Errorf("list preview machines: %w", err)
+		}
+
+		before, err := resolveMachinesRules(ctx, q, machineIDs)
+		if err != nil {
+			return err
+		}
+		groupIDs, err := listEffectiveGroupIDs(ctx, q, machineIDs)
+		if err != nil {
+			return err
+		}
+
+		if err = apply(q); err != nil {
+			return err
+		}
+
+		after, err := resolveMachinesRules(ctx, q, machineIDs)
+		if err != nil {
+			return err
+		}
+
+		machines := make([]domain.MachinePolicyImpact, 0)
+		changedIDs := make([]uuid.UUID, 0)
+		for _, machineID := range machineIDs {
+			impact, changed := diffResolvedRules(before[machineID], after[machineID])
+			if !changed {
+				continue
+			}
+			impact.MachineID = machineID
+			machines = append(machines, impact)
+			changedIDs = append(changedIDs, machineID)
+		}
+
+		if err = setPreviewHostnames(ctx, q, machines); err != nil {
+			return err
+		}
+
+		afterGroupIDs, err := listEffectiveGroupIDs(ctx, q, changedIDs)
+		if err != nil {
+			return err
+		}
+		for machineID, ids := range afterGroupIDs {
+			groupIDs[machineID] = append(groupIDs[machineID], ids...)
+		}
+
+		preview = buildPolicyImpactPreview(machines, groupIDs)
+
+		return setPreviewGroupNames(ctx, q, preview.Groups)
+	})
+	if err != nil {
+		return domain.PolicyImpactPreview{}, err
+	}
+
+	sort.Slice(preview.Groups, func(i, j int) bool {
+		a, b := preview.Groups[i], preview.Groups[j]
+		if a.MachineCount != b.MachineCount {
+			return a.MachineCount > b.MachineCount
+		}
+		return a.GroupName < b.GroupName
+	})
+
+	return preview, nil
+}
+
+func listEffectiveGroupIDs(
+	ctx context.Context,
+	q *db.Queries,
+	machineIDs []uuid.UUID,
+) (map[uuid.UUID][]uuid.UUID, error) {
+	rows, err := q.ListEffectiveGroupIDsForMachines(ctx, machineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list effective groups for machines: %w", err)
+	}
+
+	groupIDs := make(map[uuid.UUID][]uuid.UUID, len(machineIDs))
+	for _, row := range rows {
+		groupIDs[row.MachineID] = append(groupIDs[row.MachineID], row.GroupID)
+	}
+
+	return groupIDs, nil
+}
+
+func setPreviewHostnames(ctx context.Context, q *db.Queries, machines []domain.MachinePolicyImpact) error {
+	ids := make([]uuid.UUID, 0, len(machines))
+	for _, machine := range machines {
+		ids = append(ids, machine.MachineID)
+	}
+
+	rows, err := q.ListMachineHostnamesByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list machine hostnames: %w", err)
+	}
+
+	hostnames := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		hostnames[row.ID] = row.Hostname
+	}
+	for index := range machines {
+		machines[index].Hostname = hostnames[machines[index].MachineID]
+	}
+
+	return nil
+}
+
+func setPreviewGroupNames(ctx context.Context, q *db.Queries, groups []domain.GroupPolicyImpact) error {
+	ids := make([]uuid.UUID, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.GroupID)
+	}
+
+	rows, err := q.ListGroupNamesByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list group names: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+	for index := range groups {
+		groups[index].GroupName = names[groups[index].GroupID]
+	}
+
+	return nil
+}
+
+// diffResolvedRules compares a machine's resolved rules before and after a change, matching them
+// by rule type and identifier. It reports whether anything changed.
+func diffResolvedRules(before, after []domain.MachineResolvedRule) (domain.MachinePolicyImpact, bool) {
+	impact := domain.MachinePolicyImpact{
+		Added:         []domain.PolicyImpactRule{},
+		Removed:       []domain.PolicyImpactRule{},
+		PolicyChanged: []domain.PolicyImpactRule{},
+	}
+
+	previous := domain.NewMachineRuleIndex(before)
+	current := domain.NewMachineRuleIndex(after)
+
+	for _, rule := range after {
+		policy := rule.Policy
+		old, ok := previous[domain.MachineRuleTargetKey(rule.MachineRuleTarget)]
+		switch {
+		case !ok:
+			impact.Added = append(impact.Added, policyImpactRule(rule, &policy, nil))
+		case old.Policy != rule.Policy:
+			previousPolicy := old.Policy
+			impact.PolicyChanged = append(impact.PolicyChanged, policyImpactRule(rule, &policy, &previousPolicy))
+		}
+	}
+	for _, rule := range before {
+		if _, ok := current[domain.MachineRuleTargetKey(rule.MachineRuleTarget)]; ok {
+			continue
+		}
+		previousPolicy := rule.Policy
+		impact.Removed = append(impact.Removed, policyImpactRule(rule, nil, &previousPolicy))
+	}
+
+	changed := len(impact.Added) > 0 || len(impact.Removed) > 0 || len(impact.PolicyChanged) > 0
+	return impact, changed
+}
+
+func policyImpactRule(
+	rule domain.MachineResolvedRule,
+	policy *domain.RulePolicy,
+	previousPolicy *domain.RulePolicy,
+) domain.PolicyImpactRule {
+	return domain.PolicyImpactRule{
+		RuleID:         rule.RuleID,
+		Name:           rule.Name,
+		RuleType:       rule.RuleType,
+		Identifier:     rule.Identifier,
+		Policy:         policy,
+		PreviousPolicy: previousPolicy,
+	}
+}
+
+// buildPolicyImpactPreview totals the affected machines per group. groupIDs may list a group more
+// than once for a machine; each machine counts once per group.
+func buildPolicyImpactPreview(
+	machines []domain.MachinePolicyImpact,
+	groupIDs map[uuid.UUID][]uuid.UUID,
+) domain.PolicyImpactPreview {
+	groups := make(map[uuid.UUID]*domain.GroupPolicyImpact)
+	order := make([]uuid.UUID, 0)
+
+	preview := domain.PolicyImpactPreview{Machines: machines}
+	preview.AffectedMachineCount = int32(len(machines)) //nolint:gosec // machine counts stay far below MaxInt32
+	for _, machine := range machines {
+
+		seen := make(map[uuid.UUID]struct{}, len(groupIDs[machine.MachineID]))
+		for _, groupID := range groupIDs[machine.MachineID] {
+			if _, ok := seen[groupID]; ok {
+				continue
+			}
+			seen[groupID] = struct{}{}
+
+			group, ok := groups[groupID]
+			if !ok {
+				group = &domain.GroupPolicyImpact{GroupID: groupID}
+				groups[groupID] = group
+				order = append(order, groupID)
+			}
+			group.MachineCount++
+			group.AddedCount += ruleCount(machine.Added)
+			group.RemovedCount += ruleCount(machine.Removed)
+			group.PolicyChangedCount += ruleCount(machine.PolicyChanged)
+		}
+	}
+
+	preview.Groups = make([]domain.GroupPolicyImpact, 0, len(order))
+	for _, groupID := range order {
+		preview.Groups = append(preview.Groups, *groups[groupID])
+	}
+
+	return preview
+}
+
+func ruleCount(rules []domain.PolicyImpactRule) int32 {
+	return int32(len(rules)) //nolint:gosec // rule counts stay far below MaxInt32
+}
@@ -0,0 +1,100 @@
+package postgres //nolint:testpackage // exercises unexported impact diffing.
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func previewRule(ruleType domain.RuleType, identifier string, policy domain.RulePolicy) domain.MachineResolvedRule {
+	return domain.MachineResolvedRule{
+		MachineRuleTarget: domain.MachineRuleTarget{RuleType: ruleType, Identifier: identifier, Policy: policy},
+		RuleID:            uuid.New(),
+	}
+}
+
+func TestDiffResolvedRulesReportsAddedRemovedAndPolicyChanges(t *testing.T) {
+	kept := previewRule(domain.RuleTypeTeamID, "EQHXZ8M8AV", domain.RulePolicyAllowlist)
+	dropped := previewRule(domain.RuleTypeBinary, "game-sha", domain.RulePolicyBlocklist)
+	flipped := previewRule(domain.RuleTypeSigningID, "EQHXZ8M8AV:com.example.tool", domain.RulePolicyAllowlist)
+	flippedAfter := flipped
+	flippedAfter.Policy = domain.RulePolicyBlocklist
+	added := previewRule(domain.RuleTypeCertificate, "cert-sha", domain.RulePolicyAllowlist)
+
+	impact, changed := diffResolvedRules(
+		[]domain.MachineResolvedRule{kept, dropped, flipped},
+		[]domain.MachineResolvedRule{kept, flippedAfter, added},
+	)
+	if !changed {
+		t.Fatal("changed = false, want true")
+	}
+
+	if len(impact.Added) != 1 || impact.Added[0].RuleID != added.RuleID || impact.Added[0].PreviousPolicy != nil {
+		t.Fatalf("Added = %+v, want certificate rule without a previous policy", impact.Added)
+	}
+	if len(impact.Removed) != 1 || impact.Removed[0].RuleID != dropped.RuleID || impact.Removed[0].Policy != nil {
+		t.Fatalf("Removed = %+v, want binary rule without a policy", impact.Removed)
+	}
+	if len(impact.PolicyChanged) != 1 {
+		t.Fatalf("len(PolicyChanged) = %d, want 1", len(impact.PolicyChanged))
+	}
+	change := impact.PolicyChanged[0]
+	if *change.PreviousPolicy != domain.RulePolicyAllowlist || *change.Policy != domain.RulePolicyBlocklist {
+		t.Fatalf("PolicyChanged[0] = %s -> %s, want allowlist -> blocklist", *change.PreviousPolicy, *change.Policy)
+	}
+}
+
+func TestDiffResolvedRulesIgnoresUnchangedMachines(t *testing.T) {
+	rule := previewRule(domain.RuleTypeTeamID, "EQHXZ8M8AV", domain.RulePolicyAllowlist)
+
+	if _, changed := diffResolvedRules(
+		[]domain.MachineResolvedRule{rule},
+		[]domain.MachineResolvedRule{rule},
+	); changed {
+		t.Fatal("changed = true, want false")
+	}
+}
+
+func TestBuildPolicyImpactPreviewCountsEachMachineOncePerGroup(t *testing.T) {
+	laptop, desktop := uuid.New(), uuid.New()
+	staff, lab := uuid.New(), uuid.New()
+	policy := domain.RulePolicyAllowlist
+
+	machines := []domain.MachinePolicyImpact{
+		{
+			MachineID: laptop,
+			Added:     []domain.PolicyImpactRule{{Policy: &policy}, {Policy: &policy}},
+		},
+		{
+			MachineID: desktop,
+			Removed:   []domain.PolicyImpactRule{{PreviousPolicy: &policy}},
+		},
+	}
+	// The laptop's staff membership is listed both before and after the change.
+	groupIDs := map[uuid.UUID][]uuid.UUID{
+		laptop:  {staff, staff, lab},
+		desktop: {staff},
+	}
+
+	preview := buildPolicyImpactPreview(machines, groupIDs)
+
+	if preview.AffectedMachineCount != 2 {
+		t.Fatalf("AffectedMachineCount = %d, want 2", preview.AffectedMachineCount)
+	}
+	if len(preview.Groups) != 2 {
+		t.Fatalf("len(Groups) = %d, want 2", len(preview.Groups))
+	}
+
+	byID := make(map[uuid.UUID]domain.GroupPolicyImpact, len(preview.Groups))
+	for _, group := range preview.Groups {
+		byID[group.GroupID] = group
+	}
+	if got := byID[staff]; got.MachineCount != 2 || got.AddedCount != 2 || got.RemovedCount != 1 {
+		t.Fatalf("staff = %+v, want 2 machines, 2 added, 1 removed", got)
+	}
+	if got := byID[lab]; got.MachineCount != 1 || got.AddedCount != 2 || got.RemovedCount != 0 {
+		t.Fatalf("lab = %+v, want 1 machine, 2 added, 0 removed", got)
+	}
+}
@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	publisherListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"team_id":          "p.team_id",
+		"organization":     "organization",
+		"signing_id_count": "p.signing_id_count",
+		"executable_count": "p.executable_count",
+		"machine_count":    "machine_count",
+		"event_count":      "event_count",
+		"allowed_count":    "allowed_count",
+		"blocked_count":    "blocked_count",
+		"first_seen_at":    "p.first_seen_at",
+		"last_seen_at":     "prevalence.last_seen_at",
+	}
+
+	publisherListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"machine_count DESC",
+		"p.team_id ASC",
+	}
+)
+
+func (s *Store) ListPublishers(
+	ctx context.Context,
+	opts domain.PublisherListOptions,
+) ([]domain.Publisher, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		publisherListSortColumns,
+		publisherListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where, args := publisherListFilters(opts)
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(
+		publisherListQuery,
+		strings.Join(where, " AND "),
+		orderBy,
+		limitArg,
+		offsetArg,
+	)
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list publishers: %w", err)
+	}
+
+	return collectRows(rows, scanPublisherRow)
+}
+
+func (s *Store) GetPublisher(ctx context.Context, teamID string) (domain.PublisherDetail, error) {
+	publishers, _, err := s.ListPublishers(ctx, domain.PublisherListOptions{TeamID: teamID})
+	if err != nil {
+		return domain.PublisherDetail{}, err
+	}
+	if len(publishers) == 0 {
+		return domain.PublisherDetail{}, pgx.ErrNoRows
+	}
+
+	signingIDs, err := s.Queries().ListPublisherSigningIDs(ctx, teamID)
+	if err != nil {
+		return domain.PublisherDetail{}, fmt.Errorf("list publisher signing ids: %w", err)
+	}
+
+	decisionRows, err := s.Queries().ListPublisherDecisionCounts(ctx, teamID)
+	if err != nil {
+		return domain.PublisherDetail{}, fmt.Errorf("list publisher decisions: %w", err)
+	}
+
+	decisions, err := mapPublisherDecisions(decisionRows)
+	if err != nil {
+		return domain.PublisherDetail{}, err
+	}
+
+	ruleRows, err := s.Queries().ListPublisherRules(ctx, teamID)
+	if err != nil {
+		return domain.PublisherDetail{}, fmt.Errorf("list publisher rules: %w", err)
+	}
+
+	rules := make([]domain.RuleSummary, 0, len(ruleRows))
+	for _, row := range ruleRows {
+		rule, mapErr := mapRuleSummary(row)
+		if mapErr != nil {
+			return domain.PublisherDetail{}, mapErr
+		}
+		rules = append(rules, rule)
+	}
+
+	if signingIDs == nil {
+		signingIDs = []string{}
+	}
+
+	return domain.PublisherDetail{
+		Publisher:  publishers[0],
+		SigningIDs: signingIDs,
+		Decisions:  decisions,
+		Rules:      rules,
+	}, nil
+}
+
+// publisherListFilters builds the WHERE clauses and arguments for the publisher list. The first
+// two arguments are the allowed and blocked decisions the activity counts filter on.
+func publisherListFilters(opts domain.PublisherListOptions) ([]string, []any) {
+	allowed, blocked := outcomeDecisions()
+	where := []string{
+		`($3 = '' OR
+  p.team_id ILIKE $3 OR
+  org.organization ILIKE $3)`,
+	}
+	args := []any{allowed, blocked, searchPattern(opts.Search)}
+
+	if opts.TeamID != "" {
+		where = append(where, fmt.Sprintf("p.team_id = $%d", len(args)+1))
+		args = append(args, opts.TeamID)
+	}
+
+	return where, args
+}
+
+// outcomeDecisions lists the execution decisions counted as allowed and as blocked events.
+// Unknown and bundle decisions count toward neither.
+func outcomeDecisions() ([]string, []string) {
+	allowed := make([]string, 0)
+	blocked := make([]string, 0)
+	for _, decision := range domain.ExecutionDecisions() {
+		switch {
+		case decision.Allowed():
+			allowed = append(allowed, string(decision))
+		case decision.Blocked():
+			blocked = append(blocked, string(decision))
+		}
+	}
+
+	return allowed, blocked
+}
+
+func mapPublisherDecisions(rows []db.ListPublisherDecisionCountsRow) ([]domain.ExecutionDecisionCount, error) {
+	decisions := make([]domain.ExecutionDecisionCount, 0, len(rows))
+	for _, row := range rows {
+		decision, err := domain.ParseExecutionDecision(string(row.Decision))
+		if err != nil {
+			return nil, fmt.Errorf("parse execution event decision: %w", err)
+		}
+		decisions = append(decisions, domain.ExecutionDecisionCount{Decision: decision, Count: row.Count})
+	}
+
+	return decisions, nil
+}
+
+func scanPublisherRow(rows pgx.Rows) (domain.Publisher, int32, error) {
+	var (
+		item  domain.Publisher
+		total int32
+	)
+
+	if err := rows.Scan(
+		&item.TeamID,
+		&item.Organization,
+		&item.SigningIDCount,
+		&item.ExecutableCount,
+		&item.MachineCount,
+		&item.EventCount,
+		&item.AllowedCount,
+		&item.BlockedCount,
+		&item.FirstSeenAt,
+		&item.LastSeenAt,
+		&total,
+	); err != nil {
+		return domain.Publisher{}, 0, err
+	}
+
+	return item, total, nil
+}
+
+// The organization comes from the most common leaf certificate organization across the
+// publisher's executables.
+const publisherListQuery = `
+WITH publishers AS (
+  SELECT
+    e.team_id,
+    COUNT(DISTINCT NULLIF(e.signing_id, ''))::INT4 AS signing_id_count,
+    COUNT(*)::INT4 AS executable_count,
+    MIN(e.created_at) AS first_seen_at
+  FROM executables AS e
+  WHERE e.team_id <> ''
+  GROUP BY e.team_id
+)
+SELECT
+  p.team_id,
+  COALESCE(org.organization, '') AS organization,
+  p.signing_id_count,
+  p.executable_count,
+  COALESCE(prevalence.machine_count, 0)::INT4 AS machine_count,
+  COALESCE(activity.event_count, 0)::INT4 AS event_count,
+  COALESCE(activity.allowed_count, 0)::INT4 AS allowed_count,
+  COALESCE(activity.blocked_count, 0)::INT4 AS blocked_count,
+  p.first_seen_at,
+  prevalence.last_seen_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM publishers AS p
+LEFT JOIN LATERAL (
+  SELECT c.organization
+  FROM executables AS e
+  JOIN executable_certificates AS ec
+    ON ec.executable_id = e.id
+    AND ec.chain_position = 0
+  JOIN certificates AS c
+    ON c.id = ec.certificate_id
+  WHERE e.team_id = p.team_id
+    AND c.organization <> ''
+  GROUP BY c.organization
+  ORDER BY COUNT(*) DESC, c.organization ASC
+  LIMIT 1
+) AS org
+  ON TRUE
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(DISTINCT eo.machine_id)::INT4 AS machine_count,
+    MAX(eo.last_seen_at) AS last_seen_at
+  FROM executables AS e
+  JOIN executable_observations AS eo
+    ON eo.executable_id = e.id
+  WHERE e.team_id = p.team_id
+) AS prevalence
+  ON TRUE
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*)::INT4 AS event_count,
+    COUNT(*) FILTER (WHERE ee.decision::TEXT = ANY($1::TEXT[]))::INT4 AS allowed_count,
+    COUNT(*) FILTER (WHERE ee.decision::TEXT = ANY($2::TEXT[]))::INT4 AS blocked_count
+  FROM executables AS e
+  JOIN execution_events AS ee
+    ON ee.executable_id = e.id
+  WHERE e.team_id = p.team_id
+) AS activity
+  ON TRUE
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`
@@ -0,0 +1,123 @@
+package postgres //nolint:testpackage // exercises unexported publisher aggregation.
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+func TestOutcomeDecisionsMapAllowedAndBlockedEvents(t *testing.T) {
+	tests := []struct {
+		decision    domain.ExecutionDecision
+		wantAllowed bool
+		wantBlocked bool
+	}{
+		{decision: domain.ExecutionDecisionUnknown},
+		{decision: domain.ExecutionDecisionAllowUnknown, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowBinary, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowCertificate, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowScope, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowTeamID, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowSigningID, wantAllowed: true},
+		{decision: domain.ExecutionDecisionAllowCDHash, wantAllowed: true},
+		{decision: domain.ExecutionDecisionBlockUnknown, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockBinary, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockCertificate, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockScope, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockTeamID, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockSigningID, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBlockCDHash, wantBlocked: true},
+		{decision: domain.ExecutionDecisionBundleBinary},
+	}
+
+	if len(tests) != len(domain.ExecutionDecisions()) {
+		t.Fatalf("tests cover %d decisions, want all %d", len(tests), len(domain.ExecutionDecisions()))
+	}
+
+	allowed, blocked := outcomeDecisions()
+	for _, tt := range tests {
+		t.Run(string(tt.decision), func(t *testing.T) {
+			if got := slices.Contains(allowed, string(tt.decision)); got != tt.wantAllowed {
+				t.Fatalf("allowed contains %q = %v, want %v", tt.decision, got, tt.wantAllowed)
+			}
+			if got := slices.Contains(blocked, string(tt.decision)); got != tt.wantBlocked {
+				t.Fatalf("blocked contains %q = %v, want %v", tt.decision, got, tt.wantBlocked)
+			}
+		})
+	}
+}
+
+func TestPublisherListFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      domain.PublisherListOptions
+		wantWhere int
+		wantArgs  []any
+	}{
+		{
+			name:      "all publishers",
+			opts:      domain.PublisherListOptions{},
+			wantWhere: 1,
+			wantArgs:  []any{""},
+		},
+		{
+			name:      "search",
+			opts:      domain.PublisherListOptions{ListOptions: domain.ListOptions{Search: "EQHX"}},
+			wantWhere: 1,
+			wantArgs:  []any{"%EQHX%"},
+		},
+		{
+			name:      "single team",
+			opts:      domain.PublisherListOptions{TeamID: "EQHXZ8M8AV"},
+			wantWhere: 2,
+			wantArgs:  []any{"", "EQHXZ8M8AV"},
+		},
+	}
+
+	allowed, blocked := outcomeDecisions()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := publisherListFilters(tt.opts)
+
+			if len(where) != tt.wantWhere {
+				t.Fatalf("where = %q, want %d clauses", where, tt.wantWhere)
+			}
+			if tt.opts.TeamID != "" && where[len(where)-1] != "p.team_id = $4" {
+				t.Fatalf("team clause = %q, want p.team_id = $4", where[len(where)-1])
+			}
+			if len(args) != 2+len(tt.wantArgs) {
+				t.Fatalf("args = %v, want decision lists followed by %v", args, tt.wantArgs)
+			}
+			if !slices.Equal(args[0].([]string), allowed) || !slices.Equal(args[1].([]string), blocked) {
+				t.Fatalf("args[:2] = %v, want allowed then blocked decisions", args[:2])
+			}
+			if !slices.Equal(args[2:], tt.wantArgs) {
+				t.Fatalf("args[2:] = %v, want %v", args[2:], tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestMapPublisherDecisions(t *testing.T) {
+	got, err := mapPublisherDecisions([]db.ListPublisherDecisionCountsRow{
+		{Decision: db.ExecutionDecision(domain.ExecutionDecisionAllowTeamID), Count: 4},
+		{Decision: db.ExecutionDecision(domain.ExecutionDecisionBlockSigningID), Count: 2},
+	})
+	if err != nil {
+		t.Fatalf("mapPublisherDecisions() error = %v", err)
+	}
+
+	want := []domain.ExecutionDecisionCount{
+		{Decision: domain.ExecutionDecisionAllowTeamID, Count: 4},
+		{Decision: domain.ExecutionDecisionBlockSigningID, Count: 2},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("mapPublisherDecisions() = %+v, want %+v", got, want)
+	}
+
+	if _, err = mapPublisherDecisions([]db.ListPublisherDecisionCountsRow{{Decision: "allow_everything"}}); err == nil {
+		t.Fatal("mapPublisherDecisions() error = nil, want error for unknown decision")
+	}
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	roleMappingListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":               "grm.id",
+		"group_name":       "g.name",
+		"role":             "grm.role",
+		sortFieldCreatedAt: "grm.created_at",
+		sortFieldUpdatedAt: "grm.updated_at",
+	}
+
+	roleMappingListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"g.name ASC",
+		"grm.id ASC",
+	}
+)
+
+func (s *Store) ListRoleMappings(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.RoleMapping, int32, error) {
+	orderBy, err := orderBy(opts.Sort, opts.Order, roleMappingListSortColumns, roleMappingListDefaultOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"($1 = '' OR g.name ILIKE $1 OR grm.role::text ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("grm.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  grm.id,
+  grm.group_id,
+  g.name AS group_name,
+  g.source AS group_source,
+  grm.role,
+  grm.created_at,
+  grm.updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM group_role_mappings AS grm
+JOIN groups AS g
+  ON g.id = grm.group_id
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list role mappings: %w", err)
+	}
+
+	return collectRows(rows, scanRoleMappingRow)
+}
+
+func (s *Store) GetRoleMapping(ctx context.Context, id uuid.UUID) (domain.RoleMapping, error) {
+	row, err := s.Queries().GetRoleMapping(ctx, id)
+	if err != nil {
+		return domain.RoleMapping{}, err
+	}
+
+	return mapRoleMapping(row)
+}
+
+func (s *Store) CreateRoleMapping(
+	ctx context.Context,
+	groupID uuid.UUID,
+	role domain.Role,
+) (domain.RoleMapping, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.RoleMapping{}, fmt.Errorf("create role mapping id: %w", err)
+	}
+
+	if _, err = s.Queries().CreateRoleMapping(ctx, db.CreateRoleMappingParams{
+		ID:      id,
+		GroupID: groupID,
+		Role:    db.AccessRole(role),
+	}); err != nil {
+		return domain.RoleMapping{}, err
+	}
+
+	return s.GetRoleMapping(ctx, id)
+}
+
+func (s *Store) UpdateRoleMapping(
+	ctx context.Context,
+	id uuid.UUID,
+	role domain.Role,
+) (domain.RoleMapping, error) {
+	n, err := s.Queries().UpdateRoleMapping(ctx, db.UpdateRoleMappingParams{
+		ID:   id,
+		Role: db.AccessRole(role),
+	})
+	if err != nil {
+		return domain.RoleMapping{}, err
+	}
+	if n == 0 {
+		return domain.RoleMapping{}, pgx.ErrNoRows
+	}
+
+	return s.GetRoleMapping(ctx, id)
+}
+
+func (s *Store) DeleteRoleMapping(ctx context.Context, id uuid.UUID) error {
+	n, err := s.Queries().DeleteRoleMapping(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListRolesForUserUPN returns the distinct roles granted to a user through mapped groups.
+func (s *Store) ListRolesForUserUPN(ctx context.Context, upn string) ([]domain.Role, error) {
+	rows, err := s.Queries().ListRolesForUserUPN(ctx, upn)
+	if err != nil {
+		return nil, fmt.Errorf("list roles for user: %w", err)
+	}
+
+	roles := make([]domain.Role, 0, len(rows))
+	for _, row := range rows {
+		role, parseErr := domain.ParseRole(string(row))
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse role: %w", parseErr)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// GroupGrantsRole reports whether members of a group hold a role, because a role is mapped to the
+// group itself or to a group it is nested in.
+func (s *Store) GroupGrantsRole(ctx context.Context, groupID uuid.UUID) (bool, error) {
+	grantsRole, err := s.Queries().GroupGrantsRole(ctx, groupID)
+	if err != nil {
+		return false, fmt.Errorf("check group role mappings: %w", err)
+	}
+
+	return grantsRole, nil
+}
+
+func scanRoleMappingRow(rows pgx.Rows) (domain.RoleMapping, int32, error) {
+	var (
+		row   db.GetRoleMappingRow
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.GroupID,
+		&row.GroupName,
+		&row.GroupSource,
+		&row.Role,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.RoleMapping{}, 0, err
+	}
+
+	mapping, err := mapRoleMapping(row)
+	if err != nil {
+		return domain.RoleMapping{}, 0, err
+	}
+
+	return mapping, total, nil
+}
+
+func mapRoleMapping(row db.GetRoleMappingRow) (domain.RoleMapping, error) {
+	source, err := domain.ParsePrincipalSource(string(row.GroupSource))
+	if err != nil {
+		return domain.RoleMapping{}, fmt.Errorf("parse role mapping group source: %w", err)
+	}
+
+	role, err := domain.ParseRole(string(row.Role))
+	if err != nil {
+		return domain.RoleMapping{}, fmt.Errorf("parse role mapping role: %w", err)
+	}
+
+	return domain.RoleMapping{
+		ID:        row.ID,
+		GroupID:   row.GroupID,
+		GroupName: row.GroupName,
+		Source:    source,
+		Role:      role,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
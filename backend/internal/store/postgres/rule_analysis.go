@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+// ListRuleTargetsByRuleID returns rule targets keyed by rule ID: those of the given rules, or of
+// every rule when ruleIDs is nil.
+func (s *Store) ListRuleTargetsByRuleID(
+	ctx context.Context,
+	ruleIDs []uuid.UUID,
+) (map[uuid.UUID]domain.RuleTargets, error) {
+	rows, err := s.Queries().ListRuleTargetsForAnalysis(ctx, ruleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list rule targets: %w", err)
+	}
+
+	targetsByRule := make(map[uuid.UUID]domain.RuleTargets)
+	for _, row := range rows {
+		targets, ok := targetsByRule[row.RuleID]
+		if !ok {
+			targets = domain.RuleTargets{
+				Include: []domain.IncludeRuleTarget{},
+				Exclude: []domain.ExcludeRuleTarget{},
+			}
+		}
+
+		if err = appendRuleTarget(&targets, db.ListRuleTargetsByRuleRow{
+			SubjectKind:   row.SubjectKind,
+			SubjectID:     row.SubjectID,
+			Assignment:    row.Assignment,
+			Priority:      row.Priority,
+			Policy:        row.Policy,
+			CelExpression: row.CelExpression,
+			SubjectName:   row.SubjectName,
+		}); err != nil {
+			return nil, fmt.Errorf("append rule target: %w", err)
+		}
+		targetsByRule[row.RuleID] = targets
+	}
+
+	return targetsByRule, nil
+}
+
+// ListRuleOverlaps returns each pair of rules that match at least one common known executable
+// through different identifiers. A non-nil ruleID limits the pairs to those involving that rule,
+// starting from the executables it matches instead of joining every known executable's rules.
+func (s *Store) ListRuleOverlaps(ctx context.Context, ruleID *uuid.UUID) ([]domain.RuleOverlap, error) {
+	if ruleID != nil {
+		rows, err := s.Queries().ListRuleOverlapsForRule(ctx, *ruleID)
+		if err != nil {
+			return nil, fmt.Errorf("list rule overlaps for rule %s: %w", *ruleID, err)
+		}
+
+		overlaps := make([]domain.RuleOverlap, 0, len(rows))
+		for _, row := range rows {
+			overlaps = append(overlaps, domain.RuleOverlap{
+				RuleID:          row.RuleID,
+				OtherRuleID:     row.OtherRuleID,
+				ExecutableCount: row.ExecutableCount,
+			})
+		}
+
+		return overlaps, nil
+	}
+
+	rows, err := s.Queries().ListRuleOverlaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list rule overlaps: %w", err)
+	}
+
+	overlaps := make([]domain.RuleOverlap, 0, len(rows))
+	for _, row := range rows {
+		overlaps = append(overlaps, domain.RuleOverlap{
+			RuleID:          row.RuleID,
+			OtherRuleID:     row.OtherRuleID,
+			ExecutableCount: row.ExecutableCount,
+		})
+	}
+
+	return overlaps, nil
+}
+
+// GetMachineTargeting returns the machine sets rule targets resolve against. A nil scope loads the
+// whole fleet; otherwise only the sets the scope's subjects need are loaded, and
+// PrimaryUserMachineIDs is left empty unless the scope includes all users.
+func (s *Store) GetMachineTargeting(
+	ctx context.Context,
+	scope *domain.MachineTargetingScope,
+) (domain.MachineTargeting, error) {
+	queries := s.Queries()
+
+	// Nil ID slices select every row; the scope's empty slices select none.
+	var userIDs, groupIDs []uuid.UUID
+	allDevices, allUsers := true, true
+	if scope != nil {
+		allDevices, allUsers = scope.AllDevices, scope.AllUsers
+		groupIDs = scope.GroupIDs
+		if !allUsers {
+			userIDs = scope.UserIDs
+		}
+	}
+
+	var (
+		machineIDs []uuid.UUID
+		err        error
+	)
+	if allDevices {
+		machineIDs, err = queries.ListMachineIDs(ctx)
+	} else {
+		machineIDs, err = queries.ListMachineIDsByIDs(ctx, scope.MachineIDs)
+	}
+	if err != nil {
+		return domain.MachineTargeting{}, fmt.Errorf("list machine ids: %w", err)
+	}
+
+	primaryUserMachines, err := queries.ListPrimaryUserMachines(ctx, userIDs)
+	if err != nil {
+		return domain.MachineTargeting{}, fmt.Errorf("list primary user machines: %w", err)
+	}
+
+	var primaryUserMachineIDs []uuid.UUID
+	userMachineIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range primaryUserMachines {
+		if allUsers {
+			primaryUserMachineIDs = append(primaryUserMachineIDs, row.MachineID)
+		}
+		userMachineIDs[row.UserID] = append(userMachineIDs[row.UserID], row.MachineID)
+	}
+
+	rows, err := queries.ListEffectiveGroupMachines(ctx, groupIDs)
+	if err != nil {
+		return domain.MachineTargeting{}, fmt.Errorf("list effective group machines: %w", err)
+	}
+
+	groupMachineIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range rows {
+		groupMachineIDs[row.GroupID] = append(groupMachineIDs[row.GroupID], row.MachineID)
+	}
+
+	return domain.MachineTargeting{
+		MachineIDs:            machineIDs,
+		PrimaryUserMachineIDs: primaryUserMachineIDs,
+		GroupMachineIDs:       groupMachineIDs,
+		UserMachineIDs:        userMachineIDs,
+	}, nil
+}
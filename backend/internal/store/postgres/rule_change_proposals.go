@@ -0,0 +1,365 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	ruleChangeProposalListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":               "id",
+		"action":           "action",
+		"status":           "status",
+		"rule_name":        "rule_name",
+		"proposed_by_name": "proposed_by_name",
+		"reviewed_at":      "reviewed_at",
+		sortFieldCreatedAt: "created_at",
+		sortFieldUpdatedAt: "updated_at",
+	}
+
+	ruleChangeProposalListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"created_at DESC",
+		"id DESC",
+	}
+)
+
+//nolint:dupl // structurally similar to other List* functions by design
+func (s *Store) ListRuleChangeProposals(
+	ctx context.Context,
+	opts domain.RuleChangeProposalListOptions,
+) ([]domain.RuleChangeProposal, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		ruleChangeProposalListSortColumns,
+		ruleChangeProposalListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"($1 = '' OR rule_name ILIKE $1 OR proposed_by_name ILIKE $1 OR reviewed_by_name ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+	if opts.RuleID != nil {
+		where = append(where, fmt.Sprintf("rule_id = $%d", len(args)+1))
+		args = append(args, *opts.RuleID)
+	}
+	if len(opts.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("status::text = ANY($%d)", len(args)+1))
+		args = append(args, toStrings(opts.Statuses))
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  id,
+  action,
+  status,
+  rule_id,
+  rule_name,
+  base_updated_at,
+  current_rule,
+  proposed_rule,
+  proposed_by,
+  proposed_by_name,
+  reviewed_by,
+  reviewed_by_name,
+  review_comment,
+  reviewed_at,
+  created_at,
+  updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM rule_change_proposals
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list rule change proposals: %w", err)
+	}
+
+	return collectRows(rows, scanRuleChangeProposalRow)
+}
+
+func (s *Store) GetRuleChangeProposal(ctx context.Context, id uuid.UUID) (domain.RuleChangeProposal, error) {
+	row, err := s.Queries().GetRuleChangeProposal(ctx, id)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	return mapRuleChangeProposal(row)
+}
+
+func (s *Store) CreateRuleChangeProposal(
+	ctx context.Context,
+	proposal domain.RuleChangeProposal,
+) (domain.RuleChangeProposal, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.RuleChangeProposal{}, fmt.Errorf("create rule change proposal id: %w", err)
+	}
+
+	current, err := marshalRuleSnapshot(proposal.Current)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+	proposed, err := marshalRuleSnapshot(proposal.Proposed)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	if _, err = s.Queries().CreateRuleChangeProposal(ctx, db.CreateRuleChangeProposalParams{
+		ID:             id,
+		Action:         db.RuleChangeAction(proposal.Action),
+		RuleID:         proposal.RuleID,
+		RuleName:       proposal.RuleName,
+		BaseUpdatedAt:  proposal.BaseUpdatedAt,
+		CurrentRule:    current,
+		ProposedRule:   proposed,
+		ProposedBy:     proposal.ProposedBy,
+		ProposedByName: proposal.ProposedByName,
+	}); err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	return s.GetRuleChangeProposal(ctx, id)
+}
+
+// ReviewRuleChangeProposal records a decision on a pending proposal. It returns false when the
+// proposal was no longer pending, so concurrent reviewers cannot both apply the same change.
+func (s *Store) ReviewRuleChangeProposal(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.RuleChangeStatus,
+	reviewer domain.Actor,
+	comment string,
+) (bool, error) {
+	n, err := s.Queries().ReviewRuleChangeProposal(ctx, db.ReviewRuleChangeProposalParams{
+		ID:             id,
+		Status:         db.RuleChangeStatus(status),
+		ReviewedBy:     reviewer.ID,
+		ReviewedByName: reviewer.Name,
+		ReviewComment:  comment,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// ApplyRuleChangeProposal approves a pending proposal and writes its rule change in one
+// transaction, so a failed rule write leaves the proposal pending. It returns the written rule,
+// uuid.Nil for a delete, and false when the proposal was no longer pending, and
+// ErrRuleChangeConflict when the target rule is gone.
+func (s *Store) ApplyRuleChangeProposal(
+	ctx context.Context,
+	proposal domain.RuleChangeProposal,
+	reviewer domain.Actor,
+	comment string,
+) (uuid.UUID, bool, error) {
+	var (
+		ruleID  uuid.UUID
+		claimed bool
+	)
+
+	err := s.RunInTx(ctx, func(q *db.Queries) error {
+		n, err := q.ReviewRuleChangeProposal(ctx, db.ReviewRuleChangeProposalParams{
+			ID:             proposal.ID,
+			Status:         db.RuleChangeStatus(domain.RuleChangeStatusApproved),
+			ReviewedBy:     reviewer.ID,
+			ReviewedByName: reviewer.Name,
+			ReviewComment:  comment,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		claimed = true
+
+		ruleID, err = s.applyRuleChange(ctx, q, proposal)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrRuleChangeConflict
+		}
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	return ruleID, claimed, nil
+}
+
+func (s *Store) applyRuleChange(
+	ctx context.Context,
+	q *db.Queries,
+	proposal domain.RuleChangeProposal,
+) (uuid.UUID, error) {
+	switch proposal.Action {
+	case domain.RuleChangeActionCreate:
+		ruleID, err := uuid.NewV7()
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("create rule id: %w", err)
+		}
+
+		input := proposal.Proposed.WriteInput()
+		if _, err = q.CreateRule(ctx, createRuleParams(ruleID, input)); err != nil {
+			return uuid.Nil, err
+		}
+		if err = s.replaceRuleTargets(ctx, q, ruleID, input.Targets); err != nil {
+			return uuid.Nil, err
+		}
+
+		n, err := q.SetRuleChangeProposalRule(ctx, db.SetRuleChangeProposalRuleParams{
+			ID:     proposal.ID,
+			RuleID: &ruleID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if n == 0 {
+			return uuid.Nil, pgx.ErrNoRows
+		}
+		return ruleID, nil
+	case domain.RuleChangeActionUpdate:
+		input := proposal.Proposed.WriteInput()
+		if _, err := q.UpdateRule(ctx, updateRuleParams(*proposal.RuleID, input)); err != nil {
+			return uuid.Nil, err
+		}
+		return *proposal.RuleID, s.replaceRuleTargets(ctx, q, *proposal.RuleID, input.Targets)
+	case domain.RuleChangeActionDelete:
+		_, err := q.DeleteRule(ctx, *proposal.RuleID)
+		return uuid.Nil, err
+	default:
+		return uuid.Nil, fmt.Errorf("unsupported rule change action %q", proposal.Action)
+	}
+}
+
+func scanRuleChangeProposalRow(rows pgx.Rows) (domain.RuleChangeProposal, int32, error) {
+	var (
+		row   db.RuleChangeProposal
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.Action,
+		&row.Status,
+		&row.RuleID,
+		&row.RuleName,
+		&row.BaseUpdatedAt,
+		&row.CurrentRule,
+		&row.ProposedRule,
+		&row.ProposedBy,
+		&row.ProposedByName,
+		&row.ReviewedBy,
+		&row.ReviewedByName,
+		&row.ReviewComment,
+		&row.ReviewedAt,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.RuleChangeProposal{}, 0, err
+	}
+
+	proposal, err := mapRuleChangeProposal(row)
+	if err != nil {
+		return domain.RuleChangeProposal{}, 0, err
+	}
+
+	return proposal, total, nil
+}
+
+func mapRuleChangeProposal(row db.RuleChangeProposal) (domain.RuleChangeProposal, error) {
+	action, err := domain.ParseRuleChangeAction(string(row.Action))
+	if err != nil {
+		return domain.RuleChangeProposal{}, fmt.Errorf("parse rule change action: %w", err)
+	}
+
+	status, err := domain.ParseRuleChangeStatus(string(row.Status))
+	if err != nil {
+		return domain.RuleChangeProposal{}, fmt.Errorf("parse rule change status: %w", err)
+	}
+
+	current, err := unmarshalRuleSnapshot(row.CurrentRule)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	proposed, err := unmarshalRuleSnapshot(row.ProposedRule)
+	if err != nil {
+		return domain.RuleChangeProposal{}, err
+	}
+
+	return domain.RuleChangeProposal{
+		ID:             row.ID,
+		Action:         action,
+		Status:         status,
+		RuleID:         row.RuleID,
+		RuleName:       row.RuleName,
+		BaseUpdatedAt:  row.BaseUpdatedAt,
+		Current:        current,
+		Proposed:       proposed,
+		Changes:        domain.DiffRuleSnapshots(current, proposed),
+		ProposedBy:     row.ProposedBy,
+		ProposedByName: row.ProposedByName,
+		ReviewedBy:     row.ReviewedBy,
+		ReviewedByName: row.ReviewedByName,
+		ReviewComment:  row.ReviewComment,
+		ReviewedAt:     row.ReviewedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}, nil
+}
+
+func marshalRuleSnapshot(snapshot *domain.RuleSnapshot) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("encode rule snapshot: %w", err)
+	}
+
+	return raw, nil
+}
+
+func unmarshalRuleSnapshot(raw []byte) (*domain.RuleSnapshot, error) {
+	if len(raw) == 0 {
+		return nil, nil //nolint:nilnil // absent snapshots are expected for creates and deletes
+	}
+
+	var snapshot domain.RuleSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("decode rule snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/santa/model"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+func upsertRuleHits(ctx context.Context, queries *db.Queries, hits []db.UpsertRuleHitParams) error {
+	for _, hit := range hits {
+		if err := queries.UpsertRuleHit(ctx, hit); err != nil {
+			return fmt.Errorf("upsert rule hit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// planRuleHits folds a batch of execution events into one hit per rule type and identifier that
+// Santa reports having matched, ordered so concurrent ingests lock rows in the same order. Events
+// without an occurrence time count as hit at receivedAt. Identifiers without a rule are skipped
+// when the hits are written.
+func planRuleHits(
+	machineID uuid.UUID,
+	events []model.ExecutionEventWrite,
+	receivedAt time.Time,
+) ([]db.UpsertRuleHitParams, error) {
+	byRule := make(map[string]*db.UpsertRuleHitParams)
+
+	for _, event := range events {
+		ruleType, ok := event.Decision.RuleType()
+		if !ok {
+			continue
+		}
+
+		identity, err := eventExecutableIdentity(event.Executable)
+		if err != nil {
+			return nil, err
+		}
+		identifier := identity.Identifier(ruleType)
+		if identifier == "" {
+			continue
+		}
+
+		hitAt := receivedAt
+		if event.OccurredAt != nil {
+			hitAt = *event.OccurredAt
+		}
+
+		key := domain.MachineRuleTargetKey(domain.MachineRuleTarget{RuleType: ruleType, Identifier: identifier})
+		hit, ok := byRule[key]
+		if !ok {
+			hit = &db.UpsertRuleHitParams{
+				MachineID:  machineID,
+				FirstHitAt: hitAt,
+				LastHitAt:  hitAt,
+				RuleType:   db.RuleType(ruleType),
+				Identifier: identifier,
+			}
+			byRule[key] = hit
+		}
+
+		hit.HitCount++
+		if hitAt.Before(hit.FirstHitAt) {
+			hit.FirstHitAt = hitAt
+		}
+		if hitAt.After(hit.LastHitAt) {
+			hit.LastHitAt = hitAt
+		}
+	}
+
+	hits := make([]db.UpsertRuleHitParams, 0, len(byRule))
+	for _, hit := range byRule {
+		hits = append(hits, *hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].RuleType != hits[j].RuleType {
+			return hits[i].RuleType < hits[j].RuleType
+		}
+		return hits[i].Identifier < hits[j].Identifier
+	})
+
+	return hits, nil
+}
+
+func eventExecutableIdentity(executable model.ExecutableWrite) (domain.ExecutableIdentity, error) {
+	identity := domain.ExecutableIdentity{
+		FileSHA256: executable.FileSHA256,
+		CDHash:     executable.CDHash,
+		SigningID:  executable.SigningID,
+		TeamID:     executable.TeamID,
+	}
+
+	chain, err := unmarshalSigningChain(executable.SigningChain)
+	if err != nil {
+		return domain.ExecutableIdentity{}, err
+	}
+	if len(chain) > 0 {
+		identity.CertificateSHA256 = chain[0].SHA256
+	}
+
+	return identity, nil
+}
+
+// ruleHitStats returns the rule's total hits across machines and when it was last hit.
+func ruleHitStats(ctx context.Context, queries *db.Queries, ruleID uuid.UUID) (int64, *time.Time, error) {
+	row, err := queries.GetRuleHitStats(ctx, ruleID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("get rule hit stats: %w", err)
+	}
+
+	return row.HitCount, &row.LastHitAt, nil
+}
@@ -0,0 +1,63 @@
+package postgres //nolint:testpackage // exercises unexported rule hit planning.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/santa/model"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+func TestPlanRuleHitsAttributesDecisionsToMatchedIdentifiers(t *testing.T) {
+	machineID := uuid.New()
+	tool := model.ExecutableWrite{
+		FileSHA256:   "tool-sha",
+		TeamID:       "EQHXZ8M8AV",
+		SigningID:    "EQHXZ8M8AV:com.example.tool",
+		SigningChain: []byte(`[{"sha256":"leaf-sha"},{"sha256":"root-sha"}]`),
+	}
+
+	early := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+	receivedAt := early.Add(2 * time.Hour)
+
+	events := []model.ExecutionEventWrite{
+		{Executable: tool, Decision: domain.ExecutionDecisionAllowTeamID, OccurredAt: &late},
+		{Executable: tool, Decision: domain.ExecutionDecisionAllowTeamID, OccurredAt: &early},
+		{Executable: tool, Decision: domain.ExecutionDecisionBlockCertificate},
+		{Executable: tool, Decision: domain.ExecutionDecisionAllowUnknown},
+		// Without a CDHash there is no identifier a rule could have matched.
+		{Executable: tool, Decision: domain.ExecutionDecisionAllowCDHash},
+	}
+
+	got, err := planRuleHits(machineID, events, receivedAt)
+	if err != nil {
+		t.Fatalf("planRuleHits() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("planRuleHits() returned %d hits, want 2", len(got))
+	}
+
+	certificate := got[0]
+	if certificate.RuleType != db.RuleType(domain.RuleTypeCertificate) || certificate.Identifier != "leaf-sha" {
+		t.Fatalf("got[0] = %s %s, want certificate leaf-sha", certificate.RuleType, certificate.Identifier)
+	}
+	if certificate.HitCount != 1 || !certificate.LastHitAt.Equal(receivedAt) {
+		t.Fatalf("got[0] = %+v, want one hit at receivedAt", certificate)
+	}
+
+	teamID := got[1]
+	if teamID.RuleType != db.RuleType(domain.RuleTypeTeamID) || teamID.Identifier != "EQHXZ8M8AV" {
+		t.Fatalf("got[1] = %s %s, want team_id EQHXZ8M8AV", teamID.RuleType, teamID.Identifier)
+	}
+	if teamID.MachineID != machineID || teamID.HitCount != 2 {
+		t.Fatalf("got[1] = %+v, want two hits on the machine", teamID)
+	}
+	if !teamID.FirstHitAt.Equal(early) || !teamID.LastHitAt.Equal(late) {
+		t.Fatalf("got[1] hit window = %s..%s, want %s..%s", teamID.FirstHitAt, teamID.LastHitAt, early, late)
+	}
+}
@@ -33,6 +33,8 @@ var (
 		"id":             machineIDColumn,
 		"machine_id":     machineIDColumn,
 		"policy":         "wi.policy",
+		"hit_count":      "hit_count",
+		"last_hit_at":    "last_hit_at",
 		sortFieldApplied: sortFieldApplied,
 	}
 
@@ -149,6 +151,8 @@ func scanRuleMachineRow(rows pgx.Rows) (domain.RuleMachine, int32, error) {
 		&item.MachineID,
 		&policyText,
 		&item.Applied,
+		&item.HitCount,
+		&item.LastHitAt,
 		&total,
 	); err != nil {
 		return domain.RuleMachine{}, 0, err
@@ -166,27 +170,32 @@ func scanRuleMachineRow(rows pgx.Rows) (domain.RuleMachine, int32, error) {
 }
 
 const machineRuleListQuery = `
-WITH effective_groups AS (
+WITH direct_groups AS (
   SELECT gmm.group_id
   FROM group_machine_memberships AS gmm
   WHERE gmm.machine_id = $1
+    AND NOT gmm.pending
 
   UNION
 
   SELECT gum.group_id
   FROM machines AS m
-  JOIN users AS u
-    ON u.upn = NULLIF(m.primary_user, '')
   JOIN group_user_memberships AS gum
-    ON gum.user_id = u.id
+    ON gum.user_id = m.primary_user_id
+    AND NOT gum.pending
   WHERE m.id = $1
 ),
+effective_groups AS (
+  SELECT DISTINCT ga.ancestor_id AS group_id
+  FROM direct_groups AS dg
+  JOIN group_ancestors AS ga
+    ON ga.group_id = dg.group_id
+),
 machine_user AS (
-  SELECT u.id
+  SELECT m.primary_user_id AS id
   FROM machines AS m
-  JOIN users AS u
-    ON u.upn = NULLIF(m.primary_user, '')
   WHERE m.id = $1
+    AND m.primary_user_id IS NOT NULL
 ),
 matching_targets AS (
   SELECT
@@ -211,6 +220,18 @@ matching_targets AS (
         WHERE eg.group_id = rt.subject_id
       )
     )
+    OR (
+      rt.subject_kind = 'machine'
+      AND rt.subject_id = $1
+    )
+    OR (
+      rt.subject_kind = 'user'
+      AND EXISTS (
+        SELECT 1
+        FROM machine_user AS mu
+        WHERE mu.id = rt.subject_id
+      )
+    )
 ),
 matching_excludes AS (
   SELECT DISTINCT mt.rule_id
@@ -288,16 +309,15 @@ const ruleMachineListQuery = `
 WITH machine_users AS (
   SELECT
     m.id AS machine_id,
-    u.id AS user_id
+    m.primary_user_id AS user_id
   FROM machines AS m
-  LEFT JOIN users AS u
-    ON u.upn = NULLIF(m.primary_user, '')
 ),
-effective_groups AS (
+direct_groups AS (
   SELECT
     gmm.machine_id,
     gmm.group_id
   FROM group_machine_memberships AS gmm
+  WHERE NOT gmm.pending
 
   UNION
 
@@ -307,6 +327,15 @@ effective_groups AS (
   FROM machine_users AS mu
   JOIN group_user_memberships AS gum
     ON gum.user_id = mu.user_id
+    AND NOT gum.pending
+),
+effective_groups AS (
+  SELECT DISTINCT
+    dg.machine_id,
+    ga.ancestor_id AS group_id
+  FROM direct_groups AS dg
+  JOIN group_ancestors AS ga
+    ON ga.group_id = dg.group_id
 ),
 matching_targets AS (
   SELECT
@@ -336,6 +365,14 @@ matching_targets AS (
           AND eg.group_id = rt.subject_id
       )
     )
+    OR (
+      rt.subject_kind = 'machine'
+      AND rt.subject_id = m.id
+    )
+    OR (
+      rt.subject_kind = 'user'
+      AND rt.subject_id = mu.user_id
+    )
 ),
 matching_excludes AS (
   SELECT DISTINCT mt.machine_id
@@ -387,6 +424,8 @@ SELECT
         'hex'
       )
   ) AS applied,
+  COALESCE(rh.hit_count, 0)::INT8 AS hit_count,
+  rh.last_hit_at,
   COUNT(*) OVER()::INT4 AS total
 FROM winning_includes AS wi
 JOIN machines AS m
@@ -397,6 +436,9 @@ LEFT JOIN matching_excludes AS me
   ON me.machine_id = m.id
 LEFT JOIN machine_sync_states AS ms
   ON ms.machine_id = m.id
+LEFT JOIN rule_hits AS rh
+  ON rh.rule_id = r.id
+  AND rh.machine_id = m.id
 WHERE r.enabled = TRUE
   AND me.machine_id IS NULL
   AND (
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,6 +23,8 @@ var (
 		"rule_type":        "r.rule_type",
 		"identifier":       "r.identifier",
 		"enabled":          "r.enabled",
+		"hit_count":        "hit_count",
+		"last_hit_at":      "last_hit_at",
 		sortFieldCreatedAt: "r.created_at",
 		sortFieldUpdatedAt: "r.updated_at",
 	}
@@ -62,6 +65,18 @@ func (s *Store) ListRules(
 		where = append(where, fmt.Sprintf("r.rule_type = ANY($%d)", len(args)+1))
 		args = append(args, toStrings(opts.RuleTypes))
 	}
+	if opts.UnusedDays != nil {
+		// Santa only uploads blocked executions by default, so rules that allow never record
+		// hits and would always look unused.
+		where = append(where, fmt.Sprintf(
+			"r.created_at <= NOW() - make_interval(days => $%[1]d) "+
+				"AND (rh.last_hit_at IS NULL OR rh.last_hit_at <= NOW() - make_interval(days => $%[1]d)) "+
+				"AND EXISTS (SELECT 1 FROM rule_targets AS rt WHERE rt.rule_id = r.id "+
+				"AND rt.assignment = 'include' AND rt.policy IN ('blocklist', 'silent_blocklist'))",
+			len(args)+1,
+		))
+		args = append(args, *opts.UnusedDays)
+	}
 
 	limitArg := len(args) + 1
 	offsetArg := limitArg + 1
@@ -74,10 +89,19 @@ SELECT
   r.rule_type,
   r.identifier,
   r.enabled,
+  COALESCE(rh.hit_count, 0)::INT8 AS hit_count,
+  rh.last_hit_at,
   r.created_at,
   r.updated_at,
   COUNT(*) OVER()::INT4 AS total
 FROM rules AS r
+LEFT JOIN LATERAL (
+  SELECT
+    SUM(h.hit_count) AS hit_count,
+    MAX(h.last_hit_at) AS last_hit_at
+  FROM rule_hits AS h
+  WHERE h.rule_id = r.id
+) AS rh ON TRUE
 WHERE %s
 ORDER BY %s
 LIMIT NULLIF($%d::INT, 0)
@@ -107,6 +131,38 @@ func (s *Store) GetRule(ctx context.Context, id uuid.UUID) (domain.Rule, error)
 		return domain.Rule{}, err
 	}
 
+	rule, err := mapRule(row, targets)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+
+	rule.HitCount, rule.LastHitAt, err = ruleHitStats(ctx, queries, id)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// GetRuleByIdentifier returns the rule for a rule type and identifier, which are unique together.
+func (s *Store) GetRuleByIdentifier(
+	ctx context.Context,
+	ruleType domain.RuleType,
+	identifier string,
+) (domain.Rule, error) {
+	row, err := s.Queries().GetRuleByIdentifier(ctx, db.GetRuleByIdentifierParams{
+		RuleType:   db.RuleType(ruleType),
+		Identifier: identifier,
+	})
+	if err != nil {
+		return domain.Rule{}, err
+	}
+
+	targets, err := s.listRuleTargets(ctx, s.Queries(), row.ID)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+
 	return mapRule(row, targets)
 }
 
@@ -117,31 +173,13 @@ func (s *Store) CreateRule(ctx context.Context, input domain.RuleWriteInput) (do
 	}
 
 	return s.writeRule(ctx, id, input, func(q *db.Queries) (db.Rule, error) {
-		return q.CreateRule(ctx, db.CreateRuleParams{
-			ID:            id,
-			Name:          input.Name,
-			Description:   input.Description,
-			RuleType:      db.RuleType(input.RuleType),
-			Identifier:    input.Identifier,
-			CustomMessage: input.CustomMessage,
-			CustomURL:     input.CustomURL,
-			Enabled:       input.Enabled,
-		})
+		return q.CreateRule(ctx, createRuleParams(id, input))
 	})
 }
 
 func (s *Store) UpdateRule(ctx context.Context, id uuid.UUID, input domain.RuleWriteInput) (domain.Rule, error) {
 	return s.writeRule(ctx, id, input, func(q *db.Queries) (db.Rule, error) {
-		return q.UpdateRule(ctx, db.UpdateRuleParams{
-			ID:            id,
-			Name:          input.Name,
-			Description:   input.Description,
-			RuleType:      db.RuleType(input.RuleType),
-			Identifier:    input.Identifier,
-			CustomMessage: input.CustomMessage,
-			CustomURL:     input.CustomURL,
-			Enabled:       input.Enabled,
-		})
+		return q.UpdateRule(ctx, updateRuleParams(id, input))
 	})
 }
 
@@ -154,17 +192,33 @@ func (s *Store) ListResolvedMachineRules(
 	ctx context.Context,
 	machineID uuid.UUID,
 ) ([]domain.MachineResolvedRule, error) {
-	rows, err := s.Queries().ListResolvedRulesForMachine(ctx, machineID)
+	return resolveMachineRules(ctx, s.Queries(), machineID)
+}
+
+// ListResolvedRulesForMachines resolves the rules of several machines in one query, keyed by
+// machine.
+func (s *Store) ListResolvedRulesForMachines(
+	ctx context.Context,
+	machineIDs []uuid.UUID,
+) (map[uuid.UUID][]domain.MachineResolvedRule, error) {
+	return resolveMachinesRules(ctx, s.Queries(), machineIDs)
+}
+
+func resolveMachineRules(
+	ctx context.Context,
+	q *db.Queries,
+	machineID uuid.UUID,
+) ([]domain.MachineResolvedRule, error) {
+	rows, err := q.ListResolvedRulesForMachines(ctx, []uuid.UUID{machineID})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("list resolved rules for machine %s: %w", machineID, err)
 	}
 
 	rules := make([]domain.MachineResolvedRule, 0, len(rows))
-	var rule domain.MachineResolvedRule
 	for _, row := range rows {
-		rule, err = mapResolvedMachineRule(row)
-		if err != nil {
-			return nil, err
+		rule, mapErr := mapResolvedMachineRule(row)
+		if mapErr != nil {
+			return nil, mapErr
 		}
 		rules = append(rules, rule)
 	}
@@ -172,6 +226,30 @@ func (s *Store) ListResolvedMachineRules(
 	return rules, nil
 }
 
+// resolveMachinesRules resolves the rules of several machines in one query, keyed by machine.
+// Machines without any resolved rules are absent from the result.
+func resolveMachinesRules(
+	ctx context.Context,
+	q *db.Queries,
+	machineIDs []uuid.UUID,
+) (map[uuid.UUID][]domain.MachineResolvedRule, error) {
+	rows, err := q.ListResolvedRulesForMachines(ctx, machineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list resolved rules for machines: %w", err)
+	}
+
+	rules := make(map[uuid.UUID][]domain.MachineResolvedRule, len(machineIDs))
+	for _, row := range rows {
+		rule, mapErr := mapResolvedMachineRule(row)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		rules[row.MachineID] = append(rules[row.MachineID], rule)
+	}
+
+	return rules, nil
+}
+
 func (s *Store) writeRule(
 	ctx context.Context,
 	ruleID uuid.UUID,
@@ -179,8 +257,10 @@ func (s *Store) writeRule(
 	write func(*db.Queries) (db.Rule, error),
 ) (domain.Rule, error) {
 	var (
-		row     db.Rule
-		targets domain.RuleTargets
+		row       db.Rule
+		targets   domain.RuleTargets
+		hitCount  int64
+		lastHitAt *time.Time
 	)
 
 	if err := s.RunInTx(ctx, func(q *db.Queries) error {
@@ -200,18 +280,54 @@ func (s *Store) writeRule(
 			return err
 		}
 
-		return nil
+		hitCount, lastHitAt, err = ruleHitStats(ctx, q, ruleID)
+		return err
 	}); err != nil {
 		return domain.Rule{}, err
 	}
 
-	return mapRule(row, targets)
+	rule, err := mapRule(row, targets)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	rule.HitCount = hitCount
+	rule.LastHitAt = lastHitAt
+
+	return rule, nil
+}
+
+func createRuleParams(id uuid.UUID, input domain.RuleWriteInput) db.CreateRuleParams {
+	return db.CreateRuleParams{
+		ID:            id,
+		Name:          input.Name,
+		Description:   input.Description,
+		RuleType:      db.RuleType(input.RuleType),
+		Identifier:    input.Identifier,
+		CustomMessage: input.CustomMessage,
+		CustomURL:     input.CustomURL,
+		Enabled:       input.Enabled,
+	}
+}
+
+func updateRuleParams(id uuid.UUID, input domain.RuleWriteInput) db.UpdateRuleParams {
+	return db.UpdateRuleParams{
+		ID:            id,
+		Name:          input.Name,
+		Description:   input.Description,
+		RuleType:      db.RuleType(input.RuleType),
+		Identifier:    input.Identifier,
+		CustomMessage: input.CustomMessage,
+		CustomURL:     input.CustomURL,
+		Enabled:       input.Enabled,
+	}
 }
 
 func scanRuleSummaryRow(rows pgx.Rows) (domain.RuleSummary, int32, error) {
 	var (
-		row   db.Rule
-		total int32
+		row       db.Rule
+		hitCount  int64
+		lastHitAt *time.Time
+		total     int32
 	)
 
 	if err := rows.Scan(
@@ -221,6 +337,8 @@ func scanRuleSummaryRow(rows pgx.Rows) (domain.RuleSummary, int32, error) {
 		&row.RuleType,
 		&row.Identifier,
 		&row.Enabled,
+		&hitCount,
+		&lastHitAt,
 		&row.CreatedAt,
 		&row.UpdatedAt,
 		&total,
@@ -232,6 +350,8 @@ func scanRuleSummaryRow(rows pgx.Rows) (domain.RuleSummary, int32, error) {
 	if err != nil {
 		return domain.RuleSummary{}, 0, err
 	}
+	rule.HitCount = hitCount
+	rule.LastHitAt = lastHitAt
 
 	return rule, total, nil
 }
@@ -275,7 +395,7 @@ func mapRule(row db.Rule, targets domain.RuleTargets) (domain.Rule, error) {
 	}, nil
 }
 
-func mapResolvedMachineRule(row db.ListResolvedRulesForMachineRow) (domain.MachineResolvedRule, error) {
+func mapResolvedMachineRule(row db.ListResolvedRulesForMachinesRow) (domain.MachineResolvedRule, error) {
 	ruleType, err := domain.ParseRuleType(string(row.RuleType))
 	if err != nil {
 		return domain.MachineResolvedRule{}, fmt.Errorf("parse rule type: %w", err)
@@ -312,7 +432,7 @@ func (s *Store) listRuleTargets(
 
 	targets := domain.RuleTargets{
 		Include: make([]domain.IncludeRuleTarget, 0, len(rows)),
-		Exclude: make([]domain.ExcludedGroup, 0, len(rows)),
+		Exclude: make([]domain.ExcludeRuleTarget, 0, len(rows)),
 	}
 
 	for _, row := range rows {
@@ -360,7 +480,7 @@ func (s *Store) replaceRuleTargets(
 		}
 	}
 
-	for _, group := range targets.Exclude {
+	for _, target := range targets.Exclude {
 		targetID, err := uuid.NewV7()
 		if err != nil {
 			return fmt.Errorf("create exclude target id: %w", err)
@@ -369,8 +489,8 @@ func (s *Store) replaceRuleTargets(
 		if err = queries.CreateRuleTarget(ctx, db.CreateRuleTargetParams{
 			ID:            targetID,
 			RuleID:        ruleID,
-			SubjectKind:   db.RuleTargetSubjectKind(domain.RuleTargetSubjectKindGroup),
-			SubjectID:     &group.GroupID,
+			SubjectKind:   db.RuleTargetSubjectKind(target.SubjectKind),
+			SubjectID:     &target.SubjectID,
 			Assignment:    db.RuleTargetAssignment(domain.RuleTargetAssignmentExclude),
 			Priority:      pgtype.Int4{},
 			Policy:        db.NullRulePolicy{},
@@ -415,9 +535,14 @@ func appendRuleTarget(targets *domain.RuleTargets, row db.ListRuleTargetsByRuleR
 		})
 
 	case domain.RuleTargetAssignmentExclude:
-		targets.Exclude = append(targets.Exclude, domain.ExcludedGroup{
-			GroupID:   *row.SubjectID,
-			GroupName: row.SubjectName,
+		if row.SubjectID == nil {
+			return errors.New("exclude rule target missing subject")
+		}
+
+		targets.Exclude = append(targets.Exclude, domain.ExcludeRuleTarget{
+			SubjectKind: subjectKind,
+			SubjectID:   *row.SubjectID,
+			SubjectName: row.SubjectName,
 		})
 
 	default:
@@ -0,0 +1,388 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	serviceAccountListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":                 "sa.id",
+		"name":               "sa.name",
+		"description":        "sa.description",
+		"active_token_count": "active_token_count",
+		sortFieldCreatedAt:   "sa.created_at",
+		sortFieldUpdatedAt:   "sa.updated_at",
+	}
+
+	serviceAccountListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"sa.name ASC",
+		"sa.id ASC",
+	}
+
+	apiTokenListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":                   "t.id",
+		"name":                 "t.name",
+		"service_account_name": "sa.name",
+		"expires_at":           "t.expires_at",
+		"last_used_at":         "t.last_used_at",
+		"revoked_at":           "t.revoked_at",
+		sortFieldCreatedAt:     "t.created_at",
+	}
+
+	apiTokenListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"t.created_at DESC",
+		"t.id DESC",
+	}
+)
+
+func (s *Store) ListServiceAccounts( //nolint:dupl // structurally similar to other List* functions by design
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.ServiceAccount, int32, error) {
+	orderBy, err := orderBy(opts.Sort, opts.Order, serviceAccountListSortColumns, serviceAccountListDefaultOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"($1 = '' OR sa.name ILIKE $1 OR sa.description ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("sa.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  sa.id,
+  sa.name,
+  sa.description,
+  COALESCE(token_counts.active_token_count, 0)::INT4 AS active_token_count,
+  sa.created_at,
+  sa.updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM service_accounts AS sa
+LEFT JOIN (
+  SELECT
+    service_account_id,
+    COUNT(*)::INT4 AS active_token_count
+  FROM api_tokens
+  WHERE revoked_at IS NULL
+  GROUP BY service_account_id
+) AS token_counts
+  ON token_counts.service_account_id = sa.id
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list service accounts: %w", err)
+	}
+
+	return collectRows(rows, scanServiceAccountRow)
+}
+
+func (s *Store) GetServiceAccount(ctx context.Context, id uuid.UUID) (domain.ServiceAccount, error) {
+	row, err := s.Queries().GetServiceAccount(ctx, id)
+	if err != nil {
+		return domain.ServiceAccount{}, err
+	}
+
+	return mapServiceAccount(row), nil
+}
+
+func (s *Store) CreateServiceAccount(
+	ctx context.Context,
+	name string,
+	description string,
+) (domain.ServiceAccount, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.ServiceAccount{}, fmt.Errorf("create service account id: %w", err)
+	}
+
+	if _, err = s.Queries().CreateServiceAccount(ctx, db.CreateServiceAccountParams{
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}); err != nil {
+		return domain.ServiceAccount{}, err
+	}
+
+	return s.GetServiceAccount(ctx, id)
+}
+
+func (s *Store) UpdateServiceAccount(
+	ctx context.Context,
+	id uuid.UUID,
+	name string,
+	description string,
+) (domain.ServiceAccount, error) {
+	n, err := s.Queries().UpdateServiceAccount(ctx, db.UpdateServiceAccountParams{
+		ID:          id,
+		Name:        name,
+		Description: description,
+	})
+	if err != nil {
+		return domain.ServiceAccount{}, err
+	}
+	if n == 0 {
+		return domain.ServiceAccount{}, pgx.ErrNoRows
+	}
+
+	return s.GetServiceAccount(ctx, id)
+}
+
+func (s *Store) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	n, err := s.Queries().DeleteServiceAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (s *Store) ListAPITokens(
+	ctx context.Context,
+	opts domain.APITokenListOptions,
+) ([]domain.APIToken, int32, error) {
+	orderBy, err := orderBy(opts.Sort, opts.Order, apiTokenListSortColumns, apiTokenListDefaultOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"($1 = '' OR t.name ILIKE $1 OR t.token_prefix ILIKE $1 OR sa.name ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("t.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+	if opts.ServiceAccountID != nil {
+		where = append(where, fmt.Sprintf("t.service_account_id = $%d", len(args)+1))
+		args = append(args, *opts.ServiceAccountID)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  t.id,
+  t.service_account_id,
+  sa.name AS service_account_name,
+  t.name,
+  t.token_prefix,
+  t.scopes,
+  t.expires_at,
+  t.last_used_at,
+  t.revoked_at,
+  t.created_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM api_tokens AS t
+JOIN service_accounts AS sa
+  ON sa.id = t.service_account_id
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list api tokens: %w", err)
+	}
+
+	return collectRows(rows, scanAPITokenRow)
+}
+
+func (s *Store) GetAPIToken(ctx context.Context, id uuid.UUID) (domain.APIToken, error) {
+	row, err := s.Queries().GetAPIToken(ctx, id)
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+
+	return mapAPIToken(row)
+}
+
+func (s *Store) CreateAPIToken(
+	ctx context.Context,
+	serviceAccountID uuid.UUID,
+	name string,
+	prefix string,
+	hash string,
+	scopes []domain.Permission,
+	expiresAt *time.Time,
+) (domain.APIToken, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("create api token id: %w", err)
+	}
+
+	if _, err = s.Queries().CreateAPIToken(ctx, db.CreateAPITokenParams{
+		ID:               id,
+		ServiceAccountID: serviceAccountID,
+		Name:             name,
+		TokenPrefix:      prefix,
+		TokenHash:        hash,
+		Scopes:           toStrings(scopes),
+		ExpiresAt:        expiresAt,
+	}); err != nil {
+		return domain.APIToken{}, err
+	}
+
+	return s.GetAPIToken(ctx, id)
+}
+
+func (s *Store) RevokeAPIToken(ctx context.Context, id uuid.UUID) (domain.APIToken, error) {
+	n, err := s.Queries().RevokeAPIToken(ctx, id)
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+	if n == 0 {
+		return domain.APIToken{}, pgx.ErrNoRows
+	}
+
+	return s.GetAPIToken(ctx, id)
+}
+
+// AuthenticateAPIToken resolves an active token by hash and records its use.
+func (s *Store) AuthenticateAPIToken(ctx context.Context, hash string) (domain.APITokenPrincipal, error) {
+	row, err := s.Queries().AuthenticateAPIToken(ctx, hash)
+	if err != nil {
+		return domain.APITokenPrincipal{}, err
+	}
+
+	scopes, err := parsePermissions(row.Scopes)
+	if err != nil {
+		return domain.APITokenPrincipal{}, err
+	}
+
+	return domain.APITokenPrincipal{
+		TokenID:            row.ID,
+		ServiceAccountID:   row.ServiceAccountID,
+		ServiceAccountName: row.ServiceAccountName,
+		Scopes:             scopes,
+	}, nil
+}
+
+func scanServiceAccountRow(rows pgx.Rows) (domain.ServiceAccount, int32, error) {
+	var (
+		row   db.GetServiceAccountRow
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.Name,
+		&row.Description,
+		&row.ActiveTokenCount,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.ServiceAccount{}, 0, err
+	}
+
+	return mapServiceAccount(row), total, nil
+}
+
+func scanAPITokenRow(rows pgx.Rows) (domain.APIToken, int32, error) {
+	var (
+		row   db.GetAPITokenRow
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.ServiceAccountID,
+		&row.ServiceAccountName,
+		&row.Name,
+		&row.TokenPrefix,
+		&row.Scopes,
+		&row.ExpiresAt,
+		&row.LastUsedAt,
+		&row.RevokedAt,
+		&row.CreatedAt,
+		&total,
+	); err != nil {
+		return domain.APIToken{}, 0, err
+	}
+
+	token, err := mapAPIToken(row)
+	if err != nil {
+		return domain.APIToken{}, 0, err
+	}
+
+	return token, total, nil
+}
+
+func mapServiceAccount(row db.GetServiceAccountRow) domain.ServiceAccount {
+	return domain.ServiceAccount{
+		ID:               row.ID,
+		Name:             row.Name,
+		Description:      row.Description,
+		ActiveTokenCount: row.ActiveTokenCount,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}
+}
+
+func mapAPIToken(row db.GetAPITokenRow) (domain.APIToken, error) {
+	scopes, err := parsePermissions(row.Scopes)
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+
+	return domain.APIToken{
+		ID:                 row.ID,
+		ServiceAccountID:   row.ServiceAccountID,
+		ServiceAccountName: row.ServiceAccountName,
+		Name:               row.Name,
+		Prefix:             row.TokenPrefix,
+		Scopes:             scopes,
+		ExpiresAt:          row.ExpiresAt,
+		LastUsedAt:         row.LastUsedAt,
+		RevokedAt:          row.RevokedAt,
+		CreatedAt:          row.CreatedAt,
+	}, nil
+}
+
+func parsePermissions(values []string) ([]domain.Permission, error) {
+	permissions := make([]domain.Permission, 0, len(values))
+	for _, value := range values {
+		permission, err := domain.ParsePermission(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse api token scope: %w", err)
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
@@ -69,7 +69,17 @@ func (s *Store) Pool() *pgxpool.Pool {
 
 // RunInTx executes the given function in a database transaction.
 func (s *Store) RunInTx(ctx context.Context, fn func(*db.Queries) error) error {
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	return s.runInTx(ctx, pgx.TxOptions{}, fn)
+}
+
+// runInReadSnapshot executes fn in a read-only repeatable-read transaction, so every query it runs
+// sees the same snapshot.
+func (s *Store) runInReadSnapshot(ctx context.Context, fn func(*db.Queries) error) error {
+	return s.runInTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, fn)
+}
+
+func (s *Store) runInTx(ctx context.Context, options pgx.TxOptions, fn func(*db.Queries) error) error {
+	tx, err := s.pool.BeginTx(ctx, options)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -89,6 +99,24 @@ func (s *Store) RunInTx(ctx context.Context, fn func(*db.Queries) error) error {
 	return nil
 }
 
+// runInRolledBackTx executes fn in a transaction that is always rolled back, for dry runs.
+func (s *Store) runInRolledBackTx(ctx context.Context, fn func(*db.Queries) error) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	runErr := fn(db.New(tx))
+	if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+		if runErr != nil {
+			return fmt.Errorf("run transaction: %w (rollback: %w)", runErr, rollbackErr)
+		}
+		return fmt.Errorf("rollback transaction: %w", rollbackErr)
+	}
+
+	return runErr
+}
+
 // ConnectionString builds a canonical Postgres URI from environment configuration.
 func ConnectionString(cfg config.DatabaseConfig) string {
 	query := url.Values{}
@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	unblockRequestListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"id":               "ur.id",
+		"file_name":        "file_name",
+		"machine_hostname": "m.hostname",
+		"requester_upn":    "ur.requester_upn",
+		"status":           "ur.status",
+		"reviewed_at":      "ur.reviewed_at",
+		sortFieldCreatedAt: "ur.created_at",
+		sortFieldUpdatedAt: "ur.updated_at",
+	}
+
+	unblockRequestListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"ur.created_at DESC",
+		"ur.id DESC",
+	}
+)
+
+//nolint:dupl // structurally similar to other List* functions by design
+func (s *Store) ListUnblockRequests(
+	ctx context.Context,
+	opts domain.UnblockRequestListOptions,
+) ([]domain.UnblockRequest, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		unblockRequestListSortColumns,
+		unblockRequestListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		`($1 = ''
+      OR ur.file_sha256 ILIKE $1
+      OR e.file_name ILIKE $1
+      OR m.hostname ILIKE $1
+      OR ur.requester_upn ILIKE $1
+      OR ur.requester_name ILIKE $1
+      OR ur.reason ILIKE $1)`,
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("ur.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+	if opts.MachineID != nil {
+		where = append(where, fmt.Sprintf("ur.machine_id = $%d", len(args)+1))
+		args = append(args, *opts.MachineID)
+	}
+	if opts.UserID != nil {
+		where = append(where, fmt.Sprintf("ur.user_id = $%d", len(args)+1))
+		args = append(args, *opts.UserID)
+	}
+	if opts.RequesterUPN != "" {
+		where = append(where, fmt.Sprintf("lower(ur.requester_upn) = lower($%d)", len(args)+1))
+		args = append(args, opts.RequesterUPN)
+	}
+	if len(opts.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("ur.status::text = ANY($%d)", len(args)+1))
+		args = append(args, toStrings(opts.Statuses))
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  ur.id,
+  ur.file_sha256,
+  ur.machine_id,
+  m.hostname AS machine_hostname,
+  ur.executable_id,
+  COALESCE(e.file_name, '')::TEXT AS file_name,
+  ur.execution_event_id,
+  ur.user_id,
+  ur.requester_upn,
+  ur.requester_name,
+  ur.reason,
+  ur.status,
+  ur.scope,
+  ur.group_id,
+  ur.rule_id,
+  ur.rule_change_proposal_id,
+  ur.reviewed_by,
+  ur.reviewed_by_name,
+  ur.review_comment,
+  ur.reviewed_at,
+  ur.created_at,
+  ur.updated_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM unblock_requests AS ur
+JOIN machines AS m ON m.id = ur.machine_id
+LEFT JOIN executables AS e ON e.id = ur.executable_id
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list unblock requests: %w", err)
+	}
+
+	return collectRows(rows, scanUnblockRequestRow)
+}
+
+func (s *Store) GetUnblockRequest(ctx context.Context, id uuid.UUID) (domain.UnblockRequest, error) {
+	row, err := s.Queries().GetUnblockRequest(ctx, id)
+	if err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	return mapUnblockRequest(row)
+}
+
+// GetUnblockTarget finds the execution an unblock request refers to: the latest event for the
+// file hash on the machine, preferring blocked executions. It is only found for the machine's
+// primary user or a user linked to one of those events as executing or logged in, and returns
+// pgx.ErrNoRows for anyone else.
+func (s *Store) GetUnblockTarget(
+	ctx context.Context,
+	fileSHA256 string,
+	machineID uuid.UUID,
+	requesterUPN string,
+) (domain.UnblockTarget, error) {
+	isRequester, err := s.Queries().IsUnblockTargetRequester(ctx, db.IsUnblockTargetRequesterParams{
+		RequesterUpn: requesterUPN,
+		MachineID:    machineID,
+		FileSHA256:   fileSHA256,
+	})
+	if err != nil {
+		return domain.UnblockTarget{}, fmt.Errorf("check unblock requester: %w", err)
+	}
+	if !isRequester {
+		return domain.UnblockTarget{}, pgx.ErrNoRows
+	}
+
+	row, err := s.Queries().GetUnblockTarget(ctx, db.GetUnblockTargetParams{
+		MachineID:  machineID,
+		FileSHA256: fileSHA256,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		machine, machineErr := s.GetMachine(ctx, machineID)
+		if machineErr != nil {
+			return domain.UnblockTarget{}, machineErr
+		}
+		return domain.UnblockTarget{
+			FileSHA256:      fileSHA256,
+			MachineID:       machine.ID,
+			MachineHostname: machine.Hostname,
+		}, nil
+	}
+	if err != nil {
+		return domain.UnblockTarget{}, err
+	}
+
+	decision, err := domain.ParseExecutionDecision(string(row.Decision))
+	if err != nil {
+		return domain.UnblockTarget{}, fmt.Errorf("parse event decision: %w", err)
+	}
+
+	return domain.UnblockTarget{
+		FileSHA256:       fileSHA256,
+		FileName:         row.FileName,
+		MachineID:        row.MachineID,
+		MachineHostname:  row.MachineHostname,
+		ExecutableID:     &row.ExecutableID,
+		ExecutionEventID: &row.ExecutionEventID,
+		Decision:         decision,
+		OccurredAt:       row.OccurredAt,
+	}, nil
+}
+
+func (s *Store) CreateUnblockRequest(
+	ctx context.Context,
+	request domain.UnblockRequest,
+) (domain.UnblockRequest, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.UnblockRequest{}, fmt.Errorf("create unblock request id: %w", err)
+	}
+
+	if _, err = s.Queries().CreateUnblockRequest(ctx, db.CreateUnblockRequestParams{
+		ID:               id,
+		FileSHA256:       request.FileSHA256,
+		MachineID:        request.MachineID,
+		ExecutableID:     request.ExecutableID,
+		ExecutionEventID: request.ExecutionEventID,
+		RequesterUpn:     request.RequesterUPN,
+		RequesterName:    request.RequesterName,
+		Reason:           request.Reason,
+	}); err != nil {
+		return domain.UnblockRequest{}, err
+	}
+
+	return s.GetUnblockRequest(ctx, id)
+}
+
+// ReviewUnblockRequest records a decision on a pending request. It returns false when the request
+// was no longer pending, so concurrent reviewers cannot both apply it.
+func (s *Store) ReviewUnblockRequest(
+	ctx context.Context,
+	request domain.UnblockRequest,
+	reviewer domain.Actor,
+) (bool, error) {
+	return reviewUnblockRequest(ctx, s.Queries(), request, reviewer)
+}
+
+func reviewUnblockRequest(
+	ctx context.Context,
+	q *db.Queries,
+	request domain.UnblockRequest,
+	reviewer domain.Actor,
+) (bool, error) {
+	scope := db.NullUnblockScope{}
+	if request.Scope != nil {
+		scope = db.NullUnblockScope{UnblockScope: db.UnblockScope(*request.Scope), Valid: true}
+	}
+
+	n, err := q.ReviewUnblockRequest(ctx, db.ReviewUnblockRequestParams{
+		ID:             request.ID,
+		Status:         db.UnblockRequestStatus(request.Status),
+		Scope:          scope,
+		GroupID:        request.GroupID,
+		ReviewedBy:     reviewer.ID,
+		ReviewedByName: reviewer.Name,
+		ReviewComment:  request.ReviewComment,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (s *Store) ReopenUnblockRequest(ctx context.Context, id uuid.UUID) error {
+	n, err := s.Queries().ReopenUnblockRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetUnblockRequestOutcome links an approved request to the rule it changed, or to the rule
+// change proposal raised for it when rule changes require approval.
+func (s *Store) SetUnblockRequestOutcome(
+	ctx context.Context,
+	id uuid.UUID,
+	ruleID *uuid.UUID,
+	proposalID *uuid.UUID,
+) error {
+	n, err := s.Queries().SetUnblockRequestOutcome(ctx, db.SetUnblockRequestOutcomeParams{
+		ID:                   id,
+		RuleID:               ruleID,
+		RuleChangeProposalID: proposalID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanUnblockRequestRow(rows pgx.Rows) (domain.UnblockRequest, int32, error) {
+	var (
+		row   db.GetUnblockRequestRow
+		total int32
+	)
+
+	if err := rows.Scan(
+		&row.ID,
+		&row.FileSHA256,
+		&row.MachineID,
+		&row.MachineHostname,
+		&row.ExecutableID,
+		&row.FileName,
+		&row.ExecutionEventID,
+		&row.UserID,
+		&row.RequesterUpn,
+		&row.RequesterName,
+		&row.Reason,
+		&row.Status,
+		&row.Scope,
+		&row.GroupID,
+		&row.RuleID,
+		&row.RuleChangeProposalID,
+		&row.ReviewedBy,
+		&row.ReviewedByName,
+		&row.ReviewComment,
+		&row.ReviewedAt,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&total,
+	); err != nil {
+		return domain.UnblockRequest{}, 0, err
+	}
+
+	request, err := mapUnblockRequest(row)
+	if err != nil {
+		return domain.UnblockRequest{}, 0, err
+	}
+
+	return request, total, nil
+}
+
+func mapUnblockRequest(row db.GetUnblockRequestRow) (domain.UnblockRequest, error) {
+	status, err := domain.ParseUnblockRequestStatus(string(row.Status))
+	if err != nil {
+		return domain.UnblockRequest{}, fmt.Errorf("parse unblock request status: %w", err)
+	}
+
+	var scope *domain.UnblockScope
+	if row.Scope.Valid {
+		parsed, parseErr := domain.ParseUnblockScope(string(row.Scope.UnblockScope))
+		if parseErr != nil {
+			return domain.UnblockRequest{}, fmt.Errorf("parse unblock scope: %w", parseErr)
+		}
+		scope = &parsed
+	}
+
+	return domain.UnblockRequest{
+		ID:                   row.ID,
+		FileSHA256:           row.FileSHA256,
+		FileName:             row.FileName,
+		MachineID:            row.MachineID,
+		MachineHostname:      row.MachineHostname,
+		ExecutableID:         row.ExecutableID,
+		ExecutionEventID:     row.ExecutionEventID,
+		UserID:               row.UserID,
+		RequesterUPN:         row.RequesterUpn,
+		RequesterName:        row.RequesterName,
+		Reason:               row.Reason,
+		Status:               status,
+		Scope:                scope,
+		GroupID:              row.GroupID,
+		RuleID:               row.RuleID,
+		RuleChangeProposalID: row.RuleChangeProposalID,
+		ReviewedBy:           row.ReviewedBy,
+		ReviewedByName:       row.ReviewedByName,
+		ReviewComment:        row.ReviewComment,
+		ReviewedAt:           row.ReviewedAt,
+		CreatedAt:            row.CreatedAt,
+		UpdatedAt:            row.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+const userActivityTopExecutables = 10
+
+// GetUserActivity summarizes the execution events linked to a user as their executing user. Its
+// queries share one read snapshot, so the totals, breakdowns and top lists agree with each other.
+func (s *Store) GetUserActivity(ctx context.Context, userID uuid.UUID) (domain.UserActivity, error) {
+	activity := domain.UserActivity{UserID: userID}
+
+	err := s.runInReadSnapshot(ctx, func(q *db.Queries) error {
+		if _, err := q.GetUser(ctx, userID); err != nil {
+			return err
+		}
+
+		counts, err := q.GetUserActivityCounts(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user activity counts: %w", err)
+		}
+		activity.EventCount = counts.EventCount
+		activity.AllowedCount = counts.AllowedCount
+		activity.BlockedCount = counts.BlockedCount
+
+		decisionRows, err := q.ListUserActivityDecisionCounts(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list user activity decisions: %w", err)
+		}
+
+		activity.Decisions = make([]domain.ExecutionDecisionCount, 0, len(decisionRows))
+		for _, row := range decisionRows {
+			decision, parseErr := domain.ParseExecutionDecision(string(row.Decision))
+			if parseErr != nil {
+				return fmt.Errorf("parse execution event decision: %w", parseErr)
+			}
+			activity.Decisions = append(
+				activity.Decisions,
+				domain.ExecutionDecisionCount{Decision: decision, Count: row.Count},
+			)
+		}
+
+		machineRows, err := q.ListUserActivityMachines(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list user activity machines: %w", err)
+		}
+
+		activity.Machines = make([]domain.UserMachineActivity, 0, len(machineRows))
+		for _, row := range machineRows {
+			activity.Machines = append(activity.Machines, domain.UserMachineActivity{
+				MachineID:    row.MachineID,
+				Hostname:     row.Hostname,
+				EventCount:   row.EventCount,
+				BlockedCount: row.BlockedCount,
+				LastEventAt:  row.LastEventAt,
+			})
+		}
+
+		if activity.TopExecutables, err = listUserActivityExecutables(ctx, q, userID, false); err != nil {
+			return err
+		}
+
+		activity.TopBlockedExecutables, err = listUserActivityExecutables(ctx, q, userID, true)
+		return err
+	})
+	if err != nil {
+		return domain.UserActivity{}, err
+	}
+
+	return activity, nil
+}
+
+func listUserActivityExecutables(
+	ctx context.Context,
+	q *db.Queries,
+	userID uuid.UUID,
+	blockedOnly bool,
+) ([]domain.UserExecutableActivity, error) {
+	rows, err := q.ListUserActivityExecutables(ctx, db.ListUserActivityExecutablesParams{
+		UserID:      userID,
+		BlockedOnly: blockedOnly,
+		LimitCount:  userActivityTopExecutables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user activity executables: %w", err)
+	}
+
+	executables := make([]domain.UserExecutableActivity, 0, len(rows))
+	for _, row := range rows {
+		executables = append(executables, domain.UserExecutableActivity{
+			ExecutableID: row.ExecutableID,
+			FileName:     row.FileName,
+			SigningID:    row.SigningID,
+			TeamID:       row.TeamID,
+			MachineCount: row.MachineCount,
+			EventCount:   row.EventCount,
+			BlockedCount: row.BlockedCount,
+			LastEventAt:  row.LastEventAt,
+		})
+	}
+
+	return executables, nil
+}
@@ -0,0 +1,265 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	graphsync "github.com/woodleighschool/go-entrasync"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	"github.com/woodleighschool/grinch/internal/store/db"
+)
+
+var (
+	unresolvedPrimaryUserListSortColumns = map[string]string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"hostname":      "m.hostname",
+		"serial_number": "m.serial_number",
+		"primary_user":  "m.primary_user",
+		"last_seen_at":  "m.last_seen_at",
+	}
+
+	unresolvedPrimaryUserListDefaultOrder = []string{ //nolint:gochecknoglobals // package-level lookup table, not mutable state
+		"m.last_seen_at DESC",
+		"m.id ASC",
+	}
+)
+
+func (s *Store) ListUserAliases(ctx context.Context, userID uuid.UUID) ([]domain.UserAlias, int32, error) {
+	rows, err := s.Queries().ListUserAliases(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int32
+	aliases := make([]domain.UserAlias, 0, len(rows))
+	for _, row := range rows {
+		aliases = append(aliases, mapUserAlias(db.UserAlias{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			Alias:     row.Alias,
+			Source:    row.Source,
+			CreatedAt: row.CreatedAt,
+		}))
+		total = row.Total
+	}
+
+	return aliases, total, nil
+}
+
+func (s *Store) GetUserAlias(ctx context.Context, userID, id uuid.UUID) (domain.UserAlias, error) {
+	row, err := s.Queries().GetUserAlias(ctx, db.GetUserAliasParams{ID: id, UserID: userID})
+	if err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	return mapUserAlias(row), nil
+}
+
+// CreateUserAlias adds a local alias. alias must already be normalized.
+func (s *Store) CreateUserAlias(ctx context.Context, userID uuid.UUID, alias string) (domain.UserAlias, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return domain.UserAlias{}, fmt.Errorf("create user alias id: %w", err)
+	}
+
+	row, err := s.Queries().CreateUserAlias(ctx, db.CreateUserAliasParams{
+		ID:     id,
+		UserID: userID,
+		Alias:  alias,
+		Source: db.PrincipalSource(domain.PrincipalSourceLocal),
+	})
+	if err != nil {
+		return domain.UserAlias{}, err
+	}
+
+	return mapUserAlias(row), nil
+}
+
+func (s *Store) DeleteUserAlias(ctx context.Context, userID, id uuid.UUID) error {
+	n, err := s.Queries().DeleteUserAlias(ctx, db.DeleteUserAliasParams{ID: id, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListMachinePrimaryUsers returns the primary user each machine last reported, keyed by machine.
+func (s *Store) ListMachinePrimaryUsers(ctx context.Context) (map[uuid.UUID]string, error) {
+	rows, err := s.Queries().ListMachinePrimaryUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list machine primary users: %w", err)
+	}
+
+	primaryUsers := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		primaryUsers[row.ID] = row.PrimaryUser
+	}
+
+	return primaryUsers, nil
+}
+
+// ResolveMachinePrimaryUsers matches each machine in lookups to a user by the names its reported
+// primary user may stand for, and returns the machines whose resolved user changed. A machine with
+// no lookups is left without a primary user.
+func (s *Store) ResolveMachinePrimaryUsers(
+	ctx context.Context,
+	lookups map[uuid.UUID][]string,
+) ([]uuid.UUID, error) {
+	return resolveMachinePrimaryUsers(ctx, s.Queries(), lookups)
+}
+
+func resolveMachinePrimaryUsers(
+	ctx context.Context,
+	queries *db.Queries,
+	lookups map[uuid.UUID][]string,
+) ([]uuid.UUID, error) {
+	if len(lookups) == 0 {
+		return nil, nil
+	}
+
+	params := db.ResolveMachinePrimaryUsersParams{
+		MachineIds: make([]uuid.UUID, 0, len(lookups)),
+	}
+	for machineID, names := range lookups {
+		params.MachineIds = append(params.MachineIds, machineID)
+		for _, name := range names {
+			params.CandidateMachineIds = append(params.CandidateMachineIds, machineID)
+			params.CandidateLookups = append(params.CandidateLookups, name)
+		}
+	}
+
+	changed, err := queries.ResolveMachinePrimaryUsers(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("resolve machine primary users: %w", err)
+	}
+
+	return compactIDs(changed), nil
+}
+
+// ListUnresolvedPrimaryUsers lists machines that report a primary user no user could be matched to.
+func (s *Store) ListUnresolvedPrimaryUsers(
+	ctx context.Context,
+	opts domain.ListOptions,
+) ([]domain.UnresolvedPrimaryUser, int32, error) {
+	orderBy, err := orderBy(
+		opts.Sort,
+		opts.Order,
+		unresolvedPrimaryUserListSortColumns,
+		unresolvedPrimaryUserListDefaultOrder,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := []string{
+		"m.primary_user <> ''",
+		"m.primary_user_id IS NULL",
+		"NOT EXISTS (SELECT 1 FROM machine_primary_user_overrides AS o WHERE o.machine_id = m.id)",
+		"($1 = '' OR m.hostname ILIKE $1 OR m.serial_number ILIKE $1 OR m.primary_user ILIKE $1)",
+	}
+	args := []any{searchPattern(opts.Search)}
+
+	if len(opts.IDs) > 0 {
+		where = append(where, fmt.Sprintf("m.id = ANY($%d)", len(args)+1))
+		args = append(args, opts.IDs)
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := limitArg + 1
+
+	query := fmt.Sprintf(`
+SELECT
+  m.id,
+  m.hostname,
+  m.serial_number,
+  m.primary_user,
+  m.last_seen_at,
+  COUNT(*) OVER()::INT4 AS total
+FROM machines AS m
+WHERE %s
+ORDER BY %s
+LIMIT NULLIF($%d::INT, 0)
+OFFSET $%d
+`, strings.Join(where, " AND "), orderBy, limitArg, offsetArg)
+
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list unresolved primary users: %w", err)
+	}
+
+	return collectRows(rows, scanUnresolvedPrimaryUserRow)
+}
+
+// replaceEntraUserAliases swaps the Entra aliases for the nicknames in the current snapshot. A
+// nickname already used as another alias is skipped.
+func replaceEntraUserAliases(ctx context.Context, queries *db.Queries, users []graphsync.User) error {
+	if err := queries.DeleteEntraUserAliases(ctx); err != nil {
+		return fmt.Errorf("delete entra user aliases: %w", err)
+	}
+
+	params := db.InsertEntraUserAliasesParams{}
+	for _, user := range users {
+		alias := domain.NormalizeUserAlias(user.MailNickname)
+		if alias == "" {
+			continue
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("create user alias id: %w", err)
+		}
+
+		params.Ids = append(params.Ids, id)
+		params.UserIds = append(params.UserIds, user.ID)
+		params.Aliases = append(params.Aliases, alias)
+	}
+
+	if len(params.Ids) == 0 {
+		return nil
+	}
+
+	if err := queries.InsertEntraUserAliases(ctx, params); err != nil {
+		return fmt.Errorf("insert entra user aliases: %w", err)
+	}
+
+	return nil
+}
+
+func scanUnresolvedPrimaryUserRow(rows pgx.Rows) (domain.UnresolvedPrimaryUser, int32, error) {
+	var (
+		item  domain.UnresolvedPrimaryUser
+		total int32
+	)
+
+	if err := rows.Scan(
+		&item.MachineID,
+		&item.Hostname,
+		&item.SerialNumber,
+		&item.PrimaryUser,
+		&item.LastSeenAt,
+		&total,
+	); err != nil {
+		return domain.UnresolvedPrimaryUser{}, 0, err
+	}
+
+	return item, total, nil
+}
+
+func mapUserAlias(row db.UserAlias) domain.UserAlias {
+	return domain.UserAlias{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Alias:     row.Alias,
+		Source:    domain.PrincipalSource(row.Source),
+		CreatedAt: row.CreatedAt,
+	}
+}
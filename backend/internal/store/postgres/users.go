@@ -2,8 +2,10 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -56,6 +58,7 @@ SELECT
   u.upn,
   u.display_name,
   u.source,
+  u.directory_attributes,
   u.created_at,
   u.updated_at,
   COUNT(*) OVER()::INT4 AS total
@@ -82,7 +85,15 @@ func (s *Store) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
 		return domain.User{}, err
 	}
 
-	return mapUser(row)
+	return mapUser(
+		row.ID,
+		row.Upn,
+		row.DisplayName,
+		string(row.Source),
+		row.DirectoryAttributes,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
 }
 
 func scanUserRow(rows pgx.Rows) (domain.User, int32, error) {
@@ -96,6 +107,7 @@ func scanUserRow(rows pgx.Rows) (domain.User, int32, error) {
 		&row.Upn,
 		&row.DisplayName,
 		&row.Source,
+		&row.DirectoryAttributes,
 		&row.CreatedAt,
 		&row.UpdatedAt,
 		&total,
@@ -103,7 +115,15 @@ func scanUserRow(rows pgx.Rows) (domain.User, int32, error) {
 		return domain.User{}, 0, err
 	}
 
-	user, err := mapUser(row)
+	user, err := mapUser(
+		row.ID,
+		row.Upn,
+		row.DisplayName,
+		string(row.Source),
+		row.DirectoryAttributes,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
 	if err != nil {
 		return domain.User{}, 0, err
 	}
@@ -111,18 +131,45 @@ func scanUserRow(rows pgx.Rows) (domain.User, int32, error) {
 	return user, total, nil
 }
 
-func mapUser(row db.User) (domain.User, error) {
-	source, err := domain.ParsePrincipalSource(string(row.Source))
+func mapUser(
+	id uuid.UUID,
+	upn string,
+	displayName string,
+	sourceText string,
+	directoryAttributes []byte,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (domain.User, error) {
+	source, err := domain.ParsePrincipalSource(sourceText)
 	if err != nil {
 		return domain.User{}, fmt.Errorf("parse user source: %w", err)
 	}
 
+	attributes, err := decodeDirectoryAttributes(directoryAttributes)
+	if err != nil {
+		return domain.User{}, err
+	}
+
 	return domain.User{
-		ID:          row.ID,
-		UPN:         row.Upn,
-		DisplayName: row.DisplayName,
-		Source:      source,
-		CreatedAt:   row.CreatedAt,
-		UpdatedAt:   row.UpdatedAt,
+		ID:                  id,
+		UPN:                 upn,
+		DisplayName:         displayName,
+		Source:              source,
+		DirectoryAttributes: attributes,
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
 	}, nil
 }
+
+func decodeDirectoryAttributes(data []byte) (map[string]string, error) {
+	attributes := map[string]string{}
+	if len(data) == 0 {
+		return attributes, nil
+	}
+
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, fmt.Errorf("unmarshal directory attributes: %w", err)
+	}
+
+	return attributes, nil
+}
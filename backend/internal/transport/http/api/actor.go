@@ -0,0 +1,25 @@
+package apihttp
+
+import (
+	"net/http"
+
+	"github.com/go-pkgz/auth/v2/token"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+	authhttp "github.com/woodleighschool/grinch/internal/transport/http/auth"
+)
+
+// requestActor identifies the authenticated user or service account behind a request.
+func requestActor(r *http.Request) domain.Actor {
+	user, err := token.GetUserInfo(r)
+	if err != nil {
+		return domain.Actor{}
+	}
+
+	name := user.Name
+	if name == "" {
+		name = user.Email
+	}
+
+	return domain.Actor{ID: user.ID, Name: name, Admin: authhttp.UserAllows(user, domain.PermissionAdmin)}
+}
@@ -0,0 +1,50 @@
+package apihttp
+
+import (
+	"net/http"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) ListCertificates(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListCertificatesParams,
+) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.store.ListCertificates(r.Context(), domain.CertificateListOptions{
+		ListOptions:  listOptions,
+		ExecutableID: params.ExecutableId,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CertificateListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) GetCertificate(w http.ResponseWriter, r *http.Request, id Id) {
+	certificate, err := s.store.GetCertificate(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, certificate)
+}
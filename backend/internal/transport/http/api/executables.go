@@ -2,6 +2,8 @@ package apihttp //nolint:dupl // structurally similar to users.go by design
 
 import (
 	"net/http"
+
+	"github.com/woodleighschool/grinch/internal/domain"
 )
 
 func (s *Server) ListExecutables(
@@ -22,7 +24,22 @@ func (s *Server) ListExecutables(
 		return
 	}
 
-	items, total, err := s.store.ListExecutables(r.Context(), listOptions)
+	switch {
+	case params.MinMachineCount != nil && *params.MinMachineCount < 0:
+		writeError(w, badRequestError("min_machine_count must be >= 0"))
+		return
+	case params.MaxMachineCount != nil && *params.MaxMachineCount < 0:
+		writeError(w, badRequestError("max_machine_count must be >= 0"))
+		return
+	}
+
+	items, total, err := s.store.ListExecutables(r.Context(), domain.ExecutableListOptions{
+		ListOptions:     listOptions,
+		CertificateID:   params.CertificateId,
+		TeamID:          optionalString(params.TeamId),
+		MinMachineCount: params.MinMachineCount,
+		MaxMachineCount: params.MaxMachineCount,
+	})
 	if err != nil {
 		writeError(w, err)
 		return
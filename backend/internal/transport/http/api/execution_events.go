@@ -31,11 +31,12 @@ func (s *Server) ListExecutionEvents(
 	}
 
 	items, total, err := s.store.ListExecutionEvents(r.Context(), domain.ExecutionEventListOptions{
-		ListOptions:  listOptions,
-		MachineID:    params.MachineId,
-		UserID:       params.UserId,
-		ExecutableID: params.ExecutableId,
-		Decisions:    decisions,
+		ListOptions:     listOptions,
+		MachineID:       params.MachineId,
+		UserID:          params.UserId,
+		ExecutingUserID: params.ExecutingUserId,
+		ExecutableID:    params.ExecutableId,
+		Decisions:       decisions,
 	})
 	if err != nil {
 		writeError(w, err)
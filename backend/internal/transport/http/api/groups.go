@@ -4,11 +4,14 @@ import (
 	"net/http"
 
 	appgroups "github.com/woodleighschool/grinch/internal/app/groups"
+	"github.com/woodleighschool/grinch/internal/domain"
 )
 
 type groupWriteRequestBody struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
+	Name            string                       `json:"name"`
+	Description     *string                      `json:"description,omitempty"`
+	MachineCriteria *domain.MachineGroupCriteria `json:"machine_criteria,omitempty"`
+	UserCriteria    *domain.UserGroupCriteria    `json:"user_criteria,omitempty"`
 }
 
 func (s *Server) ListGroups(w http.ResponseWriter, r *http.Request, params ListGroupsParams) {
@@ -45,8 +48,10 @@ func (s *Server) CreateGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	group, err := s.groups.CreateGroup(r.Context(), appgroups.WriteInput{
-		Name:        body.Name,
-		Description: optionalString(body.Description),
+		Name:            body.Name,
+		Description:     optionalString(body.Description),
+		MachineCriteria: body.MachineCriteria,
+		UserCriteria:    body.UserCriteria,
 	})
 	if err != nil {
 		writeError(w, err)
@@ -75,10 +80,13 @@ func (s *Server) UpdateGroup(w http.ResponseWriter, r *http.Request, id Id) {
 
 	updated, err := s.groups.UpdateGroup(
 		r.Context(),
+		requestActor(r),
 		id,
 		appgroups.WriteInput{
-			Name:        body.Name,
-			Description: optionalString(body.Description),
+			Name:            body.Name,
+			Description:     optionalString(body.Description),
+			MachineCriteria: body.MachineCriteria,
+			UserCriteria:    body.UserCriteria,
 		},
 	)
 	if err != nil {
@@ -90,10 +98,14 @@ func (s *Server) UpdateGroup(w http.ResponseWriter, r *http.Request, id Id) {
 }
 
 func (s *Server) DeleteGroup(w http.ResponseWriter, r *http.Request, id Id) {
-	if err := s.groups.DeleteGroup(r.Context(), id); err != nil {
+	if err := s.groups.DeleteGroup(r.Context(), requestActor(r), id); err != nil {
 		writeError(w, err)
 		return
 	}
 
 	writeNoContent(w)
 }
+
+func (s *Server) RequestGroupCleanSync(w http.ResponseWriter, r *http.Request, id Id) {
+	s.requestCleanSync(w, r, nil, &id)
+}
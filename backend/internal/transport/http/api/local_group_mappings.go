@@ -0,0 +1,90 @@
+package apihttp
+
+import (
+	"net/http"
+
+	appgroups "github.com/woodleighschool/grinch/internal/app/groups"
+)
+
+func (s *Server) ListLocalGroupMappings(w http.ResponseWriter, r *http.Request, params ListLocalGroupMappingsParams) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.groups.ListLocalGroupMappings(r.Context(), listOptions)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, LocalGroupMappingListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) CreateLocalGroupMapping(w http.ResponseWriter, r *http.Request) {
+	var body CreateLocalGroupMappingJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	mapping, err := s.groups.CreateLocalGroupMapping(r.Context(), appgroups.LocalGroupMappingWriteInput{
+		LocalGroup: body.LocalGroup,
+		GroupID:    body.GroupId,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, mapping)
+}
+
+func (s *Server) GetLocalGroupMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	mapping, err := s.groups.GetLocalGroupMapping(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapping)
+}
+
+func (s *Server) UpdateLocalGroupMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	var body UpdateLocalGroupMappingJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	mapping, err := s.groups.UpdateLocalGroupMapping(r.Context(), id, appgroups.LocalGroupMappingWriteInput{
+		LocalGroup: body.LocalGroup,
+		GroupID:    body.GroupId,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapping)
+}
+
+func (s *Server) DeleteLocalGroupMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	if err := s.groups.DeleteLocalGroupMapping(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeNoContent(w)
+}
@@ -0,0 +1,32 @@
+package apihttp
+
+import (
+	"net/http"
+
+	applockdown "github.com/woodleighschool/grinch/internal/app/lockdown"
+)
+
+func (s *Server) AnalyzeLockdownReadiness(w http.ResponseWriter, r *http.Request) {
+	var body AnalyzeLockdownReadinessJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var lookbackDays int32
+	if body.LookbackDays != nil {
+		lookbackDays = *body.LookbackDays
+	}
+
+	report, err := s.lockdown.Readiness(r.Context(), applockdown.ReadinessInput{
+		GroupID:      body.GroupId,
+		MachineIDs:   cloneUUIDs(body.MachineIds),
+		LookbackDays: lookbackDays,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
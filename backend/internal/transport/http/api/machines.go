@@ -59,6 +59,78 @@ func (s *Server) GetMachine(w http.ResponseWriter, r *http.Request, id Id) {
 	writeJSON(w, http.StatusOK, machine)
 }
 
+// ExplainMachineRule traces how the rule for a rule type and identifier resolves on a machine.
+func (s *Server) ExplainMachineRule(
+	w http.ResponseWriter,
+	r *http.Request,
+	id Id,
+	params ExplainMachineRuleParams,
+) {
+	explanation, err := s.rules.ExplainMachineRule(r.Context(), id, params.RuleType, params.Identifier)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, explanation)
+}
+
+// SetMachineTags replaces a machine's tags and re-evaluates its dynamic group memberships.
+func (s *Server) SetMachineTags(w http.ResponseWriter, r *http.Request, id Id) {
+	var body MachineTagsRequest
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	machine, err := s.groups.SetMachineTags(r.Context(), id, body.Tags)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, machine)
+}
+
+// SetMachinePrimaryUser pins a machine's primary user, or pins no user when user_id is null.
+func (s *Server) SetMachinePrimaryUser(w http.ResponseWriter, r *http.Request, id Id) {
+	var body MachinePrimaryUserRequest
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	machine, err := s.users.SetMachinePrimaryUser(r.Context(), id, body.UserId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, machine)
+}
+
+// ClearMachinePrimaryUser removes a machine's pinned primary user.
+func (s *Server) ClearMachinePrimaryUser(w http.ResponseWriter, r *http.Request, id Id) {
+	machine, err := s.users.ClearMachinePrimaryUser(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, machine)
+}
+
+// GetMachineSyncDiagnostics reports a machine's sync state and the payload its next sync would receive.
+func (s *Server) GetMachineSyncDiagnostics(w http.ResponseWriter, r *http.Request, id Id) {
+	diagnostics, err := s.sync.MachineSyncDiagnostics(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diagnostics)
+}
+
 func (s *Server) DeleteMachine(w http.ResponseWriter, r *http.Request, id Id) {
 	if err := s.store.DeleteMachine(r.Context(), id); err != nil {
 		writeError(w, err)
@@ -67,3 +139,65 @@ func (s *Server) DeleteMachine(w http.ResponseWriter, r *http.Request, id Id) {
 
 	writeNoContent(w)
 }
+
+func (s *Server) RequestFleetCleanSync(w http.ResponseWriter, r *http.Request) {
+	s.requestCleanSync(w, r, nil, nil)
+}
+
+func (s *Server) RequestMachineCleanSync(w http.ResponseWriter, r *http.Request, id Id) {
+	s.requestCleanSync(w, r, &id, nil)
+}
+
+// requestCleanSync flags one machine, a group's effective members, or the whole fleet when both
+// IDs are nil.
+func (s *Server) requestCleanSync(w http.ResponseWriter, r *http.Request, machineID, groupID *Id) {
+	var body CleanSyncRequest
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	syncType, err := domain.ParseCleanSyncType(string(body.SyncType))
+	if err != nil {
+		writeError(w, badRequestError("sync_type must be clean or clean_all"))
+		return
+	}
+
+	result, err := s.store.RequestCleanSync(r.Context(), syncType, machineID, groupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) ListUnresolvedPrimaryUsers(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListUnresolvedPrimaryUsersParams,
+) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.store.ListUnresolvedPrimaryUsers(r.Context(), listOptions)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UnresolvedPrimaryUserListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
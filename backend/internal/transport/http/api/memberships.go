@@ -2,6 +2,7 @@ package apihttp
 
 import (
 	"net/http"
+	"time"
 
 	appmemberships "github.com/woodleighschool/grinch/internal/app/memberships"
 	"github.com/woodleighschool/grinch/internal/domain"
@@ -21,10 +22,12 @@ func (s *Server) ListMemberships(
 	items, total, err := s.memberships.ListMemberships(
 		r.Context(),
 		domain.MembershipListOptions{
-			ListOptions: listOptions,
-			GroupID:     params.GroupId,
-			UserID:      params.UserId,
-			MachineID:   params.MachineId,
+			ListOptions:      listOptions,
+			GroupID:          params.GroupId,
+			UserID:           params.UserId,
+			MachineID:        params.MachineId,
+			MemberGroupID:    params.MemberGroupId,
+			IncludeInherited: params.IncludeInherited != nil && *params.IncludeInherited,
 		},
 	)
 	if err != nil {
@@ -38,6 +41,41 @@ func (s *Server) ListMemberships(
 	})
 }
 
+const defaultExpiringWithinDays = 7
+
+// ListExpiringMemberships lists memberships expiring within the requested number of days.
+func (s *Server) ListExpiringMemberships(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListExpiringMembershipsParams,
+) {
+	listOptions, err := parseListOptions(params.Limit, params.Offset, params.Search, params.Sort, params.Order, nil)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	withinDays := int32(defaultExpiringWithinDays)
+	if params.WithinDays != nil {
+		withinDays = *params.WithinDays
+	}
+
+	items, total, err := s.memberships.ListExpiringMemberships(
+		r.Context(),
+		time.Duration(withinDays)*24*time.Hour,
+		listOptions,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, MembershipListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
 func (s *Server) CreateMembership(w http.ResponseWriter, r *http.Request) {
 	var body CreateMembershipJSONRequestBody
 	if err := decodeJSONBody(r, &body); err != nil {
@@ -47,10 +85,13 @@ func (s *Server) CreateMembership(w http.ResponseWriter, r *http.Request) {
 
 	membership, err := s.memberships.CreateMembership(
 		r.Context(),
+		requestActor(r),
 		appmemberships.CreateInput{
 			GroupID:    body.GroupId,
 			MemberKind: body.MemberKind,
 			MemberID:   body.MemberId,
+			StartsAt:   body.StartsAt,
+			ExpiresAt:  body.ExpiresAt,
 		},
 	)
 	if err != nil {
@@ -61,6 +102,51 @@ func (s *Server) CreateMembership(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, membership)
 }
 
+// ImportMemberships applies a CSV of machines and users to a group's explicit memberships.
+func (s *Server) ImportMemberships(w http.ResponseWriter, r *http.Request) {
+	var body ImportMembershipsJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result, err := s.memberships.ImportMemberships(r.Context(), requestActor(r), appmemberships.ImportInput{
+		GroupID:        body.GroupId,
+		Mode:           body.Mode,
+		CSV:            body.Csv,
+		AllowUnmatched: body.AllowUnmatched != nil && *body.AllowUnmatched,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// PreviewMembershipChange reports the rule changes a membership add or remove would cause
+// without saving it.
+func (s *Server) PreviewMembershipChange(w http.ResponseWriter, r *http.Request) {
+	var body PreviewMembershipChangeJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preview, err := s.memberships.PreviewMembershipChange(r.Context(), domain.MembershipChange{
+		Action:     body.Action,
+		GroupID:    body.GroupId,
+		MemberKind: body.MemberKind,
+		MemberID:   body.MemberId,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preview)
+}
+
 func (s *Server) GetMembership(w http.ResponseWriter, r *http.Request, id MembershipId) {
 	membership, err := s.memberships.GetMembership(r.Context(), id)
 	if err != nil {
@@ -72,7 +158,7 @@ func (s *Server) GetMembership(w http.ResponseWriter, r *http.Request, id Member
 }
 
 func (s *Server) DeleteMembership(w http.ResponseWriter, r *http.Request, id MembershipId) {
-	if err := s.memberships.DeleteMembership(r.Context(), id); err != nil {
+	if err := s.memberships.DeleteMembership(r.Context(), requestActor(r), id); err != nil {
 		writeError(w, err)
 		return
 	}
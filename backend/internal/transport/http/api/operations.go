@@ -0,0 +1,47 @@
+package apihttp
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//nolint:gochecknoglobals // lazily built lookup table derived from the embedded spec
+var operationIndex = sync.OnceValue(buildOperationIndex)
+
+// OperationID returns the OpenAPI operation ID of the route matched for the request.
+// It returns an empty string when the request did not match a documented operation.
+func OperationID(r *http.Request) string {
+	routeContext := chi.RouteContext(r.Context())
+	if routeContext == nil {
+		return ""
+	}
+
+	return operationIndex()[operationKey(r.Method, routeContext.RoutePattern())]
+}
+
+func buildOperationIndex() map[string]string {
+	spec, err := GetSpec()
+	if err != nil {
+		panic("load embedded OpenAPI spec: " + err.Error())
+	}
+
+	var basePath string
+	if len(spec.Servers) > 0 {
+		basePath = spec.Servers[0].URL
+	}
+
+	index := make(map[string]string)
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			index[operationKey(method, basePath+path)] = operation.OperationID
+		}
+	}
+
+	return index
+}
+
+func operationKey(method string, pattern string) string {
+	return method + " " + pattern
+}
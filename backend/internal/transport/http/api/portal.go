@@ -0,0 +1,91 @@
+package apihttp
+
+import (
+	"net/http"
+
+	"github.com/go-pkgz/auth/v2/token"
+
+	appunblockrequests "github.com/woodleighschool/grinch/internal/app/unblockrequests"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) GetUnblockTarget(w http.ResponseWriter, r *http.Request, params GetUnblockTargetParams) {
+	target, err := s.unblockRequests.GetTarget(r.Context(), requestRequester(r), params.FileSha256, params.MachineId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, target)
+}
+
+func (s *Server) ListOwnUnblockRequests(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListOwnUnblockRequestsParams,
+) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		(*Search)(nil),
+		params.Sort,
+		params.Order,
+		nil,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	statuses, err := parseOptionalValues(params.Status, domain.ParseUnblockRequestStatus)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.unblockRequests.ListOwnRequests(r.Context(), requestRequester(r),
+		domain.UnblockRequestListOptions{
+			ListOptions: listOptions,
+			Statuses:    statuses,
+		},
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UnblockRequestListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) SubmitUnblockRequest(w http.ResponseWriter, r *http.Request) {
+	var body SubmitUnblockRequestJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	request, err := s.unblockRequests.Submit(r.Context(), requestRequester(r), appunblockrequests.SubmitInput{
+		FileSHA256: body.FileSha256,
+		MachineID:  body.MachineId,
+		Reason:     body.Reason,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, request)
+}
+
+// requestRequester identifies the signed-in user by the UPN their session was issued for.
+func requestRequester(r *http.Request) appunblockrequests.Requester {
+	user, err := token.GetUserInfo(r)
+	if err != nil {
+		return appunblockrequests.Requester{}
+	}
+
+	return appunblockrequests.Requester{UPN: user.Email, Name: user.Name}
+}
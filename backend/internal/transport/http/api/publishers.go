@@ -0,0 +1,42 @@
+package apihttp
+
+import (
+	"net/http"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) ListPublishers(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListPublishersParams,
+) {
+	listOptions, err := parseListOptions(params.Limit, params.Offset, params.Search, params.Sort, params.Order, nil)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.store.ListPublishers(r.Context(), domain.PublisherListOptions{
+		ListOptions: listOptions,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PublisherListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) GetPublisher(w http.ResponseWriter, r *http.Request, teamID TeamId) {
+	publisher, err := s.store.GetPublisher(r.Context(), teamID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, publisher)
+}
@@ -45,9 +45,18 @@ func writeError(w http.ResponseWriter, err error) {
 	case errors.Is(err, pgx.ErrNoRows):
 		w.WriteHeader(http.StatusNotFound)
 		return
-	case errors.Is(err, domain.ErrGroupReadOnly):
+	case errors.Is(err, domain.ErrGroupReadOnly),
+		errors.Is(err, domain.ErrRoleGroupAdminOnly),
+		errors.Is(err, domain.ErrRuleChangeSelfReview):
 		w.WriteHeader(http.StatusForbidden)
 		return
+	case errors.Is(err, domain.ErrRuleChangeConflict),
+		errors.Is(err, domain.ErrUnblockRequestClosed),
+		errors.Is(err, domain.ErrMembershipManaged),
+		errors.Is(err, domain.ErrMembershipCycle),
+		errors.Is(err, domain.ErrUserAliasManaged):
+		w.WriteHeader(http.StatusConflict)
+		return
 	case errors.Is(err, domain.ErrInvalidSort), errors.As(err, &badReqErr):
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -0,0 +1,89 @@
+package apihttp
+
+import (
+	"net/http"
+
+	appaccess "github.com/woodleighschool/grinch/internal/app/access"
+)
+
+func (s *Server) ListRoleMappings(w http.ResponseWriter, r *http.Request, params ListRoleMappingsParams) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.access.ListRoleMappings(r.Context(), listOptions)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RoleMappingListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) CreateRoleMapping(w http.ResponseWriter, r *http.Request) {
+	var body CreateRoleMappingJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	mapping, err := s.access.CreateRoleMapping(r.Context(), appaccess.RoleMappingWriteInput{
+		GroupID: body.GroupId,
+		Role:    body.Role,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, mapping)
+}
+
+func (s *Server) GetRoleMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	mapping, err := s.access.GetRoleMapping(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapping)
+}
+
+func (s *Server) UpdateRoleMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	var body UpdateRoleMappingJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	mapping, err := s.access.UpdateRoleMapping(r.Context(), id, appaccess.RoleMappingWriteInput{
+		Role: body.Role,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mapping)
+}
+
+func (s *Server) DeleteRoleMapping(w http.ResponseWriter, r *http.Request, id Id) {
+	if err := s.access.DeleteRoleMapping(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeNoContent(w)
+}
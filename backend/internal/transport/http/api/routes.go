@@ -6,9 +6,10 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func (s *Server) RegisterRoutes(r chi.Router) {
+func (s *Server) RegisterRoutes(r chi.Router, middlewares ...MiddlewareFunc) {
 	_ = HandlerWithOptions(s, ChiServerOptions{
-		BaseRouter: r,
+		BaseRouter:  r,
+		Middlewares: middlewares,
 		ErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, _ error) {
 			w.WriteHeader(http.StatusBadRequest)
 		},
@@ -0,0 +1,109 @@
+package apihttp
+
+import (
+	"net/http"
+
+	apprulechanges "github.com/woodleighschool/grinch/internal/app/rulechanges"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) ListRuleChangeProposals(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListRuleChangeProposalsParams,
+) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	statuses, err := parseOptionalValues(params.Status, domain.ParseRuleChangeStatus)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.ruleChanges.ListProposals(r.Context(), domain.RuleChangeProposalListOptions{
+		ListOptions: listOptions,
+		RuleID:      params.RuleId,
+		Statuses:    statuses,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RuleChangeProposalListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) GetRuleChangeProposal(w http.ResponseWriter, r *http.Request, id Id) {
+	proposal, err := s.ruleChanges.GetProposal(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, proposal)
+}
+
+func (s *Server) ApproveRuleChangeProposal(w http.ResponseWriter, r *http.Request, id Id) {
+	var body ApproveRuleChangeProposalJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	proposal, err := s.ruleChanges.Approve(r.Context(), requestActor(r), id, apprulechanges.ReviewInput{
+		Comment: optionalString(body.Comment),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, proposal)
+}
+
+func (s *Server) RejectRuleChangeProposal(w http.ResponseWriter, r *http.Request, id Id) {
+	var body RejectRuleChangeProposalJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	proposal, err := s.ruleChanges.Reject(r.Context(), requestActor(r), id, apprulechanges.ReviewInput{
+		Comment: optionalString(body.Comment),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, proposal)
+}
+
+// writeRuleChangeProposal records a proposed rule write on behalf of the request's actor.
+func (s *Server) writeRuleChangeProposal(
+	w http.ResponseWriter,
+	r *http.Request,
+	propose func(domain.Actor) (domain.RuleChangeProposal, error),
+) {
+	proposal, err := propose(requestActor(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, proposal)
+}
@@ -3,6 +3,8 @@ package apihttp
 import (
 	"net/http"
 
+	"github.com/google/uuid"
+
 	"github.com/woodleighschool/grinch/internal/domain"
 )
 
@@ -17,6 +19,17 @@ type ruleWriteRequestBody struct {
 	Targets       domain.RuleTargets `json:"targets"`
 }
 
+type ruleFromObservationRequestBody struct {
+	ExecutableID     *uuid.UUID         `json:"executable_id,omitempty"`
+	ExecutionEventID *uuid.UUID         `json:"execution_event_id,omitempty"`
+	RuleType         domain.RuleType    `json:"rule_type"`
+	Name             *string            `json:"name,omitempty"`
+	Description      *string            `json:"description,omitempty"`
+	CustomMessage    *string            `json:"custom_message,omitempty"`
+	CustomURL        *string            `json:"custom_url,omitempty"`
+	Targets          domain.RuleTargets `json:"targets"`
+}
+
 func (s *Server) ListRules(w http.ResponseWriter, r *http.Request, params ListRulesParams) {
 	listOptions, err := parseListOptions(
 		params.Limit,
@@ -37,10 +50,16 @@ func (s *Server) ListRules(w http.ResponseWriter, r *http.Request, params ListRu
 		return
 	}
 
+	if params.UnusedDays != nil && *params.UnusedDays < 0 {
+		writeError(w, badRequestError("unused_days must be >= 0"))
+		return
+	}
+
 	items, total, err := s.rules.ListRules(r.Context(), domain.RuleListOptions{
 		ListOptions: listOptions,
 		Enabled:     cloneBools(params.Enabled),
 		RuleTypes:   ruleTypes,
+		UnusedDays:  params.UnusedDays,
 	})
 	if err != nil {
 		writeError(w, err)
@@ -53,6 +72,20 @@ func (s *Server) ListRules(w http.ResponseWriter, r *http.Request, params ListRu
 	})
 }
 
+// ListRuleFindings runs rule analysis, optionally limited to findings involving one rule.
+func (s *Server) ListRuleFindings(w http.ResponseWriter, r *http.Request, params ListRuleFindingsParams) {
+	findings, err := s.rules.AnalyzeRules(r.Context(), params.RuleId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RuleFindingListResponse{
+		Rows:  findings,
+		Total: int32(len(findings)), //nolint:gosec // finding counts stay far below MaxInt32
+	})
+}
+
 func (s *Server) CreateRule(w http.ResponseWriter, r *http.Request) {
 	var body ruleWriteRequestBody
 	if err := decodeJSONBody(r, &body); err != nil {
@@ -60,7 +93,15 @@ func (s *Server) CreateRule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rule, err := s.rules.CreateRule(r.Context(), decodeRuleWriteRequest(body))
+	input := decodeRuleWriteRequest(body)
+	if s.ruleChanges.RequiresApproval() {
+		s.writeRuleChangeProposal(w, r, func(actor domain.Actor) (domain.RuleChangeProposal, error) {
+			return s.ruleChanges.ProposeCreate(r.Context(), actor, input)
+		})
+		return
+	}
+
+	rule, err := s.rules.CreateRule(r.Context(), input)
 	if err != nil {
 		writeError(w, err)
 		return
@@ -69,6 +110,46 @@ func (s *Server) CreateRule(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, rule)
 }
 
+// CreateRuleFromObservation creates or extends a rule whose identifier is derived from an
+// executable or execution event.
+func (s *Server) CreateRuleFromObservation(w http.ResponseWriter, r *http.Request) {
+	var body ruleFromObservationRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result, err := s.observedRules.CreateRule(r.Context(), requestActor(r), domain.ObservedRuleInput{
+		ExecutableID:     body.ExecutableID,
+		ExecutionEventID: body.ExecutionEventID,
+		RuleType:         body.RuleType,
+		Name:             optionalString(body.Name),
+		Description:      optionalString(body.Description),
+		CustomMessage:    optionalString(body.CustomMessage),
+		CustomURL:        optionalString(body.CustomURL),
+		Targets:          decodeRuleTargets(body.Targets),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case result.Proposal != nil:
+		status = http.StatusAccepted
+	case result.Created:
+		status = http.StatusCreated
+	}
+
+	writeJSON(w, status, result)
+}
+
+// PreviewRuleCreate reports the rule changes creating a rule would cause without saving it.
+func (s *Server) PreviewRuleCreate(w http.ResponseWriter, r *http.Request) {
+	s.previewRuleWrite(w, r, nil)
+}
+
 func (s *Server) GetRule(w http.ResponseWriter, r *http.Request, id Id) {
 	rule, err := s.rules.GetRule(r.Context(), id)
 	if err != nil {
@@ -86,7 +167,15 @@ func (s *Server) UpdateRule(w http.ResponseWriter, r *http.Request, id Id) {
 		return
 	}
 
-	updated, err := s.rules.UpdateRule(r.Context(), id, decodeRuleWriteRequest(body))
+	input := decodeRuleWriteRequest(body)
+	if s.ruleChanges.RequiresApproval() {
+		s.writeRuleChangeProposal(w, r, func(actor domain.Actor) (domain.RuleChangeProposal, error) {
+			return s.ruleChanges.ProposeUpdate(r.Context(), actor, id, input)
+		})
+		return
+	}
+
+	updated, err := s.rules.UpdateRule(r.Context(), id, input)
 	if err != nil {
 		writeError(w, err)
 		return
@@ -95,7 +184,35 @@ func (s *Server) UpdateRule(w http.ResponseWriter, r *http.Request, id Id) {
 	writeJSON(w, http.StatusOK, updated)
 }
 
+// PreviewRuleUpdate reports the rule changes updating a rule would cause without saving it.
+func (s *Server) PreviewRuleUpdate(w http.ResponseWriter, r *http.Request, id Id) {
+	s.previewRuleWrite(w, r, &id)
+}
+
+func (s *Server) previewRuleWrite(w http.ResponseWriter, r *http.Request, id *uuid.UUID) {
+	var body ruleWriteRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preview, err := s.rules.PreviewRuleWrite(r.Context(), id, decodeRuleWriteRequest(body))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preview)
+}
+
 func (s *Server) DeleteRule(w http.ResponseWriter, r *http.Request, id Id) {
+	if s.ruleChanges.RequiresApproval() {
+		s.writeRuleChangeProposal(w, r, func(actor domain.Actor) (domain.RuleChangeProposal, error) {
+			return s.ruleChanges.ProposeDelete(r.Context(), actor, id)
+		})
+		return
+	}
+
 	if err := s.rules.DeleteRule(r.Context(), id); err != nil {
 		writeError(w, err)
 		return
@@ -110,8 +227,21 @@ func decodeRuleWriteRequest(body ruleWriteRequestBody) domain.RuleWriteInput {
 		enabled = *body.Enabled
 	}
 
-	include := make([]domain.IncludeRuleTargetWriteInput, 0, len(body.Targets.Include))
-	for _, t := range body.Targets.Include {
+	return domain.RuleWriteInput{
+		CustomMessage: optionalString(body.CustomMessage),
+		CustomURL:     optionalString(body.CustomURL),
+		Description:   optionalString(body.Description),
+		Enabled:       enabled,
+		Identifier:    body.Identifier,
+		Name:          body.Name,
+		RuleType:      body.RuleType,
+		Targets:       decodeRuleTargets(body.Targets),
+	}
+}
+
+func decodeRuleTargets(targets domain.RuleTargets) domain.RuleTargetsWriteInput {
+	include := make([]domain.IncludeRuleTargetWriteInput, 0, len(targets.Include))
+	for _, t := range targets.Include {
 		include = append(include, domain.IncludeRuleTargetWriteInput{
 			SubjectKind:   t.SubjectKind,
 			SubjectID:     t.SubjectID,
@@ -120,22 +250,16 @@ func decodeRuleWriteRequest(body ruleWriteRequestBody) domain.RuleWriteInput {
 		})
 	}
 
-	exclude := make([]domain.ExcludedGroupWriteInput, 0, len(body.Targets.Exclude))
-	for _, t := range body.Targets.Exclude {
-		exclude = append(exclude, domain.ExcludedGroupWriteInput{GroupID: t.GroupID})
+	exclude := make([]domain.ExcludeRuleTargetWriteInput, 0, len(targets.Exclude))
+	for _, t := range targets.Exclude {
+		exclude = append(exclude, domain.ExcludeRuleTargetWriteInput{
+			SubjectKind: t.SubjectKind,
+			SubjectID:   t.SubjectID,
+		})
 	}
 
-	return domain.RuleWriteInput{
-		CustomMessage: optionalString(body.CustomMessage),
-		CustomURL:     optionalString(body.CustomURL),
-		Description:   optionalString(body.Description),
-		Enabled:       enabled,
-		Identifier:    body.Identifier,
-		Name:          body.Name,
-		RuleType:      body.RuleType,
-		Targets: domain.RuleTargetsWriteInput{
-			Include: include,
-			Exclude: exclude,
-		},
+	return domain.RuleTargetsWriteInput{
+		Include: include,
+		Exclude: exclude,
 	}
 }
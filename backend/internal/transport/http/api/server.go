@@ -1,29 +1,61 @@
 package apihttp
 
 import (
+	appaccess "github.com/woodleighschool/grinch/internal/app/access"
 	appgroups "github.com/woodleighschool/grinch/internal/app/groups"
+	applockdown "github.com/woodleighschool/grinch/internal/app/lockdown"
 	appmemberships "github.com/woodleighschool/grinch/internal/app/memberships"
+	appobservedrules "github.com/woodleighschool/grinch/internal/app/observedrules"
+	apprulechanges "github.com/woodleighschool/grinch/internal/app/rulechanges"
 	apprules "github.com/woodleighschool/grinch/internal/app/rules"
+	appsanta "github.com/woodleighschool/grinch/internal/app/santa"
+	appserviceaccounts "github.com/woodleighschool/grinch/internal/app/serviceaccounts"
+	appunblockrequests "github.com/woodleighschool/grinch/internal/app/unblockrequests"
+	appusers "github.com/woodleighschool/grinch/internal/app/users"
 	"github.com/woodleighschool/grinch/internal/store/postgres"
 )
 
 type Server struct {
-	store       *postgres.Store
-	groups      *appgroups.Service
-	memberships *appmemberships.Service
-	rules       *apprules.Service
+	store           *postgres.Store
+	access          *appaccess.Service
+	groups          *appgroups.Service
+	memberships     *appmemberships.Service
+	rules           *apprules.Service
+	ruleChanges     *apprulechanges.Service
+	serviceAccounts *appserviceaccounts.Service
+	unblockRequests *appunblockrequests.Service
+	observedRules   *appobservedrules.Service
+	lockdown        *applockdown.Service
+	sync            *appsanta.Service
+	users           *appusers.Service
 }
 
 func New(
 	store *postgres.Store,
+	access *appaccess.Service,
 	groups *appgroups.Service,
 	rules *apprules.Service,
+	ruleChanges *apprulechanges.Service,
 	memberships *appmemberships.Service,
+	serviceAccounts *appserviceaccounts.Service,
+	unblockRequests *appunblockrequests.Service,
+	observedRules *appobservedrules.Service,
+	lockdown *applockdown.Service,
+	sync *appsanta.Service,
+	users *appusers.Service,
 ) *Server {
 	return &Server{
-		store:       store,
-		groups:      groups,
-		memberships: memberships,
-		rules:       rules,
+		store:           store,
+		access:          access,
+		groups:          groups,
+		memberships:     memberships,
+		rules:           rules,
+		ruleChanges:     ruleChanges,
+		serviceAccounts: serviceAccounts,
+		unblockRequests: unblockRequests,
+		observedRules:   observedRules,
+		lockdown:        lockdown,
+		sync:            sync,
+		users:           users,
 	}
 }
@@ -0,0 +1,161 @@
+package apihttp
+
+import (
+	"net/http"
+
+	appserviceaccounts "github.com/woodleighschool/grinch/internal/app/serviceaccounts"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) ListServiceAccounts(w http.ResponseWriter, r *http.Request, params ListServiceAccountsParams) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.serviceAccounts.ListServiceAccounts(r.Context(), listOptions)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ServiceAccountListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var body CreateServiceAccountJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	account, err := s.serviceAccounts.CreateServiceAccount(r.Context(), appserviceaccounts.WriteInput{
+		Name:        body.Name,
+		Description: optionalString(body.Description),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, account)
+}
+
+func (s *Server) GetServiceAccount(w http.ResponseWriter, r *http.Request, id Id) {
+	account, err := s.serviceAccounts.GetServiceAccount(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) UpdateServiceAccount(w http.ResponseWriter, r *http.Request, id Id) {
+	var body UpdateServiceAccountJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	account, err := s.serviceAccounts.UpdateServiceAccount(r.Context(), id, appserviceaccounts.WriteInput{
+		Name:        body.Name,
+		Description: optionalString(body.Description),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) DeleteServiceAccount(w http.ResponseWriter, r *http.Request, id Id) {
+	if err := s.serviceAccounts.DeleteServiceAccount(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeNoContent(w)
+}
+
+func (s *Server) ListApiTokens(w http.ResponseWriter, r *http.Request, params ListApiTokensParams) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.serviceAccounts.ListAPITokens(r.Context(), domain.APITokenListOptions{
+		ListOptions:      listOptions,
+		ServiceAccountID: params.ServiceAccountId,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ApiTokenListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) CreateApiToken(w http.ResponseWriter, r *http.Request) {
+	var body CreateApiTokenJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	created, err := s.serviceAccounts.CreateAPIToken(r.Context(), appserviceaccounts.TokenCreateInput{
+		ServiceAccountID: body.ServiceAccountId,
+		Name:             body.Name,
+		Scopes:           body.Scopes,
+		ExpiresAt:        body.ExpiresAt,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) GetApiToken(w http.ResponseWriter, r *http.Request, id Id) {
+	apiToken, err := s.serviceAccounts.GetAPIToken(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apiToken)
+}
+
+func (s *Server) RevokeApiToken(w http.ResponseWriter, r *http.Request, id Id) {
+	apiToken, err := s.serviceAccounts.RevokeAPIToken(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apiToken)
+}
@@ -0,0 +1,97 @@
+package apihttp
+
+import (
+	"net/http"
+
+	appunblockrequests "github.com/woodleighschool/grinch/internal/app/unblockrequests"
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+func (s *Server) ListUnblockRequests(
+	w http.ResponseWriter,
+	r *http.Request,
+	params ListUnblockRequestsParams,
+) {
+	listOptions, err := parseListOptions(
+		params.Limit,
+		params.Offset,
+		params.Search,
+		params.Sort,
+		params.Order,
+		params.Ids,
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	statuses, err := parseOptionalValues(params.Status, domain.ParseUnblockRequestStatus)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	items, total, err := s.unblockRequests.ListRequests(r.Context(), domain.UnblockRequestListOptions{
+		ListOptions: listOptions,
+		MachineID:   params.MachineId,
+		UserID:      params.UserId,
+		Statuses:    statuses,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UnblockRequestListResponse{
+		Rows:  items,
+		Total: total,
+	})
+}
+
+func (s *Server) GetUnblockRequest(w http.ResponseWriter, r *http.Request, id Id) {
+	request, err := s.unblockRequests.GetRequest(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, request)
+}
+
+func (s *Server) ApproveUnblockRequest(w http.ResponseWriter, r *http.Request, id Id) {
+	var body ApproveUnblockRequestJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	request, err := s.unblockRequests.Approve(r.Context(), requestActor(r), id, appunblockrequests.ApproveInput{
+		Scope:   body.Scope,
+		GroupID: body.GroupId,
+		Comment: optionalString(body.Comment),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, request)
+}
+
+func (s *Server) RejectUnblockRequest(w http.ResponseWriter, r *http.Request, id Id) {
+	var body RejectUnblockRequestJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	request, err := s.unblockRequests.Reject(r.Context(), requestActor(r), id, appunblockrequests.RejectInput{
+		Comment: optionalString(body.Comment),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, request)
+}
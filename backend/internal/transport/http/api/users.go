@@ -39,3 +39,52 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request, id Id) {
 
 	writeJSON(w, http.StatusOK, user)
 }
+
+// GetUserActivity summarizes the machines, executables and blocks of the events a user ran.
+func (s *Server) GetUserActivity(w http.ResponseWriter, r *http.Request, id Id) {
+	activity, err := s.store.GetUserActivity(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, activity)
+}
+
+func (s *Server) ListUserAliases(w http.ResponseWriter, r *http.Request, id Id) {
+	aliases, total, err := s.users.ListUserAliases(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UserAliasListResponse{
+		Rows:  aliases,
+		Total: total,
+	})
+}
+
+func (s *Server) CreateUserAlias(w http.ResponseWriter, r *http.Request, id Id) {
+	var body CreateUserAliasJSONRequestBody
+	if err := decodeJSONBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	alias, err := s.users.CreateUserAlias(r.Context(), id, body.Alias)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, alias)
+}
+
+func (s *Server) DeleteUserAlias(w http.ResponseWriter, r *http.Request, id Id, aliasID AliasId) {
+	if err := s.users.DeleteUserAlias(r.Context(), id, aliasID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeNoContent(w)
+}
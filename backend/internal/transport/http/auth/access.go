@@ -0,0 +1,247 @@
+package authhttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-pkgz/auth/v2/token"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const (
+	roleSeparator     = ","
+	roleLookupTimeout = 5 * time.Second
+)
+
+// RoleResolver maps an authenticated user's UPN to the roles granted by mapped groups.
+type RoleResolver interface {
+	ResolveRoles(ctx context.Context, upn string) ([]domain.Role, error)
+}
+
+//nolint:gochecknoglobals // package-level lookup table, not mutable state
+var operationPermissions = map[string]domain.Permission{
+	"listApiTokens":              domain.PermissionAdmin,
+	"getApiToken":                domain.PermissionAdmin,
+	"createApiToken":             domain.PermissionAdmin,
+	"revokeApiToken":             domain.PermissionAdmin,
+	"listCertificates":           domain.PermissionRead,
+	"getCertificate":             domain.PermissionRead,
+	"listExecutables":            domain.PermissionRead,
+	"getExecutable":              domain.PermissionRead,
+	"listExecutionEvents":        domain.PermissionReadEvents,
+	"getExecutionEvent":          domain.PermissionReadEvents,
+	"deleteExecutionEvent":       domain.PermissionAdmin,
+	"listFileAccessEvents":       domain.PermissionReadEvents,
+	"getFileAccessEvent":         domain.PermissionReadEvents,
+	"deleteFileAccessEvent":      domain.PermissionAdmin,
+	"listGroups":                 domain.PermissionRead,
+	"getGroup":                   domain.PermissionRead,
+	"createGroup":                domain.PermissionWriteMemberships,
+	"updateGroup":                domain.PermissionWriteMemberships,
+	"deleteGroup":                domain.PermissionAdmin,
+	"requestGroupCleanSync":      domain.PermissionAdmin,
+	"listLocalGroupMappings":     domain.PermissionRead,
+	"getLocalGroupMapping":       domain.PermissionRead,
+	"createLocalGroupMapping":    domain.PermissionWriteMemberships,
+	"updateLocalGroupMapping":    domain.PermissionWriteMemberships,
+	"deleteLocalGroupMapping":    domain.PermissionWriteMemberships,
+	"analyzeLockdownReadiness":   domain.PermissionReadEvents,
+	"listMachineRules":           domain.PermissionRead,
+	"listMachines":               domain.PermissionRead,
+	"listUnresolvedPrimaryUsers": domain.PermissionRead,
+	"getMachine":                 domain.PermissionRead,
+	"deleteMachine":              domain.PermissionAdmin,
+	"requestMachineCleanSync":    domain.PermissionAdmin,
+	"setMachineTags":             domain.PermissionWriteMemberships,
+	"setMachinePrimaryUser":      domain.PermissionWriteMemberships,
+	"clearMachinePrimaryUser":    domain.PermissionWriteMemberships,
+	"requestFleetCleanSync":      domain.PermissionAdmin,
+	"explainMachineRule":         domain.PermissionRead,
+	"getMachineSyncDiagnostics":  domain.PermissionRead,
+	"listMemberships":            domain.PermissionRead,
+	"getMembership":              domain.PermissionRead,
+	"listExpiringMemberships":    domain.PermissionRead,
+	"createMembership":           domain.PermissionWriteMemberships,
+	"importMemberships":          domain.PermissionWriteMemberships,
+	"previewMembershipChange":    domain.PermissionWriteMemberships,
+	"deleteMembership":           domain.PermissionWriteMemberships,
+	"listPublishers":             domain.PermissionRead,
+	"getPublisher":               domain.PermissionRead,
+	"listRoleMappings":           domain.PermissionAdmin,
+	"getRoleMapping":             domain.PermissionAdmin,
+	"createRoleMapping":          domain.PermissionAdmin,
+	"updateRoleMapping":          domain.PermissionAdmin,
+	"deleteRoleMapping":          domain.PermissionAdmin,
+	"listRuleChangeProposals":    domain.PermissionRead,
+	"getRuleChangeProposal":      domain.PermissionRead,
+	"approveRuleChangeProposal":  domain.PermissionWriteRules,
+	"rejectRuleChangeProposal":   domain.PermissionWriteRules,
+	"listRuleMachines":           domain.PermissionRead,
+	"listRules":                  domain.PermissionRead,
+	"getRule":                    domain.PermissionRead,
+	"listRuleFindings":           domain.PermissionRead,
+	"createRule":                 domain.PermissionWriteRules,
+	"createRuleFromObservation":  domain.PermissionWriteRules,
+	"updateRule":                 domain.PermissionWriteRules,
+	"previewRuleCreate":          domain.PermissionWriteRules,
+	"previewRuleUpdate":          domain.PermissionWriteRules,
+	"deleteRule":                 domain.PermissionWriteRules,
+	"listServiceAccounts":        domain.PermissionAdmin,
+	"getServiceAccount":          domain.PermissionAdmin,
+	"createServiceAccount":       domain.PermissionAdmin,
+	"updateServiceAccount":       domain.PermissionAdmin,
+	"deleteServiceAccount":       domain.PermissionAdmin,
+	"listUnblockRequests":        domain.PermissionReadEvents,
+	"getUnblockRequest":          domain.PermissionReadEvents,
+	"approveUnblockRequest":      domain.PermissionWriteRules,
+	"rejectUnblockRequest":       domain.PermissionWriteRules,
+	"listUsers":                  domain.PermissionRead,
+	"getUser":                    domain.PermissionRead,
+	"getUserActivity":            domain.PermissionReadEvents,
+	"listUserAliases":            domain.PermissionRead,
+	"createUserAlias":            domain.PermissionWriteMemberships,
+	"deleteUserAlias":            domain.PermissionWriteMemberships,
+}
+
+// selfServiceOperations are open to every signed-in person, including users without a role, so
+// anyone blocked by Santa can request an unblock. API tokens cannot call them.
+//
+//nolint:gochecknoglobals // package-level lookup table, not mutable state
+var selfServiceOperations = map[string]bool{
+	"getUnblockTarget":       true,
+	"listOwnUnblockRequests": true,
+	"submitUnblockRequest":   true,
+}
+
+// OperationMiddleware authorizes API requests by the permission required for their OpenAPI
+// operation ID. Operations without an explicit entry require the admin permission.
+func OperationMiddleware(operationID func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			user, err := token.GetUserInfo(request)
+			if err != nil || user.ID == "" {
+				writeUnauthorized(writer)
+				return
+			}
+
+			operation := operationID(request)
+			if selfServiceOperations[operation] {
+				if isTokenUser(user) {
+					writeForbidden(writer)
+					return
+				}
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			// Users outside every mapped group carry no roles and only reach the self-service
+			// operations.
+			if !isTokenUser(user) && len(UserRoles(user)) == 0 {
+				writeForbidden(writer)
+				return
+			}
+
+			permission, ok := operationPermissions[operation]
+			if !ok {
+				permission = domain.PermissionAdmin
+			}
+
+			if !UserAllows(user, permission) {
+				writeForbidden(writer)
+				return
+			}
+
+			next.ServeHTTP(writer, request)
+		})
+	}
+}
+
+// UserRoles returns the roles stamped on the session user when the token was issued.
+func UserRoles(user token.User) []domain.Role {
+	if user.Role == "" {
+		return nil
+	}
+
+	parts := strings.Split(user.Role, roleSeparator)
+	roles := make([]domain.Role, 0, len(parts))
+	for _, part := range parts {
+		role, err := domain.ParseRole(part)
+		if err != nil {
+			continue
+		}
+		roles = append(roles, role)
+	}
+
+	return roles
+}
+
+// UserAllows checks session users by role and token users by their granted scopes.
+func UserAllows(user token.User, permission domain.Permission) bool {
+	if isTokenUser(user) {
+		scopes := user.SliceAttr(tokenScopesAttrKey)
+		permissions := make([]domain.Permission, 0, len(scopes))
+		for _, scope := range scopes {
+			permissions = append(permissions, domain.Permission(scope))
+		}
+		return domain.ScopesAllow(permissions, permission)
+	}
+
+	return domain.RolesAllow(UserRoles(user), permission)
+}
+
+// roleClaimsUpdater stamps the user's current roles onto every issued or refreshed token.
+func roleClaimsUpdater(resolver RoleResolver) token.ClaimsUpdFunc {
+	return func(claims token.Claims) token.Claims {
+		if claims.User == nil {
+			return claims
+		}
+
+		roles := resolveUserRoles(resolver, *claims.User)
+		claims.User.SetRole(strings.Join(toStrings(roles), roleSeparator))
+
+		return claims
+	}
+}
+
+func resolveUserRoles(resolver RoleResolver, user token.User) []domain.Role {
+	if isLocalAdmin(user) {
+		return []domain.Role{domain.RoleAdmin}
+	}
+	if resolver == nil || user.Email == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), roleLookupTimeout)
+	defer cancel()
+
+	roles, err := resolver.ResolveRoles(ctx, user.Email)
+	if err != nil {
+		return nil
+	}
+
+	return roles
+}
+
+func isTokenUser(user token.User) bool {
+	return strings.HasPrefix(user.ID, tokenUserIDPrefix)
+}
+
+func isLocalAdmin(user token.User) bool {
+	return strings.HasPrefix(user.ID, localProviderName+"_")
+}
+
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, string(value))
+	}
+
+	return out
+}
+
+func writeForbidden(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusForbidden)
+}
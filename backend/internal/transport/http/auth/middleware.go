@@ -1,16 +1,34 @@
 package authhttp
 
 import (
+	"context"
 	"net/http"
+	"strings"
 
 	"github.com/go-pkgz/auth/v2/token"
+
+	"github.com/woodleighschool/grinch/internal/domain"
+)
+
+const (
+	bearerScheme       = "Bearer "
+	tokenUserIDPrefix  = "token_"
+	tokenScopesAttrKey = "scopes"
 )
 
+// TokenAuthenticator resolves API bearer tokens to the service account they belong to.
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, raw string) (domain.APITokenPrincipal, error)
+}
+
+// APIMiddleware authenticates API requests by bearer token when one is presented and by
+// session cookie otherwise.
 func APIMiddleware(
 	sessionAuth func(http.Handler) http.Handler,
+	tokens TokenAuthenticator,
 ) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return sessionAuth(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		session := sessionAuth(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			user, err := token.GetUserInfo(request)
 			if err != nil || user.ID == "" {
 				writeUnauthorized(writer)
@@ -18,7 +36,48 @@ func APIMiddleware(
 			}
 			next.ServeHTTP(writer, request)
 		}))
+
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			raw, ok := bearerToken(request)
+			if !ok {
+				session.ServeHTTP(writer, request)
+				return
+			}
+
+			if tokens == nil {
+				writeUnauthorized(writer)
+				return
+			}
+
+			principal, err := tokens.AuthenticateToken(request.Context(), raw)
+			if err != nil {
+				writeUnauthorized(writer)
+				return
+			}
+
+			next.ServeHTTP(writer, token.SetUserInfo(request, tokenUser(principal)))
+		})
+	}
+}
+
+func bearerToken(request *http.Request) (string, bool) {
+	header := request.Header.Get("Authorization")
+	if len(header) < len(bearerScheme) || !strings.EqualFold(header[:len(bearerScheme)], bearerScheme) {
+		return "", false
+	}
+
+	raw := strings.TrimSpace(header[len(bearerScheme):])
+	return raw, raw != ""
+}
+
+func tokenUser(principal domain.APITokenPrincipal) token.User {
+	user := token.User{
+		ID:   tokenUserIDPrefix + principal.ServiceAccountID.String(),
+		Name: principal.ServiceAccountName,
 	}
+	user.SetSliceAttr(tokenScopesAttrKey, toStrings(principal.Scopes))
+
+	return user
 }
 
 func writeUnauthorized(w http.ResponseWriter) {
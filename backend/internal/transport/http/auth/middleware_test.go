@@ -1,12 +1,17 @@
 package authhttp_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/go-pkgz/auth/v2/token"
+	"github.com/google/uuid"
 
+	"github.com/woodleighschool/grinch/internal/domain"
+	apihttp "github.com/woodleighschool/grinch/internal/transport/http/api"
 	authhttp "github.com/woodleighschool/grinch/internal/transport/http/auth"
 )
 
@@ -18,7 +23,7 @@ func testSessionAuth(next http.Handler) http.Handler {
 }
 
 func TestAPIMiddleware_AuthenticatesSessionRequests(t *testing.T) {
-	middleware := authhttp.APIMiddleware(testSessionAuth)
+	middleware := authhttp.APIMiddleware(testSessionAuth, nil)
 
 	called := false
 	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
@@ -44,7 +49,7 @@ func TestAPIMiddleware_ReturnsUnauthorizedWithoutUserInfo(t *testing.T) {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			next.ServeHTTP(writer, request)
 		})
-	})
+	}, nil)
 
 	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
 		writer.WriteHeader(http.StatusOK)
@@ -62,3 +67,183 @@ func TestAPIMiddleware_ReturnsUnauthorizedWithoutUserInfo(t *testing.T) {
 		t.Fatalf("Body length = %d, want 0", response.Body.Len())
 	}
 }
+
+func TestOperationMiddleware_AuthorizesByOperationPermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		operationID string
+		want        int
+	}{
+		{name: "viewer reads rules", role: "viewer", operationID: "listRules", want: http.StatusOK},
+		{name: "viewer cannot write rules", role: "viewer", operationID: "createRule", want: http.StatusForbidden},
+		{name: "viewer cannot read events", role: "viewer", operationID: "listExecutionEvents", want: http.StatusForbidden},
+		{name: "helpdesk manages memberships", role: "helpdesk", operationID: "createMembership", want: http.StatusOK},
+		{name: "helpdesk cannot write rules", role: "helpdesk", operationID: "updateRule", want: http.StatusForbidden},
+		{name: "helpdesk cannot delete groups", role: "helpdesk", operationID: "deleteGroup", want: http.StatusForbidden},
+		{name: "rule editor writes rules", role: "rule_editor,viewer", operationID: "deleteRule", want: http.StatusOK},
+		{name: "unknown operation requires admin", role: "rule_editor", operationID: "", want: http.StatusForbidden},
+		{name: "admin manages role mappings", role: "admin", operationID: "createRoleMapping", want: http.StatusOK},
+		{name: "roleless user submits unblock", role: "", operationID: "submitUnblockRequest", want: http.StatusOK},
+		{name: "roleless user cannot read rules", role: "", operationID: "listRules", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := authhttp.OperationMiddleware(func(*http.Request) string {
+				return tt.operationID
+			})
+
+			handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(http.StatusOK)
+			}))
+
+			user := token.User{ID: "microsoft_user", Role: tt.role}
+			request := token.SetUserInfo(httptest.NewRequest(http.MethodGet, "/api/v1/rules", nil), user)
+			response := httptest.NewRecorder()
+
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tt.want {
+				t.Fatalf("Code = %d, want %d", response.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationMiddleware_LimitsRolelessUsersToPortal(t *testing.T) {
+	spec, err := apihttp.GetSwagger()
+	if err != nil {
+		t.Fatalf("GetSwagger() error = %v", err)
+	}
+
+	portal := map[string]bool{
+		"getUnblockTarget":       true,
+		"listOwnUnblockRequests": true,
+		"submitUnblockRequest":   true,
+	}
+
+	for _, pathItem := range spec.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			want := http.StatusForbidden
+			if portal[operation.OperationID] {
+				want = http.StatusOK
+			}
+
+			t.Run(operation.OperationID, func(t *testing.T) {
+				middleware := authhttp.OperationMiddleware(func(*http.Request) string {
+					return operation.OperationID
+				})
+
+				handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+					writer.WriteHeader(http.StatusOK)
+				}))
+
+				user := token.User{ID: "microsoft_student", Email: "student@example.com"}
+				request := token.SetUserInfo(httptest.NewRequest(http.MethodGet, "/api/v1/", nil), user)
+				response := httptest.NewRecorder()
+
+				handler.ServeHTTP(response, request)
+
+				if response.Code != want {
+					t.Fatalf("Code = %d, want %d", response.Code, want)
+				}
+			})
+		}
+	}
+}
+
+type fakeTokenAuthenticator map[string]domain.APITokenPrincipal
+
+func (f fakeTokenAuthenticator) AuthenticateToken(_ context.Context, raw string) (domain.APITokenPrincipal, error) {
+	principal, ok := f[raw]
+	if !ok {
+		return domain.APITokenPrincipal{}, errors.New("invalid token")
+	}
+	return principal, nil
+}
+
+func TestAPIMiddleware_AuthorizesBearerTokensByScope(t *testing.T) {
+	tokens := fakeTokenAuthenticator{
+		"grn_reader": {
+			TokenID:            uuid.New(),
+			ServiceAccountID:   uuid.New(),
+			ServiceAccountName: "inventory-sync",
+			Scopes:             []domain.Permission{domain.PermissionRead},
+		},
+		"grn_admin": {
+			TokenID:            uuid.New(),
+			ServiceAccountID:   uuid.New(),
+			ServiceAccountName: "automation",
+			Scopes:             []domain.Permission{domain.PermissionAdmin},
+		},
+		"grn_rules": {
+			TokenID:            uuid.New(),
+			ServiceAccountID:   uuid.New(),
+			ServiceAccountName: "rule-sync",
+			Scopes:             []domain.Permission{domain.PermissionWriteRules},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		header      string
+		operationID string
+		want        int
+	}{
+		{name: "scoped read", header: "Bearer grn_reader", operationID: "listRules", want: http.StatusOK},
+		{name: "missing scope", header: "Bearer grn_reader", operationID: "createRule", want: http.StatusForbidden},
+		{name: "admin scope reads", header: "Bearer grn_admin", operationID: "listRules", want: http.StatusOK},
+		{
+			name:        "rule scope reads events",
+			header:      "Bearer grn_rules",
+			operationID: "listExecutionEvents",
+			want:        http.StatusOK,
+		},
+		{
+			name:        "rule scope cannot manage memberships",
+			header:      "Bearer grn_rules",
+			operationID: "createMembership",
+			want:        http.StatusForbidden,
+		},
+		{name: "unknown token", header: "Bearer grn_unknown", operationID: "listRules", want: http.StatusUnauthorized},
+		{
+			name:        "self-service operation",
+			header:      "Bearer grn_reader",
+			operationID: "submitUnblockRequest",
+			want:        http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionCalled := false
+			sessionAuth := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					sessionCalled = true
+					next.ServeHTTP(writer, request)
+				})
+			}
+
+			operation := authhttp.OperationMiddleware(func(*http.Request) string { return tt.operationID })
+			handler := authhttp.APIMiddleware(sessionAuth, tokens)(operation(http.HandlerFunc(
+				func(writer http.ResponseWriter, _ *http.Request) {
+					writer.WriteHeader(http.StatusOK)
+				},
+			)))
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/rules", nil)
+			request.Header.Set("Authorization", tt.header)
+			response := httptest.NewRecorder()
+
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tt.want {
+				t.Fatalf("Code = %d, want %d", response.Code, tt.want)
+			}
+			if sessionCalled {
+				t.Fatalf("sessionCalled = true, want false")
+			}
+		})
+	}
+}
@@ -22,7 +22,7 @@ const (
 	localProviderName     = "local"
 	microsoftProviderName = "microsoft"
 
-	defaultTokenDuration  = 12 * time.Hour
+	defaultTokenDuration  = 15 * time.Minute
 	defaultCookieDuration = 7 * 24 * time.Hour
 	localAdminUsername    = "admin"
 )
@@ -38,6 +38,8 @@ type Config struct {
 
 	JWTSecret          string
 	LocalAdminPassword string
+
+	Roles RoleResolver
 }
 
 type Service struct {
@@ -75,6 +77,7 @@ func authOptions(cfg Config) auth.Opts {
 		SecretReader: token.SecretFunc(func(_ string) (string, error) {
 			return cfg.JWTSecret, nil
 		}),
+		ClaimsUpd:      roleClaimsUpdater(cfg.Roles),
 		TokenDuration:  defaultTokenDuration,
 		CookieDuration: defaultCookieDuration,
 		Issuer:         "grinch",
@@ -83,6 +86,9 @@ func authOptions(cfg Config) auth.Opts {
 		SameSiteCookie: http.SameSiteLaxMode,
 		JWTCookieName:  sessionCookieName,
 		XSRFCookieName: xsrfCookieName,
+		// Tokens are refreshed every TokenDuration, re-resolving roles. Users outside every mapped
+		// group carry no roles; they keep a session for the unblock portal, and
+		// OperationMiddleware refuses them every other operation.
 		Validator: token.ValidatorFunc(func(_ string, claims token.Claims) bool {
 			return claims.User != nil
 		}),
@@ -16,6 +16,9 @@ import type {
   Rule,
   RuleListResponse,
   RuleMachineListResponse,
+  UnblockRequest,
+  UnblockRequestListResponse,
+  UnblockTarget,
   User,
   UserListResponse,
 } from "@/api/openapi";
@@ -217,3 +220,11 @@ export const usersApi = {
   list: list<UserListResponse>("/users"),
   get: getOne<User>("/users/{id}"),
 };
+
+// Portal operations are open to every signed-in user, including those without a role.
+export const portalApi = {
+  getTarget: (query: { file_sha256: string; machine_id: string }, signal?: AbortSignal): Promise<UnblockTarget> =>
+    expectBody(request<UnblockTarget>("GET", "/portal/unblock", { query, ...(signal ? { signal } : {}) })),
+  listOwnRequests: list<UnblockRequestListResponse>("/portal/unblock-requests"),
+  submit: createOne<UnblockRequest>("/portal/unblock-requests"),
+};
@@ -1,4 +1,5 @@
 import { App } from "@/admin";
+import { PORTAL_PATH, UnblockPortal } from "@/portal";
 import { StrictMode } from "react";
 import { createRoot } from "react-dom/client";
 
@@ -6,8 +7,8 @@ const rootElement = document.querySelector("#root");
 if (!rootElement) {
   throw new Error("Root element not found");
 }
-createRoot(rootElement).render(
-  <StrictMode>
-    <App />
-  </StrictMode>,
-);
+
+// The unblock portal is served outside react-admin, which signs out users the API refuses.
+const isPortal = globalThis.location.pathname === PORTAL_PATH;
+
+createRoot(rootElement).render(<StrictMode>{isPortal ? <UnblockPortal /> : <App />}</StrictMode>);
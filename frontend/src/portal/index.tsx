@@ -0,0 +1,269 @@
+import { lightTheme } from "@/admin/theme";
+import { portalApi } from "@/api/adminClient";
+import { getCurrentUser } from "@/api/auth";
+import type { AuthUser } from "@/api/authClient";
+import type { UnblockRequest, UnblockTarget } from "@/api/openapi";
+import MicrosoftIcon from "@mui/icons-material/Microsoft";
+import {
+  Alert,
+  Box,
+  Button,
+  Card,
+  CardContent,
+  Chip,
+  CircularProgress,
+  CssBaseline,
+  List,
+  ListItem,
+  ListItemText,
+  Stack,
+  TextField,
+  Typography,
+} from "@mui/material";
+import { createTheme, ThemeProvider } from "@mui/material/styles";
+import { useEffect, useState, type FormEvent, type ReactElement } from "react";
+
+export const PORTAL_PATH = "/unblock";
+
+const theme = createTheme(lightTheme);
+
+type LoadState =
+  | { kind: "loading" }
+  | { kind: "signedOut" }
+  | { kind: "error"; message: string }
+  | { kind: "ready"; user: AuthUser; target?: UnblockTarget; requests: UnblockRequest[] };
+
+interface PortalLink {
+  fileSHA256: string;
+  machineID: string;
+}
+
+// The block dialog links here with Santa's %file_sha% and %machine_id% placeholders filled in.
+const readPortalLink = (): PortalLink | undefined => {
+  const parameters = new URLSearchParams(globalThis.location.search);
+  const fileSHA256 = parameters.get("file_sha256") ?? "";
+  const machineID = parameters.get("machine_id") ?? "";
+  if (fileSHA256 === "" || machineID === "") {
+    return undefined;
+  }
+  return { fileSHA256, machineID };
+};
+
+const errorMessage = (error: unknown): string =>
+  error instanceof Error && error.message !== "" ? error.message : "Something went wrong.";
+
+const statusColor = (status: UnblockRequest["status"]): "default" | "success" | "error" => {
+  switch (status) {
+    case "approved": {
+      return "success";
+    }
+    case "rejected": {
+      return "error";
+    }
+    default: {
+      return "default";
+    }
+  }
+};
+
+const requestSummary = (request: UnblockRequest): string =>
+  `${request.machine_hostname || request.machine_id} · ${new Date(request.created_at).toLocaleString()}`;
+
+const SignIn = (): ReactElement => {
+  const origin = globalThis.location.origin;
+  const parameters = new URLSearchParams({ site: origin, from: globalThis.location.href });
+
+  return (
+    <Stack spacing={2}>
+      <Typography>Sign in with your school account to request access to a blocked application.</Typography>
+      <Button
+        component="a"
+        href={`/auth/microsoft/login?${parameters.toString()}`}
+        variant="contained"
+        size="large"
+        startIcon={<MicrosoftIcon />}
+      >
+        Continue With Microsoft
+      </Button>
+    </Stack>
+  );
+};
+
+const TargetDetails = ({ target }: { target: UnblockTarget }): ReactElement => (
+  <Stack spacing={0.5}>
+    <Typography variant="h6">{target.file_name || "Unknown application"}</Typography>
+    <Typography variant="body2" color="text.secondary">
+      Blocked on {target.machine_hostname || target.machine_id}
+      {target.occurred_at ? ` at ${new Date(target.occurred_at).toLocaleString()}` : ""}
+    </Typography>
+    <Typography variant="caption" color="text.secondary" sx={{ fontFamily: "monospace", wordBreak: "break-all" }}>
+      {target.file_sha256}
+    </Typography>
+  </Stack>
+);
+
+const RequestForm = ({
+  target,
+  onSubmitted,
+}: {
+  target: UnblockTarget;
+  onSubmitted: (request: UnblockRequest) => void;
+}): ReactElement => {
+  const [reason, setReason] = useState("");
+  const [submitting, setSubmitting] = useState(false);
+  const [error, setError] = useState<string | undefined>();
+
+  const handleSubmit = (event: FormEvent<HTMLFormElement>): void => {
+    event.preventDefault();
+    setSubmitting(true);
+    setError(undefined);
+
+    portalApi
+      .submit({ file_sha256: target.file_sha256, machine_id: target.machine_id, reason })
+      .then((request: UnblockRequest): void => {
+        setReason("");
+        onSubmitted(request);
+      })
+      .catch((submitError: unknown): void => {
+        setError(errorMessage(submitError));
+      })
+      .finally((): void => {
+        setSubmitting(false);
+      });
+  };
+
+  return (
+    <Stack component="form" spacing={2} onSubmit={handleSubmit}>
+      <TextField
+        label="Why do you need this application?"
+        value={reason}
+        onChange={(event): void => {
+          setReason(event.target.value);
+        }}
+        multiline
+        minRows={3}
+        required
+        slotProps={{ htmlInput: { maxLength: 2000 } }}
+      />
+      {error ? <Alert severity="error">{error}</Alert> : undefined}
+      <Button type="submit" variant="contained" disabled={submitting || reason.trim() === ""}>
+        Request Unblock
+      </Button>
+    </Stack>
+  );
+};
+
+const OwnRequests = ({ requests }: { requests: UnblockRequest[] }): ReactElement => (
+  <Stack spacing={1}>
+    <Typography variant="subtitle1" sx={{ fontWeight: 600 }}>
+      Your Requests
+    </Typography>
+    {requests.length === 0 ? (
+      <Typography variant="body2" color="text.secondary">
+        You have not requested any unblocks yet.
+      </Typography>
+    ) : (
+      <List dense disablePadding>
+        {requests.map((request) => (
+          <ListItem
+            key={request.id}
+            disableGutters
+            secondaryAction={<Chip label={request.status} color={statusColor(request.status)} size="small" />}
+          >
+            <ListItemText primary={request.file_name || request.file_sha256} secondary={requestSummary(request)} />
+          </ListItem>
+        ))}
+      </List>
+    )}
+  </Stack>
+);
+
+export const UnblockPortal = (): ReactElement => {
+  const [state, setState] = useState<LoadState>({ kind: "loading" });
+  const [submitted, setSubmitted] = useState(false);
+
+  useEffect(() => {
+    const controller = new AbortController();
+    const link = readPortalLink();
+
+    const load = async (): Promise<LoadState> => {
+      const user = await getCurrentUser(controller.signal);
+      if (!user) {
+        return { kind: "signedOut" };
+      }
+
+      const [target, requests] = await Promise.all([
+        link
+          ? portalApi.getTarget({ file_sha256: link.fileSHA256, machine_id: link.machineID }, controller.signal)
+          : Promise.resolve(undefined),
+        portalApi.listOwnRequests({ sort: "created_at", order: "desc", limit: 20 }, controller.signal),
+      ]);
+
+      return { kind: "ready", user, requests: requests.rows, ...(target ? { target } : {}) };
+    };
+
+    load()
+      .then(setState)
+      .catch((error: unknown): void => {
+        if (error instanceof Error && error.name === "AbortError") {
+          return;
+        }
+        setState({ kind: "error", message: errorMessage(error) });
+      });
+
+    return (): void => {
+      controller.abort();
+    };
+  }, []);
+
+  const handleSubmitted = (request: UnblockRequest): void => {
+    setSubmitted(true);
+    setState((current) =>
+      current.kind === "ready" ? { ...current, requests: [request, ...current.requests] } : current,
+    );
+  };
+
+  return (
+    <ThemeProvider theme={theme}>
+      <CssBaseline />
+      <Box sx={{ display: "flex", justifyContent: "center", px: 2, py: 6 }}>
+        <Card sx={{ width: "100%", maxWidth: 560 }}>
+          <CardContent>
+            <Stack spacing={3}>
+              <Typography variant="h5" sx={{ fontWeight: 600 }}>
+                Request an Unblock
+              </Typography>
+
+              {state.kind === "loading" ? <CircularProgress sx={{ alignSelf: "center" }} /> : undefined}
+              {state.kind === "signedOut" ? <SignIn /> : undefined}
+              {state.kind === "error" ? <Alert severity="error">{state.message}</Alert> : undefined}
+
+              {state.kind === "ready" ? (
+                <>
+                  <Typography variant="body2" color="text.secondary">
+                    Signed in as {state.user.name ?? state.user.email}
+                  </Typography>
+                  {state.target ? (
+                    <>
+                      <TargetDetails target={state.target} />
+                      {submitted ? (
+                        <Alert severity="success">
+                          Your request was sent. You will be able to run the application once it is approved.
+                        </Alert>
+                      ) : (
+                        <RequestForm target={state.target} onSubmitted={handleSubmitted} />
+                      )}
+                    </>
+                  ) : (
+                    <Alert severity="info">Open this page from the link in Santa&apos;s block dialog.</Alert>
+                  )}
+                  <OwnRequests requests={state.requests} />
+                </>
+              ) : undefined}
+            </Stack>
+          </CardContent>
+        </Card>
+      </Box>
+    </ThemeProvider>
+  );
+};
@@ -225,6 +225,7 @@ const listHandlers: Record<ResourceName, ListHandler> = {
         asListQuery(parameters, {
           machine_id: getOptionalString(filter.machine_id),
           user_id: getOptionalString(filter.user_id),
+          executing_user_id: getOptionalString(filter.executing_user_id),
           executable_id: getOptionalString(filter.executable_id),
           "decision[]": getOptionalStringArray(filter.decision),
         }),
@@ -18,6 +18,9 @@ export const ExecutionEventShow = (): ReactElement => (
           <ExecutionDecisionField />
         </Labeled>
         <TextField source="executing_user" label="Executing User" />
+        <ReferenceField source="executing_user_id" reference="users" label="Matched User">
+          <TextField source="display_name" />
+        </ReferenceField>
         <DateField source="occurred_at" label="Occurred At" showTime />
         <DateField source="created_at" label="Ingested At" showTime />
       </TabbedShowLayout.Tab>
@@ -31,7 +31,8 @@ export const MachineShow = (): ReactElement => (
         <ReferenceField source="primary_user_id" reference="users" label="Primary User">
           <TextField source="display_name" />
         </ReferenceField>
-        <TextField source="primary_user" label="Primary User UPN" />
+        <BooleanField source="primary_user_overridden" label="Primary User Pinned" />
+        <TextField source="primary_user" label="Reported Primary User" />
         <SelectField source="rule_sync_status" label="Rule Sync Status" choices={RULE_SYNC_STATUS_CHOICES} />
         <SelectField source="client_mode" label="Client Mode" choices={CLIENT_MODE_CHOICES} />
         <NumberField source="binary_rule_count" label="Binary Rules" />
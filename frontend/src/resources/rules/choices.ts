@@ -24,8 +24,14 @@ export const RULE_POLICY_CHOICES = [
   { id: "cel", name: "CEL" },
 ] satisfies { id: RulePolicy; name: string }[];
 
-export const RULE_TARGET_SUBJECT_KIND_CHOICES = [
+export const RULE_EXCLUDE_SUBJECT_KIND_CHOICES = [
   { id: "group", name: "Group" },
+  { id: "machine", name: "Machine" },
+  { id: "user", name: "User" },
+] satisfies { id: RuleTargetSubjectKind; name: string }[];
+
+export const RULE_TARGET_SUBJECT_KIND_CHOICES = [
+  ...RULE_EXCLUDE_SUBJECT_KIND_CHOICES,
   { id: "all_devices", name: "All Devices" },
   { id: "all_users", name: "All Users" },
 ] satisfies { id: RuleTargetSubjectKind; name: string }[];
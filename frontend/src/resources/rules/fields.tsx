@@ -1,5 +1,10 @@
 import type { components } from "@/api/openapi";
-import { RULE_POLICY_CHOICES, RULE_TARGET_SUBJECT_KIND_CHOICES, RULE_TYPE_CHOICES } from "@/resources/rules/choices";
+import {
+  RULE_EXCLUDE_SUBJECT_KIND_CHOICES,
+  RULE_POLICY_CHOICES,
+  RULE_TARGET_SUBJECT_KIND_CHOICES,
+  RULE_TYPE_CHOICES,
+} from "@/resources/rules/choices";
 import { SANTA_CEL_PLAYGROUND_URL } from "@/resources/shared/externalLinks";
 import { searchFilterToQuery } from "@/resources/shared/search";
 import CodeIcon from "@mui/icons-material/Code";
@@ -204,20 +209,30 @@ const IncludeTargetCelField = (): ReactElement | undefined => {
   );
 };
 
+const SUBJECT_REFERENCES = {
+  group: { reference: "groups", label: "Group", optionText: "name" },
+  machine: { reference: "machines", label: "Machine", optionText: "hostname" },
+  user: { reference: "users", label: "User", optionText: "display_name" },
+} as const;
+
 const SubjectField = (): ReactElement => (
   <FormDataConsumer>
     {({ scopedFormData }): ReactElement => {
       const subjectKind = scopedFormData?.subject_kind as RuleTargetSubjectKind | undefined;
-      const isGroup = subjectKind === "group" || subjectKind === undefined;
+      const needsSubject = subjectKind !== "all_devices" && subjectKind !== "all_users";
+      const subject =
+        subjectKind === "machine" || subjectKind === "user"
+          ? SUBJECT_REFERENCES[subjectKind]
+          : SUBJECT_REFERENCES.group;
       return (
-        <ReferenceInput reference="groups" source="subject_id">
+        <ReferenceInput key={subject.reference} reference={subject.reference} source="subject_id">
           <AutocompleteInput
-            label="Group"
-            optionText="name"
+            label={subject.label}
+            optionText={subject.optionText}
             optionValue="id"
             filterToQuery={searchFilterToQuery}
-            validate={isGroup ? [required()] : []}
-            disabled={!isGroup}
+            validate={needsSubject ? [required()] : []}
+            disabled={!needsSubject}
           />
         </ReferenceInput>
       );
@@ -245,22 +260,21 @@ export const RuleTargetsFields = (): ReactElement => (
       </SimpleFormIterator>
     </ArrayInput>
     <Typography variant="h6" sx={{ mt: 2 }}>
-      Excluded Groups
+      Exclude Targets
     </Typography>
     <Typography variant="body2" color="text.secondary">
-      Machines in any excluded group are skipped regardless of include targets.
+      Machines matched by any exclude target are skipped regardless of include targets.
     </Typography>
     <ArrayInput source="targets.exclude" label={false}>
       <SimpleFormIterator inline>
-        <ReferenceInput reference="groups" source="group_id">
-          <AutocompleteInput
-            label="Group"
-            optionText="name"
-            optionValue="id"
-            filterToQuery={searchFilterToQuery}
-            validate={[required()]}
-          />
-        </ReferenceInput>
+        <SelectInput
+          source="subject_kind"
+          label="Target"
+          choices={RULE_EXCLUDE_SUBJECT_KIND_CHOICES}
+          defaultValue="group"
+          validate={[required()]}
+        />
+        <SubjectField />
       </SimpleFormIterator>
     </ArrayInput>
   </>